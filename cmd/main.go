@@ -1,21 +1,86 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/app/reconcile"
 	"github.com/Borislavv/video-streaming/internal/app/resource"
 	"github.com/Borislavv/video-streaming/internal/app/stream"
+	"github.com/Borislavv/video-streaming/internal/app/supervisor"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di"
-	"sync"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"log"
+	"os"
+)
+
+const (
+	// runs all the registered apps
+	allCommand = "all"
+	// RestApi, Static files serving, Native rendering (http server)
+	restCommand = "rest"
+	// streaming app (websocket server)
+	streamCommand = "stream"
+	// reconciliation of the resources directory and the stored records (runs once)
+	reconcileCommand = "reconcile"
 )
 
 func main() {
-	wg := &sync.WaitGroup{}
-	wg.Add(2)
+	reconcileFlags := flag.NewFlagSet(reconcileCommand, flag.ExitOnError)
+	quarantine := reconcileFlags.Bool("quarantine", false, "move the orphaned files into the quarantine directory")
+	repair := reconcileFlags.Bool("repair", false, "remove the records which point at the missing files")
 
-	// Run streaming app (websocket server)
-	go stream.NewStreamingApp(di.NewServiceContainerManager()).Run(wg)
+	s := supervisor.NewSupervisor(di.NewServiceContainerManager()).
+		Register(restCommand, func(serviceContainer di_interface.ContainerManager) supervisor.App {
+			return resource.NewResourcesApp(serviceContainer)
+		}).
+		Register(streamCommand, func(serviceContainer di_interface.ContainerManager) supervisor.App {
+			return stream.NewStreamingApp(serviceContainer)
+		}).
+		RegisterTask(reconcileCommand, func(serviceContainer di_interface.ContainerManager) supervisor.App {
+			return reconcile.NewReconcileApp(serviceContainer, dto.NewReconcileRequestDTO(*quarantine, *repair))
+		})
 
-	// RestApi, Static files serving, Native rendering (http server)
-	go resource.NewResourcesApp(di.NewServiceContainerManager()).Run(wg)
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %v [command]\n\n", os.Args[0])
+		_, _ = fmt.Fprintln(flag.CommandLine.Output(), "Commands:")
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "  %-10v runs the REST API and the streaming apps in one process (default)\n", allCommand)
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "  %-10v runs the REST API, static files serving and rendering only (HTTP server)\n", restCommand)
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "  %-10v runs the streaming only (WebSocket server)\n", streamCommand)
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "  %-10v reports the orphaned files and the records of missing files, then exits\n", reconcileCommand)
+		_, _ = fmt.Fprintln(flag.CommandLine.Output(), "\nFlags of reconcile:")
+		reconcileFlags.SetOutput(flag.CommandLine.Output())
+		reconcileFlags.PrintDefaults()
+	}
+	flag.Parse()
+
+	command := flag.Arg(0)
+	if flag.NArg() > 1 && command != reconcileCommand {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var names []string
+	switch command {
+	case "", allCommand:
+		names = s.Names()
+	case restCommand, streamCommand:
+		names = []string{command}
+	case reconcileCommand:
+		// dry-run if nothing is asked to be fixed
+		_ = reconcileFlags.Parse(flag.Args()[1:])
+		if reconcileFlags.NArg() > 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		names = []string{command}
+	default:
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "undefined command '%v'\n\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
 
-	wg.Wait()
+	if err := s.Run(names...); err != nil {
+		log.Fatalln(err)
+	}
 }
@@ -1,5 +1,7 @@
 package app
 
+import "time"
+
 type Config struct {
 	// >>> RESOURCES HTTP SERVER <<<
 	// Host is an HTTP server serving host.
@@ -47,6 +49,17 @@ type Config struct {
 	JwtTokenExpiresAfter int64 `env:"JWT_TOKEN_EXPIRES_AFTER" envDefault:"86400"`
 	// JwtTokenEncryptAlgo is a value which will be used as encrypt algo for encode the token.
 	JwtTokenEncryptAlgo string `env:"JWT_TOKEN_ENCRYPT_ALGO" envDefault:"HS256" opts:"HS256,HS384,HS512"`
+	// JwtMFAChallengeExpiresAfter is a value which defined TTL of the MFA challenge token in seconds.
+	// The challenge token is issued instead of access token when a user has enabled two-factor authentication
+	// and must be exchanged on the real access token with a valid TOTP or recovery code. Default: `300` (5 min.).
+	JwtMFAChallengeExpiresAfter int64 `env:"JWT_MFA_CHALLENGE_EXPIRES_AFTER" envDefault:"300"`
+	// TOTPIssuer is an issuer name which will be shown in the authenticator application of a user.
+	TOTPIssuer string `env:"TOTP_ISSUER" envDefault:"Streaming video service"`
+	// TOTPSkew is a number of 30 seconds periods before and after the current one within which a code
+	// is still accepted (compensates the clock drift between the server and a user device).
+	TOTPSkew int `env:"TOTP_SKEW" envDefault:"1"`
+	// TOTPRecoveryCodesNumber is a number of one-time recovery codes which will be issued on 2FA confirmation.
+	TOTPRecoveryCodesNumber int `env:"TOTP_RECOVERY_CODES_NUMBER" envDefault:"10"`
 	// ResourceUploadingStrategy is an uploading strategy which will be used for upload files on the server.
 	// 	1. 'muiltipart_form' is a strategy which used builtin sugar approach. It will be parsing a whole file into the
 	//		memory (if a file more than ResourceInMemoryFileSizeThreshold, it will be saved on the disk, otherwise, it will be
@@ -90,8 +103,187 @@ type Config struct {
 	// LoggerRequestsBufferCap is requests channel capacity.
 	// Use only when you are logging input requests/responses.
 	LoggerRequestsBufferCap int `env:"LOGGER_REQUESTS_BUFFER_CAPACITY" envDefault:"10"`
+	// LoggerLevel is a minimum level of records which will be written, the lower ones will be skipped.
+	// Levels by ascending of importance: DEBUG, INFO, WARNING, ERROR, CRITICAL, EMERGENCY.
+	LoggerLevel string `env:"LOGGER_LEVEL" envDefault:"INFO" opts:"DEBUG,INFO,WARNING,ERROR,CRITICAL,EMERGENCY"`
+	// LoggerSinks is a list of outputs separated by comma. Available sinks: stdout, stderr, file, syslog.
+	LoggerSinks []string `env:"LOGGER_SINKS" envDefault:"stderr" envSeparator:","`
+	// LoggerSamplingInitial is a number of records per call site (the line of code which writes a record)
+	// which will be written within each LoggerSamplingTick. The records of ERROR level and above are never sampled.
+	// Zero value disables the sampling.
+	LoggerSamplingInitial int `env:"LOGGER_SAMPLING_INITIAL" envDefault:"100"`
+	// LoggerSamplingThereafter means that every Nth record per call site will be written when the LoggerSamplingInitial
+	// is exceeded within the LoggerSamplingTick. Zero value means that all the rest records will be skipped.
+	LoggerSamplingThereafter int `env:"LOGGER_SAMPLING_THEREAFTER" envDefault:"100"`
+	// LoggerSamplingTick is a time window within which the records of the same call site are counted.
+	LoggerSamplingTick time.Duration `env:"LOGGER_SAMPLING_TICK" envDefault:"1s"`
+	// LoggerNonBlocking enables the mode when records are dropped (and counted) instead of blocking the caller
+	// when the buffer is full. Use it when the logging must never slow down the streaming.
+	LoggerNonBlocking bool `env:"LOGGER_NON_BLOCKING" envDefault:"false"`
+	// LoggerFilePath is a path of the log file (used by 'file' sink).
+	LoggerFilePath string `env:"LOGGER_FILE_PATH" envDefault:"/var/log/streaming/app.log"`
+	// LoggerFileMaxSize is a max. size of the log file in bytes, the file will be rotated when it's reached.
+	// By default, it's 100mb.
+	LoggerFileMaxSize int64 `env:"LOGGER_FILE_MAX_SIZE" envDefault:"104857600"`
+	// LoggerFileMaxAge is a max. age of the rotated files, the older ones will be removed. By default, it's 7 days.
+	LoggerFileMaxAge time.Duration `env:"LOGGER_FILE_MAX_AGE" envDefault:"168h"`
+	// LoggerFileMaxBackups is a max. number of the rotated files, the older ones will be removed.
+	LoggerFileMaxBackups int `env:"LOGGER_FILE_MAX_BACKUPS" envDefault:"10"`
+	// LoggerSyslogNetwork is a network of the local syslog socket (used by 'syslog' sink).
+	LoggerSyslogNetwork string `env:"LOGGER_SYSLOG_NETWORK" envDefault:"unixgram" opts:"unixgram,unix"`
+	// LoggerSyslogAddress is an address of the local syslog socket.
+	LoggerSyslogAddress string `env:"LOGGER_SYSLOG_ADDRESS" envDefault:"/dev/log"`
+	// LoggerSyslogTag is an application name which will be passed with each syslog message.
+	LoggerSyslogTag string `env:"LOGGER_SYSLOG_TAG" envDefault:"streaming"`
+	// >>> METRICS <<<
+	// MetricsPath is a path of the resources HTTP server on which the Prometheus metrics of both servers are exposed.
+	// The route is served without authorization, so restrict access to it on the proxy level if necessary.
+	MetricsPath string `env:"METRICS_PATH" envDefault:"/metrics"`
+	// >>> HEALTH <<<
+	// HealthCheckTimeout is a max. duration of all checks of one probe request (the checks are run concurrently).
+	HealthCheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT" envDefault:"2s"`
+	// HealthLoggerSaturationThreshold is a filling of the logger buffer (from 0 to 1) from which the logger
+	// is considered as stuck and the liveness probe is failed.
+	HealthLoggerSaturationThreshold float64 `env:"HEALTH_LOGGER_SATURATION_THRESHOLD" envDefault:"0.9"`
+	// HealthShutdownDelay is a delay between switching the readiness into failing state and stopping the servers
+	// on shutdown. Set it up to the readiness probe period of your orchestrator, so the instance will be removed
+	// from the balancing before it stops accepting the connections.
+	HealthShutdownDelay time.Duration `env:"HEALTH_SHUTDOWN_DELAY" envDefault:"0s"`
+	// >>> CACHE <<<
+	// CacheStorage is a storage of the repositories cache: 'map' keeps the values in process memory,
+	// 'redis' keeps them in Redis, so they are shared by the replicas (the local copies are invalidated by pub/sub).
+	CacheStorage string `env:"CACHE_STORAGE" envDefault:"map" opts:"map,redis"`
+	// CacheEvictionPolicy chooses the item which will be evicted from the in-memory cache (the 'map' storage and
+	// the local copies of 'redis' storage) when the limits are reached: 'lru' (least recently used), 'lfu' (least
+	// frequently used) or 'none' (the items are removed on expiration only, the limits are not applied).
+	CacheEvictionPolicy string `env:"CACHE_EVICTION_POLICY" envDefault:"lru" opts:"lru,lfu,none"`
+	// CacheMaxEntries is a max. number of the in-memory cache items (zero means unlimited).
+	CacheMaxEntries int `env:"CACHE_MAX_ENTRIES" envDefault:"100000"`
+	// CacheMaxBytes is an approximate max. size of the in-memory cache items in bytes (zero means unlimited).
+	// The size of items is estimated by walking through their values, so it's less than the real memory usage.
+	CacheMaxBytes int64 `env:"CACHE_MAX_BYTES" envDefault:"268435456"`
+	// CacheShards is a number of independently locked parts of the in-memory cache, the limits are divided
+	// between them. Increase it if the lock contention is noticeable under the high load.
+	CacheShards int `env:"CACHE_SHARDS" envDefault:"16"`
+	// CacheStaleWhileRevalidate is a period after expiration of the cached item within which it's still served
+	// while one request recomputes it in background, so the readers do not miss at once (zero disables it).
+	CacheStaleWhileRevalidate time.Duration `env:"CACHE_STALE_WHILE_REVALIDATE" envDefault:"30s"`
+	// CacheNegativeTTL is a lifetime of the cached 'not found' results, so the requests of missing entities
+	// do not reach the database each time (zero disables it). The entries are invalidated on writes anyway.
+	CacheNegativeTTL time.Duration `env:"CACHE_NEGATIVE_TTL" envDefault:"5s"`
+	// CacheRedisAddress is a host and port of the Redis server (used by 'redis' storage).
+	CacheRedisAddress string `env:"CACHE_REDIS_ADDRESS" envDefault:"localhost:6379"`
+	// CacheRedisPassword is a password of the Redis server (AUTH command is not sent if it's empty).
+	CacheRedisPassword string `env:"CACHE_REDIS_PASSWORD" envDefault:""`
+	// CacheRedisDB is a number of the Redis database.
+	CacheRedisDB int `env:"CACHE_REDIS_DB" envDefault:"0"`
+	// CacheRedisPoolSize is a max. number of idle connections to the Redis server.
+	CacheRedisPoolSize int `env:"CACHE_REDIS_POOL_SIZE" envDefault:"10"`
+	// CacheRedisTimeout is a timeout of dialing and of each command, the value is computed without the cache
+	// if the timeout is reached, so keep it small.
+	CacheRedisTimeout time.Duration `env:"CACHE_REDIS_TIMEOUT" envDefault:"500ms"`
+	// CacheRedisKeyPrefix is a prefix of the keys, so the Redis server can be shared with other services.
+	CacheRedisKeyPrefix string `env:"CACHE_REDIS_KEY_PREFIX" envDefault:"streaming:cache:"`
+	// CacheRedisInvalidationChannel is a pub/sub channel through which the replicas are notified about deleted keys and tags.
+	CacheRedisInvalidationChannel string `env:"CACHE_REDIS_INVALIDATION_CHANNEL" envDefault:"streaming:cache:invalidation"`
+	// CacheVideoTTL is a lifetime of the cached videos and video lists (they are also invalidated on writes).
+	CacheVideoTTL time.Duration `env:"CACHE_VIDEO_TTL" envDefault:"1h"`
+	// CacheUserTTL is a lifetime of the cached users (they are also invalidated on writes).
+	CacheUserTTL time.Duration `env:"CACHE_USER_TTL" envDefault:"1h"`
+	// CacheResourceTTL is a lifetime of the cached resources (they are also invalidated on writes).
+	CacheResourceTTL time.Duration `env:"CACHE_RESOURCE_TTL" envDefault:"1h"`
+	// >>> SHUTDOWN <<<
+	// ShutdownDrainPeriod is a max. duration of waiting for the in-flight requests, streams and uploads on shutdown.
+	// The streams which are not finished within the period are interrupted with the 'going away' message which
+	// contains the current offset, so the client side can resume them on another instance.
+	ShutdownDrainPeriod time.Duration `env:"SHUTDOWN_DRAIN_PERIOD" envDefault:"10s"`
+	// >>> SUPERVISOR <<<
+	// SupervisorRestartPolicy is a behaviour of the supervisor when one of the apps failed: 'never' stops the whole
+	// process (so the other app is not left half-alive and the orchestrator restarts the instance), 'on-failure'
+	// restarts the failed app until the max. number of restarts is reached.
+	SupervisorRestartPolicy string `env:"SUPERVISOR_RESTART_POLICY" envDefault:"never" opts:"never,on-failure"`
+	// SupervisorMaxRestarts is a max. number of restarts of each app (used by 'on-failure' policy).
+	SupervisorMaxRestarts int `env:"SUPERVISOR_MAX_RESTARTS" envDefault:"5"`
+	// SupervisorRestartBackoff is a delay before the first restart, each next one is delayed by the value more.
+	SupervisorRestartBackoff time.Duration `env:"SUPERVISOR_RESTART_BACKOFF" envDefault:"1s"`
+	// >>> TRACING <<<
+	// TracingExporter is an exporter of the OpenTelemetry spans: 'otlp' exports them into a collector by OTLP/HTTP,
+	// 'stdout' writes them into the standard output (useful for tests and debugging), 'none' disables the export
+	// (the incoming W3C trace context is still propagated).
+	TracingExporter string `env:"TRACING_EXPORTER" envDefault:"none" opts:"none,otlp,stdout"`
+	// TracingOTLPEndpoint is a host and port of the OTLP/HTTP collector (used by 'otlp' exporter).
+	TracingOTLPEndpoint string `env:"TRACING_OTLP_ENDPOINT" envDefault:"localhost:4318"`
+	// TracingOTLPInsecure disables TLS of the connection to the collector (commonly used with a local collector).
+	TracingOTLPInsecure bool `env:"TRACING_OTLP_INSECURE" envDefault:"true"`
+	// TracingSamplingRatio is a part of traces which will be recorded (from 0 to 1), the traces started
+	// by an upstream service follow its sampling decision.
+	TracingSamplingRatio float64 `env:"TRACING_SAMPLING_RATIO" envDefault:"1"`
+	// TracingServiceName is a name of the service which will be passed with each span.
+	TracingServiceName string `env:"TRACING_SERVICE_NAME" envDefault:"streaming"`
 	// >>> FILE READER <<<
 	// StreamingChunkSize is a value which means the size of one chunk while reading the file when streaming a resource.
 	// By default, it's 1mb.
 	StreamingChunkSize int `env:"FILE_READER_CHUNK_SIZE" envDefault:"1048576"`
+	// FileReaderMaxChunkSize is a max. size of one chunk. The chunk size of the file is doubled from the min. one
+	// (FILE_READER_CHUNK_SIZE) until the file is split into no more than 256 chunks, so the big files are sent
+	// by fewer messages. Set it equal to the min. one for disable the adaptive chunk size.
+	FileReaderMaxChunkSize int64 `env:"FILE_READER_MAX_CHUNK_SIZE" envDefault:"4194304"`
+	// FileReaderCacheSize is a max. size in bytes of the file chunks which are kept in memory and shared by all
+	// the connections, so the viewers of a popular resource share the disk reads (zero disables the caching).
+	FileReaderCacheSize int64 `env:"FILE_READER_CACHE_SIZE" envDefault:"268435456"`
+	// FileReaderPrefetchChunks is a number of the next chunks which are read into the cache in background
+	// while the current one is being sent.
+	FileReaderPrefetchChunks int `env:"FILE_READER_PREFETCH_CHUNKS" envDefault:"2"`
+	// >>> LIVE <<<
+	// LivePublishPath is a path of the websocket server on which the live broadcasts are published.
+	LivePublishPath string `env:"LIVE_PUBLISH_PATH" envDefault:"/live/publish"`
+	// LiveMaxSegmentSize is a max. size in bytes of one published media segment (the bigger message closes the broadcast).
+	LiveMaxSegmentSize int64 `env:"LIVE_MAX_SEGMENT_SIZE" envDefault:"4194304"`
+	// LiveGOPMaxBytes is a max. size in bytes of the segments from the last keyframe which are kept for the late
+	// joiners, so they start on a keyframe. If the group of pictures is bigger, they wait for the next keyframe.
+	LiveGOPMaxBytes int64 `env:"LIVE_GOP_MAX_BYTES" envDefault:"16777216"`
+	// LiveViewerBuffer is a number of segments which are queued for each viewer, the viewer which falls behind
+	// skips the segments until the next keyframe, so the slow connections do not block the broadcast.
+	LiveViewerBuffer int `env:"LIVE_VIEWER_BUFFER" envDefault:"64"`
+	// LiveRecordingEnabled allows the publishers to record the broadcasts into the new resources.
+	LiveRecordingEnabled bool `env:"LIVE_RECORDING_ENABLED" envDefault:"true"`
+	// >>> ROOMS <<<
+	// RoomSyncInterval is a period of sending the playback state to the participants of watch-party rooms,
+	// so they correct the drift of their players (zero disables the periodic sync messages).
+	RoomSyncInterval time.Duration `env:"ROOM_SYNC_INTERVAL" envDefault:"5s"`
+	// RoomMaxParticipants is a max. number of the connections of one watch-party room (including the owner).
+	RoomMaxParticipants int `env:"ROOM_MAX_PARTICIPANTS" envDefault:"50"`
+	// >>> HISTORY <<<
+	// HistoryFinishedRatio is a part of the video duration after which the video is considered as watched,
+	// so it's not offered for continue watching and the next stream of it starts from the beginning.
+	HistoryFinishedRatio float64 `env:"HISTORY_FINISHED_RATIO" envDefault:"0.95"`
+
+	// >>> ANALYTICS <<<
+	// AnalyticsFinishedRatio is a part of the video duration after playing which the play session is counted as finished.
+	AnalyticsFinishedRatio float64 `env:"ANALYTICS_FINISHED_RATIO" envDefault:"0.95"`
+
+	// >>> QUOTA <<<
+	// QuotaMaxBytes is a max. total size of files uploaded by one user in bytes (zero means unlimited).
+	QuotaMaxBytes int64 `env:"QUOTA_MAX_BYTES" envDefault:"21474836480"`
+	// QuotaMaxVideos is a max. number of files uploaded by one user (zero means unlimited).
+	QuotaMaxVideos int64 `env:"QUOTA_MAX_VIDEOS" envDefault:"100"`
+
+	// >>> RECONCILE <<<
+	// ReconcileInterval is a period of the reconciliation of the resources directory and the stored records
+	// which is scheduled by the REST API app (zero disables it, the reconcile command may be run instead).
+	ReconcileInterval time.Duration `env:"RECONCILE_INTERVAL" envDefault:"0s"`
+	// ReconcileQuarantine enables moving of the orphaned files into the quarantine directory by the scheduled job.
+	ReconcileQuarantine bool `env:"RECONCILE_QUARANTINE" envDefault:"false"`
+	// ReconcileRepair enables removing of the records which point at the missing files by the scheduled job.
+	ReconcileRepair bool `env:"RECONCILE_REPAIR" envDefault:"false"`
+	// ReconcileGracePeriod is an age of the file after which it may be considered as orphaned,
+	// the younger files may be still uploading or recording (their records are not stored yet).
+	ReconcileGracePeriod time.Duration `env:"RECONCILE_GRACE_PERIOD" envDefault:"1h"`
+
+	// >>> TRASH <<<
+	// TrashRetention is a period during which the deleted videos may be restored from the trash.
+	TrashRetention time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+	// TrashPurgeInterval is a period of the purging of the videos which were kept in the trash
+	// longer than the retention period (zero disables it, so the trashed videos are kept forever).
+	TrashPurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
 }
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+)
+
+// LoggerOptions - builds the logger settings with sinks from the config.
+func (cfg *Config) LoggerOptions() (logger.Options, error) {
+	sinks, err := logger.NewSinks(logger.SinksOptions{
+		Names:          cfg.LoggerSinks,
+		FilePath:       cfg.LoggerFilePath,
+		FileMaxSize:    cfg.LoggerFileMaxSize,
+		FileMaxAge:     cfg.LoggerFileMaxAge,
+		FileMaxBackups: cfg.LoggerFileMaxBackups,
+		SyslogNetwork:  cfg.LoggerSyslogNetwork,
+		SyslogAddress:  cfg.LoggerSyslogAddress,
+		SyslogTag:      cfg.LoggerSyslogTag,
+	})
+	if err != nil {
+		return logger.Options{}, err
+	}
+
+	if _, err = logger.ParseLevel(cfg.LoggerLevel); err != nil {
+		return logger.Options{}, err
+	}
+
+	return logger.Options{
+		ErrorsBuffer:       cfg.LoggerErrorsBufferCap,
+		RequestsBuffer:     cfg.LoggerRequestsBufferCap,
+		MinLevel:           cfg.LoggerLevel,
+		SamplingInitial:    cfg.LoggerSamplingInitial,
+		SamplingThereafter: cfg.LoggerSamplingThereafter,
+		SamplingTick:       cfg.LoggerSamplingTick,
+		NonBlocking:        cfg.LoggerNonBlocking,
+		Sinks:              sinks,
+	}, nil
+}
@@ -0,0 +1,402 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/app"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	cacheservice "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	quotaservice "github.com/Borislavv/video-streaming/internal/domain/service/quota"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	reconcilerservice "github.com/Borislavv/video-streaming/internal/domain/service/reconciler"
+	reconciler_interface "github.com/Borislavv/video-streaming/internal/domain/service/reconciler/interface"
+	storager_interface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
+	mongodb_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher"
+	cacher_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file"
+	file_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"os"
+	"reflect"
+	"time"
+)
+
+// ReconcileApp - the maintenance task which reconciles the resources directory and the stored records once,
+// the report is written into the standard output.
+type ReconcileApp struct {
+	cfg *app.Config
+	di  di_interface.ContainerManager
+	req *dto.ReconcileRequestDTO
+}
+
+func NewReconcileApp(di di_interface.ContainerManager, req *dto.ReconcileRequestDTO) *ReconcileApp {
+	return &ReconcileApp{cfg: &app.Config{}, di: di, req: req}
+}
+
+// Run is method which running the reconciliation, the error is returned if it could not be done.
+func (app *ReconcileApp) Run(ctx context.Context) error {
+	// ctx, cancelFunc
+	cancel := app.InitAppCtx(ctx)
+	defer cancel()
+
+	// logger (shared by all apps)
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	// config (shared by all apps)
+	if err = app.InitConfig(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// tracing
+	tracerShutdownFunc, err := app.InitTracerService()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+	defer tracerShutdownFunc()
+
+	// mongo database
+	databaseCancelFunc, err := app.InitMongoDatabase()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+	defer databaseCancelFunc()
+
+	// cache dependencies initialization (the removed records are invalidated)
+	if err = app.InitCacheService(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// files storage
+	if err = app.InitStorageService(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// resource and quota repositories
+	if err = app.InitResourceRepositories(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// video repository and the repositories of the video references
+	if err = app.InitVideoRepositories(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// reconciliation dependencies initialization
+	if err = app.InitReconcilerServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	reconcilerService, err := app.di.GetReconcilerService()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	report, err := reconcilerService.Reconcile(ctx, app.req)
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	return nil
+}
+
+// InitAppCtx - the app context is derived from the given one, so the reconciliation is interrupted on shutdown.
+func (app *ReconcileApp) InitAppCtx(parent context.Context) context.CancelFunc {
+	ctx, cancel := context.WithCancel(parent)
+
+	app.di.
+		Set(ctx, reflect.TypeOf((*context.Context)(nil))).
+		Set(cancel, reflect.TypeOf((*context.CancelFunc)(nil)))
+
+	return cancel
+}
+
+// InitConfig - the config is parsed and validated once by the supervisor and shared by all apps.
+func (app *ReconcileApp) InitConfig() error {
+	cfg, err := app.di.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	app.cfg = cfg
+
+	return nil
+}
+
+func (app *ReconcileApp) InitTracerService() (deferFunc func(), err error) {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := tracer.NewOTelTracer(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	deferFunc = func() {
+		// the app. context is already canceled here, so the buffered spans are flushed within a separate timeout
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		if err = t.Shutdown(ctx); err != nil {
+			loggerService.Error(err)
+		}
+	}
+
+	app.di.
+		Set(t, reflect.TypeOf((*tracer_interface.Tracer)(nil))).
+		Set(t, nil)
+
+	return deferFunc, nil
+}
+
+func (app *ReconcileApp) InitMongoDatabase() (deferFunc func(), err error) {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := app.di.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	metricsService, err := app.di.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := app.di.GetTracerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	monitor := mongodb.NewCommandMonitor(metricsService, tracerService)
+
+	c, err := mongo.Connect(ctx, options.Client().ApplyURI(app.cfg.MongoUri).SetMonitor(monitor))
+	if err != nil {
+		return nil, loggerService.CriticalPropagate(err)
+	}
+
+	deferFunc = func() {
+		_ = c.Disconnect(ctx)
+	}
+
+	if err = c.Ping(ctx, readpref.Primary()); err != nil {
+		return deferFunc, loggerService.CriticalPropagate(err)
+	}
+
+	d := c.Database(app.cfg.MongoDb)
+
+	app.di.
+		Set(c, nil).
+		Set(d, nil)
+
+	return deferFunc, nil
+}
+
+func (app *ReconcileApp) InitCacheService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	ctx, err := app.di.GetCtx()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	metricsService, err := app.di.GetMetricsService()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := app.di.GetTracerService()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	var storage cacher_interface.Storage
+	switch app.cfg.CacheStorage {
+	case "redis":
+		if storage, err = cacher.NewRedisCacheStorage(app.di); err != nil {
+			return loggerService.LogPropagate(err)
+		}
+	default:
+		storage = cacher.NewMapCacheStorage(ctx, metricsService, cacher.MapCacheOptions{
+			Policy:               app.cfg.CacheEvictionPolicy,
+			MaxEntries:           app.cfg.CacheMaxEntries,
+			MaxBytes:             app.cfg.CacheMaxBytes,
+			Shards:               app.cfg.CacheShards,
+			StaleWhileRevalidate: app.cfg.CacheStaleWhileRevalidate,
+			NegativeTTL:          app.cfg.CacheNegativeTTL,
+		})
+	}
+
+	c := cacher.NewCache(
+		storage,
+		cacher.NewCacheDisplacer(ctx, time.Second*1),
+		tracerService,
+	)
+
+	app.di.
+		Set(c, reflect.TypeOf((*cacheservice.Cacher)(nil))).
+		Set(c, nil)
+
+	return nil
+}
+
+func (app *ReconcileApp) InitStorageService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	s, err := file.NewFilesystemStorageService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*file_interface.Storage)(nil))).
+		Set(s, reflect.TypeOf((*storager_interface.Storage)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ReconcileApp) InitResourceRepositories() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewResourceRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*mongodb_interface.Resource)(nil))).
+		Set(r, nil)
+
+	c, err := cache.NewResourceRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repository_interface.Resource)(nil))).
+		Set(c, nil)
+
+	q, err := mongodb.NewQuotaRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(q, reflect.TypeOf((*repository_interface.Quota)(nil))).
+		Set(q, reflect.TypeOf((*mongodb_interface.Quota)(nil))).
+		Set(q, nil)
+
+	s, err := quotaservice.NewQuotaService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*quota_interface.Quota)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ReconcileApp) InitVideoRepositories() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewVideoRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*mongodb_interface.Video)(nil))).
+		Set(r, nil)
+
+	c, err := cache.NewVideoRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repository_interface.Video)(nil))).
+		Set(c, nil)
+
+	p, err := mongodb.NewPlaylistRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(p, reflect.TypeOf((*repository_interface.Playlist)(nil))).
+		Set(p, reflect.TypeOf((*mongodb_interface.Playlist)(nil))).
+		Set(p, nil)
+
+	h, err := mongodb.NewWatchProgressRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(h, reflect.TypeOf((*repository_interface.WatchProgress)(nil))).
+		Set(h, reflect.TypeOf((*mongodb_interface.WatchProgress)(nil))).
+		Set(h, nil)
+
+	sr, err := mongodb.NewPlaySessionRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(sr, reflect.TypeOf((*repository_interface.PlaySession)(nil))).
+		Set(sr, reflect.TypeOf((*mongodb_interface.PlaySession)(nil))).
+		Set(sr, nil)
+
+	vr, err := mongodb.NewVideoStatsRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(vr, reflect.TypeOf((*repository_interface.VideoStats)(nil))).
+		Set(vr, reflect.TypeOf((*mongodb_interface.VideoStats)(nil))).
+		Set(vr, nil)
+
+	return nil
+}
+
+func (app *ReconcileApp) InitReconcilerServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	s, err := reconcilerservice.NewReconcilerService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*reconciler_interface.Reconciler)(nil))).
+		Set(s, nil)
+
+	return nil
+}
@@ -5,20 +5,33 @@ import (
 	"github.com/Borislavv/video-streaming/internal/app"
 	"github.com/Borislavv/video-streaming/internal/domain/builder"
 	builder_interface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
-	loggerservice "github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/accessor"
 	accessor_interface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	analyticsservice "github.com/Borislavv/video-streaming/internal/domain/service/analytics"
+	analytics_interface "github.com/Borislavv/video-streaming/internal/domain/service/analytics/interface"
+	apikeyservice "github.com/Borislavv/video-streaming/internal/domain/service/apikey"
+	apikey_interface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	authservice "github.com/Borislavv/video-streaming/internal/domain/service/authenticator"
 	authenticator_interface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacheservice "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	historyservice "github.com/Borislavv/video-streaming/internal/domain/service/history"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
+	playlistservice "github.com/Borislavv/video-streaming/internal/domain/service/playlist"
+	playlist_interface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	quotaservice "github.com/Borislavv/video-streaming/internal/domain/service/quota"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	reconcilerservice "github.com/Borislavv/video-streaming/internal/domain/service/reconciler"
+	reconciler_interface "github.com/Borislavv/video-streaming/internal/domain/service/reconciler/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource"
 	resource_interface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	securityservice "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
 	storager_interface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
 	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactorservice "github.com/Borislavv/video-streaming/internal/domain/service/twofactor"
+	twofactor_interface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	uploaderservice "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user"
 	user_interface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
@@ -28,36 +41,44 @@ import (
 	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/render"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/analytics"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/apikey"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/audio"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/auth"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/history"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/playlist"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/quota"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/resource"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/twofactor"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/user"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/video"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/static"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/request"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response"
 	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
 	mongodb_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/server/http"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher"
-	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	cacher_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/detector"
+	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/health"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/security"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tokenizer"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file"
 	file_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
-	"github.com/caarlos0/env/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
-	"log"
-	"os"
-	"os/signal"
 	"reflect"
 	"sync"
-	"syscall"
 	"time"
 )
 
@@ -70,161 +91,237 @@ func NewResourcesApp(di di_interface.ContainerManager) *ResourcesApp {
 	return &ResourcesApp{cfg: &app.Config{}, di: di}
 }
 
-// Run is method which running the REST API part of app
-func (app *ResourcesApp) Run(mWg *sync.WaitGroup) {
-	defer mWg.Done()
+// Run is method which running the REST API part of app until the given context will be canceled.
+// The error is returned if the app could not be started or its server stopped unexpectedly.
+func (app *ResourcesApp) Run(ctx context.Context) error {
 	wg := &sync.WaitGroup{}
 
-	// ctx, cancelFunc
-	app.InitAppCtx()
+	// ctx, cancelFunc (the app context is canceled anyway, even if the app failed on start)
+	cancel := app.InitAppCtx(ctx)
+	defer cancel()
 
-	// logger
-	loggerService, loggerCancelFunc, err := app.InitLoggerService(wg)
+	// logger (shared by all apps)
+	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
-	defer loggerCancelFunc()
 
-	// config
+	// config (shared by all apps)
 	if err = app.InitConfig(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// tracing
+	tracerShutdownFunc, err := app.InitTracerService()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
+	defer tracerShutdownFunc()
 
 	// mongo database
 	databaseCancelFunc, err := app.InitMongoDatabase()
 	if err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 	defer databaseCancelFunc()
 
+	// liveness and readiness probes
+	healthChecker, err := app.InitHealthChecker()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
 	// cache dependencies initialization
 	if err = app.InitCacheService(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// request-response dependencies initialization
 	if err = app.InitRequestResponseServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// access service
 	if err = app.InitAccessService(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// file uploader dependencies initialization
 	if err = app.InitUploaderServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// resource codecs detector
+	if err = app.InitCodecsInfoService(); err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// resource dependencies initialization
 	if err = app.InitResourceServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// playlist repository (video services depend on it)
+	if err = app.InitPlaylistRepository(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// watch history repository (video services depend on it)
+	if err = app.InitWatchHistoryRepository(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// analytics repositories (video services depend on them)
+	if err = app.InitAnalyticsRepositories(); err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// video dependencies initialization
 	if err = app.InitVideoServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// playlist dependencies initialization
+	if err = app.InitPlaylistServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// watch history dependencies initialization
+	if err = app.InitWatchHistoryServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// analytics dependencies initialization
+	if err = app.InitAnalyticsServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// reconciliation of the resources directory and the stored records
+	if err = app.InitReconcilerServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// password services
 	if err = app.InitPasswordService(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// user dependencies initialization
 	if err = app.InitUserServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// token dependencies initialization
 	if err = app.InitTokenServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// two-factor auth. services
+	if err = app.InitTwoFactorServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// API keys services
+	if err = app.InitAPIKeyServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// auth services
 	if err = app.InitAuthServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// scheduled reconciliation (it's stopped with the servers)
+	if err = app.InitReconcileJob(wg); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// scheduled purging of the trash (it's stopped with the servers)
+	if err = app.InitTrashPurgeJob(wg); err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// HTTP server
-	if err = app.InitHttpServer(wg); err != nil {
-		loggerService.Critical(err)
-		return
+	listenErrCh, err := app.InitHttpServer(wg)
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
-	<-app.shutdown()
-}
+	select {
+	case <-ctx.Done():
+		// the readiness is failed first, so the orchestrator stops routing the traffic before the server will be stopped
+		healthChecker.Shutdown()
+		time.Sleep(app.cfg.HealthShutdownDelay)
+	case err = <-listenErrCh:
+		// the server could not be started or stopped unexpectedly, so the app is failed
+	}
+
+	// the servers must be drained before the database and the tracer will be closed by the deferred funcs
+	cancel()
+	wg.Wait()
 
-func (app *ResourcesApp) shutdown() chan os.Signal {
-	stopCh := make(chan os.Signal, 1)
-	signal.Notify(stopCh, os.Interrupt, syscall.SIGTERM)
-	return stopCh
+	// the uploads which were interrupted after the drain period must not be left on disk
+	fileStorage, err := app.di.GetFileStorageService()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+	if rmErr := fileStorage.RemovePartials(); rmErr != nil {
+		loggerService.Error(rmErr)
+	}
+
+	return err
 }
 
-func (app *ResourcesApp) InitAppCtx() {
-	ctx, cancel := context.WithCancel(context.Background())
+// InitAppCtx - the app context is derived from the given one (canceled on shutdown), but it is canceled separately
+// when the app is stopped, so the servers can be drained before the rest resources will be closed.
+func (app *ResourcesApp) InitAppCtx(parent context.Context) context.CancelFunc {
+	ctx, cancel := context.WithCancel(parent)
 
 	app.di.
 		Set(ctx, reflect.TypeOf((*context.Context)(nil))).
 		Set(cancel, reflect.TypeOf((*context.CancelFunc)(nil)))
-}
 
-func (app *ResourcesApp) InitLoggerService(wg *sync.WaitGroup) (
-	loggerService loggerservice.Logger,
-	deferFunc func(),
-	err error,
-) {
-	ctx, err := app.di.GetCtx()
-	if err != nil {
-		return nil, nil, err
-	}
+	return cancel
+}
 
-	cancel, err := app.di.GetCancelFunc()
+// InitConfig - the config is parsed and validated once by the supervisor and shared by all apps.
+func (app *ResourcesApp) InitConfig() error {
+	cfg, err := app.di.GetConfig()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	loggerService, cls := logger.NewStdOut(ctx, app.cfg.LoggerErrorsBufferCap, app.cfg.LoggerRequestsBufferCap)
-
-	app.di.
-		Set(loggerService, reflect.TypeOf((*loggerservice.Logger)(nil))).
-		Set(loggerService, nil)
+	app.cfg = cfg
 
-	return loggerService,
-		func() {
-			cancel()
-			wg.Wait()
-			cls()
-		}, nil
+	return nil
 }
 
-func (app *ResourcesApp) InitConfig() error {
+func (app *ResourcesApp) InitTracerService() (deferFunc func(), err error) {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err = env.Parse(app.cfg); err != nil {
-		return loggerService.LogPropagate(err)
+	t, err := tracer.NewOTelTracer(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	deferFunc = func() {
+		// the app. context is already canceled here, so the buffered spans are flushed within a separate timeout
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		if err = t.Shutdown(ctx); err != nil {
+			loggerService.Error(err)
+		}
 	}
 
 	app.di.
-		Set(app.cfg, nil)
+		Set(t, reflect.TypeOf((*tracer_interface.Tracer)(nil))).
+		Set(t, nil)
 
-	return nil
+	return deferFunc, nil
 }
 
 func (app *ResourcesApp) InitMongoDatabase() (deferFunc func(), err error) {
@@ -238,7 +335,18 @@ func (app *ResourcesApp) InitMongoDatabase() (deferFunc func(), err error) {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	c, err := mongo.Connect(ctx, options.Client().ApplyURI(app.cfg.MongoUri))
+	metricsService, err := app.di.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := app.di.GetTracerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	monitor := mongodb.NewCommandMonitor(metricsService, tracerService)
+
+	c, err := mongo.Connect(ctx, options.Client().ApplyURI(app.cfg.MongoUri).SetMonitor(monitor))
 	if err != nil {
 		return nil, loggerService.CriticalPropagate(err)
 	}
@@ -283,6 +391,40 @@ func (app *ResourcesApp) InitPasswordService() error {
 	return nil
 }
 
+func (app *ResourcesApp) InitHealthChecker() (checker health_interface.Checker, err error) {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := app.di.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// the process must be restarted only if it's stuck, the unavailable dependencies affect only the readiness
+	liveness := []health_interface.Check{
+		health.NewLoggerCheck(loggerService, app.cfg.HealthLoggerSaturationThreshold),
+	}
+	readiness := []health_interface.Check{
+		health.NewMongoCheck(database),
+		health.NewWritableDirCheck("resources_dir", helper.ResourcesDir),
+		health.NewBinaryCheck("ffprobe"),
+		health.NewLoggerCheck(loggerService, app.cfg.HealthLoggerSaturationThreshold),
+	}
+
+	c, err := health.NewHealthChecker(app.di, liveness, readiness)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	app.di.
+		Set(c, reflect.TypeOf((*health_interface.Checker)(nil))).
+		Set(c, nil)
+
+	return c, nil
+}
+
 func (app *ResourcesApp) InitCacheService() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -294,9 +436,37 @@ func (app *ResourcesApp) InitCacheService() error {
 		return loggerService.LogPropagate(err)
 	}
 
+	metricsService, err := app.di.GetMetricsService()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := app.di.GetTracerService()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	var storage cacher_interface.Storage
+	switch app.cfg.CacheStorage {
+	case "redis":
+		if storage, err = cacher.NewRedisCacheStorage(app.di); err != nil {
+			return loggerService.LogPropagate(err)
+		}
+	default:
+		storage = cacher.NewMapCacheStorage(ctx, metricsService, cacher.MapCacheOptions{
+			Policy:               app.cfg.CacheEvictionPolicy,
+			MaxEntries:           app.cfg.CacheMaxEntries,
+			MaxBytes:             app.cfg.CacheMaxBytes,
+			Shards:               app.cfg.CacheShards,
+			StaleWhileRevalidate: app.cfg.CacheStaleWhileRevalidate,
+			NegativeTTL:          app.cfg.CacheNegativeTTL,
+		})
+	}
+
 	c := cacher.NewCache(
-		cacher.NewMapCacheStorage(ctx),
+		storage,
 		cacher.NewCacheDisplacer(ctx, time.Second*1),
+		tracerService,
 	)
 
 	app.di.
@@ -355,6 +525,245 @@ func (app *ResourcesApp) InitVideoServices() error {
 	return nil
 }
 
+func (app *ResourcesApp) InitPlaylistRepository() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewPlaylistRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repository_interface.Playlist)(nil))).
+		Set(r, reflect.TypeOf((*mongodb_interface.Playlist)(nil))).
+		Set(r, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitPlaylistServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	v, err := validator.NewPlaylistValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validator_interface.Playlist)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewPlaylistBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builder_interface.Playlist)(nil))).
+		Set(b, nil)
+
+	s, err := playlistservice.NewCRUDService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*playlist_interface.CRUD)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitWatchHistoryRepository() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewWatchProgressRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repository_interface.WatchProgress)(nil))).
+		Set(r, reflect.TypeOf((*mongodb_interface.WatchProgress)(nil))).
+		Set(r, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitWatchHistoryServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	v, err := validator.NewWatchHistoryValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validator_interface.WatchHistory)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewWatchHistoryBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builder_interface.WatchHistory)(nil))).
+		Set(b, nil)
+
+	s, err := historyservice.NewWatchHistoryService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*history_interface.WatchHistory)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitAnalyticsRepositories() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	sr, err := mongodb.NewPlaySessionRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(sr, reflect.TypeOf((*repository_interface.PlaySession)(nil))).
+		Set(sr, reflect.TypeOf((*mongodb_interface.PlaySession)(nil))).
+		Set(sr, nil)
+
+	vr, err := mongodb.NewVideoStatsRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(vr, reflect.TypeOf((*repository_interface.VideoStats)(nil))).
+		Set(vr, reflect.TypeOf((*mongodb_interface.VideoStats)(nil))).
+		Set(vr, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitAnalyticsServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	v, err := validator.NewAnalyticsValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validator_interface.Analytics)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewAnalyticsBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builder_interface.Analytics)(nil))).
+		Set(b, nil)
+
+	s, err := analyticsservice.NewAnalyticsService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*analytics_interface.Analytics)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitReconcilerServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	s, err := reconcilerservice.NewReconcilerService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*reconciler_interface.Reconciler)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+// InitReconcileJob - starts the periodic reconciliation if it's enabled, the job is stopped by the app context.
+func (app *ResourcesApp) InitReconcileJob(wg *sync.WaitGroup) error {
+	if app.cfg.ReconcileInterval <= 0 {
+		return nil
+	}
+
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	ctx, err := app.di.GetCtx()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	j, err := reconcilerservice.NewReconcileJob(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		j.Run(ctx)
+	}()
+
+	return nil
+}
+
+// InitTrashPurgeJob - starts the periodic purging of the trash if it's enabled, the job is stopped by the app context.
+func (app *ResourcesApp) InitTrashPurgeJob(wg *sync.WaitGroup) error {
+	if app.cfg.TrashPurgeInterval <= 0 {
+		return nil
+	}
+
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	ctx, err := app.di.GetCtx()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	j, err := videoservice.NewPurgeJob(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		j.Run(ctx)
+	}()
+
+	return nil
+}
+
 func (app *ResourcesApp) InitResourceServices() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -377,6 +786,11 @@ func (app *ResourcesApp) InitResourceServices() error {
 		Set(c, reflect.TypeOf((*repository_interface.Resource)(nil))).
 		Set(c, nil)
 
+	// the used storage is initialized by the resources, the uploading of resources is limited by the quota
+	if err = app.InitQuotaServices(); err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
 	v, err := validator.NewResourceValidator(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
@@ -404,6 +818,40 @@ func (app *ResourcesApp) InitResourceServices() error {
 	return nil
 }
 
+func (app *ResourcesApp) InitQuotaServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewQuotaRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repository_interface.Quota)(nil))).
+		Set(r, reflect.TypeOf((*mongodb_interface.Quota)(nil))).
+		Set(r, nil)
+
+	b, err := builder.NewQuotaBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builder_interface.Quota)(nil))).
+		Set(b, nil)
+
+	s, err := quotaservice.NewQuotaService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*quota_interface.Quota)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
 func (app *ResourcesApp) InitUserServices() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -486,6 +934,89 @@ func (app *ResourcesApp) InitAuthServices() error {
 	return nil
 }
 
+func (app *ResourcesApp) InitTwoFactorServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	o, err := security.NewTOTPService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(o, reflect.TypeOf((*securityservice.OTP)(nil))).
+		Set(o, nil)
+
+	b, err := builder.NewTwoFactorBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builder_interface.TwoFactor)(nil))).
+		Set(b, nil)
+
+	v, err := validator.NewTwoFactorValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validator_interface.TwoFactor)(nil))).
+		Set(v, nil)
+
+	s, err := twofactorservice.NewTwoFactorService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*twofactor_interface.TwoFactor)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitAPIKeyServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewAPIKeyRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repository_interface.APIKey)(nil))).
+		Set(r, reflect.TypeOf((*mongodb_interface.APIKey)(nil))).
+		Set(r, nil)
+
+	b, err := builder.NewAPIKeyBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builder_interface.APIKey)(nil))).
+		Set(b, nil)
+
+	v, err := validator.NewAPIKeyValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validator_interface.APIKey)(nil))).
+		Set(v, nil)
+
+	s, err := apikeyservice.NewAPIKeyService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*apikey_interface.APIKey)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
 func (app *ResourcesApp) InitTokenServices() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -559,6 +1090,23 @@ func (app *ResourcesApp) InitUploaderServices() error {
 	return nil
 }
 
+func (app *ResourcesApp) InitCodecsInfoService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	c, err := detector.NewResourceCodecs(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*detector_interface.Codecs)(nil))).
+		Set(c, nil)
+
+	return nil
+}
+
 func (app *ResourcesApp) InitAccessService() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -624,6 +1172,86 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	// two-factor auth.
+	twoFactorEnableController, err := twofactor.NewEnableController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	twoFactorConfirmController, err := twofactor.NewConfirmController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	twoFactorDisableController, err := twofactor.NewDisableController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	twoFactorRegenerateController, err := twofactor.NewRegenerateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// API keys
+	apiKeyCreateController, err := apikey.NewCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	apiKeyListController, err := apikey.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	apiKeyRevokeController, err := apikey.NewRevokeController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// playlist
+	playlistCreateController, err := playlist.NewCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistUpdateController, err := playlist.NewUpdateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistGetController, err := playlist.NewGetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistListController, err := playlist.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistDeleteController, err := playlist.NewDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// watch history
+	historyListController, err := history.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	historyContinueController, err := history.NewContinueController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// quota
+	quotaGetController, err := quota.NewGetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// analytics
+	analyticsGetController, err := analytics.NewGetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	analyticsListController, err := analytics.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	// video
 	videoCreateController, err := video.NewCreateController(app.di)
 	if err != nil {
@@ -645,6 +1273,14 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
+	videoTrashController, err := video.NewTrashController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	videoRestoreController, err := video.NewRestoreController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
 
 	return []controller.Controller{
 		// resource
@@ -655,6 +1291,22 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 		videoGetController,
 		videoListController,
 		videoDeleteController,
+		videoTrashController,
+		videoRestoreController,
+		// playlist
+		playlistCreateController,
+		playlistUpdateController,
+		playlistGetController,
+		playlistListController,
+		playlistDeleteController,
+		// watch history
+		historyListController,
+		historyContinueController,
+		// quota
+		quotaGetController,
+		// analytics
+		analyticsGetController,
+		analyticsListController,
 		// audio
 		audio.NewCreateController(),
 		audio.NewDeleteController(),
@@ -665,6 +1317,15 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 		userUpdateController,
 		userGetController,
 		userDeleteController,
+		// two-factor auth.
+		twoFactorEnableController,
+		twoFactorConfirmController,
+		twoFactorDisableController,
+		twoFactorRegenerateController,
+		// API keys
+		apiKeyCreateController,
+		apiKeyListController,
+		apiKeyRevokeController,
 	}, nil
 }
 
@@ -679,6 +1340,11 @@ func (app *ResourcesApp) InitUnauthedRestApiControllers() ([]controller.Controll
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	mfaAuthorizationController, err := auth.NewMFAAuthorizationController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	registrationController, err := auth.NewRegistrationController(app.di)
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -686,6 +1352,7 @@ func (app *ResourcesApp) InitUnauthedRestApiControllers() ([]controller.Controll
 
 	return []controller.Controller{
 		authorizationController,
+		mfaAuthorizationController,
 		registrationController,
 	}, nil
 }
@@ -738,41 +1405,43 @@ func (app *ResourcesApp) InitStaticServingControllers() ([]controller.Controller
 	}, nil
 }
 
-func (app *ResourcesApp) InitHttpServer(wg *sync.WaitGroup) error {
+// InitHttpServer - starts the server, the returned channel receives the result of listening
+// (the error means that the server could not be started or stopped unexpectedly).
+func (app *ResourcesApp) InitHttpServer(wg *sync.WaitGroup) (listenErrCh <-chan error, err error) {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx, err := app.di.GetCtx()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 
 	// RestAPI
 	authedRestAPIControllers, err := app.InitAuthedRestApiControllers()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 	unauthedRestAPIController, err := app.InitUnauthedRestApiControllers()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 
 	// HTML rendering
 	authedNativeControllers, err := app.InitAuthedNativeRenderingControllers()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 	unauthedNativeControllers, err := app.InitUnauthedNativeRenderingControllers()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 
 	// Static files
 	staticFilesControllers, err := app.InitStaticServingControllers()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 
 	server, err := http.NewHttpServer(
@@ -784,11 +1453,12 @@ func (app *ResourcesApp) InitHttpServer(wg *sync.WaitGroup) error {
 		staticFilesControllers,
 	)
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 
+	errCh := make(chan error, 1)
 	wg.Add(1)
-	go server.Listen(ctx, wg)
+	go func() { errCh <- server.Listen(ctx, wg) }()
 
-	return nil
+	return errCh, nil
 }
@@ -3,19 +3,28 @@ package stream
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/app"
-	loggerservice "github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	analyticsservice "github.com/Borislavv/video-streaming/internal/domain/service/analytics"
+	analytics_interface "github.com/Borislavv/video-streaming/internal/domain/service/analytics/interface"
 	cacheservice "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	historyservice "github.com/Borislavv/video-streaming/internal/domain/service/history"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
+	quotaservice "github.com/Borislavv/video-streaming/internal/domain/service/quota"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
 	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/validator"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
 	mongodb_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	server "github.com/Borislavv/video-streaming/internal/infrastructure/server/ws"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher"
+	cacher_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/detector"
 	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
-	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/health"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader"
 	reader_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer"
@@ -26,19 +35,20 @@ import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener"
 	listener_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
 	streamer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
 	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/ws"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room"
+	room_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tokenizer"
-	"github.com/caarlos0/env/v9"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
-	"log"
-	"os"
-	"os/signal"
 	"reflect"
 	"sync"
-	"syscall"
 	"time"
 )
 
@@ -51,150 +61,193 @@ func NewStreamingApp(di di_interface.ContainerManager) *StreamingApp {
 	return &StreamingApp{cfg: &app.Config{}, di: di}
 }
 
-// Run is method which running the streaming part of app
-func (app *StreamingApp) Run(mWg *sync.WaitGroup) {
-	defer mWg.Done()
+// Run is method which running the streaming part of app until the given context will be canceled.
+// The error is returned if the app could not be started or its server stopped unexpectedly.
+func (app *StreamingApp) Run(ctx context.Context) error {
 	wg := &sync.WaitGroup{}
 
-	// ctx, cancelFunc
-	app.InitAppCtx()
+	// ctx, cancelFunc (the app context is canceled anyway, even if the app failed on start)
+	cancel := app.InitAppCtx(ctx)
+	defer cancel()
 
-	// logger
-	loggerService, loggerCancelFunc, err := app.InitLoggerService(wg)
+	// logger (shared by all apps)
+	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
-	defer loggerCancelFunc()
 
-	// Config
+	// config (shared by all apps)
 	if err = app.InitConfig(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// tracing
+	tracerShutdownFunc, err := app.InitTracerService()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
+	defer tracerShutdownFunc()
 
 	// mongo database
 	databaseCancelFunc, err := app.InitMongoDatabase()
 	if err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 	defer databaseCancelFunc()
 
+	// liveness and readiness probes
+	healthChecker, err := app.InitHealthChecker()
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
 	// cache dependencies initialization
 	if err = app.InitCacheService(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// video dependencies initialization
 	if err = app.InitVideoServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// watch history (the progress is reported by client side and saved by the streaming)
+	if err = app.InitWatchHistoryServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// analytics (the play sessions are recorded by the streaming)
+	if err = app.InitAnalyticsServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// resource reader service
 	if err = app.InitFileReaderService(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// custom websocket communication protocol
 	if err = app.InitWebSocketCommunicator(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// resource codecs detector
 	if err = app.InitCodecsInfoService(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// token services
 	if err = app.InitTokenServices(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// resource repository (the recorded live broadcasts are saved as resources)
+	if err = app.InitResourceRepository(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// storage quotas (the recorded live broadcasts are counted into the used storage)
+	if err = app.InitQuotaServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// live broadcasting hub and publisher
+	if err = app.InitLiveServices(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	// watch-party rooms
+	if err = app.InitRoomsService(); err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// websocket actions listener
 	if err = app.InitWebSocketListener(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// websocket actions handler
 	if err = app.InitWebSocketHandler(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// resource streaming service
 	if err = app.InitStreamingService(); err != nil {
-		loggerService.Critical(err)
-		return
+		return loggerService.CriticalPropagate(err)
 	}
 
 	// WebSocket server
-	if err = app.InitWebSocketServer(wg); err != nil {
-		loggerService.Critical(err)
-		return
+	listenErrCh, err := app.InitWebSocketServer(wg)
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
 	}
 
-	<-app.shutdown()
-}
+	select {
+	case <-ctx.Done():
+		// the readiness is failed first, so the orchestrator stops routing the traffic before the server will be stopped
+		healthChecker.Shutdown()
+		time.Sleep(app.cfg.HealthShutdownDelay)
+	case err = <-listenErrCh:
+		// the server could not be started or stopped unexpectedly, so the app is failed
+	}
 
-func (app *StreamingApp) shutdown() chan os.Signal {
-	stopCh := make(chan os.Signal, 1)
-	signal.Notify(stopCh, os.Interrupt, syscall.SIGTERM)
-	return stopCh
+	// the servers must be drained before the database and the tracer will be closed by the deferred funcs
+	cancel()
+	wg.Wait()
+
+	return err
 }
 
-func (app *StreamingApp) InitAppCtx() {
-	ctx, cancel := context.WithCancel(context.Background())
+// InitAppCtx - the app context is derived from the given one (canceled on shutdown), but it is canceled separately
+// when the app is stopped, so the servers can be drained before the rest resources will be closed.
+func (app *StreamingApp) InitAppCtx(parent context.Context) context.CancelFunc {
+	ctx, cancel := context.WithCancel(parent)
 
 	app.di.
 		Set(ctx, reflect.TypeOf((*context.Context)(nil))).
 		Set(cancel, reflect.TypeOf((*context.CancelFunc)(nil)))
+
+	return cancel
 }
 
-func (app *StreamingApp) InitLoggerService(wg *sync.WaitGroup) (
-	loggerService loggerservice.Logger,
-	deferFunc func(),
-	err error,
-) {
-	ctx, err := app.di.GetCtx()
+// InitConfig - the config is parsed and validated once by the supervisor and shared by all apps.
+func (app *StreamingApp) InitConfig() error {
+	cfg, err := app.di.GetConfig()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	cancel, err := app.di.GetCancelFunc()
+	app.cfg = cfg
+
+	return nil
+}
+
+func (app *StreamingApp) InitTracerService() (deferFunc func(), err error) {
+	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	loggerService, cls := logger.NewStdOut(ctx, app.cfg.LoggerErrorsBufferCap, app.cfg.LoggerRequestsBufferCap)
+	t, err := tracer.NewOTelTracer(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
 
-	app.di.
-		Set(loggerService, reflect.TypeOf((*loggerservice.Logger)(nil))).
-		Set(loggerService, nil)
-
-	return loggerService,
-		func() {
-			cancel()
-			wg.Wait()
-			cls()
-		}, nil
-}
+	deferFunc = func() {
+		// the app. context is already canceled here, so the buffered spans are flushed within a separate timeout
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
 
-func (app *StreamingApp) InitConfig() error {
-	if err := env.Parse(app.cfg); err != nil {
-		return err
+		if err = t.Shutdown(ctx); err != nil {
+			loggerService.Error(err)
+		}
 	}
 
 	app.di.
-		Set(app.cfg, nil)
+		Set(t, reflect.TypeOf((*tracer_interface.Tracer)(nil))).
+		Set(t, nil)
 
-	return nil
+	return deferFunc, nil
 }
 
 func (app *StreamingApp) InitMongoDatabase() (deferFunc func(), err error) {
@@ -208,7 +261,18 @@ func (app *StreamingApp) InitMongoDatabase() (deferFunc func(), err error) {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	c, err := mongo.Connect(ctx, options.Client().ApplyURI(app.cfg.MongoUri))
+	metricsService, err := app.di.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := app.di.GetTracerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	monitor := mongodb.NewCommandMonitor(metricsService, tracerService)
+
+	c, err := mongo.Connect(ctx, options.Client().ApplyURI(app.cfg.MongoUri).SetMonitor(monitor))
 	if err != nil {
 		return nil, loggerService.CriticalPropagate(err)
 	}
@@ -230,15 +294,81 @@ func (app *StreamingApp) InitMongoDatabase() (deferFunc func(), err error) {
 	return deferFunc, nil
 }
 
+func (app *StreamingApp) InitHealthChecker() (checker health_interface.Checker, err error) {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := app.di.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// the process must be restarted only if it's stuck, the unavailable dependencies affect only the readiness
+	liveness := []health_interface.Check{
+		health.NewLoggerCheck(loggerService, app.cfg.HealthLoggerSaturationThreshold),
+	}
+	readiness := []health_interface.Check{
+		health.NewMongoCheck(database),
+		health.NewBinaryCheck("ffprobe"),
+		health.NewLoggerCheck(loggerService, app.cfg.HealthLoggerSaturationThreshold),
+	}
+
+	c, err := health.NewHealthChecker(app.di, liveness, readiness)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	app.di.
+		Set(c, reflect.TypeOf((*health_interface.Checker)(nil))).
+		Set(c, nil)
+
+	return c, nil
+}
+
 func (app *StreamingApp) InitCacheService() error {
-	ctx, err := app.di.GetCtx()
+	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
 		return err
 	}
 
+	ctx, err := app.di.GetCtx()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	metricsService, err := app.di.GetMetricsService()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := app.di.GetTracerService()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	var storage cacher_interface.Storage
+	switch app.cfg.CacheStorage {
+	case "redis":
+		if storage, err = cacher.NewRedisCacheStorage(app.di); err != nil {
+			return loggerService.LogPropagate(err)
+		}
+	default:
+		storage = cacher.NewMapCacheStorage(ctx, metricsService, cacher.MapCacheOptions{
+			Policy:               app.cfg.CacheEvictionPolicy,
+			MaxEntries:           app.cfg.CacheMaxEntries,
+			MaxBytes:             app.cfg.CacheMaxBytes,
+			Shards:               app.cfg.CacheShards,
+			StaleWhileRevalidate: app.cfg.CacheStaleWhileRevalidate,
+			NegativeTTL:          app.cfg.CacheNegativeTTL,
+		})
+	}
+
 	c := cacher.NewCache(
-		cacher.NewMapCacheStorage(ctx),
+		storage,
 		cacher.NewCacheDisplacer(ctx, time.Second*1),
+		tracerService,
 	)
 
 	app.di.
@@ -270,6 +400,92 @@ func (app *StreamingApp) InitVideoServices() error {
 		Set(c, reflect.TypeOf((*repository_interface.Video)(nil))).
 		Set(c, nil)
 
+	p, err := mongodb.NewPlaylistRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(p, reflect.TypeOf((*repository_interface.Playlist)(nil))).
+		Set(p, reflect.TypeOf((*mongodb_interface.Playlist)(nil))).
+		Set(p, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitWatchHistoryServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewWatchProgressRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repository_interface.WatchProgress)(nil))).
+		Set(r, reflect.TypeOf((*mongodb_interface.WatchProgress)(nil))).
+		Set(r, nil)
+
+	v, err := validator.NewWatchHistoryValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validator_interface.WatchHistory)(nil))).
+		Set(v, nil)
+
+	s, err := historyservice.NewWatchHistoryService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*history_interface.WatchHistory)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitAnalyticsServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	sr, err := mongodb.NewPlaySessionRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(sr, reflect.TypeOf((*repository_interface.PlaySession)(nil))).
+		Set(sr, reflect.TypeOf((*mongodb_interface.PlaySession)(nil))).
+		Set(sr, nil)
+
+	vr, err := mongodb.NewVideoStatsRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(vr, reflect.TypeOf((*repository_interface.VideoStats)(nil))).
+		Set(vr, reflect.TypeOf((*mongodb_interface.VideoStats)(nil))).
+		Set(vr, nil)
+
+	v, err := validator.NewAnalyticsValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validator_interface.Analytics)(nil))).
+		Set(v, nil)
+
+	s, err := analyticsservice.NewAnalyticsService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*analytics_interface.Analytics)(nil))).
+		Set(s, nil)
+
 	return nil
 }
 
@@ -324,6 +540,99 @@ func (app *StreamingApp) InitCodecsInfoService() error {
 	return nil
 }
 
+func (app *StreamingApp) InitResourceRepository() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewResourceRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*mongodb_interface.Resource)(nil))).
+		Set(r, nil)
+
+	c, err := cache.NewResourceRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repository_interface.Resource)(nil))).
+		Set(c, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitQuotaServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewQuotaRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repository_interface.Quota)(nil))).
+		Set(r, reflect.TypeOf((*mongodb_interface.Quota)(nil))).
+		Set(r, nil)
+
+	s, err := quotaservice.NewQuotaService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*quota_interface.Quota)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitLiveServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	h, err := live.NewHub(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(h, reflect.TypeOf((*live_interface.Hub)(nil))).
+		Set(h, nil)
+
+	p, err := live.NewPublisher(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(p, reflect.TypeOf((*live_interface.Publisher)(nil))).
+		Set(p, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitRoomsService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := room.NewRooms(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*room_interface.Rooms)(nil))).
+		Set(r, nil)
+
+	return nil
+}
+
 func (app *StreamingApp) InitWebSocketListener() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -352,10 +661,70 @@ func (app *StreamingApp) InitWebSocketHandler() error {
 	if err != nil {
 		return loggerService.LogPropagate(err)
 	}
+	streamByIDWithOffsetStrategy, err := strategy.NewStreamByIDWithOffsetActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	streamPlaylistStrategy, err := strategy.NewStreamPlaylistActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	streamLiveStrategy, err := strategy.NewStreamLiveActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	roomCreateStrategy, err := strategy.NewRoomCreateActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	roomJoinStrategy, err := strategy.NewRoomJoinActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	roomLeaveStrategy, err := strategy.NewRoomLeaveActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	roomControlStrategy, err := strategy.NewRoomControlActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	roomTransferStrategy, err := strategy.NewRoomTransferActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	roomSyncStrategy, err := strategy.NewRoomSyncActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	progressStrategy, err := strategy.NewProgressActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
 	app.di.
 		Set(streamByIDStrategy, nil).
+		Set(streamByIDWithOffsetStrategy, nil).
+		Set(streamPlaylistStrategy, nil).
+		Set(streamLiveStrategy, nil).
+		Set(roomCreateStrategy, nil).
+		Set(roomJoinStrategy, nil).
+		Set(roomLeaveStrategy, nil).
+		Set(roomControlStrategy, nil).
+		Set(roomTransferStrategy, nil).
+		Set(roomSyncStrategy, nil).
+		Set(progressStrategy, nil).
 		Set([]strategy_interface.ActionStrategy{
 			streamByIDStrategy,
+			streamByIDWithOffsetStrategy,
+			streamPlaylistStrategy,
+			streamLiveStrategy,
+			roomCreateStrategy,
+			roomJoinStrategy,
+			roomLeaveStrategy,
+			roomControlStrategy,
+			roomTransferStrategy,
+			roomSyncStrategy,
+			progressStrategy,
 		}, reflect.TypeOf((*[]strategy_interface.ActionStrategy)(nil)))
 
 	// handler which use strategies
@@ -412,24 +781,27 @@ func (app *StreamingApp) InitTokenServices() error {
 	return nil
 }
 
-func (app *StreamingApp) InitWebSocketServer(wg *sync.WaitGroup) error {
+// InitWebSocketServer - starts the server, the returned channel receives the result of listening
+// (the error means that the server could not be started or stopped unexpectedly).
+func (app *StreamingApp) InitWebSocketServer(wg *sync.WaitGroup) (listenErrCh <-chan error, err error) {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx, err := app.di.GetCtx()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 
 	s, err := server.NewWebSocketServer(app.di)
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return nil, loggerService.LogPropagate(err)
 	}
 
+	errCh := make(chan error, 1)
 	wg.Add(1)
-	go s.Listen(ctx, wg)
+	go func() { errCh <- s.Listen(ctx, wg) }()
 
-	return nil
+	return errCh, nil
 }
@@ -0,0 +1,227 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/app"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"github.com/caarlos0/env/v9"
+	"os"
+	"os/signal"
+	"reflect"
+	"runtime/debug"
+	"syscall"
+	"time"
+)
+
+const (
+	// RestartNever - the whole process is stopped if any app failed.
+	RestartNever = "never"
+	// RestartOnFailure - the failed app is restarted until the max. number of restarts is reached.
+	RestartOnFailure = "on-failure"
+)
+
+// App - a part of the process which is supervised (the REST API, the streaming, etc.).
+type App interface {
+	// Run - runs the app until the given context will be canceled.
+	// The error means that the app could not be started or stopped unexpectedly.
+	Run(ctx context.Context) error
+}
+
+// Factory - builds a new app on each (re)start. The given container is a child of the root one,
+// so the app shares the config, the logger and the metrics, but its own services are isolated.
+type Factory func(serviceContainer di_interface.ContainerManager) App
+
+// Supervisor - owns the signals handling and the shared services of the apps. It runs the apps concurrently
+// and restarts them or fails fast according to the policy, so no app is left half-alive when the other one failed.
+type Supervisor struct {
+	cfg       *app.Config
+	di        di_interface.ContainerManager
+	names     []string
+	factories map[string]Factory
+	// tasks are the apps which are done by themselves (maintenance commands), they are never restarted
+	tasks map[string]struct{}
+}
+
+func NewSupervisor(di di_interface.ContainerManager) *Supervisor {
+	return &Supervisor{
+		cfg:       &app.Config{},
+		di:        di,
+		factories: make(map[string]Factory),
+		tasks:     make(map[string]struct{}),
+	}
+}
+
+// Register - adds the app which may be run by the given name.
+func (s *Supervisor) Register(name string, factory Factory) *Supervisor {
+	if _, exists := s.factories[name]; !exists {
+		s.names = append(s.names, name)
+	}
+	s.factories[name] = factory
+	return s
+}
+
+// RegisterTask - adds the app which is done by itself and may be run by the given name, the nil error of it
+// means that the task is completed. The tasks are not a part of the registered apps names (they run separately).
+func (s *Supervisor) RegisterTask(name string, factory Factory) *Supervisor {
+	s.tasks[name] = struct{}{}
+	s.factories[name] = factory
+	return s
+}
+
+// Names - returns the names of the registered apps in order of registration.
+func (s *Supervisor) Names() []string {
+	return append([]string(nil), s.names...)
+}
+
+// Run - runs the apps by the given names until SIGINT/SIGTERM is received or any app failed.
+// The first error of the apps is returned (the rest apps are drained before).
+func (s *Supervisor) Run(names ...string) error {
+	for _, name := range names {
+		if _, found := s.factories[name]; !found {
+			return fmt.Errorf("supervisor: undefined app '%v'", name)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the config must be parsed before the logger, because the logger settings are a part of it
+	if err := env.Parse(s.cfg); err != nil {
+		return err
+	}
+
+	loggerService, closeFunc, err := s.InitLoggerService(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFunc()
+
+	s.di.Set(s.cfg, nil)
+
+	if err = s.InitMetricsService(); err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	errCh := make(chan error, len(names))
+	for _, name := range names {
+		go func(name string, factory Factory) {
+			supErr := s.supervise(ctx, loggerService, name, factory)
+			if supErr != nil {
+				supErr = fmt.Errorf("app '%v' failed: %w", name, supErr)
+				// fail fast, the rest apps are drained
+				cancel()
+			}
+			errCh <- supErr
+		}(name, s.factories[name])
+	}
+
+	for range names {
+		if appErr := <-errCh; appErr != nil && err == nil {
+			err = appErr
+		}
+	}
+
+	if err != nil {
+		return loggerService.CriticalPropagate(err)
+	}
+
+	loggerService.Info("all apps are stopped")
+
+	return nil
+}
+
+func (s *Supervisor) InitLoggerService(ctx context.Context) (
+	loggerService logger_interface.Logger,
+	closeFunc func(),
+	err error,
+) {
+	opts, err := s.cfg.LoggerOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	structured, closeFunc := logger.NewStructured(ctx, opts)
+
+	s.di.
+		Set(structured, reflect.TypeOf((*logger_interface.Logger)(nil))).
+		Set(structured, nil)
+
+	return structured, closeFunc, nil
+}
+
+func (s *Supervisor) InitMetricsService() error {
+	loggerService, err := s.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	m, err := metrics.NewPrometheusMetrics(s.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	s.di.
+		Set(m, reflect.TypeOf((*metrics_interface.Metrics)(nil))).
+		Set(m, nil)
+
+	return nil
+}
+
+// supervise - runs the app and restarts it according to the policy. Nil is returned if the app was stopped
+// by the context cancellation, otherwise the last error of the app.
+func (s *Supervisor) supervise(ctx context.Context, loggerService logger_interface.Logger, name string, factory Factory) error {
+	for restarts := 0; ; restarts++ {
+		loggerService.Info(fmt.Sprintf("app '%v' is starting", name))
+
+		err := s.run(ctx, factory)
+		if ctx.Err() != nil {
+			return err
+		}
+		if _, isTask := s.tasks[name]; isTask {
+			if err == nil {
+				loggerService.Info(fmt.Sprintf("task '%v' is completed", name))
+			}
+			return err
+		}
+		if err == nil {
+			err = fmt.Errorf("app '%v' stopped unexpectedly", name)
+		}
+
+		if s.cfg.SupervisorRestartPolicy != RestartOnFailure || restarts >= s.cfg.SupervisorMaxRestarts {
+			return err
+		}
+
+		backoff := s.cfg.SupervisorRestartBackoff * time.Duration(restarts+1)
+		loggerService.Error(
+			fmt.Sprintf("app '%v' failed: %v, restarting in %v (%d/%d)",
+				name, err.Error(), backoff, restarts+1, s.cfg.SupervisorMaxRestarts,
+			),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// run - runs the new app within a child container, the panic of app is converted into the error.
+func (s *Supervisor) run(ctx context.Context, factory Factory) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return factory(di.NewChildServiceContainerManager(s.di)).Run(ctx)
+}
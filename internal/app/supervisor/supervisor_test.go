@@ -0,0 +1,219 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testApp - runs the given func, the number of starts is counted by the factory.
+type testApp struct {
+	App
+	run func(ctx context.Context) error
+}
+
+func (a *testApp) Run(ctx context.Context) error {
+	return a.run(ctx)
+}
+
+// newTestFactory - the run func receives the number of the current start (from 1).
+func newTestFactory(starts *int32, run func(ctx context.Context, start int32) error) Factory {
+	return func(serviceContainer di_interface.ContainerManager) App {
+		start := atomic.AddInt32(starts, 1)
+		return &testApp{run: func(ctx context.Context) error { return run(ctx, start) }}
+	}
+}
+
+func TestSupervisor_Supervise(t *testing.T) {
+	appErr := errors.New("app failed")
+
+	tests := []struct {
+		name        string
+		policy      string
+		maxRestarts int
+		isTask      bool
+		run         func(ctx context.Context, cancel context.CancelFunc, start int32) error
+		starts      int32
+		err         string // substring of the expected error, empty means no error
+	}{
+		{
+			name:   "failed app is not restarted by never policy",
+			policy: RestartNever,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				return appErr
+			},
+			starts: 1,
+			err:    appErr.Error(),
+		},
+		{
+			name:   "stopped app is a failure",
+			policy: RestartNever,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				return nil
+			},
+			starts: 1,
+			err:    "stopped unexpectedly",
+		},
+		{
+			name:        "failed app is restarted by on-failure policy",
+			policy:      RestartOnFailure,
+			maxRestarts: 5,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				if start < 3 {
+					return appErr
+				}
+				// the third start is healthy and works until the shutdown
+				cancel()
+				<-ctx.Done()
+				return nil
+			},
+			starts: 3,
+		},
+		{
+			name:        "restarts are limited",
+			policy:      RestartOnFailure,
+			maxRestarts: 2,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				return appErr
+			},
+			starts: 3,
+			err:    appErr.Error(),
+		},
+		{
+			name:        "panic is converted into error",
+			policy:      RestartOnFailure,
+			maxRestarts: 1,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				panic("nil map")
+			},
+			starts: 2,
+			err:    "panic: nil map",
+		},
+		{
+			name:        "completed task is not restarted",
+			policy:      RestartOnFailure,
+			maxRestarts: 5,
+			isTask:      true,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				return nil
+			},
+			starts: 1,
+		},
+		{
+			name:        "failed task is not restarted",
+			policy:      RestartOnFailure,
+			maxRestarts: 5,
+			isTask:      true,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				return appErr
+			},
+			starts: 1,
+			err:    appErr.Error(),
+		},
+		{
+			name:        "shutdown during backoff",
+			policy:      RestartOnFailure,
+			maxRestarts: 5,
+			run: func(ctx context.Context, cancel context.CancelFunc, start int32) error {
+				cancel()
+				return appErr
+			},
+			starts: 1,
+			err:    appErr.Error(), // the error of the app which was stopped by the shutdown is kept
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			s := NewSupervisor(di.NewServiceContainerManager())
+			s.cfg.SupervisorRestartPolicy = tt.policy
+			s.cfg.SupervisorMaxRestarts = tt.maxRestarts
+			s.cfg.SupervisorRestartBackoff = time.Millisecond
+
+			starts := int32(0)
+			factory := newTestFactory(&starts, func(ctx context.Context, start int32) error {
+				return tt.run(ctx, cancel, start)
+			})
+			if tt.isTask {
+				s.RegisterTask("app", factory)
+			} else {
+				s.Register("app", factory)
+			}
+
+			err := s.supervise(ctx, logger_stub.NewLogger(), "app", factory)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+			}
+
+			if starts != tt.starts {
+				t.Errorf("starts = %d, want %d", starts, tt.starts)
+			}
+		})
+	}
+}
+
+func TestSupervisor_Run(t *testing.T) {
+	t.Setenv("LOGGER_SINKS", "stderr")
+	t.Setenv("LOGGER_LEVEL", "CRITICAL")
+	t.Setenv("SUPERVISOR_RESTART_POLICY", RestartNever)
+
+	tests := []struct {
+		name      string
+		names     []string
+		isDrained bool // the healthy app must be stopped because of the failed one
+		err       string
+	}{
+		{name: "undefined app", names: []string{"healthy", "unknown"}, err: "undefined app 'unknown'"},
+		{name: "failed app drains the rest", names: []string{"healthy", "failed"}, isDrained: true, err: "app 'failed' failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthyStarts, failedStarts := int32(0), int32(0)
+			isDrained := &atomic.Bool{}
+
+			s := NewSupervisor(di.NewServiceContainerManager()).
+				Register("healthy", newTestFactory(&healthyStarts, func(ctx context.Context, start int32) error {
+					<-ctx.Done()
+					isDrained.Store(true)
+					return nil
+				})).
+				Register("failed", newTestFactory(&failedStarts, func(ctx context.Context, start int32) error {
+					return errors.New("port is already in use")
+				}))
+
+			if names := s.Names(); len(names) != 2 || names[0] != "healthy" || names[1] != "failed" {
+				t.Fatalf("names = %v, want [healthy failed]", names)
+			}
+
+			done := make(chan error, 1)
+			go func() { done <- s.Run(tt.names...) }()
+
+			select {
+			case err := <-done:
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("the supervisor was not stopped")
+			}
+
+			if isDrained.Load() != tt.isDrained {
+				t.Errorf("healthy app is drained = %v, want %v", isDrained.Load(), tt.isDrained)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package agg
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type Quota struct {
+	entity.Quota `bson:",inline"`
+
+	// Timestamp.UpdatedAt is the time of the last change of the used storage
+	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
+}
@@ -3,12 +3,15 @@ package agg
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
 )
 
 type Resource struct {
 	entity.Resource `bson:",inline"`
 
 	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
+	// DeletedAt is set while the resource is in the trash with its video (the file is kept until the purge).
+	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 }
 
 func (r *Resource) GetName() string {
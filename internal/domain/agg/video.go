@@ -3,6 +3,7 @@ package agg
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
 )
 
 type Video struct {
@@ -10,4 +11,6 @@ type Video struct {
 
 	Resource  entity.Resource `json:"resource" bson:"resource"`
 	Timestamp vo.Timestamp    `json:"timestamp" bson:",inline"`
+	// DeletedAt is set while the video is in the trash (it's purged after the retention period).
+	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 }
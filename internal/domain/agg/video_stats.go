@@ -0,0 +1,13 @@
+package agg
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type VideoStats struct {
+	entity.VideoStats `bson:",inline"`
+
+	// Timestamp.CreatedAt is the time of the first play, Timestamp.UpdatedAt is the time of the last one
+	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
+}
@@ -0,0 +1,13 @@
+package agg
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type WatchProgress struct {
+	entity.WatchProgress `bson:",inline"`
+
+	// Timestamp.UpdatedAt is the time of the last watching
+	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
+}
@@ -0,0 +1,144 @@
+package builder
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AnalyticsBuilder struct {
+	logger    logger_interface.Logger
+	extractor extractor_interface.RequestParams
+}
+
+// NewAnalyticsBuilder is a constructor of AnalyticsBuilder
+func NewAnalyticsBuilder(serviceContainer di_interface.ContainerManager) (*AnalyticsBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AnalyticsBuilder{
+		logger:    loggerService,
+		extractor: requestParametersExtractor,
+	}, nil
+}
+
+// BuildGetRequestDTOFromRequest - build a dto.GetVideoAnalyticsRequest from raw *http.Request
+func (b *AnalyticsBuilder) BuildGetRequestDTOFromRequest(r *http.Request) (*dto.VideoAnalyticsGetRequestDTO, error) {
+	analyticsDTO := &dto.VideoAnalyticsGetRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		analyticsDTO.UserID = userID
+	}
+
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	analyticsDTO.VideoID = vo.ID{Value: oID}
+
+	if analyticsDTO.From, analyticsDTO.To, err = b.buildRange(r); err != nil {
+		return nil, err
+	}
+
+	return analyticsDTO, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListVideoAnalyticsRequest from raw *http.Request
+func (b *AnalyticsBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.VideoAnalyticsListRequestDTO, error) {
+	analyticsDTO := &dto.VideoAnalyticsListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		analyticsDTO.UserID = userID
+	}
+
+	var err error
+	if analyticsDTO.From, analyticsDTO.To, err = b.buildRange(r); err != nil {
+		return nil, err
+	}
+
+	if b.extractor.HasParameter(pageField, r) {
+		pg, _ := b.extractor.GetParameter(pageField, r)
+		pgi, atoiErr := strconv.Atoi(pg)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		analyticsDTO.Page = pgi
+	} else {
+		analyticsDTO.Page = pageDefaultValue
+	}
+	if b.extractor.HasParameter(limitField, r) {
+		l, _ := b.extractor.GetParameter(limitField, r)
+		li, atoiErr := strconv.Atoi(l)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		analyticsDTO.Limit = li
+	} else {
+		analyticsDTO.Limit = limitDefaultValue
+	}
+	if b.extractor.HasParameter(cursorField, r) {
+		if c, err := b.extractor.GetParameter(cursorField, r); err == nil {
+			analyticsDTO.Cursor = c
+		}
+	}
+
+	return analyticsDTO, nil
+}
+
+// buildRange - parses the optional range of time, both of boundaries are included.
+func (b *AnalyticsBuilder) buildRange(r *http.Request) (from time.Time, to time.Time, err error) {
+	if b.extractor.HasParameter(fromField, r) {
+		f, _ := b.extractor.GetParameter(fromField, r)
+		if from, err = helper.ParseTime(f); err != nil {
+			return time.Time{}, time.Time{}, b.logger.LogPropagate(err)
+		}
+	}
+	if b.extractor.HasParameter(toField, r) {
+		t, _ := b.extractor.GetParameter(toField, r)
+		if to, err = helper.ParseTime(t); err != nil {
+			return time.Time{}, time.Time{}, b.logger.LogPropagate(err)
+		}
+	}
+	return from, to, nil
+}
+
+// BuildGetResponseDTO - build a dto.VideoAnalyticsResponseDTO
+func (b *AnalyticsBuilder) BuildGetResponseDTO(totals *agg.VideoStats, daily []*agg.VideoStats) *dto.VideoAnalyticsResponseDTO {
+	return &dto.VideoAnalyticsResponseDTO{
+		Totals: totals,
+		Daily:  daily,
+	}
+}
+
+// BuildListResponseDTO - build a dto.ListResponseDTO with pagination cursors of adjacent pages
+// (the analytics is ordered by the number of plays).
+func (b *AnalyticsBuilder) BuildListResponseDTO(
+	req dto_interface.ListVideoAnalyticsRequest, list []*agg.VideoStats, total int64,
+) *dto.ListResponseDTO {
+	return dto.NewListResponseDTO(list, buildPaginationResponseDTO(req, total, len(list), func(i int, isPrev bool) vo.Cursor {
+		return vo.NewNumberCursor(list[i].VideoID, list[i].Plays, isPrev)
+	}))
+}
@@ -0,0 +1,109 @@
+package builder
+
+import (
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"time"
+)
+
+type APIKeyBuilder struct {
+	logger    logger_interface.Logger
+	extractor extractor_interface.RequestParams
+}
+
+// NewAPIKeyBuilder is a constructor of APIKeyBuilder
+func NewAPIKeyBuilder(serviceContainer di_interface.ContainerManager) (*APIKeyBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &APIKeyBuilder{
+		logger:    loggerService,
+		extractor: requestParametersExtractor,
+	}, nil
+}
+
+// BuildCreateRequestDTOFromRequest - build a dto.CreateAPIKeyRequest from raw *http.Request
+func (b *APIKeyBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.APIKeyCreateRequestDTO, error) {
+	apiKeyDTO := &dto.APIKeyCreateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(apiKeyDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errors.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		apiKeyDTO.UserID = userID
+	}
+
+	return apiKeyDTO, nil
+}
+
+// BuildAggFromCreateRequestDTO - build an agg.APIKey from dto.CreateAPIKeyRequest.
+// The key hash and prefix must be set up by the caller which generates the raw key.
+func (b *APIKeyBuilder) BuildAggFromCreateRequestDTO(req dto_interface.CreateAPIKeyRequest) (*agg.APIKey, error) {
+	return &agg.APIKey{
+		APIKey: entity.APIKey{
+			UserID: req.GetUserID(),
+			Name:   req.GetName(),
+			Scopes: req.GetScopes(),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListAPIKeyRequest from raw *http.Request
+func (b *APIKeyBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.APIKeyListRequestDTO, error) {
+	apiKeyDTO := &dto.APIKeyListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		apiKeyDTO.UserID = userID
+	}
+
+	return apiKeyDTO, nil
+}
+
+// BuildRevokeRequestDTOFromRequest - build a dto.RevokeAPIKeyRequest from raw *http.Request
+func (b *APIKeyBuilder) BuildRevokeRequestDTOFromRequest(r *http.Request) (*dto.APIKeyRevokeRequestDTO, error) {
+	apiKeyDTO := &dto.APIKeyRevokeRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		apiKeyDTO.UserID = userID
+	}
+
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	apiKeyDTO.ID = vo.ID{Value: oID}
+
+	return apiKeyDTO, nil
+}
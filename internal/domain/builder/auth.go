@@ -34,3 +34,14 @@ func (b *AuthBuilder) BuildAuthRequestDTOFromRequest(r *http.Request) (dto_inter
 	}
 	return authDTO, nil
 }
+
+func (b *AuthBuilder) BuildAuthMFARequestDTOFromRequest(r *http.Request) (dto_interface.AuthMFARequest, error) {
+	mfaDTO := &dto.AuthMFARequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(mfaDTO); err != nil {
+		if err == io.EOF {
+			return nil, errors.NewRequestBodyIsEmptyError()
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+	return mfaDTO, nil
+}
@@ -0,0 +1,15 @@
+package builder_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type Analytics interface {
+	BuildGetRequestDTOFromRequest(r *http.Request) (*dto.VideoAnalyticsGetRequestDTO, error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.VideoAnalyticsListRequestDTO, error)
+	BuildGetResponseDTO(totals *agg.VideoStats, daily []*agg.VideoStats) *dto.VideoAnalyticsResponseDTO
+	BuildListResponseDTO(reqDTO dto_interface.ListVideoAnalyticsRequest, list []*agg.VideoStats, total int64) *dto.ListResponseDTO
+}
@@ -0,0 +1,15 @@
+package builder_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type APIKey interface {
+	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.APIKeyCreateRequestDTO, error)
+	BuildAggFromCreateRequestDTO(reqDTO dto_interface.CreateAPIKeyRequest) (*agg.APIKey, error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.APIKeyListRequestDTO, error)
+	BuildRevokeRequestDTOFromRequest(r *http.Request) (*dto.APIKeyRevokeRequestDTO, error)
+}
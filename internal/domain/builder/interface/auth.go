@@ -7,4 +7,5 @@ import (
 
 type Auth interface {
 	BuildAuthRequestDTOFromRequest(r *http.Request) (dto_interface.AuthRequest, error)
+	BuildAuthMFARequestDTOFromRequest(r *http.Request) (dto_interface.AuthMFARequest, error)
 }
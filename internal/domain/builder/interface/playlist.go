@@ -0,0 +1,19 @@
+package builder_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type Playlist interface {
+	BuildGetRequestDTOFromRequest(r *http.Request) (*dto.PlaylistGetRequestDTO, error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.PlaylistListRequestDTO, error)
+	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistCreateRequestDTO, error)
+	BuildAggFromCreateRequestDTO(ctx context.Context, reqDTO dto_interface.CreatePlaylistRequest) (*agg.Playlist, error)
+	BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistUpdateRequestDTO, error)
+	BuildAggFromUpdateRequestDTO(ctx context.Context, reqDTO dto_interface.UpdatePlaylistRequest) (*agg.Playlist, error)
+	BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.PlaylistDeleteRequestDTO, error)
+}
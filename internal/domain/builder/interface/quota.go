@@ -0,0 +1,10 @@
+package builder_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"net/http"
+)
+
+type Quota interface {
+	BuildGetRequestDTOFromRequest(r *http.Request) *dto.QuotaGetRequestDTO
+}
@@ -0,0 +1,13 @@
+package builder_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"net/http"
+)
+
+type TwoFactor interface {
+	BuildEnableRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorEnableRequestDTO, error)
+	BuildConfirmRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorConfirmRequestDTO, error)
+	BuildDisableRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorDisableRequestDTO, error)
+	BuildRegenerateRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorRegenerateRequestDTO, error)
+}
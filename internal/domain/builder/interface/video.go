@@ -1,6 +1,7 @@
 package builder_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
@@ -10,9 +11,13 @@ import (
 type Video interface {
 	BuildGetRequestDTOFromRequest(r *http.Request) (*dto.VideoGetRequestDTO, error)
 	BuildListRequestDTOFromRequest(r *http.Request) (*dto.VideoListRequestDTO, error)
+	BuildListResponseDTO(reqDTO dto_interface.ListVideoRequest, list []*agg.Video, total int64) *dto.ListResponseDTO
 	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.VideoCreateRequestDTO, error)
-	BuildAggFromCreateRequestDTO(reqDTO dto_interface.CreateVideoRequest) (*agg.Video, error)
+	BuildAggFromCreateRequestDTO(ctx context.Context, reqDTO dto_interface.CreateVideoRequest) (*agg.Video, error)
 	BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.VideoUpdateRequestDTO, error)
-	BuildAggFromUpdateRequestDTO(reqDTO dto_interface.UpdateVideoRequest) (*agg.Video, error)
+	BuildAggFromUpdateRequestDTO(ctx context.Context, reqDTO dto_interface.UpdateVideoRequest) (*agg.Video, error)
 	BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.VideoDeleteRequestDto, error)
+	BuildTrashListRequestDTOFromRequest(r *http.Request) (*dto.VideoTrashListRequestDTO, error)
+	BuildTrashListResponseDTO(reqDTO dto_interface.ListTrashedVideoRequest, list []*agg.Video, total int64) *dto.ListResponseDTO
+	BuildRestoreRequestDTOFromRequest(r *http.Request) (*dto.VideoRestoreRequestDTO, error)
 }
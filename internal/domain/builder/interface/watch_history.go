@@ -0,0 +1,14 @@
+package builder_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type WatchHistory interface {
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.WatchHistoryListRequestDTO, error)
+	BuildContinueListRequestDTOFromRequest(r *http.Request) (*dto.WatchHistoryListRequestDTO, error)
+	BuildListResponseDTO(reqDTO dto_interface.ListWatchHistoryRequest, list []*agg.WatchProgress, total int64) *dto.ListResponseDTO
+}
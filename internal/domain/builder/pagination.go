@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+// buildPaginationResponseDTO - build a dto.PaginationResponseDTO with cursors of adjacent pages,
+// the cursorOf func makes the position of the record by its index into the list of given length.
+func buildPaginationResponseDTO(
+	req dto_interface.PaginatedRequest, total int64, length int, cursorOf func(index int, isPrev bool) vo.Cursor,
+) *dto.PaginationResponseDTO {
+	pagination := &dto.PaginationResponseDTO{
+		Page:  req.GetPage(),
+		Limit: req.GetLimit(),
+		Total: total,
+	}
+
+	if length == 0 {
+		return pagination
+	}
+
+	isPrevRequested := false
+	if req.GetCursor() != "" {
+		if cursor, err := vo.DecodeCursor(req.GetCursor()); err == nil {
+			isPrevRequested = cursor.IsPrev
+		}
+	}
+
+	// a full page means that more records may exist in the requested direction,
+	// the opposite direction has records as far as we came from there
+	isFullPage := length == req.GetLimit()
+	hasNext := isFullPage || isPrevRequested
+	hasPrev := (isFullPage && isPrevRequested) ||
+		(req.GetCursor() != "" && !isPrevRequested) ||
+		(req.GetCursor() == "" && req.GetPage() > 1)
+
+	if hasNext {
+		pagination.NextCursor = cursorOf(length-1, false).Encode()
+	}
+	if hasPrev {
+		pagination.PrevCursor = cursorOf(0, true).Encode()
+	}
+
+	return pagination
+}
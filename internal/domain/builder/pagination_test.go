@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"testing"
+	"time"
+)
+
+func TestBuildPaginationResponseDTO(t *testing.T) {
+	cursorOf := func(index int, isPrev bool) vo.Cursor {
+		return vo.NewNumberCursor(vo.ID{}, int64(index), isPrev)
+	}
+	next := vo.NewNumberCursor(vo.ID{}, 9, false).Encode()
+	prev := vo.NewNumberCursor(vo.ID{}, 0, true).Encode()
+
+	tests := []struct {
+		name   string
+		req    dto.PaginationRequestDTO
+		length int
+		next   string
+		prev   string
+	}{
+		{name: "the first full page", req: dto.PaginationRequestDTO{Page: 1, Limit: 10}, length: 10, next: next},
+		{name: "the last page", req: dto.PaginationRequestDTO{Page: 2, Limit: 20}, length: 10, prev: prev},
+		{name: "the empty page", req: dto.PaginationRequestDTO{Page: 1, Limit: 10}},
+		{
+			name:   "the next page by cursor",
+			req:    dto.PaginationRequestDTO{Page: 1, Limit: 10, Cursor: vo.NewCursor(vo.ID{}, time.Time{}, false).Encode()},
+			length: 10,
+			next:   next,
+			prev:   prev,
+		},
+		{
+			name:   "the first page by previous cursor",
+			req:    dto.PaginationRequestDTO{Page: 1, Limit: 20, Cursor: vo.NewCursor(vo.ID{}, time.Time{}, true).Encode()},
+			length: 10,
+			next:   next,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagination := buildPaginationResponseDTO(tt.req, 100, tt.length, cursorOf)
+
+			if pagination.NextCursor != tt.next {
+				t.Fatalf("expected next cursor '%v', got '%v'", tt.next, pagination.NextCursor)
+			}
+			if pagination.PrevCursor != tt.prev {
+				t.Fatalf("expected prev cursor '%v', got '%v'", tt.prev, pagination.PrevCursor)
+			}
+			if pagination.Total != 100 || pagination.Page != tt.req.Page || pagination.Limit != tt.req.Limit {
+				t.Fatalf("unexpected pagination: %+v", pagination)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+type PlaylistBuilder struct {
+	logger             logger_interface.Logger
+	extractor          extractor_interface.RequestParams
+	playlistRepository repository_interface.Playlist
+}
+
+// NewPlaylistBuilder is a constructor of PlaylistBuilder
+func NewPlaylistBuilder(serviceContainer di_interface.ContainerManager) (*PlaylistBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PlaylistBuilder{
+		logger:             loggerService,
+		extractor:          requestParametersExtractor,
+		playlistRepository: playlistRepository,
+	}, nil
+}
+
+// BuildCreateRequestDTOFromRequest - build a dto.CreatePlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistCreateRequestDTO, error) {
+	playlistDTO := &dto.PlaylistCreateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(playlistDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errors.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	return playlistDTO, nil
+}
+
+// BuildAggFromCreateRequestDTO - build an agg.Playlist from dto.CreatePlaylistRequest
+func (b *PlaylistBuilder) BuildAggFromCreateRequestDTO(ctx context.Context, req dto_interface.CreatePlaylistRequest) (*agg.Playlist, error) {
+	videoIDs := req.GetVideoIDs()
+	if videoIDs == nil {
+		videoIDs = []vo.ID{}
+	}
+
+	return &agg.Playlist{
+		Playlist: entity.Playlist{
+			UserID:      req.GetUserID(),
+			Name:        req.GetName(),
+			Description: req.GetDescription(),
+			VideoIDs:    videoIDs,
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}, nil
+}
+
+// BuildUpdateRequestDTOFromRequest - build a dto.UpdatePlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistUpdateRequestDTO, error) {
+	playlistDTO := &dto.PlaylistUpdateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(playlistDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errors.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	// setting up a playlist id
+	id, err := b.extractID(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	playlistDTO.ID = id
+
+	return playlistDTO, nil
+}
+
+// BuildAggFromUpdateRequestDTO - build an agg.Playlist from dto.UpdatePlaylistRequest
+func (b *PlaylistBuilder) BuildAggFromUpdateRequestDTO(ctx context.Context, req dto_interface.UpdatePlaylistRequest) (*agg.Playlist, error) {
+	logger := b.logger.WithContext(ctx)
+
+	playlist, err := b.playlistRepository.FindOneByID(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	changes := 0
+	if req.GetName() != "" && playlist.Name != req.GetName() {
+		playlist.Name = req.GetName()
+		changes++
+	}
+	if playlist.Description != req.GetDescription() {
+		playlist.Description = req.GetDescription()
+		changes++
+	}
+	if req.GetVideoIDs() != nil && !slices.Equal(playlist.VideoIDs, req.GetVideoIDs()) {
+		playlist.VideoIDs = req.GetVideoIDs()
+		changes++
+	}
+	if changes > 0 {
+		playlist.Timestamp.UpdatedAt = time.Now()
+	}
+
+	return playlist, nil
+}
+
+// BuildGetRequestDTOFromRequest - build a dto.GetPlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildGetRequestDTOFromRequest(r *http.Request) (*dto.PlaylistGetRequestDTO, error) {
+	playlistDTO := &dto.PlaylistGetRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	// setting up a playlist id
+	id, err := b.extractID(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	playlistDTO.ID = id
+
+	return playlistDTO, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListPlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.PlaylistListRequestDTO, error) {
+	playlistDTO := &dto.PlaylistListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	return playlistDTO, nil
+}
+
+// BuildDeleteRequestDTOFromRequest - build a dto.DeletePlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.PlaylistDeleteRequestDTO, error) {
+	playlistDTO := &dto.PlaylistDeleteRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	// setting up a playlist id
+	id, err := b.extractID(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	playlistDTO.ID = id
+
+	return playlistDTO, nil
+}
+
+func (b *PlaylistBuilder) extractID(r *http.Request) (vo.ID, error) {
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	return vo.NewID(oID), nil
+}
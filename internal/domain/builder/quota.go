@@ -0,0 +1,38 @@
+package builder
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"net/http"
+)
+
+type QuotaBuilder struct {
+	logger logger_interface.Logger
+}
+
+// NewQuotaBuilder is a constructor of QuotaBuilder
+func NewQuotaBuilder(serviceContainer di_interface.ContainerManager) (*QuotaBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaBuilder{
+		logger: loggerService,
+	}, nil
+}
+
+// BuildGetRequestDTOFromRequest - build a dto.GetQuotaRequest of the authed user from raw *http.Request
+func (b *QuotaBuilder) BuildGetRequestDTOFromRequest(r *http.Request) *dto.QuotaGetRequestDTO {
+	quotaDTO := &dto.QuotaGetRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		quotaDTO.UserID = userID
+	}
+
+	return quotaDTO
+}
@@ -0,0 +1,94 @@
+package builder
+
+import (
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"io"
+	"net/http"
+)
+
+type TwoFactorBuilder struct {
+	logger logger_interface.Logger
+}
+
+// NewTwoFactorBuilder is a constructor of TwoFactorBuilder.
+func NewTwoFactorBuilder(serviceContainer di_interface.ContainerManager) (*TwoFactorBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorBuilder{logger: loggerService}, nil
+}
+
+// BuildEnableRequestDTOFromRequest - build a dto.EnableTwoFactorRequest from raw *http.Request.
+func (b *TwoFactorBuilder) BuildEnableRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorEnableRequestDTO, error) {
+	reqDTO := &dto.TwoFactorEnableRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		reqDTO.UserID = userID
+	}
+
+	return reqDTO, nil
+}
+
+// BuildConfirmRequestDTOFromRequest - build a dto.ConfirmTwoFactorRequest from raw *http.Request.
+func (b *TwoFactorBuilder) BuildConfirmRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorConfirmRequestDTO, error) {
+	reqDTO := &dto.TwoFactorConfirmRequestDTO{}
+	if err := b.decode(r, reqDTO); err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		reqDTO.UserID = userID
+	}
+
+	return reqDTO, nil
+}
+
+// BuildDisableRequestDTOFromRequest - build a dto.DisableTwoFactorRequest from raw *http.Request.
+func (b *TwoFactorBuilder) BuildDisableRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorDisableRequestDTO, error) {
+	reqDTO := &dto.TwoFactorDisableRequestDTO{}
+	if err := b.decode(r, reqDTO); err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		reqDTO.UserID = userID
+	}
+
+	return reqDTO, nil
+}
+
+// BuildRegenerateRequestDTOFromRequest - build a dto.RegenerateTwoFactorRequest from raw *http.Request.
+func (b *TwoFactorBuilder) BuildRegenerateRequestDTOFromRequest(r *http.Request) (*dto.TwoFactorRegenerateRequestDTO, error) {
+	reqDTO := &dto.TwoFactorRegenerateRequestDTO{}
+	if err := b.decode(r, reqDTO); err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		reqDTO.UserID = userID
+	}
+
+	return reqDTO, nil
+}
+
+func (b *TwoFactorBuilder) decode(r *http.Request, reqDTO any) error {
+	if err := json.NewDecoder(r.Body).Decode(reqDTO); err != nil {
+		if err == io.EOF {
+			return errors.NewRequestBodyIsEmptyError()
+		}
+		return err
+	}
+	return nil
+}
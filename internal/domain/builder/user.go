@@ -23,7 +23,6 @@ import (
 
 type UserBuilder struct {
 	logger         logger_interface.Logger
-	ctx            context.Context
 	extractor      extractor_interface.RequestParams
 	userRepository repository_interface.User
 	passwordHasher security_interface.PasswordHasher
@@ -36,11 +35,6 @@ func NewUserBuilder(serviceContainer di_interface.ContainerManager) (*UserBuilde
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	requestParametersExtractorService, err := serviceContainer.GetRequestParametersExtractorService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -57,7 +51,6 @@ func NewUserBuilder(serviceContainer di_interface.ContainerManager) (*UserBuilde
 	}
 
 	return &UserBuilder{
-		ctx:            ctx,
 		logger:         loggerService,
 		extractor:      requestParametersExtractorService,
 		userRepository: userRepository,
@@ -87,20 +80,22 @@ func (b *UserBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.Us
 }
 
 // BuildAggFromCreateRequestDTO - build an agg.User from dto.CreateUserRequest
-func (b *UserBuilder) BuildAggFromCreateRequestDTO(req dto_interface.CreateUserRequest) (*agg.User, error) {
+func (b *UserBuilder) BuildAggFromCreateRequestDTO(ctx context.Context, req dto_interface.CreateUserRequest) (*agg.User, error) {
+	logger := b.logger.WithContext(ctx)
+
 	// this validation checked previously into the DTO validator
 	birthday, err := time.Parse(enum.BirthdayDatePattern, req.GetBirthday())
 	if err != nil {
 		// logging the real parsing error
-		b.logger.Log(err)
+		logger.Log(err)
 		// logging the error which will be thrown
-		return nil, b.logger.LogPropagate(errors.NewBirthdayIsInvalidError(req.GetBirthday()))
+		return nil, logger.LogPropagate(errors.NewBirthdayIsInvalidError(req.GetBirthday()))
 	}
 
 	// hash user's real password
 	passwordHash, err := b.passwordHasher.Hash(req.GetPassword())
 	if err != nil {
-		return nil, b.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	u := &agg.User{
@@ -141,10 +136,12 @@ func (b *UserBuilder) BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.Us
 }
 
 // BuildAggFromUpdateRequestDTO - build an agg.User from dto.UpdateUserRequest.
-func (b *UserBuilder) BuildAggFromUpdateRequestDTO(req dto_interface.UpdateUserRequest) (*agg.User, error) {
-	user, err := b.userRepository.FindOneByID(b.ctx, req)
+func (b *UserBuilder) BuildAggFromUpdateRequestDTO(ctx context.Context, req dto_interface.UpdateUserRequest) (*agg.User, error) {
+	logger := b.logger.WithContext(ctx)
+
+	user, err := b.userRepository.FindOneByID(ctx, req)
 	if err != nil {
-		return nil, b.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	changes := 0
@@ -158,7 +155,7 @@ func (b *UserBuilder) BuildAggFromUpdateRequestDTO(req dto_interface.UpdateUserR
 		birthday, perr := time.Parse(enum.BirthdayDatePattern, req.GetBirthday())
 		if perr != nil {
 			// here, we must have a valid date or occurred internal error
-			return nil, b.logger.CriticalPropagate(perr)
+			return nil, logger.CriticalPropagate(perr)
 		}
 		user.Birthday = birthday
 		changes++
@@ -167,7 +164,7 @@ func (b *UserBuilder) BuildAggFromUpdateRequestDTO(req dto_interface.UpdateUserR
 	// hash user's real password
 	passwordHash, err := b.passwordHasher.Hash(req.GetPassword())
 	if err != nil {
-		return nil, b.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	if req.GetPassword() != passwordHash {
@@ -18,7 +18,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -28,15 +30,24 @@ const (
 	createdAtField    = "createdAt"
 	fromField         = "from"
 	toField           = "to"
+	queryField        = "query"
+	tagsField         = "tags"
+	minDurationField  = "minDuration"
+	maxDurationField  = "maxDuration"
+	videoCodecField   = "videoCodec"
+	audioCodecField   = "audioCodec"
+	minFilesizeField  = "minFilesize"
+	maxFilesizeField  = "maxFilesize"
+	sortField         = "sort"
 	pageField         = "page"
 	limitField        = "limit"
+	cursorField       = "cursor"
 	limitDefaultValue = 25
 	pageDefaultValue  = 1
 )
 
 type VideoBuilder struct {
 	logger             logger_interface.Logger
-	ctx                context.Context
 	extractor          extractor_interface.RequestParams
 	videoRepository    repository_interface.Video
 	resourceRepository repository_interface.Resource
@@ -49,11 +60,6 @@ func NewVideoBuilder(serviceContainer di_interface.ContainerManager) (*VideoBuil
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -70,7 +76,6 @@ func NewVideoBuilder(serviceContainer di_interface.ContainerManager) (*VideoBuil
 	}
 
 	return &VideoBuilder{
-		ctx:                ctx,
 		logger:             loggerService,
 		extractor:          requestParametersExtractor,
 		videoRepository:    videoRepository,
@@ -97,12 +102,14 @@ func (b *VideoBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.V
 }
 
 // BuildAggFromCreateRequestDTO - build an agg.Video from dto.CreateVideoRequest
-func (b *VideoBuilder) BuildAggFromCreateRequestDTO(req dto_interface.CreateVideoRequest) (*agg.Video, error) {
+func (b *VideoBuilder) BuildAggFromCreateRequestDTO(ctx context.Context, req dto_interface.CreateVideoRequest) (*agg.Video, error) {
+	logger := b.logger.WithContext(ctx)
+
 	resource, err := b.resourceRepository.FindOneByID(
-		b.ctx, dto.NewResourceGetRequestDTO(req.GetResourceID(), req.GetUserID()),
+		ctx, dto.NewResourceGetRequestDTO(req.GetResourceID(), req.GetUserID()),
 	)
 	if err != nil {
-		return nil, b.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return &agg.Video{
@@ -110,6 +117,7 @@ func (b *VideoBuilder) BuildAggFromCreateRequestDTO(req dto_interface.CreateVide
 			UserID:      req.GetUserID(),
 			Name:        req.GetName(),
 			Description: req.GetDescription(),
+			Tags:        b.normalizeTags(req.GetTags()),
 		},
 		Resource: resource.Resource,
 		Timestamp: vo.Timestamp{
@@ -148,10 +156,12 @@ func (b *VideoBuilder) BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.V
 }
 
 // BuildAggFromUpdateRequestDTO - build an agg.Video from dto.UpdateVideoRequest
-func (b *VideoBuilder) BuildAggFromUpdateRequestDTO(req dto_interface.UpdateVideoRequest) (*agg.Video, error) {
-	video, err := b.videoRepository.FindOneByID(b.ctx, req)
+func (b *VideoBuilder) BuildAggFromUpdateRequestDTO(ctx context.Context, req dto_interface.UpdateVideoRequest) (*agg.Video, error) {
+	logger := b.logger.WithContext(ctx)
+
+	video, err := b.videoRepository.FindOneByID(ctx, req)
 	if err != nil {
-		return nil, b.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	changes := 0
@@ -163,12 +173,18 @@ func (b *VideoBuilder) BuildAggFromUpdateRequestDTO(req dto_interface.UpdateVide
 		video.Description = req.GetDescription()
 		changes++
 	}
+	if req.GetTags() != nil {
+		if tags := b.normalizeTags(req.GetTags()); !slices.Equal(video.Tags, tags) {
+			video.Tags = tags
+			changes++
+		}
+	}
 	if !req.GetResourceID().Value.IsZero() {
 		resource, ferr := b.resourceRepository.FindOneByID(
-			b.ctx, dto.NewResourceGetRequestDTO(req.GetResourceID(), req.GetUserID()),
+			ctx, dto.NewResourceGetRequestDTO(req.GetResourceID(), req.GetUserID()),
 		)
 		if ferr != nil {
-			return nil, b.logger.LogPropagate(ferr)
+			return nil, logger.LogPropagate(ferr)
 		}
 		if video.Resource.ID.Value != resource.Resource.ID.Value {
 			video.Resource = resource.Resource
@@ -248,6 +264,69 @@ func (b *VideoBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.Vid
 			videoDTO.To = parsedTo
 		}
 	}
+	if b.extractor.HasParameter(queryField, r) {
+		if q, err := b.extractor.GetParameter(queryField, r); err == nil {
+			videoDTO.Query = strings.TrimSpace(q)
+		}
+	}
+	if b.extractor.HasParameter(tagsField, r) {
+		// comma separated list of tags, for example: "tags=music,live"
+		if tags, err := b.extractor.GetParameter(tagsField, r); err == nil {
+			videoDTO.Tags = b.normalizeTags(strings.Split(tags, ","))
+		}
+	}
+	if b.extractor.HasParameter(minDurationField, r) {
+		d, _ := b.extractor.GetParameter(minDurationField, r)
+		df, parseErr := strconv.ParseFloat(d, 64)
+		if parseErr != nil {
+			return nil, b.logger.LogPropagate(parseErr)
+		}
+		videoDTO.MinDuration = df
+	}
+	if b.extractor.HasParameter(maxDurationField, r) {
+		d, _ := b.extractor.GetParameter(maxDurationField, r)
+		df, parseErr := strconv.ParseFloat(d, 64)
+		if parseErr != nil {
+			return nil, b.logger.LogPropagate(parseErr)
+		}
+		videoDTO.MaxDuration = df
+	}
+	if b.extractor.HasParameter(videoCodecField, r) {
+		if c, err := b.extractor.GetParameter(videoCodecField, r); err == nil {
+			videoDTO.VideoCodec = c
+		}
+	}
+	if b.extractor.HasParameter(audioCodecField, r) {
+		if c, err := b.extractor.GetParameter(audioCodecField, r); err == nil {
+			videoDTO.AudioCodec = c
+		}
+	}
+	if b.extractor.HasParameter(minFilesizeField, r) {
+		fs, _ := b.extractor.GetParameter(minFilesizeField, r)
+		fsi, parseErr := strconv.ParseInt(fs, 10, 64)
+		if parseErr != nil {
+			return nil, b.logger.LogPropagate(parseErr)
+		}
+		videoDTO.MinFilesize = fsi
+	}
+	if b.extractor.HasParameter(maxFilesizeField, r) {
+		fs, _ := b.extractor.GetParameter(maxFilesizeField, r)
+		fsi, parseErr := strconv.ParseInt(fs, 10, 64)
+		if parseErr != nil {
+			return nil, b.logger.LogPropagate(parseErr)
+		}
+		videoDTO.MaxFilesize = fsi
+	}
+	if b.extractor.HasParameter(sortField, r) {
+		// comma separated list of fields, for example: "sort=-relevance,createdAt"
+		if srt, err := b.extractor.GetParameter(sortField, r); err == nil {
+			for _, field := range strings.Split(srt, ",") {
+				if field = strings.TrimSpace(field); field != "" {
+					videoDTO.Sort = append(videoDTO.Sort, field)
+				}
+			}
+		}
+	}
 	if b.extractor.HasParameter(pageField, r) {
 		pg, _ := b.extractor.GetParameter(pageField, r)
 		pgi, atoiErr := strconv.Atoi(pg)
@@ -268,10 +347,32 @@ func (b *VideoBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.Vid
 	} else {
 		videoDTO.Limit = limitDefaultValue
 	}
+	if b.extractor.HasParameter(cursorField, r) {
+		if c, err := b.extractor.GetParameter(cursorField, r); err == nil {
+			videoDTO.Cursor = c
+		}
+	}
 
 	return videoDTO, nil
 }
 
+// BuildListResponseDTO - build a dto.ListResponseDTO with pagination cursors of adjacent pages.
+func (b *VideoBuilder) BuildListResponseDTO(
+	req dto_interface.ListVideoRequest, list []*agg.Video, total int64,
+) *dto.ListResponseDTO {
+	if !req.IsCursorPaginationAvailable() {
+		return dto.NewListResponseDTO(list, &dto.PaginationResponseDTO{
+			Page:  req.GetPage(),
+			Limit: req.GetLimit(),
+			Total: total,
+		})
+	}
+
+	return dto.NewListResponseDTO(list, buildPaginationResponseDTO(req, total, len(list), func(i int, isPrev bool) vo.Cursor {
+		return vo.NewCursor(list[i].ID, list[i].Timestamp.CreatedAt, isPrev)
+	}))
+}
+
 // BuildDeleteRequestDTOFromRequest - build a dto.DeleteVideoRequest from raw *http.Request
 func (b *VideoBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.VideoDeleteRequestDto, error) {
 	videoGetDTO, err := b.BuildGetRequestDTOFromRequest(r)
@@ -281,3 +382,78 @@ func (b *VideoBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.V
 
 	return &dto.VideoDeleteRequestDto{ID: videoGetDTO.ID, UserID: videoGetDTO.UserID}, nil
 }
+
+// BuildTrashListRequestDTOFromRequest - build a dto.ListTrashedVideoRequest from raw *http.Request
+func (b *VideoBuilder) BuildTrashListRequestDTOFromRequest(r *http.Request) (*dto.VideoTrashListRequestDTO, error) {
+	videoDTO := &dto.VideoTrashListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		videoDTO.UserID = userID
+	}
+
+	if b.extractor.HasParameter(pageField, r) {
+		pg, _ := b.extractor.GetParameter(pageField, r)
+		pgi, atoiErr := strconv.Atoi(pg)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		videoDTO.Page = pgi
+	} else {
+		videoDTO.Page = pageDefaultValue
+	}
+	if b.extractor.HasParameter(limitField, r) {
+		l, _ := b.extractor.GetParameter(limitField, r)
+		li, atoiErr := strconv.Atoi(l)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		videoDTO.Limit = li
+	} else {
+		videoDTO.Limit = limitDefaultValue
+	}
+	if b.extractor.HasParameter(cursorField, r) {
+		if c, err := b.extractor.GetParameter(cursorField, r); err == nil {
+			videoDTO.Cursor = c
+		}
+	}
+
+	return videoDTO, nil
+}
+
+// BuildTrashListResponseDTO - build a dto.ListResponseDTO with pagination cursors of adjacent pages
+// (the trash is ordered by the time of deletion).
+func (b *VideoBuilder) BuildTrashListResponseDTO(
+	req dto_interface.ListTrashedVideoRequest, list []*agg.Video, total int64,
+) *dto.ListResponseDTO {
+	return dto.NewListResponseDTO(list, buildPaginationResponseDTO(req, total, len(list), func(i int, isPrev bool) vo.Cursor {
+		var deletedAt time.Time
+		if list[i].DeletedAt != nil {
+			deletedAt = *list[i].DeletedAt
+		}
+		return vo.NewCursor(list[i].ID, deletedAt, isPrev)
+	}))
+}
+
+// BuildRestoreRequestDTOFromRequest - build a dto.RestoreVideoRequest from raw *http.Request
+func (b *VideoBuilder) BuildRestoreRequestDTOFromRequest(r *http.Request) (*dto.VideoRestoreRequestDTO, error) {
+	videoGetDTO, err := b.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return dto.NewVideoRestoreRequestDTO(videoGetDTO.ID, videoGetDTO.UserID), nil
+}
+
+// normalizeTags - tags are case-insensitive, so they will be trimmed, lowercased and deduplicated (order is kept).
+func (b *VideoBuilder) normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || slices.Contains(normalized, tag) {
+			continue
+		}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
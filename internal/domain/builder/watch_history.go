@@ -0,0 +1,97 @@
+package builder
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"net/http"
+	"strconv"
+)
+
+type WatchHistoryBuilder struct {
+	logger    logger_interface.Logger
+	extractor extractor_interface.RequestParams
+}
+
+// NewWatchHistoryBuilder is a constructor of WatchHistoryBuilder
+func NewWatchHistoryBuilder(serviceContainer di_interface.ContainerManager) (*WatchHistoryBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &WatchHistoryBuilder{
+		logger:    loggerService,
+		extractor: requestParametersExtractor,
+	}, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListWatchHistoryRequest of all watched videos from raw *http.Request
+func (b *WatchHistoryBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.WatchHistoryListRequestDTO, error) {
+	historyDTO := &dto.WatchHistoryListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		historyDTO.UserID = userID
+	}
+
+	if b.extractor.HasParameter(pageField, r) {
+		pg, _ := b.extractor.GetParameter(pageField, r)
+		pgi, atoiErr := strconv.Atoi(pg)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		historyDTO.Page = pgi
+	} else {
+		historyDTO.Page = pageDefaultValue
+	}
+	if b.extractor.HasParameter(limitField, r) {
+		l, _ := b.extractor.GetParameter(limitField, r)
+		li, atoiErr := strconv.Atoi(l)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		historyDTO.Limit = li
+	} else {
+		historyDTO.Limit = limitDefaultValue
+	}
+	if b.extractor.HasParameter(cursorField, r) {
+		if c, err := b.extractor.GetParameter(cursorField, r); err == nil {
+			historyDTO.Cursor = c
+		}
+	}
+
+	return historyDTO, nil
+}
+
+// BuildContinueListRequestDTOFromRequest - build a dto.ListWatchHistoryRequest of the videos
+// which may be continued (they were started, but not finished) from raw *http.Request
+func (b *WatchHistoryBuilder) BuildContinueListRequestDTOFromRequest(r *http.Request) (*dto.WatchHistoryListRequestDTO, error) {
+	historyDTO, err := b.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	historyDTO.Unfinished = true
+
+	return historyDTO, nil
+}
+
+// BuildListResponseDTO - build a dto.ListResponseDTO with pagination cursors of adjacent pages
+// (the history is ordered by the time of the last watching).
+func (b *WatchHistoryBuilder) BuildListResponseDTO(
+	req dto_interface.ListWatchHistoryRequest, list []*agg.WatchProgress, total int64,
+) *dto.ListResponseDTO {
+	return dto.NewListResponseDTO(list, buildPaginationResponseDTO(req, total, len(list), func(i int, isPrev bool) vo.Cursor {
+		return vo.NewCursor(list[i].ID, list[i].Timestamp.UpdatedAt, isPrev)
+	}))
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// VideoAnalyticsGetRequestDTO - used when the owner wants to fetch the analytics of a video.
+type VideoAnalyticsGetRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Required*/ VideoID vo.ID `json:"videoID"`
+	/*Optional*/ From time.Time `json:"from" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ To time.Time `json:"to" format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (req *VideoAnalyticsGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *VideoAnalyticsGetRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *VideoAnalyticsGetRequestDTO) GetFrom() time.Time {
+	return req.From
+}
+func (req *VideoAnalyticsGetRequestDTO) GetTo() time.Time {
+	return req.To
+}
+
+// VideoAnalyticsListRequestDTO - used when the owner wants to fetch the analytics of all his videos
+// (from the most played one).
+type VideoAnalyticsListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Optional*/ From time.Time `json:"from" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ To time.Time `json:"to" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ PaginationRequestDTO
+}
+
+func (req *VideoAnalyticsListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *VideoAnalyticsListRequestDTO) GetFrom() time.Time {
+	return req.From
+}
+func (req *VideoAnalyticsListRequestDTO) GetTo() time.Time {
+	return req.To
+}
+
+// VideoAnalyticsResponseDTO - the totals of a video within the requested range and its daily rollups.
+type VideoAnalyticsResponseDTO struct {
+	Totals *agg.VideoStats   `json:"totals"`
+	Daily  []*agg.VideoStats `json:"daily"`
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+// APIKeyCreateRequestDTO - used when u want to issue a new API key.
+type APIKeyCreateRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Required*/ Name string `json:"name"`
+	/*Required*/ Scopes []string `json:"scopes"`
+}
+
+func (req *APIKeyCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *APIKeyCreateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *APIKeyCreateRequestDTO) GetScopes() []string {
+	return req.Scopes
+}
+
+// APIKeyGetRequestDTO - used when u want to find a single API key by ID or by hash of raw key.
+type APIKeyGetRequestDTO struct {
+	/*Optional*/ ID vo.ID
+	/*Optional*/ UserID vo.ID
+	/*Optional*/ Hash string
+}
+
+func NewAPIKeyGetRequestDTO(id vo.ID, userID vo.ID, hash string) *APIKeyGetRequestDTO {
+	return &APIKeyGetRequestDTO{
+		ID:     id,
+		UserID: userID,
+		Hash:   hash,
+	}
+}
+func (req *APIKeyGetRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *APIKeyGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *APIKeyGetRequestDTO) GetHash() string {
+	return req.Hash
+}
+
+// APIKeyListRequestDTO - used when u want to fetch all API keys of a user.
+type APIKeyListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+}
+
+func (req *APIKeyListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// APIKeyRevokeRequestDTO - used when u want to revoke an API key.
+type APIKeyRevokeRequestDTO struct {
+	/*Required*/ ID vo.ID
+	/*Required*/ UserID vo.ID
+}
+
+func (req *APIKeyRevokeRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *APIKeyRevokeRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// APIKeyCreateResponseDTO - contains the raw API key, it's shown only once.
+type APIKeyCreateResponseDTO struct {
+	Key    string      `json:"key"`
+	APIKey *agg.APIKey `json:"apiKey"`
+}
+
+func NewAPIKeyCreateResponseDTO(key string, apiKey *agg.APIKey) *APIKeyCreateResponseDTO {
+	return &APIKeyCreateResponseDTO{Key: key, APIKey: apiKey}
+}
@@ -12,3 +12,32 @@ func (r *AuthRequestDTO) GetEmail() string {
 func (r *AuthRequestDTO) GetPassword() string {
 	return r.Password
 }
+
+type AuthMFARequestDTO struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recoveryCode"`
+}
+
+func (r *AuthMFARequestDTO) GetChallengeToken() string {
+	return r.ChallengeToken
+}
+
+func (r *AuthMFARequestDTO) GetCode() string {
+	return r.Code
+}
+
+func (r *AuthMFARequestDTO) GetRecoveryCode() string {
+	return r.RecoveryCode
+}
+
+// AuthResponseDTO - contains an access token or an MFA challenge token (when the MFARequired is true),
+// the challenge token must be exchanged on the access token by the second step of authorization.
+type AuthResponseDTO struct {
+	Token       string `json:"token"`
+	MFARequired bool   `json:"mfaRequired"`
+}
+
+func NewAuthResponseDTO(token string, mfaRequired bool) *AuthResponseDTO {
+	return &AuthResponseDTO{Token: token, MFARequired: mfaRequired}
+}
@@ -0,0 +1,20 @@
+package dto_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type GetVideoAnalyticsRequest interface {
+	GetUserID() vo.ID
+	GetVideoID() vo.ID
+	GetFrom() time.Time
+	GetTo() time.Time
+}
+
+type ListVideoAnalyticsRequest interface {
+	GetUserID() vo.ID
+	GetFrom() time.Time
+	GetTo() time.Time
+	PaginatedRequest
+}
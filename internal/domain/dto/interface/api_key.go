@@ -0,0 +1,18 @@
+package dto_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type CreateAPIKeyRequest interface {
+	GetUserID() vo.ID
+	GetName() string
+	GetScopes() []string
+}
+
+type ListAPIKeyRequest interface {
+	GetUserID() vo.ID
+}
+
+type RevokeAPIKeyRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
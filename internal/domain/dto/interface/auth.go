@@ -4,3 +4,9 @@ type AuthRequest interface {
 	GetEmail() string
 	GetPassword() string
 }
+
+type AuthMFARequest interface {
+	GetChallengeToken() string
+	GetCode() string
+	GetRecoveryCode() string
+}
@@ -3,4 +3,5 @@ package dto_interface
 type PaginatedRequest interface {
 	GetPage() int
 	GetLimit() int
+	GetCursor() string
 }
@@ -0,0 +1,29 @@
+package dto_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type CreatePlaylistRequest interface {
+	GetName() string
+	GetUserID() vo.ID
+	GetDescription() string
+	GetVideoIDs() []vo.ID
+}
+
+type UpdatePlaylistRequest interface {
+	GetID() vo.ID
+	GetName() string
+	GetUserID() vo.ID
+	GetDescription() string
+	GetVideoIDs() []vo.ID
+}
+
+type GetPlaylistRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type ListPlaylistRequest interface {
+	GetUserID() vo.ID
+}
+
+type DeletePlaylistRequest GetPlaylistRequest
@@ -0,0 +1,7 @@
+package dto_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type GetQuotaRequest interface {
+	GetUserID() vo.ID
+}
@@ -0,0 +1,7 @@
+package dto_interface
+
+type ReconcileRequest interface {
+	IsQuarantine() bool
+	IsRepair() bool
+	IsDryRun() bool
+}
@@ -23,6 +23,9 @@ type UploadResourceRequest interface {
 	SetUploadedFilesize(filesize int64)
 	GetUploadedFiletype() string
 	SetUploadedFiletype(filetype string)
+	// GetQuota is a number of bytes which the user may upload yet (zero means unlimited).
+	GetQuota() int64
+	SetQuota(quota int64)
 }
 
 type GetResourceRequest interface {
@@ -31,3 +34,5 @@ type GetResourceRequest interface {
 }
 
 type DeleteResourceRequest GetResourceRequest
+
+type RestoreResourceRequest GetResourceRequest
@@ -0,0 +1,23 @@
+package dto_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type EnableTwoFactorRequest interface {
+	GetUserID() vo.ID
+}
+
+type ConfirmTwoFactorRequest interface {
+	GetUserID() vo.ID
+	GetCode() string
+}
+
+type DisableTwoFactorRequest interface {
+	GetUserID() vo.ID
+	GetCode() string
+	GetRecoveryCode() string
+}
+
+type RegenerateTwoFactorRequest interface {
+	GetUserID() vo.ID
+	GetCode() string
+}
@@ -10,6 +10,7 @@ type CreateVideoRequest interface {
 	GetUserID() vo.ID
 	GetResourceID() vo.ID
 	GetDescription() string
+	GetTags() []string
 }
 
 type UpdateVideoRequest interface {
@@ -18,6 +19,7 @@ type UpdateVideoRequest interface {
 	GetUserID() vo.ID
 	GetResourceID() vo.ID
 	GetDescription() string
+	GetTags() []string
 }
 
 type GetVideoRequest interface {
@@ -30,7 +32,25 @@ type ListVideoRequest interface {
 	GetCreatedAt() time.Time // concrete search date point
 	GetFrom() time.Time      // search date limit from
 	GetTo() time.Time        // search date limit to
+	GetQuery() string        // full-text search query
+	GetTags() []string       // tags which video must be marked by
+	GetMinDuration() float64 // duration limit from (seconds)
+	GetMaxDuration() float64 // duration limit to (seconds)
+	GetVideoCodec() string   // video stream codec name
+	GetAudioCodec() string   // audio stream codec name
+	GetMinFilesize() int64   // file size limit from (bytes)
+	GetMaxFilesize() int64   // file size limit to (bytes)
+	GetSort() []string       // sort fields, "-" prefix means desc. order
+	// IsCursorPaginationAvailable - whether the requested ordering allows the cursor pagination.
+	IsCursorPaginationAvailable() bool
 	PaginatedRequest
 }
 
 type DeleteVideoRequest GetVideoRequest
+
+type ListTrashedVideoRequest interface {
+	GetUserID() vo.ID
+	PaginatedRequest
+}
+
+type RestoreVideoRequest GetVideoRequest
@@ -0,0 +1,21 @@
+package dto_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type WatchProgressRequest interface {
+	GetUserID() vo.ID
+	GetVideoID() vo.ID
+	GetPosition() float64
+	GetDuration() float64
+}
+
+type GetWatchProgressRequest interface {
+	GetUserID() vo.ID
+	GetVideoID() vo.ID
+}
+
+type ListWatchHistoryRequest interface {
+	GetUserID() vo.ID
+	IsUnfinished() bool // only the videos which may be continued
+	PaginatedRequest
+}
@@ -1,8 +1,11 @@
 package dto
 
+// PaginationRequestDTO - if the cursor is passed, then the keyset pagination will be used
+// and the page will be ignored, otherwise the page/limit one.
 type PaginationRequestDTO struct {
-	Page  int `json:"page"`
-	Limit int `json:"limit"`
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor"`
 }
 
 func (p PaginationRequestDTO) GetPage() int {
@@ -12,3 +15,27 @@ func (p PaginationRequestDTO) GetPage() int {
 func (p PaginationRequestDTO) GetLimit() int {
 	return p.Limit
 }
+
+func (p PaginationRequestDTO) GetCursor() string {
+	return p.Cursor
+}
+
+// PaginationResponseDTO - the cursors are empty if there is no adjacent page,
+// or if the list ordering does not support the cursor pagination.
+type PaginationResponseDTO struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"nextCursor"`
+	PrevCursor string `json:"prevCursor"`
+}
+
+// ListResponseDTO - a list of items with pagination data.
+type ListResponseDTO struct {
+	List       any                    `json:"list"`
+	Pagination *PaginationResponseDTO `json:"pagination"`
+}
+
+func NewListResponseDTO(list any, pagination *PaginationResponseDTO) *ListResponseDTO {
+	return &ListResponseDTO{List: list, Pagination: pagination}
+}
@@ -0,0 +1,94 @@
+package dto
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// PlaylistCreateRequestDTO - used when u want to create a new one playlist.
+type PlaylistCreateRequestDTO struct {
+	/*Required*/ Name string `json:"name"`
+	/*Required*/ UserID vo.ID
+	/*Optional*/ Description string `json:"description,omitempty"`
+	/*Optional*/ VideoIDs []vo.ID `json:"videos,omitempty"` // ordered list of videos
+}
+
+func (req *PlaylistCreateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *PlaylistCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *PlaylistCreateRequestDTO) GetDescription() string {
+	return req.Description
+}
+func (req *PlaylistCreateRequestDTO) GetVideoIDs() []vo.ID {
+	return req.VideoIDs
+}
+
+// PlaylistUpdateRequestDTO - used when u want to update a playlist record (for reordering pass the whole list).
+type PlaylistUpdateRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+	/*Optional*/ Name string `json:"name"`
+	/*Optional*/ Description string `json:"description,omitempty"`
+	/*Optional*/ VideoIDs []vo.ID `json:"videos"` // nil means the videos will not be changed, empty list removes them
+}
+
+func (req *PlaylistUpdateRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *PlaylistUpdateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *PlaylistUpdateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *PlaylistUpdateRequestDTO) GetDescription() string {
+	return req.Description
+}
+func (req *PlaylistUpdateRequestDTO) GetVideoIDs() []vo.ID {
+	return req.VideoIDs
+}
+
+// PlaylistGetRequestDTO - used when you want to find a single playlist by ID or Name, but you always must specify a UserID.
+type PlaylistGetRequestDTO struct {
+	/*Optional*/ ID vo.ID `json:"id"`
+	/*Optional*/ Name string
+	/*Required*/ UserID vo.ID
+}
+
+func NewPlaylistGetRequestDTO(id vo.ID, userID vo.ID) *PlaylistGetRequestDTO {
+	return &PlaylistGetRequestDTO{
+		ID:     id,
+		UserID: userID,
+	}
+}
+func (req *PlaylistGetRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *PlaylistGetRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *PlaylistGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// PlaylistListRequestDTO - used when u want to fetch all playlists of a user.
+type PlaylistListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+}
+
+func (req *PlaylistListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// PlaylistDeleteRequestDTO - used when you want to remove the playlist (videos will be left as is).
+type PlaylistDeleteRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+}
+
+func (req *PlaylistDeleteRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *PlaylistDeleteRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// QuotaGetRequestDTO - used when the user wants to know the used storage.
+type QuotaGetRequestDTO struct {
+	/*Required*/ UserID vo.ID
+}
+
+func NewQuotaGetRequestDTO(userID vo.ID) *QuotaGetRequestDTO {
+	return &QuotaGetRequestDTO{UserID: userID}
+}
+func (req *QuotaGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// ReconcileRequestDTO - used when the resources directory and the stored records must be reconciled,
+// the inconsistencies are only reported if nothing is asked to be fixed (dry-run).
+type ReconcileRequestDTO struct {
+	/*Optional*/ Quarantine bool // the orphaned files are moved into the quarantine directory
+	/*Optional*/ Repair bool // the records which point at the missing files are removed
+}
+
+func NewReconcileRequestDTO(quarantine bool, repair bool) *ReconcileRequestDTO {
+	return &ReconcileRequestDTO{Quarantine: quarantine, Repair: repair}
+}
+func (req *ReconcileRequestDTO) IsQuarantine() bool {
+	return req.Quarantine
+}
+func (req *ReconcileRequestDTO) IsRepair() bool {
+	return req.Repair
+}
+func (req *ReconcileRequestDTO) IsDryRun() bool {
+	return !req.Quarantine && !req.Repair
+}
+
+// ReconciliationReportDTO - the found inconsistencies and the number of fixed ones.
+type ReconciliationReportDTO struct {
+	// OrphanFiles are the files of the resources directory which have no resource.
+	OrphanFiles []string `json:"orphanFiles"`
+	// MissingFiles are the resources which point at the missing files.
+	MissingFiles []vo.ID `json:"missingFiles"`
+	// BrokenVideos are the videos which point at the missing resources or files.
+	BrokenVideos []vo.ID `json:"brokenVideos"`
+	// Quarantined is a number of the orphaned files which were moved into the quarantine directory.
+	Quarantined int `json:"quarantined"`
+	// RemovedResources is a number of the removed resources which pointed at the missing files.
+	RemovedResources int `json:"removedResources"`
+	// RemovedVideos is a number of the removed broken videos.
+	RemovedVideos int `json:"removedVideos"`
+	// Failed is a number of the inconsistencies which could not be fixed (the errors are logged).
+	Failed int `json:"failed"`
+}
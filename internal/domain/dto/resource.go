@@ -14,6 +14,7 @@ type ResourceUploadRequestDTO struct {
 	uploadedFilepath string
 	uploadedFiletype string
 	uploadedFilesize int64
+	quota            int64
 }
 
 func NewResourceUploadRequest(r *http.Request) (dto *ResourceUploadRequestDTO) {
@@ -53,6 +54,12 @@ func (r *ResourceUploadRequestDTO) GetUploadedFilesize() int64 {
 func (r *ResourceUploadRequestDTO) SetUploadedFilesize(filesize int64) {
 	r.uploadedFilesize = filesize
 }
+func (r *ResourceUploadRequestDTO) GetQuota() int64 {
+	return r.quota
+}
+func (r *ResourceUploadRequestDTO) SetQuota(quota int64) {
+	r.quota = quota
+}
 func (r *ResourceUploadRequestDTO) GetUploadedFiletype() string {
 	return r.uploadedFiletype
 }
@@ -95,3 +102,21 @@ func (req *ResourceDeleteRequestDTO) GetID() vo.ID {
 func (req *ResourceDeleteRequestDTO) GetUserID() vo.ID {
 	return req.UserID
 }
+
+type ResourceRestoreRequestDTO struct {
+	ID     vo.ID `json:"id"`
+	UserID vo.ID
+}
+
+func NewResourceRestoreRequestDTO(id vo.ID, userID vo.ID) *ResourceRestoreRequestDTO {
+	return &ResourceRestoreRequestDTO{
+		ID:     id,
+		UserID: userID,
+	}
+}
+func (req *ResourceRestoreRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *ResourceRestoreRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
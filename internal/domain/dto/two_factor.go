@@ -0,0 +1,73 @@
+package dto
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// TwoFactorEnableRequestDTO - used when u want to start the 2FA enrolment
+type TwoFactorEnableRequestDTO struct {
+	UserID vo.ID `json:"userID"`
+}
+
+func (req *TwoFactorEnableRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// TwoFactorConfirmRequestDTO - used when u want to finish the 2FA enrolment
+type TwoFactorConfirmRequestDTO struct {
+	UserID vo.ID  `json:"userID"`
+	Code   string `json:"code"`
+}
+
+func (req *TwoFactorConfirmRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *TwoFactorConfirmRequestDTO) GetCode() string {
+	return req.Code
+}
+
+// TwoFactorDisableRequestDTO - used when u want to disable the 2FA
+type TwoFactorDisableRequestDTO struct {
+	UserID       vo.ID  `json:"userID"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+func (req *TwoFactorDisableRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *TwoFactorDisableRequestDTO) GetCode() string {
+	return req.Code
+}
+func (req *TwoFactorDisableRequestDTO) GetRecoveryCode() string {
+	return req.RecoveryCode
+}
+
+// TwoFactorRegenerateRequestDTO - used when u want to regenerate the recovery codes
+type TwoFactorRegenerateRequestDTO struct {
+	UserID vo.ID  `json:"userID"`
+	Code   string `json:"code"`
+}
+
+func (req *TwoFactorRegenerateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *TwoFactorRegenerateRequestDTO) GetCode() string {
+	return req.Code
+}
+
+type TwoFactorEnrolmentResponseDTO struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func NewTwoFactorEnrolmentResponseDTO(secret string, uri string) *TwoFactorEnrolmentResponseDTO {
+	return &TwoFactorEnrolmentResponseDTO{Secret: secret, URI: uri}
+}
+
+// TwoFactorRecoveryCodesResponseDTO - contains the raw recovery codes, they are shown only once
+type TwoFactorRecoveryCodesResponseDTO struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func NewTwoFactorRecoveryCodesResponseDTO(codes []string) *TwoFactorRecoveryCodesResponseDTO {
+	return &TwoFactorRecoveryCodesResponseDTO{RecoveryCodes: codes}
+}
@@ -1,7 +1,9 @@
 package dto
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"strings"
 	"time"
 )
 
@@ -11,6 +13,7 @@ type VideoCreateRequestDTO struct {
 	/*Required*/ UserID vo.ID
 	/*Required*/ ResourceID vo.ID `json:"resourceID"`
 	/*Optional*/ Description string `json:"description,omitempty"`
+	/*Optional*/ Tags []string `json:"tags,omitempty"`
 }
 
 func (req *VideoCreateRequestDTO) GetName() string {
@@ -25,6 +28,9 @@ func (req *VideoCreateRequestDTO) GetResourceID() vo.ID {
 func (req *VideoCreateRequestDTO) GetDescription() string {
 	return req.Description
 }
+func (req *VideoCreateRequestDTO) GetTags() []string {
+	return req.Tags
+}
 
 // VideoUpdateRequestDTO - used when u want to update a video record.
 type VideoUpdateRequestDTO struct {
@@ -33,6 +39,7 @@ type VideoUpdateRequestDTO struct {
 	/*Optional*/ UserID vo.ID
 	/*Optional*/ ResourceID vo.ID `json:"resourceID"`
 	/*Optional*/ Description string `json:"description,omitempty"`
+	/*Optional*/ Tags []string `json:"tags"` // nil means the tags will not be changed, empty list removes them
 }
 
 func (req *VideoUpdateRequestDTO) GetID() vo.ID {
@@ -50,6 +57,9 @@ func (req *VideoUpdateRequestDTO) GetResourceID() vo.ID {
 func (req *VideoUpdateRequestDTO) GetDescription() string {
 	return req.Description
 }
+func (req *VideoUpdateRequestDTO) GetTags() []string {
+	return req.Tags
+}
 
 // VideoGetRequestDTO - used when you want to find a single video by Name or ID, but you always must specify a UserID.
 type VideoGetRequestDTO struct {
@@ -87,6 +97,15 @@ type VideoListRequestDTO struct {
 	/*Optional*/ CreatedAt time.Time `json:"createdAt" format:"2006-01-02T15:04:05Z07:00"`
 	/*Optional*/ From time.Time `json:"from" format:"2006-01-02T15:04:05Z07:00"`
 	/*Optional*/ To time.Time `json:"to" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ Query string `json:"query"` // full-text search by name, description and tags
+	/*Optional*/ Tags []string `json:"tags"` // video must be marked by each of them
+	/*Optional*/ MinDuration float64 `json:"minDuration"` // in seconds
+	/*Optional*/ MaxDuration float64 `json:"maxDuration"` // in seconds
+	/*Optional*/ VideoCodec string `json:"videoCodec"`
+	/*Optional*/ AudioCodec string `json:"audioCodec"`
+	/*Optional*/ MinFilesize int64 `json:"minFilesize"` // in bytes
+	/*Optional*/ MaxFilesize int64 `json:"maxFilesize"` // in bytes
+	/*Optional*/ Sort []string `json:"sort"` // fields from enum.VideoSortFields, "-" prefix means desc. order
 	/*Optional*/ PaginationRequestDTO
 }
 
@@ -122,6 +141,42 @@ func (req *VideoListRequestDTO) GetFrom() time.Time {
 func (req *VideoListRequestDTO) GetTo() time.Time {
 	return req.To
 }
+func (req *VideoListRequestDTO) GetQuery() string {
+	return req.Query
+}
+func (req *VideoListRequestDTO) GetTags() []string {
+	return req.Tags
+}
+func (req *VideoListRequestDTO) GetMinDuration() float64 {
+	return req.MinDuration
+}
+func (req *VideoListRequestDTO) GetMaxDuration() float64 {
+	return req.MaxDuration
+}
+func (req *VideoListRequestDTO) GetVideoCodec() string {
+	return req.VideoCodec
+}
+func (req *VideoListRequestDTO) GetAudioCodec() string {
+	return req.AudioCodec
+}
+func (req *VideoListRequestDTO) GetMinFilesize() int64 {
+	return req.MinFilesize
+}
+func (req *VideoListRequestDTO) GetMaxFilesize() int64 {
+	return req.MaxFilesize
+}
+func (req *VideoListRequestDTO) GetSort() []string {
+	return req.Sort
+}
+
+// IsCursorPaginationAvailable - the cursor pagination works only when the list is ordered by createdAt
+// (the default order without full-text search, the relevance is used by default otherwise).
+func (req *VideoListRequestDTO) IsCursorPaginationAvailable() bool {
+	if len(req.Sort) == 0 {
+		return req.Query == ""
+	}
+	return len(req.Sort) == 1 && strings.TrimPrefix(req.Sort[0], enum.SortDescPrefix) == enum.VideoSortByCreatedAt
+}
 
 // VideoDeleteRequestDto - used when you want to remove the video.
 type VideoDeleteRequestDto struct {
@@ -141,3 +196,33 @@ func (req *VideoDeleteRequestDto) GetID() vo.ID {
 func (req *VideoDeleteRequestDto) GetUserID() vo.ID {
 	return req.UserID
 }
+
+// VideoTrashListRequestDTO - used when you want to fetch the deleted videos which may be restored yet
+// (from the last deleted one).
+type VideoTrashListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Optional*/ PaginationRequestDTO
+}
+
+func (req *VideoTrashListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// VideoRestoreRequestDTO - used when you want to restore the deleted video from the trash.
+type VideoRestoreRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+}
+
+func NewVideoRestoreRequestDTO(id vo.ID, userID vo.ID) *VideoRestoreRequestDTO {
+	return &VideoRestoreRequestDTO{
+		ID:     id,
+		UserID: userID,
+	}
+}
+func (req *VideoRestoreRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *VideoRestoreRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
@@ -0,0 +1,65 @@
+package dto
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// WatchProgressRequestDTO - used when the client side reports the playback position of a video.
+type WatchProgressRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Required*/ VideoID vo.ID `json:"videoID"`
+	/*Required*/ Position float64 `json:"position"` // in seconds
+	/*Optional*/ Duration float64 `json:"duration"` // in seconds, the video metadata is used if it's omitted
+}
+
+func NewWatchProgressRequestDTO(userID vo.ID, videoID vo.ID, position float64, duration float64) *WatchProgressRequestDTO {
+	return &WatchProgressRequestDTO{
+		UserID:   userID,
+		VideoID:  videoID,
+		Position: position,
+		Duration: duration,
+	}
+}
+func (req *WatchProgressRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *WatchProgressRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *WatchProgressRequestDTO) GetPosition() float64 {
+	return req.Position
+}
+func (req *WatchProgressRequestDTO) GetDuration() float64 {
+	return req.Duration
+}
+
+// WatchProgressGetRequestDTO - used when you want to find the saved progress of a video.
+type WatchProgressGetRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Required*/ VideoID vo.ID `json:"videoID"`
+}
+
+func NewWatchProgressGetRequestDTO(userID vo.ID, videoID vo.ID) *WatchProgressGetRequestDTO {
+	return &WatchProgressGetRequestDTO{
+		UserID:  userID,
+		VideoID: videoID,
+	}
+}
+func (req *WatchProgressGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *WatchProgressGetRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+
+// WatchHistoryListRequestDTO - used when u want to fetch the watched videos of a user (from the last watched one).
+type WatchHistoryListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Optional*/ Unfinished bool `json:"unfinished"` // only the videos which may be continued
+	/*Optional*/ PaginationRequestDTO
+}
+
+func (req *WatchHistoryListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *WatchHistoryListRequestDTO) IsUnfinished() bool {
+	return req.Unfinished
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type APIKey struct {
+	ID         vo.ID     `json:"id" bson:",inline"`
+	UserID     vo.ID     `json:"userID" bson:"user"`
+	Name       string    `json:"name" bson:"name"`
+	Prefix     string    `json:"prefix" bson:"prefix"` // first symbols of the raw key, helps to identify a key
+	Hash       string    `json:"-" bson:"hash"`        // sha256 of the raw key, unique key
+	Scopes     []string  `json:"scopes" bson:"scopes"`
+	LastUsedAt time.Time `json:"lastUsedAt" bson:"lastUsedAt"`
+	RevokedAt  time.Time `json:"revokedAt" bson:"revokedAt"`
+}
+
+func (r APIKey) GetID() vo.ID {
+	return r.ID
+}
+func (r APIKey) GetUserID() vo.ID {
+	return r.UserID
+}
+func (r APIKey) GetScopes() []string {
+	return r.Scopes
+}
+func (r APIKey) IsRevoked() bool {
+	return !r.RevokedAt.IsZero()
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// PlaySession - one streaming of a video to client side, from the start of the sending to its stop.
+type PlaySession struct {
+	ID         vo.ID     `json:"id" bson:",inline"`
+	UserID     vo.ID     `json:"userID" bson:"user"` // owner of the video
+	VideoID    vo.ID     `json:"videoID" bson:"video"`
+	ViewerID   vo.ID     `json:"viewerID" bson:"viewer"`
+	Action     string    `json:"action" bson:"action"`         // streaming action which started the session
+	Offset     int64     `json:"offset" bson:"offset"`         // offset of the file the streaming was started from
+	Bytes      int64     `json:"bytes" bson:"bytes"`           // number of bytes which were sent
+	Size       int64     `json:"size" bson:"size"`             // size of the streamed file
+	Completion float64   `json:"completion" bson:"completion"` // ratio of the video which was played by client side
+	Finished   bool      `json:"finished" bson:"finished"`
+	StopReason string    `json:"stopReason" bson:"stopReason"`
+	ClientIP   string    `json:"clientIP" bson:"clientIP"`
+	UserAgent  string    `json:"userAgent" bson:"userAgent"`
+	StartedAt  time.Time `json:"startedAt" bson:"startedAt"`
+	StoppedAt  time.Time `json:"stoppedAt" bson:"stoppedAt"`
+}
+
+func (s PlaySession) GetID() vo.ID {
+	return s.ID
+}
+func (s PlaySession) GetUserID() vo.ID {
+	return s.UserID
+}
+func (s PlaySession) GetVideoID() vo.ID {
+	return s.VideoID
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type Playlist struct {
+	ID          vo.ID   `json:"id" bson:",inline"`
+	UserID      vo.ID   `json:"userID" bson:"user"`
+	Name        string  `json:"name" bson:"name"`
+	Description string  `json:"description" bson:"description,omitempty"`
+	VideoIDs    []vo.ID `json:"videos" bson:"videos"` // ordered references, the playback goes from the first one
+}
+
+func (r Playlist) GetID() vo.ID {
+	return r.ID
+}
+func (r Playlist) GetUserID() vo.ID {
+	return r.UserID
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// Quota - the storage used by a user, only one record exists for each user. The limits are not stored,
+// they are taken from the configuration (zero means unlimited).
+type Quota struct {
+	ID        vo.ID `json:"id" bson:",inline"`
+	UserID    vo.ID `json:"userID" bson:"user"`
+	Bytes     int64 `json:"bytes" bson:"bytes"`   // total size of uploaded files
+	Videos    int64 `json:"videos" bson:"videos"` // number of uploaded files
+	MaxBytes  int64 `json:"maxBytes" bson:"-"`
+	MaxVideos int64 `json:"maxVideos" bson:"-"`
+}
+
+func (q Quota) GetID() vo.ID {
+	return q.ID
+}
+func (q Quota) GetUserID() vo.ID {
+	return q.UserID
+}
@@ -17,6 +17,8 @@ type Resource struct {
 	Filepath string `json:"filepath" bson:"filepath"` // path to uploaded file
 	Filetype string `json:"filetype" bson:"filetype"` // filetype
 	Filesize int64  `json:"filesize" bson:"filesize"` // size of uploaded file
+	// Metadata is a media streams description (duration, codecs), may be empty if detection failed.
+	Metadata vo.MediaMetadata `json:"metadata" bson:"metadata"`
 }
 
 func (r Resource) GetID() vo.ID {
@@ -40,3 +42,6 @@ func (r Resource) GetFilesize() int64 {
 func (r Resource) GetFiletype() string {
 	return r.Filetype
 }
+func (r Resource) GetMetadata() vo.MediaMetadata {
+	return r.Metadata
+}
@@ -11,6 +11,8 @@ type User struct {
 	Password string    `bson:"password"` // hash
 	Email    string    `bson:"email"`    // unique key
 	Birthday time.Time `bson:"birthday,omitempty"`
+	// TwoFactor is a TOTP second factor settings (secret and hashed recovery codes).
+	TwoFactor vo.TwoFactor `bson:"twoFactor"`
 }
 
 func (r *User) GetID() vo.ID {
@@ -22,3 +24,9 @@ func (r *User) GetPassword() string {
 func (r *User) SetPassword(password string) {
 	r.Password = password
 }
+func (r *User) GetEmail() string {
+	return r.Email
+}
+func (r *User) IsTwoFactorEnabled() bool {
+	return r.TwoFactor.Enabled
+}
@@ -3,10 +3,11 @@ package entity
 import "github.com/Borislavv/video-streaming/internal/domain/vo"
 
 type Video struct {
-	ID          vo.ID  `json:"id" bson:",inline"`
-	UserID      vo.ID  `json:"userID" bson:"user"`
-	Name        string `json:"name" bson:"name"`
-	Description string `json:"description" bson:"description,omitempty"`
+	ID          vo.ID    `json:"id" bson:",inline"`
+	UserID      vo.ID    `json:"userID" bson:"user"`
+	Name        string   `json:"name" bson:"name"`
+	Description string   `json:"description" bson:"description,omitempty"`
+	Tags        []string `json:"tags" bson:"tags"`
 }
 
 func (r Video) GetID() vo.ID {
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// VideoStats - the counters of play sessions of a video, the totals or the rollup of one day.
+type VideoStats struct {
+	UserID        vo.ID      `json:"userID" bson:"user"` // owner of the video
+	VideoID       vo.ID      `json:"videoID" bson:"video"`
+	Day           *time.Time `json:"day,omitempty" bson:"day,omitempty"` // start of the day (UTC), it's omitted by the totals
+	Plays         int64      `json:"plays" bson:"plays"`
+	Finished      int64      `json:"finished" bson:"finished"` // sessions which reached the finished ratio
+	Bytes         int64      `json:"bytes" bson:"bytes"`
+	CompletionSum float64    `json:"-" bson:"completionSum"`
+	AvgCompletion float64    `json:"avgCompletion" bson:"-"` // computed from the sum of completions
+	Stops         PlayStops  `json:"stops" bson:"stops"`
+}
+
+// PlayStops - the number of play sessions by the reason of their stop.
+type PlayStops struct {
+	Completed int64 `json:"completed" bson:"completed"`
+	Failed    int64 `json:"failed" bson:"failed"`
+	Shutdown  int64 `json:"shutdown" bson:"shutdown"`
+	Replaced  int64 `json:"replaced" bson:"replaced"`
+}
+
+func (s VideoStats) GetUserID() vo.ID {
+	return s.UserID
+}
+func (s VideoStats) GetVideoID() vo.ID {
+	return s.VideoID
+}
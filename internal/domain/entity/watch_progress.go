@@ -0,0 +1,25 @@
+package entity
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// WatchProgress - the playback progress of a video by user, the only one record exists for each pair of them.
+type WatchProgress struct {
+	ID       vo.ID   `json:"id" bson:",inline"`
+	UserID   vo.ID   `json:"userID" bson:"user"`
+	VideoID  vo.ID   `json:"videoID" bson:"video"`
+	Position float64 `json:"position" bson:"position"` // last position reported by client side (seconds)
+	Duration float64 `json:"duration" bson:"duration"` // duration of the video reported by client side (seconds)
+	Offset   int64   `json:"offset" bson:"offset"`     // number of bytes which were sent by the server
+	Size     int64   `json:"size" bson:"size"`         // size of the streamed file
+	Finished bool    `json:"finished" bson:"finished"`
+}
+
+func (r WatchProgress) GetID() vo.ID {
+	return r.ID
+}
+func (r WatchProgress) GetUserID() vo.ID {
+	return r.UserID
+}
+func (r WatchProgress) GetVideoID() vo.ID {
+	return r.VideoID
+}
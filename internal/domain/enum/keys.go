@@ -1,3 +1,12 @@
 package enum
 
 const UniqueRequestIDKey = "UniqueRequestId"
+
+// RouteContextKey - the matched route (method and path template) of the current request.
+const RouteContextKey = "Route"
+
+// ClientIPContextKey - the address of the client which made the request (the upgrade request of websocket connections).
+const ClientIPContextKey = "ClientIP"
+
+// UserAgentContextKey - the user agent of the client which made the request.
+const UserAgentContextKey = "UserAgent"
@@ -0,0 +1,9 @@
+package enum
+
+// Reasons of the stop of a play session.
+const (
+	PlayStopCompleted = "completed" // the whole file was sent
+	PlayStopFailed    = "failed"    // the sending or the reading was failed (usually the client has gone)
+	PlayStopShutdown  = "shutdown"  // the connection was drained by the server shutdown
+	PlayStopReplaced  = "replaced"  // the next stream was requested by the client
+)
@@ -0,0 +1,23 @@
+package enum
+
+// API keys scopes.
+const (
+	VideoReadScope      = "video:read"
+	VideoWriteScope     = "video:write"
+	AudioReadScope      = "audio:read"
+	AudioWriteScope     = "audio:write"
+	ResourceUploadScope = "resource:upload"
+	UserReadScope       = "user:read"
+	UserWriteScope      = "user:write"
+)
+
+// Scopes is a list of all scopes which may be granted to an API key.
+var Scopes = []string{
+	VideoReadScope,
+	VideoWriteScope,
+	AudioReadScope,
+	AudioWriteScope,
+	ResourceUploadScope,
+	UserReadScope,
+	UserWriteScope,
+}
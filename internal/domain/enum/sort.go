@@ -0,0 +1,22 @@
+package enum
+
+// SortDescPrefix is a prefix of sort field which means the descending order (e.g. "-createdAt").
+const SortDescPrefix = "-"
+
+// Video list sort fields.
+const (
+	VideoSortByRelevance = "relevance" // available only with full-text search query
+	VideoSortByName      = "name"
+	VideoSortByCreatedAt = "createdAt"
+	VideoSortByDuration  = "duration"
+	VideoSortByFilesize  = "filesize"
+)
+
+// VideoSortFields is a list of fields which may be used for sort a video list.
+var VideoSortFields = []string{
+	VideoSortByRelevance,
+	VideoSortByName,
+	VideoSortByCreatedAt,
+	VideoSortByDuration,
+	VideoSortByFilesize,
+}
@@ -1,3 +1,5 @@
 package enum
 
 const AccessTokenHeaderKey = "x-access-token"
+
+const APIKeyHeaderKey = "x-api-key"
@@ -36,6 +36,11 @@ func NewAuthFailedError(msg string) *AuthFailedError {
 	}
 }
 
+func IsAuthFailedError(err error) bool {
+	_, ok := err.(*AuthFailedError)
+	return ok
+}
+
 type AccessTokenIsEmptyOrOmittedError struct{ publicError }
 
 func NewAccessTokenIsEmptyOrOmittedError() *AccessTokenIsEmptyOrOmittedError {
@@ -140,3 +145,98 @@ func NewTokenIssuerWasNotMatchedInternalError(token string) *TokenIssuerWasNotMa
 		},
 	}
 }
+
+type TokenTypeWasNotMatchedError struct{ internalError }
+
+func NewTokenTypeWasNotMatchedInternalError(token string) *TokenTypeWasNotMatchedError {
+	return &TokenTypeWasNotMatchedError{
+		internalError{
+			errored{
+				ErrorMessage: fmt.Sprintf("token '%v' type was not matched", token),
+				ErrorType:    authErrType,
+				errorStatus:  internalAuthErrStatus,
+				errorLevel:   internalAuthErrLevel,
+			},
+		},
+	}
+}
+
+type TwoFactorCodeIsInvalidError struct{ publicError }
+
+func NewTwoFactorCodeIsInvalidError() *TwoFactorCodeIsInvalidError {
+	return &TwoFactorCodeIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: two-factor code is invalid",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+func IsTwoFactorCodeIsInvalidError(err error) bool {
+	_, ok := err.(*TwoFactorCodeIsInvalidError)
+	return ok
+}
+
+type TwoFactorIsAlreadyEnabledError struct{ publicError }
+
+func NewTwoFactorIsAlreadyEnabledError() *TwoFactorIsAlreadyEnabledError {
+	return &TwoFactorIsAlreadyEnabledError{
+		publicError{
+			errored{
+				ErrorMessage: "two-factor authentication is already enabled",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type TwoFactorIsNotEnabledError struct{ publicError }
+
+func NewTwoFactorIsNotEnabledError() *TwoFactorIsNotEnabledError {
+	return &TwoFactorIsNotEnabledError{
+		publicError{
+			errored{
+				ErrorMessage: "two-factor authentication is not enabled",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type TwoFactorIsNotEnrolledError struct{ publicError }
+
+func NewTwoFactorIsNotEnrolledError() *TwoFactorIsNotEnrolledError {
+	return &TwoFactorIsNotEnrolledError{
+		publicError{
+			errored{
+				ErrorMessage: "two-factor authentication enrolment was not started",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type APIKeyIsInvalidError struct{ publicError }
+
+func NewAPIKeyIsInvalidError() *APIKeyIsInvalidError {
+	return &APIKeyIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: provided api key is invalid or revoked",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
@@ -26,3 +26,40 @@ func NewResourceAlreadyExistsError(name string) *ResourceAlreadyExistsError {
 		},
 	}
 }
+
+type FileIsTooLargeError struct{ publicError }
+
+func NewFileIsTooLargeError(maxFilesize int64) *FileIsTooLargeError {
+	return &FileIsTooLargeError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the uploading file is larger than threshold value %d", maxFilesize),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusRequestEntityTooLarge,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type QuotaExceededError struct{ publicError }
+
+func NewQuotaExceededError(msg string) *QuotaExceededError {
+	baseMsg := "the storage quota is exceeded"
+	if msg != "" {
+		msg = fmt.Sprintf("%v: %v", baseMsg, msg)
+	} else {
+		msg = baseMsg
+	}
+
+	return &QuotaExceededError{
+		publicError{
+			errored{
+				ErrorMessage: msg,
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusForbidden,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
@@ -258,3 +258,142 @@ func NewTimeParsingValidationError(date string) *TimeParsingValidationError {
 		},
 	}
 }
+
+type ScopeIsNotSupportedError struct{ publicError }
+
+func NewScopeIsNotSupportedError(scope string, supported []string) *ScopeIsNotSupportedError {
+	return &ScopeIsNotSupportedError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf(
+					"scope '%v' is not supported, available scopes: [%v]", scope, strings.Join(supported, ", "),
+				),
+				ErrorType:   validationType,
+				errorLevel:  publicValidationLevel,
+				errorStatus: publicValidationStatus,
+			},
+		},
+	}
+}
+
+type ValueMustBeNonNegativeError struct{ publicError }
+
+func NewValueMustBeNonNegativeError(field string) *ValueMustBeNonNegativeError {
+	return &ValueMustBeNonNegativeError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("field '%v' cannot be negative", field),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type ValueMustBePositiveError struct{ publicError }
+
+func NewValueMustBePositiveError(field string) *ValueMustBePositiveError {
+	return &ValueMustBePositiveError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("field '%v' must be greater than zero", field),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type RangeIsInvalidError struct{ publicError }
+
+func NewRangeIsInvalidError(fromField string, toField string) *RangeIsInvalidError {
+	return &RangeIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("field '%v' cannot be greater than field '%v'", fromField, toField),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type SortFieldIsNotSupportedError struct{ publicError }
+
+func NewSortFieldIsNotSupportedError(field string, supported []string) *SortFieldIsNotSupportedError {
+	return &SortFieldIsNotSupportedError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf(
+					"sort by '%v' is not supported, available fields: [%v]", field, strings.Join(supported, ", "),
+				),
+				ErrorType:   validationType,
+				errorLevel:  publicValidationLevel,
+				errorStatus: publicValidationStatus,
+			},
+		},
+	}
+}
+
+type CursorIsInvalidError struct{ publicError }
+
+func NewCursorIsInvalidError() *CursorIsInvalidError {
+	return &CursorIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: "pagination cursor is invalid or malformed",
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type ParametersCombinationIsInvalidError struct{ publicError }
+
+func NewParametersCombinationIsInvalidError(message string) *ParametersCombinationIsInvalidError {
+	return &ParametersCombinationIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: message,
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type TooManyValuesError struct{ publicError }
+
+func NewTooManyValuesError(field string, max int) *TooManyValuesError {
+	return &TooManyValuesError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("field '%v' cannot contain more than %d values", field, max),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type PositionIsOutOfRangeError struct{ publicError }
+
+func NewPositionIsOutOfRangeError(position int, total int) *PositionIsOutOfRangeError {
+	return &PositionIsOutOfRangeError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("position %d is out of range, the list contains %d items", position, total),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
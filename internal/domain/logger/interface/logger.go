@@ -31,7 +31,13 @@ type Logger interface {
 	Writer() io.Writer
 	SetOutput(w io.Writer)
 	Context() context.Context
-	SetContext(ctx context.Context)
+	// WithContext will return a request scoped logger, which marks each line by request ID, user ID and route
+	// from the given context (the shared logger is not mutated, so concurrent requests don't affect each other).
+	WithContext(ctx context.Context) Logger
+	// Dropped will return the number of error and request records which were dropped due to the full buffer.
+	Dropped() (errors uint64, requests uint64)
+	// Saturation will return the filling of the most loaded records buffer (from 0 to 1).
+	Saturation() float64
 
 	Close() func()
 }
@@ -0,0 +1,51 @@
+package logger_stub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"io"
+)
+
+// Logger - drops all the records and propagates the errors as is, it's used by the tests of services
+// which need a logger but don't check what was logged.
+type Logger struct {
+	ctx context.Context
+}
+
+func NewLogger() *Logger {
+	return &Logger{ctx: context.Background()}
+}
+
+func (l *Logger) Log(err error)                {}
+func (l *Logger) LogPropagate(err error) error { return err }
+func (l *Logger) LogData(data any)             {}
+
+func (l *Logger) Info(strOrErr any)                     {}
+func (l *Logger) InfoPropagate(strOrErr any) error      { return l.error(strOrErr) }
+func (l *Logger) Debug(strOrErr any)                    {}
+func (l *Logger) DebugPropagate(strOrErr any) error     { return l.error(strOrErr) }
+func (l *Logger) Warning(strOrErr any)                  {}
+func (l *Logger) WarningPropagate(strOrErr any) error   { return l.error(strOrErr) }
+func (l *Logger) Error(strOrErr any)                    {}
+func (l *Logger) ErrorPropagate(strOrErr any) error     { return l.error(strOrErr) }
+func (l *Logger) Critical(strOrErr any)                 {}
+func (l *Logger) CriticalPropagate(strOrErr any) error  { return l.error(strOrErr) }
+func (l *Logger) Emergency(strOrErr any)                {}
+func (l *Logger) EmergencyPropagate(strOrErr any) error { return l.error(strOrErr) }
+
+func (l *Logger) Writer() io.Writer                                       { return io.Discard }
+func (l *Logger) SetOutput(w io.Writer)                                   {}
+func (l *Logger) Context() context.Context                                { return l.ctx }
+func (l *Logger) WithContext(ctx context.Context) logger_interface.Logger { return l }
+func (l *Logger) Dropped() (errors uint64, requests uint64)               { return 0, 0 }
+func (l *Logger) Saturation() float64                                     { return 0 }
+func (l *Logger) Close() func()                                           { return func() {} }
+
+func (l *Logger) error(strOrErr any) error {
+	if err, isErr := strOrErr.(error); isErr {
+		return err
+	}
+	return errors.New(fmt.Sprint(strOrErr))
+}
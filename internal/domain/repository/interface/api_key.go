@@ -0,0 +1,17 @@
+package repository_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"time"
+)
+
+type APIKey interface {
+	FindOneByID(context.Context, query_interface.FindOneAPIKeyByID) (*agg.APIKey, error)
+	FindOneByHash(context.Context, query_interface.FindOneAPIKeyByHash) (*agg.APIKey, error)
+	FindList(context.Context, query_interface.FindAPIKeyList) ([]*agg.APIKey, error)
+	Insert(context.Context, *agg.APIKey) (*agg.APIKey, error)
+	Update(context.Context, *agg.APIKey) (*agg.APIKey, error)
+	UpdateLastUsedAt(ctx context.Context, apiKey *agg.APIKey, usedAt time.Time) error
+}
@@ -0,0 +1,18 @@
+package repository_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type PlaySession interface {
+	Insert(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error)
+	// Stop will store the sent bytes and the stop of the session, the stopped session is returned.
+	Stop(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error)
+	// Watch will increase the completion of the last session of the video by the viewer, the session is returned
+	// as it was before the update (nil if its completion is not less than the given one).
+	Watch(ctx context.Context, viewerID vo.ID, videoID vo.ID, completion float64, isFinished bool) (*agg.PlaySession, error)
+	// RemoveVideo will remove the play sessions of the video.
+	RemoveVideo(ctx context.Context, video *agg.Video) error
+}
@@ -10,4 +10,14 @@ type Resource interface {
 	FindOneByID(context.Context, query_interface.FindOneResourceByID) (*agg.Resource, error)
 	Insert(context.Context, *agg.Resource) (*agg.Resource, error)
 	Remove(context.Context, *agg.Resource) error
+	// Trash will mark the resource as deleted, so it's hidden by the rest queries until it will be restored.
+	Trash(context.Context, *agg.Resource) error
+	// Restore will bring the resource back from the trash.
+	Restore(context.Context, *agg.Resource) error
+	FindOneTrashedByID(context.Context, query_interface.FindOneResourceByID) (*agg.Resource, error)
+	// FindUsage will sum up the sizes of files of the user.
+	FindUsage(context.Context, query_interface.FindResourcesUsage) (size int64, count int64, err error)
+	// Walk will call the given func for each resource of all users (including the trashed ones),
+	// the walking is stopped by the first error.
+	Walk(ctx context.Context, fn func(*agg.Resource) error) error
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"time"
 )
 
 type Video interface {
@@ -14,4 +15,15 @@ type Video interface {
 	Insert(ctx context.Context, video *agg.Video) (*agg.Video, error)
 	Update(ctx context.Context, video *agg.Video) (*agg.Video, error)
 	Remove(ctx context.Context, video *agg.Video) error
+	// Trash will mark the video as deleted, so it's hidden by the rest queries until it will be restored.
+	Trash(ctx context.Context, video *agg.Video) error
+	// Restore will bring the video back from the trash.
+	Restore(ctx context.Context, video *agg.Video) error
+	FindOneTrashedByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error)
+	FindTrashedList(ctx context.Context, q query_interface.FindTrashedVideoList) (list []*agg.Video, total int64, err error)
+	// FindTrashedBefore will fetch the videos which were trashed before the given time (from the oldest one).
+	FindTrashedBefore(ctx context.Context, before time.Time, limit int64) ([]*agg.Video, error)
+	// Walk will call the given func for each video of all users (including the trashed ones),
+	// the walking is stopped by the first error.
+	Walk(ctx context.Context, fn func(*agg.Video) error) error
 }
@@ -20,6 +20,7 @@ const (
 	AudioType
 	ResourceType
 	UserType
+	PlaylistType
 )
 
 type AccessService struct {
@@ -67,6 +68,31 @@ func (s *AccessService) IsGranted(userID vo.ID, aggregates ...agg_interface.Aggr
 	return nil
 }
 
+// IsGrantedScope is a method which will check that the required scope is present into the granted scopes
+// (used for requests which were authed by API key). An empty required scope means that the target
+// is not available by scopes at all.
+func (s *AccessService) IsGrantedScope(granted []string, required string) error {
+	if required == "" {
+		return s.logger.LogPropagate(
+			errors.NewAccessDeniedError("the target is not available for API keys"),
+		)
+	}
+
+	for _, scope := range granted {
+		if scope == required {
+			// access is granted, scope was matched
+			return nil
+		}
+	}
+
+	// scope was not matched, access is denied
+	return s.logger.LogPropagate(
+		errors.NewAccessDeniedError(
+			fmt.Sprintf("you have not enough rights, the '%v' scope is required", required),
+		),
+	)
+}
+
 // video
 func (s *AccessService) videoHandler(userID vo.ID, aggregate agg_interface.Aggregate) error {
 	videoAgg, ok := aggregate.(*agg.Video)
@@ -191,6 +217,37 @@ func (s *AccessService) userIsAppropriateHandler(v agg_interface.Aggregate) (isA
 	return false
 }
 
+// playlist
+func (s *AccessService) playlistHandler(userID vo.ID, aggregate agg_interface.Aggregate) error {
+	playlistAgg, ok := aggregate.(*agg.Playlist)
+	if !ok {
+		return s.logger.LogPropagate(
+			fmt.Errorf(
+				"unable to check access for given aggregate of type '%v' in playlist access handler",
+				reflect.TypeOf(aggregate).Name(),
+			),
+		)
+	}
+
+	if userID.Value == playlistAgg.UserID.Value {
+		// user is owner of playlist
+		return nil
+	}
+
+	// playlist was not matched, access is denied
+	return s.logger.LogPropagate(
+		errors.NewAccessDeniedError(
+			"you have not enough rights, one of entities is playlist and it's not belong to you",
+		),
+	)
+}
+func (s *AccessService) playlistIsAppropriateHandler(v agg_interface.Aggregate) (isAppropriate bool) {
+	if _, ok := v.(*agg.Playlist); ok {
+		return true
+	}
+	return false
+}
+
 func (s *AccessService) setHandlers() *AccessService {
 	// video
 	s.handlers[VideoType] = s.videoHandler
@@ -204,6 +261,9 @@ func (s *AccessService) setHandlers() *AccessService {
 	// user
 	s.handlers[UserType] = s.userHandler
 	s.isAppropriateHandlerFuncs[UserType] = s.userIsAppropriateHandler
+	// playlist
+	s.handlers[PlaylistType] = s.playlistHandler
+	s.isAppropriateHandlerFuncs[PlaylistType] = s.playlistIsAppropriateHandler
 	// fluent setter
 	return s
 }
@@ -0,0 +1,53 @@
+package accessor
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	"testing"
+)
+
+func TestAccessService_IsGrantedScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		granted   []string
+		required  string
+		isGranted bool
+	}{
+		{
+			name:      "scope is granted",
+			granted:   []string{enum.VideoReadScope, enum.AudioReadScope},
+			required:  enum.AudioReadScope,
+			isGranted: true,
+		},
+		{
+			name:     "scope is missing",
+			granted:  []string{enum.VideoReadScope},
+			required: enum.VideoWriteScope,
+		},
+		{
+			name:     "no granted scopes",
+			required: enum.VideoReadScope,
+		},
+		{
+			name:    "target is not available by scopes",
+			granted: enum.Scopes,
+		},
+	}
+
+	s := &AccessService{logger: logger_stub.NewLogger()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.IsGrantedScope(tt.granted, tt.required)
+			if tt.isGranted {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if _, ok := err.(*errors.AccessDeniedError); !ok {
+				t.Fatalf("expected AccessDeniedError, got %v", err)
+			}
+		})
+	}
+}
@@ -8,4 +8,6 @@ import (
 type Accessor interface {
 	// IsGranted is a method which will check the access to target aggregates scope.
 	IsGranted(userID vo.ID, aggregates ...agg_interface.Aggregate) error
+	// IsGrantedScope is a method which will check that the required scope is present into the granted scopes.
+	IsGrantedScope(granted []string, required string) error
 }
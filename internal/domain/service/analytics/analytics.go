@@ -0,0 +1,212 @@
+package analytics
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type AnalyticsService struct {
+	logger            logger_interface.Logger
+	validator         validator_interface.Analytics
+	sessionRepository repository_interface.PlaySession
+	statsRepository   repository_interface.VideoStats
+	videoRepository   repository_interface.Video
+	finishedRatio     float64
+}
+
+func NewAnalyticsService(serviceContainer di_interface.ContainerManager) (*AnalyticsService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	analyticsValidator, err := serviceContainer.GetAnalyticsValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playSessionRepository, err := serviceContainer.GetPlaySessionRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoStatsRepository, err := serviceContainer.GetVideoStatsRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AnalyticsService{
+		logger:            loggerService,
+		validator:         analyticsValidator,
+		sessionRepository: playSessionRepository,
+		statsRepository:   videoStatsRepository,
+		videoRepository:   videoRepository,
+		finishedRatio:     cfg.AnalyticsFinishedRatio,
+	}, nil
+}
+
+// Start - will store the started play session, its completion is reported by client side while the video is played.
+func (s *AnalyticsService) Start(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error) {
+	logger := s.logger.WithContext(ctx)
+
+	session.Timestamp = vo.Timestamp{
+		CreatedAt: time.Now(),
+	}
+
+	session, err := s.sessionRepository.Insert(ctx, session)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return session, nil
+}
+
+// Stop - will store the stop of the play session and add it to the totals and the daily rollup of the video
+// (with the completion which was reported till the stop).
+func (s *AnalyticsService) Stop(ctx context.Context, session *agg.PlaySession) error {
+	logger := s.logger.WithContext(ctx)
+
+	session.Timestamp.UpdatedAt = time.Now()
+
+	stopped, err := s.sessionRepository.Stop(ctx, session)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	if err = s.statsRepository.Increment(ctx, stopped); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// Watched - will compute the completion of the last play session of the video by the viewer from the position
+// reported by client side (the file is sent much faster than it's played, so the sent bytes tell nothing about it).
+// The completion is never decreased, its growth is added to the stats if the session is stopped already.
+func (s *AnalyticsService) Watched(ctx context.Context, progress *agg.WatchProgress) error {
+	logger := s.logger.WithContext(ctx)
+
+	// the completion is unknown without duration of the video
+	if progress.Duration <= 0 {
+		return nil
+	}
+	completion := min(progress.Position/progress.Duration, 1)
+	isFinished := completion >= s.finishedRatio
+
+	before, err := s.sessionRepository.Watch(ctx, progress.UserID, progress.VideoID, completion, isFinished)
+	if err != nil {
+		// the video was never streamed by the viewer
+		if errors.IsEntityNotFoundError(err) {
+			return nil
+		}
+		return logger.LogPropagate(err)
+	}
+
+	// the completion is not increased or the session is not stopped yet (it will be added by its stop)
+	if before == nil || before.StoppedAt.IsZero() {
+		return nil
+	}
+
+	if err = s.statsRepository.AddCompletion(ctx, before, completion-before.Completion, isFinished && !before.Finished); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// Get - will fetch the analytics of the video, the video must belong to the user.
+// The video which was never played within the range has the zero totals.
+func (s *AnalyticsService) Get(
+	ctx context.Context, req dto_interface.GetVideoAnalyticsRequest,
+) (
+	totals *agg.VideoStats, daily []*agg.VideoStats, err error,
+) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err = s.validator.ValidateGetRequestDTO(req); err != nil {
+		return nil, nil, logger.LogPropagate(err)
+	}
+
+	// the analytics of foreign or removed videos is not available
+	if _, err = s.videoRepository.FindOneByID(ctx, dto.NewVideoGetRequestDTO(req.GetVideoID(), "", vo.ID{}, req.GetUserID())); err != nil {
+		return nil, nil, logger.LogPropagate(err)
+	}
+
+	totals, err = s.statsRepository.FindOne(ctx, req)
+	if err != nil {
+		if !errors.IsEntityNotFoundError(err) {
+			return nil, nil, logger.LogPropagate(err)
+		}
+		totals = &agg.VideoStats{
+			VideoStats: entity.VideoStats{
+				UserID:  req.GetUserID(),
+				VideoID: req.GetVideoID(),
+			},
+		}
+	}
+
+	daily, err = s.statsRepository.FindDaily(ctx, req)
+	if err != nil {
+		return nil, nil, logger.LogPropagate(err)
+	}
+
+	s.average(totals)
+	for _, stats := range daily {
+		s.average(stats)
+	}
+
+	return totals, daily, nil
+}
+
+// List - will fetch the analytics of the videos of the user from the most played one.
+func (s *AnalyticsService) List(
+	ctx context.Context, req dto_interface.ListVideoAnalyticsRequest,
+) (
+	list []*agg.VideoStats, total int64, err error,
+) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err = s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	list, total, err = s.statsRepository.FindList(ctx, req)
+	if err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	for _, stats := range list {
+		s.average(stats)
+	}
+
+	return list, total, nil
+}
+
+// average - computes the average completion of the play sessions from the sum of their completions.
+func (s *AnalyticsService) average(stats *agg.VideoStats) {
+	if stats.Plays > 0 {
+		stats.AvgCompletion = stats.CompletionSum / float64(stats.Plays)
+	}
+}
@@ -0,0 +1,517 @@
+package analytics
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/validator"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	query_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"math"
+	"testing"
+	"time"
+)
+
+// testPlaySessionRepository - stores the sessions in memory or fails by the given error.
+type testPlaySessionRepository struct {
+	repository_interface.PlaySession
+	err      error
+	sessions []*agg.PlaySession
+}
+
+func (r *testPlaySessionRepository) Insert(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	session.ID = vo.NewID(primitive.NewObjectID())
+	inserted := *session
+	r.sessions = append(r.sessions, &inserted)
+	return session, nil
+}
+
+func (r *testPlaySessionRepository) Stop(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, stored := range r.sessions {
+		if stored.ID == session.ID {
+			stored.Bytes, stored.StopReason, stored.StoppedAt = session.Bytes, session.StopReason, session.StoppedAt
+			stopped := *stored
+			return &stopped, nil
+		}
+	}
+	return nil, errors.NewEntityNotFoundError("play session", "id")
+}
+
+func (r *testPlaySessionRepository) Watch(
+	ctx context.Context, viewerID vo.ID, videoID vo.ID, completion float64, isFinished bool,
+) (*agg.PlaySession, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var last *agg.PlaySession
+	for _, stored := range r.sessions {
+		if stored.ViewerID == viewerID && stored.VideoID == videoID && (last == nil || stored.StartedAt.After(last.StartedAt)) {
+			last = stored
+		}
+	}
+	if last == nil {
+		return nil, errors.NewEntityNotFoundError("play session", "id")
+	}
+	if last.Completion >= completion {
+		return nil, nil
+	}
+	before := *last
+	last.Completion, last.Finished = completion, last.Finished || isFinished
+	return &before, nil
+}
+
+// testVideoStatsRepository - returns the stored totals and rollups, sums up the incremented sessions.
+type testVideoStatsRepository struct {
+	repository_interface.VideoStats
+	err           error
+	totals        map[vo.ID]*agg.VideoStats
+	daily         map[vo.ID][]*agg.VideoStats
+	list          []*agg.VideoStats
+	plays         int64
+	finished      int64
+	completionSum float64
+}
+
+func (r *testVideoStatsRepository) FindOne(ctx context.Context, q query_interface.FindOneVideoStats) (*agg.VideoStats, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if stats, ok := r.totals[q.GetVideoID()]; ok {
+		return stats, nil
+	}
+	return nil, errors.NewEntityNotFoundError("video stats", "video")
+}
+
+func (r *testVideoStatsRepository) FindDaily(ctx context.Context, q query_interface.FindOneVideoStats) ([]*agg.VideoStats, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.daily[q.GetVideoID()], nil
+}
+
+func (r *testVideoStatsRepository) FindList(
+	ctx context.Context, q query_interface.FindVideoStatsList,
+) (list []*agg.VideoStats, total int64, err error) {
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	return r.list, int64(len(r.list)), nil
+}
+
+func (r *testVideoStatsRepository) Increment(ctx context.Context, session *agg.PlaySession) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.plays++
+	r.completionSum += session.Completion
+	if session.Finished {
+		r.finished++
+	}
+	return nil
+}
+
+func (r *testVideoStatsRepository) AddCompletion(ctx context.Context, session *agg.PlaySession, delta float64, isFinished bool) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.completionSum += delta
+	if isFinished {
+		r.finished++
+	}
+	return nil
+}
+
+// testVideoRepository - a video is found only for its owner.
+type testVideoRepository struct {
+	repository_interface.Video
+	videos map[vo.ID]vo.ID // video -> owner
+}
+
+func (r *testVideoRepository) FindOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	if userID, ok := r.videos[q.GetID()]; ok && userID == q.GetUserID() {
+		return &agg.Video{Video: entity.Video{ID: q.GetID(), UserID: userID}}, nil
+	}
+	return nil, errors.NewEntityNotFoundError("video", "id")
+}
+
+func newTestStats(plays int64, completionSum float64) *agg.VideoStats {
+	return &agg.VideoStats{VideoStats: entity.VideoStats{Plays: plays, CompletionSum: completionSum}}
+}
+
+type testAnalytics struct {
+	service     *AnalyticsService
+	sessions    *testPlaySessionRepository
+	stats       *testVideoStatsRepository
+	owner       vo.ID
+	playedVideo vo.ID // has the totals and two daily rollups
+	newVideo    vo.ID // was never played
+}
+
+func newTestAnalytics() *testAnalytics {
+	f := &testAnalytics{
+		sessions:    &testPlaySessionRepository{},
+		owner:       vo.NewID(primitive.NewObjectID()),
+		playedVideo: vo.NewID(primitive.NewObjectID()),
+		newVideo:    vo.NewID(primitive.NewObjectID()),
+	}
+	f.stats = &testVideoStatsRepository{
+		totals: map[vo.ID]*agg.VideoStats{f.playedVideo: newTestStats(4, 3)},
+		daily:  map[vo.ID][]*agg.VideoStats{f.playedVideo: {newTestStats(1, 1), newTestStats(3, 2)}},
+		list:   []*agg.VideoStats{newTestStats(4, 3), newTestStats(0, 0)},
+	}
+	f.service = &AnalyticsService{
+		logger:            logger_stub.NewLogger(),
+		validator:         &validator.AnalyticsValidator{},
+		sessionRepository: f.sessions,
+		statsRepository:   f.stats,
+		videoRepository: &testVideoRepository{
+			videos: map[vo.ID]vo.ID{f.playedVideo: f.owner, f.newVideo: f.owner},
+		},
+		finishedRatio: 0.9,
+	}
+	return f
+}
+
+func TestAnalyticsService_Start(t *testing.T) {
+	storageErr := goerrors.New("storage is not available")
+
+	tests := []struct {
+		name      string
+		insertErr error
+	}{
+		{name: "session is stored"},
+		{name: "session is not stored", insertErr: storageErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestAnalytics()
+			f.sessions.err = tt.insertErr
+
+			session, err := f.service.Start(context.Background(), &agg.PlaySession{PlaySession: entity.PlaySession{
+				UserID:  f.owner,
+				VideoID: f.playedVideo,
+			}})
+			if !goerrors.Is(err, tt.insertErr) {
+				t.Fatalf("error = %v, want %v", err, tt.insertErr)
+			}
+			if tt.insertErr != nil {
+				return
+			}
+			if session.ID.Value.IsZero() || session.Timestamp.CreatedAt.IsZero() {
+				t.Errorf("session = %+v, want the stored one with creation time", session)
+			}
+			if f.stats.plays != 0 {
+				t.Errorf("started session is counted into the stats")
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_Stop(t *testing.T) {
+	storageErr := goerrors.New("storage is not available")
+
+	tests := []struct {
+		name      string
+		stopErr   error
+		statsErr  error
+		plays     int64
+		isStopped bool
+	}{
+		{name: "session is counted", plays: 1, isStopped: true},
+		{name: "stop is not stored", stopErr: storageErr},
+		{name: "stats are not incremented", statsErr: storageErr, isStopped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestAnalytics()
+
+			ctx := context.Background()
+			session, err := f.service.Start(ctx, &agg.PlaySession{PlaySession: entity.PlaySession{
+				UserID:    f.owner,
+				VideoID:   f.playedVideo,
+				StartedAt: time.Now(),
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			f.sessions.err, f.stats.err = tt.stopErr, tt.statsErr
+
+			session.Bytes, session.StopReason, session.StoppedAt = 50, "completed", time.Now()
+			err = f.service.Stop(ctx, session)
+
+			wantErr := tt.stopErr
+			if wantErr == nil {
+				wantErr = tt.statsErr
+			}
+			if !goerrors.Is(err, wantErr) {
+				t.Fatalf("error = %v, want %v", err, wantErr)
+			}
+			if isStopped := !f.sessions.sessions[0].StoppedAt.IsZero(); isStopped != tt.isStopped {
+				t.Errorf("session is stopped = %v, want %v", isStopped, tt.isStopped)
+			}
+			if f.stats.plays != tt.plays {
+				t.Errorf("plays = %d, want %d", f.stats.plays, tt.plays)
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_Watched(t *testing.T) {
+	tests := []struct {
+		name          string
+		duration      float64
+		playing       []float64 // the positions reported while streaming
+		played        []float64 // the positions reported after the stop of streaming
+		completion    float64
+		finished      bool
+		completionSum float64
+		finishedCount int64
+	}{
+		{name: "reported while streaming", duration: 100, playing: []float64{30, 60}, completion: 0.6, completionSum: 0.6},
+		{
+			name:          "reported after stop",
+			duration:      100,
+			playing:       []float64{10},
+			played:        []float64{50, 95},
+			completion:    0.95,
+			finished:      true,
+			completionSum: 0.95,
+			finishedCount: 1,
+		},
+		{name: "seeking back does not decrease", duration: 100, playing: []float64{80}, played: []float64{20}, completion: 0.8, completionSum: 0.8},
+		{
+			name:          "completion is capped",
+			duration:      100,
+			played:        []float64{95, 120},
+			completion:    1,
+			finished:      true,
+			completionSum: 1,
+			finishedCount: 1,
+		},
+		{name: "unknown duration", playing: []float64{30}, played: []float64{60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestAnalytics()
+			ctx := context.Background()
+			viewer := vo.NewID(primitive.NewObjectID())
+
+			// the previous session of the viewer must be left as is
+			previous := &agg.PlaySession{PlaySession: entity.PlaySession{
+				ViewerID:  viewer,
+				VideoID:   f.playedVideo,
+				StartedAt: time.Now().Add(-time.Hour),
+				StoppedAt: time.Now().Add(-time.Hour),
+			}}
+			f.sessions.sessions = append(f.sessions.sessions, previous)
+
+			session, err := f.service.Start(ctx, &agg.PlaySession{PlaySession: entity.PlaySession{
+				UserID:    f.owner,
+				VideoID:   f.playedVideo,
+				ViewerID:  viewer,
+				StartedAt: time.Now(),
+			}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			watch := func(positions []float64) {
+				for _, position := range positions {
+					progress := &agg.WatchProgress{WatchProgress: entity.WatchProgress{
+						UserID:   viewer,
+						VideoID:  f.playedVideo,
+						Position: position,
+						Duration: tt.duration,
+					}}
+					if err = f.service.Watched(ctx, progress); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				}
+			}
+			watch(tt.playing)
+			session.StopReason, session.StoppedAt = "completed", time.Now()
+			if err = f.service.Stop(ctx, session); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			watch(tt.played)
+
+			stored := f.sessions.sessions[1]
+			if stored.Completion != tt.completion || stored.Finished != tt.finished {
+				t.Errorf("completion = %v, finished = %v, want %v and %v", stored.Completion, stored.Finished, tt.completion, tt.finished)
+			}
+			if math.Abs(f.stats.completionSum-tt.completionSum) > 1e-9 || f.stats.finished != tt.finishedCount {
+				t.Errorf("stats completion sum = %v, finished = %d, want %v and %d",
+					f.stats.completionSum, f.stats.finished, tt.completionSum, tt.finishedCount)
+			}
+			if previous.Completion != 0 {
+				t.Errorf("completion of the previous session = %v, want 0", previous.Completion)
+			}
+		})
+	}
+
+	t.Run("never streamed video", func(t *testing.T) {
+		f := newTestAnalytics()
+
+		progress := &agg.WatchProgress{WatchProgress: entity.WatchProgress{
+			UserID:   f.owner,
+			VideoID:  f.newVideo,
+			Position: 30,
+			Duration: 100,
+		}}
+		if err := f.service.Watched(context.Background(), progress); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if f.stats.completionSum != 0 {
+			t.Errorf("stats completion sum = %v, want 0", f.stats.completionSum)
+		}
+	})
+}
+
+func TestAnalyticsService_Get(t *testing.T) {
+	storageErr := goerrors.New("storage is not available")
+
+	tests := []struct {
+		name       string
+		req        func(f *testAnalytics) *dto.VideoAnalyticsGetRequestDTO
+		statsErr   error
+		err        func(err error) bool
+		plays      int64
+		completion float64
+		daily      []float64 // the average completions of the daily rollups
+	}{
+		{
+			name: "played video",
+			req: func(f *testAnalytics) *dto.VideoAnalyticsGetRequestDTO {
+				return &dto.VideoAnalyticsGetRequestDTO{UserID: f.owner, VideoID: f.playedVideo}
+			},
+			plays:      4,
+			completion: 0.75,
+			daily:      []float64{1, 2.0 / 3},
+		},
+		{
+			name: "never played video has zero totals",
+			req: func(f *testAnalytics) *dto.VideoAnalyticsGetRequestDTO {
+				return &dto.VideoAnalyticsGetRequestDTO{UserID: f.owner, VideoID: f.newVideo}
+			},
+		},
+		{
+			name: "foreign video",
+			req: func(f *testAnalytics) *dto.VideoAnalyticsGetRequestDTO {
+				return &dto.VideoAnalyticsGetRequestDTO{UserID: vo.NewID(primitive.NewObjectID()), VideoID: f.playedVideo}
+			},
+			err: errors.IsEntityNotFoundError,
+		},
+		{
+			name: "invalid range",
+			req: func(f *testAnalytics) *dto.VideoAnalyticsGetRequestDTO {
+				return &dto.VideoAnalyticsGetRequestDTO{
+					UserID:  f.owner,
+					VideoID: f.playedVideo,
+					From:    time.Now(),
+					To:      time.Now().Add(-time.Hour),
+				}
+			},
+			err: func(err error) bool { return err != nil },
+		},
+		{
+			name: "stats are not available",
+			req: func(f *testAnalytics) *dto.VideoAnalyticsGetRequestDTO {
+				return &dto.VideoAnalyticsGetRequestDTO{UserID: f.owner, VideoID: f.playedVideo}
+			},
+			statsErr: storageErr,
+			err:      func(err error) bool { return goerrors.Is(err, storageErr) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestAnalytics()
+			f.stats.err = tt.statsErr
+
+			req := tt.req(f)
+			totals, daily, err := f.service.Get(context.Background(), req)
+			if tt.err != nil {
+				if !tt.err(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if totals.Plays != tt.plays || totals.AvgCompletion != tt.completion {
+				t.Errorf("totals = %+v, want %v plays with %v avg. completion", totals.VideoStats, tt.plays, tt.completion)
+			}
+			if tt.plays == 0 && (totals.UserID != req.UserID || totals.VideoID != req.VideoID) {
+				t.Errorf("zero totals = %+v, want of the requested video", totals.VideoStats)
+			}
+			if len(daily) != len(tt.daily) {
+				t.Fatalf("daily rollups = %d, want %d", len(daily), len(tt.daily))
+			}
+			for i, stats := range daily {
+				if stats.AvgCompletion != tt.daily[i] {
+					t.Errorf("avg. completion of day %d = %v, want %v", i, stats.AvgCompletion, tt.daily[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_List(t *testing.T) {
+	tests := []struct {
+		name        string
+		pagination  dto.PaginationRequestDTO
+		completions []float64
+		isErr       bool
+	}{
+		{name: "averages are computed", pagination: dto.PaginationRequestDTO{Page: 1, Limit: 10}, completions: []float64{0.75, 0}},
+		{name: "zero limit", pagination: dto.PaginationRequestDTO{Page: 1}, isErr: true},
+		{name: "too big limit", pagination: dto.PaginationRequestDTO{Page: 1, Limit: 101}, isErr: true},
+		{name: "invalid cursor", pagination: dto.PaginationRequestDTO{Page: 1, Limit: 10, Cursor: "%"}, isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestAnalytics()
+
+			list, total, err := f.service.List(context.Background(), &dto.VideoAnalyticsListRequestDTO{
+				UserID:               f.owner,
+				PaginationRequestDTO: tt.pagination,
+			})
+			if tt.isErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if total != int64(len(tt.completions)) || len(list) != len(tt.completions) {
+				t.Fatalf("list = %d of %d, want %d", len(list), total, len(tt.completions))
+			}
+			for i, stats := range list {
+				if stats.AvgCompletion != tt.completions[i] {
+					t.Errorf("avg. completion of video %d = %v, want %v", i, stats.AvgCompletion, tt.completions[i])
+				}
+			}
+		})
+	}
+}
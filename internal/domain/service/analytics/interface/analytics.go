@@ -0,0 +1,20 @@
+package analytics_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Analytics interface {
+	// Start will store the started play session.
+	Start(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error)
+	// Stop will store the stop of the play session and add it to the counters of the video.
+	Stop(ctx context.Context, session *agg.PlaySession) error
+	// Watched will update the completion of the last play session of the video by the position reported by client side.
+	Watched(ctx context.Context, progress *agg.WatchProgress) error
+	// Get will fetch the totals of the video and its daily rollups within the requested range.
+	Get(ctx context.Context, reqDTO dto_interface.GetVideoAnalyticsRequest) (totals *agg.VideoStats, daily []*agg.VideoStats, err error)
+	// List will fetch the totals of the videos within the requested range from the most played one.
+	List(ctx context.Context, reqDTO dto_interface.ListVideoAnalyticsRequest) (list []*agg.VideoStats, total int64, err error)
+}
@@ -0,0 +1,198 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+const (
+	// keyPrefix helps to recognize the API key (for example, by secret scanners).
+	keyPrefix = "vsk_"
+	// keyLength is a number of random bytes of the API key.
+	keyLength = 32
+	// keyVisiblePrefixLength is a number of symbols of the raw key which will be stored as is for identify a key.
+	keyVisiblePrefixLength = 12
+	// lastUsedAtUpdatePeriod is a min. period between writes of the last-used timestamp,
+	// it prevents an extra write into the database on each request.
+	lastUsedAtUpdatePeriod = time.Minute
+)
+
+type APIKeyService struct {
+	logger     logger_interface.Logger
+	builder    builder_interface.APIKey
+	validator  validator_interface.APIKey
+	repository repository_interface.APIKey
+}
+
+func NewAPIKeyService(serviceContainer di_interface.ContainerManager) (*APIKeyService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyBuilder, err := serviceContainer.GetAPIKeyBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyValidator, err := serviceContainer.GetAPIKeyValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyRepository, err := serviceContainer.GetAPIKeyRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &APIKeyService{
+		logger:     loggerService,
+		builder:    apiKeyBuilder,
+		validator:  apiKeyValidator,
+		repository: apiKeyRepository,
+	}, nil
+}
+
+// Create will issue a new API key, the raw key is returned only once and further stored as hash.
+func (s *APIKeyService) Create(ctx context.Context, req dto_interface.CreateAPIKeyRequest) (key string, apiKey *agg.APIKey, err error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err = s.validator.ValidateCreateRequestDTO(req); err != nil {
+		return "", nil, logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	apiKey, err = s.builder.BuildAggFromCreateRequestDTO(req)
+	if err != nil {
+		return "", nil, logger.LogPropagate(err)
+	}
+
+	// generating a raw key, only the hash of it will be stored
+	key, err = s.generate()
+	if err != nil {
+		return "", nil, logger.LogPropagate(err)
+	}
+	apiKey.Prefix = key[:keyVisiblePrefixLength]
+	apiKey.Hash = s.hash(key)
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(apiKey); err != nil {
+		return "", nil, logger.LogPropagate(err)
+	}
+
+	// saving an aggregate into storage
+	apiKey, err = s.repository.Insert(ctx, apiKey)
+	if err != nil {
+		return "", nil, logger.LogPropagate(err)
+	}
+
+	return key, apiKey, nil
+}
+
+// List will fetch all API keys of a user (including revoked ones).
+func (s *APIKeyService) List(ctx context.Context, req dto_interface.ListAPIKeyRequest) ([]*agg.APIKey, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// fetching a list of user keys
+	list, err := s.repository.FindList(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return list, nil
+}
+
+// Revoke will make the API key unusable. Access check is unnecessary because the query
+// will fetch a key only for specified user.
+func (s *APIKeyService) Revoke(ctx context.Context, req dto_interface.RevokeAPIKeyRequest) error {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateRevokeRequestDTO(req); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// fetching a key which will be revoked
+	apiKey, err := s.repository.FindOneByID(ctx, req)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// the key is already revoked, nothing to do
+	if apiKey.IsRevoked() {
+		return nil
+	}
+
+	apiKey.RevokedAt = time.Now()
+	apiKey.Timestamp.UpdatedAt = time.Now()
+
+	// saving the revoked key
+	if _, err = s.repository.Update(ctx, apiKey); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// Authenticate will find an active API key by the raw key and mark it as used.
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (*agg.APIKey, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// fetching a key by hash of given raw key
+	apiKey, err := s.repository.FindOneByHash(ctx, dto.NewAPIKeyGetRequestDTO(vo.ID{}, vo.ID{}, s.hash(key)))
+	if err != nil {
+		if errors.IsEntityNotFoundError(err) {
+			return nil, logger.LogPropagate(errors.NewAPIKeyIsInvalidError())
+		}
+		return nil, logger.LogPropagate(err)
+	}
+
+	// checking the key is still active
+	if apiKey.IsRevoked() {
+		return nil, logger.LogPropagate(errors.NewAPIKeyIsInvalidError())
+	}
+
+	// updating the last-used timestamp
+	if time.Since(apiKey.LastUsedAt) > lastUsedAtUpdatePeriod {
+		apiKey.LastUsedAt = time.Now()
+		if err = s.repository.UpdateLastUsedAt(ctx, apiKey, apiKey.LastUsedAt); err != nil {
+			// the key is valid, so the request must not fail because of this
+			logger.Log(err)
+		}
+	}
+
+	return apiKey, nil
+}
+
+func (s *APIKeyService) generate() (string, error) {
+	b := make([]byte, keyLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (s *APIKeyService) hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
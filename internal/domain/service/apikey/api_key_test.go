@@ -0,0 +1,306 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/builder"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	domain_errors "github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/validator"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	query_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testAPIKeyRepository - stores the keys in memory, each operation may be failed by the given error.
+type testAPIKeyRepository struct {
+	repository_interface.APIKey
+	keys          map[primitive.ObjectID]*agg.APIKey
+	err           error
+	lastUsedAtErr error
+	updates       int
+	lastUsedAts   int
+}
+
+func newTestAPIKeyRepository(keys ...*agg.APIKey) *testAPIKeyRepository {
+	r := &testAPIKeyRepository{keys: make(map[primitive.ObjectID]*agg.APIKey, len(keys))}
+	for _, key := range keys {
+		r.keys[key.ID.Value] = key
+	}
+	return r
+}
+
+func (r *testAPIKeyRepository) FindOneByID(ctx context.Context, q query_interface.FindOneAPIKeyByID) (*agg.APIKey, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if key, ok := r.keys[q.GetID().Value]; ok && key.UserID == q.GetUserID() {
+		return key, nil
+	}
+	return nil, domain_errors.NewEntityNotFoundError("apiKey", "id")
+}
+
+func (r *testAPIKeyRepository) FindOneByHash(ctx context.Context, q query_interface.FindOneAPIKeyByHash) (*agg.APIKey, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	for _, key := range r.keys {
+		if key.Hash == q.GetHash() {
+			return key, nil
+		}
+	}
+	return nil, domain_errors.NewEntityNotFoundError("apiKey", "hash")
+}
+
+func (r *testAPIKeyRepository) Insert(ctx context.Context, key *agg.APIKey) (*agg.APIKey, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	key.ID = vo.NewID(primitive.NewObjectID())
+	r.keys[key.ID.Value] = key
+	return key, nil
+}
+
+func (r *testAPIKeyRepository) Update(ctx context.Context, key *agg.APIKey) (*agg.APIKey, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.updates++
+	r.keys[key.ID.Value] = key
+	return key, nil
+}
+
+func (r *testAPIKeyRepository) UpdateLastUsedAt(ctx context.Context, key *agg.APIKey, usedAt time.Time) error {
+	if r.lastUsedAtErr != nil {
+		return r.lastUsedAtErr
+	}
+	r.lastUsedAts++
+	return nil
+}
+
+func newTestAPIKeyService(repository *testAPIKeyRepository) *APIKeyService {
+	return &APIKeyService{
+		logger:     logger_stub.NewLogger(),
+		builder:    &builder.APIKeyBuilder{},
+		validator:  &validator.APIKeyValidator{},
+		repository: repository,
+	}
+}
+
+func newTestAPIKey(s *APIKeyService, key string, userID vo.ID, lastUsedAt time.Time, revokedAt time.Time) *agg.APIKey {
+	return &agg.APIKey{
+		APIKey: entity.APIKey{
+			ID:         vo.NewID(primitive.NewObjectID()),
+			UserID:     userID,
+			Hash:       s.hash(key),
+			Scopes:     []string{enum.VideoReadScope},
+			LastUsedAt: lastUsedAt,
+			RevokedAt:  revokedAt,
+		},
+	}
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	userID := vo.NewID(primitive.NewObjectID())
+
+	tests := []struct {
+		name    string
+		req     *dto.APIKeyCreateRequestDTO
+		repoErr error
+		isValid bool
+	}{
+		{
+			name:    "valid",
+			req:     &dto.APIKeyCreateRequestDTO{UserID: userID, Name: "ci", Scopes: []string{enum.VideoReadScope}},
+			isValid: true,
+		},
+		{
+			name: "empty scopes",
+			req:  &dto.APIKeyCreateRequestDTO{UserID: userID, Name: "ci"},
+		},
+		{
+			name: "unsupported scope",
+			req:  &dto.APIKeyCreateRequestDTO{UserID: userID, Name: "ci", Scopes: []string{"video:delete"}},
+		},
+		{
+			name: "empty name",
+			req:  &dto.APIKeyCreateRequestDTO{UserID: userID, Scopes: []string{enum.VideoReadScope}},
+		},
+		{
+			name:    "storage failure",
+			req:     &dto.APIKeyCreateRequestDTO{UserID: userID, Name: "ci", Scopes: []string{enum.VideoReadScope}},
+			repoErr: errors.New("insert failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newTestAPIKeyRepository()
+			repository.err = tt.repoErr
+			s := newTestAPIKeyService(repository)
+
+			key, apiKey, err := s.Create(context.Background(), tt.req)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				if len(repository.keys) != 0 {
+					t.Fatalf("expected no stored keys, got %d", len(repository.keys))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasPrefix(key, keyPrefix) {
+				t.Errorf("key %q has no %q prefix", key, keyPrefix)
+			}
+			if apiKey.Prefix != key[:keyVisiblePrefixLength] {
+				t.Errorf("prefix = %q, want %q", apiKey.Prefix, key[:keyVisiblePrefixLength])
+			}
+			if apiKey.Hash != s.hash(key) || strings.Contains(apiKey.Hash, key) {
+				t.Errorf("hash = %q, must be a sha256 of the raw key", apiKey.Hash)
+			}
+			if stored, ok := repository.keys[apiKey.ID.Value]; !ok || stored.Hash != apiKey.Hash {
+				t.Errorf("the key was not stored")
+			}
+
+			// the issued key must be accepted by the authentication
+			authed, err := s.Authenticate(context.Background(), key)
+			if err != nil {
+				t.Fatalf("the issued key was not authenticated: %v", err)
+			}
+			if authed.ID != apiKey.ID {
+				t.Errorf("authenticated key id = %v, want %v", authed.ID, apiKey.ID)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	const rawKey = "vsk_test"
+	repoErr := errors.New("find failed")
+
+	tests := []struct {
+		name          string
+		key           string
+		lastUsedAt    time.Duration // ago, zero means never
+		isRevoked     bool
+		repoErr       error
+		lastUsedAtErr error
+		err           error
+		isInvalid     bool
+		lastUsedAts   int
+	}{
+		{name: "never used", key: rawKey, lastUsedAts: 1},
+		{name: "used long ago", key: rawKey, lastUsedAt: time.Hour, lastUsedAts: 1},
+		{name: "used recently", key: rawKey, lastUsedAt: time.Second},
+		{name: "last used update failure", key: rawKey, lastUsedAtErr: errors.New("update failed")},
+		{name: "unknown key", key: "vsk_unknown", isInvalid: true},
+		{name: "revoked key", key: rawKey, isRevoked: true, isInvalid: true},
+		{name: "storage failure", key: rawKey, repoErr: repoErr, err: repoErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newTestAPIKeyRepository()
+			s := newTestAPIKeyService(repository)
+
+			var lastUsedAt, revokedAt time.Time
+			if tt.lastUsedAt > 0 {
+				lastUsedAt = time.Now().Add(-tt.lastUsedAt)
+			}
+			if tt.isRevoked {
+				revokedAt = time.Now()
+			}
+			stored := newTestAPIKey(s, rawKey, vo.NewID(primitive.NewObjectID()), lastUsedAt, revokedAt)
+			repository.keys[stored.ID.Value] = stored
+			repository.err = tt.repoErr
+			repository.lastUsedAtErr = tt.lastUsedAtErr
+
+			apiKey, err := s.Authenticate(context.Background(), tt.key)
+			switch {
+			case tt.isInvalid:
+				if _, ok := err.(*domain_errors.APIKeyIsInvalidError); !ok {
+					t.Fatalf("expected APIKeyIsInvalidError, got %v", err)
+				}
+			case tt.err != nil:
+				if err != tt.err {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if apiKey.ID != stored.ID {
+					t.Errorf("key id = %v, want %v", apiKey.ID, stored.ID)
+				}
+			}
+
+			if repository.lastUsedAts != tt.lastUsedAts {
+				t.Errorf("last used at updates = %d, want %d", repository.lastUsedAts, tt.lastUsedAts)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	userID := vo.NewID(primitive.NewObjectID())
+
+	tests := []struct {
+		name      string
+		userID    vo.ID
+		isRevoked bool
+		isFound   bool
+		updates   int
+	}{
+		{name: "active key", userID: userID, isFound: true, updates: 1},
+		{name: "already revoked key", userID: userID, isRevoked: true, isFound: true},
+		{name: "key of another user", userID: vo.NewID(primitive.NewObjectID())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newTestAPIKeyRepository()
+			s := newTestAPIKeyService(repository)
+
+			var revokedAt time.Time
+			if tt.isRevoked {
+				revokedAt = time.Now().Add(-time.Hour)
+			}
+			stored := newTestAPIKey(s, "vsk_test", userID, time.Time{}, revokedAt)
+			repository.keys[stored.ID.Value] = stored
+
+			err := s.Revoke(context.Background(), &dto.APIKeyRevokeRequestDTO{ID: stored.ID, UserID: tt.userID})
+			if !tt.isFound {
+				if !domain_errors.IsEntityNotFoundError(err) {
+					t.Fatalf("expected EntityNotFoundError, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if repository.updates != tt.updates {
+				t.Errorf("updates = %d, want %d", repository.updates, tt.updates)
+			}
+			if tt.isFound && !stored.IsRevoked() {
+				t.Errorf("the key must be revoked")
+			}
+			if tt.isRevoked && !stored.RevokedAt.Equal(revokedAt) {
+				t.Errorf("revoked at was overwritten: %v, want %v", stored.RevokedAt, revokedAt)
+			}
+			if !tt.isFound && stored.IsRevoked() {
+				t.Errorf("the key of another user must not be revoked")
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package apikey_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type APIKey interface {
+	// Create will issue a new API key, the raw key is returned only once and further stored as hash.
+	Create(ctx context.Context, reqDTO dto_interface.CreateAPIKeyRequest) (key string, apiKey *agg.APIKey, err error)
+	// List will fetch all API keys of a user (including revoked ones).
+	List(ctx context.Context, reqDTO dto_interface.ListAPIKeyRequest) ([]*agg.APIKey, error)
+	// Revoke will make the API key unusable.
+	Revoke(ctx context.Context, reqDTO dto_interface.RevokeAPIKeyRequest) error
+	// Authenticate will find an active API key by the raw key and mark it as used.
+	Authenticate(ctx context.Context, key string) (*agg.APIKey, error)
+}
@@ -1,14 +1,17 @@
 package authenticator
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	apikey_interface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	security_interface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
 	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactor_interface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	user_interface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
@@ -16,7 +19,9 @@ import (
 )
 
 var (
-	tokenVerificationFailed = "token verification failed"
+	tokenVerificationFailed    = "token verification failed"
+	mfaChallengeWasUsed        = "mfa challenge was exchanged on the access token"
+	mfaChallengeAttemptsFailed = "mfa challenge verification failed"
 )
 
 type AuthService struct {
@@ -25,6 +30,8 @@ type AuthService struct {
 	validator      validator_interface.Auth
 	tokenizer      tokenizer_interface.Tokenizer
 	passwordHasher security_interface.PasswordHasher
+	twoFactor      twofactor_interface.TwoFactor
+	apiKeyService  apikey_interface.APIKey
 }
 
 func NewAuthService(serviceContainer di_interface.ContainerManager) (*AuthService, error) {
@@ -53,65 +60,146 @@ func NewAuthService(serviceContainer di_interface.ContainerManager) (*AuthServic
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	twoFactorService, err := serviceContainer.GetTwoFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyService, err := serviceContainer.GetAPIKeyService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &AuthService{
 		logger:         loggerService,
 		userService:    userCRUDService,
 		validator:      authValidator,
 		tokenizer:      tokenizerService,
 		passwordHasher: passwordHasherService,
+		twoFactor:      twoFactorService,
+		apiKeyService:  apiKeyService,
 	}, nil
 }
 
 // Auth will check raw credentials and generate a new access token for given user.
-func (s *AuthService) Auth(req dto_interface.AuthRequest) (token string, err error) {
+// If the user has enabled two-factor authentication, then instead of access token will be returned
+// a short-lived MFA challenge token (mfaRequired is true) which must be passed into the AuthMFA.
+func (s *AuthService) Auth(ctx context.Context, req dto_interface.AuthRequest) (token string, mfaRequired bool, err error) {
+	logger := s.logger.WithContext(ctx)
+
 	// raw request validation (checking that email and pass is not empty)
 	if err = s.validator.ValidateAuthRequest(req); err != nil {
-		return "", s.logger.LogPropagate(err)
+		return "", false, logger.LogPropagate(err)
 	}
 
 	// getting the target user agg. by email
-	userAgg, err := s.userService.Get(dto.NewUserGetRequestDTO(vo.ID{}, req.GetEmail()))
+	userAgg, err := s.userService.Get(ctx, dto.NewUserGetRequestDTO(vo.ID{}, req.GetEmail()))
 	if err != nil {
-		return "", s.logger.LogPropagate(err)
+		return "", false, logger.LogPropagate(err)
 	}
 
 	// checking that credentials are valid
 	if err = s.passwordHasher.Verify(userAgg, req.GetPassword()); err != nil {
-		return "", s.logger.LogPropagate(err)
+		return "", false, logger.LogPropagate(err)
+	}
+
+	// the second factor is required, generating a challenge token instead of access token
+	if userAgg.IsTwoFactorEnabled() {
+		token, err = s.tokenizer.NewMFAChallenge(userAgg)
+		if err != nil {
+			return "", false, logger.LogPropagate(err)
+		}
+		return token, true, nil
 	}
 
 	// generating a new access token string
 	token, err = s.tokenizer.New(userAgg)
 	if err != nil {
-		return "", s.logger.LogPropagate(err)
+		return "", false, logger.LogPropagate(err)
+	}
+
+	return token, false, nil
+}
+
+// AuthMFA will exchange the MFA challenge token on a new access token by the TOTP or recovery code.
+func (s *AuthService) AuthMFA(ctx context.Context, req dto_interface.AuthMFARequest) (token string, err error) {
+	logger := s.logger.WithContext(ctx)
+
+	// raw request validation (checking that challenge token and one of codes are not empty)
+	if err = s.validator.ValidateAuthMFARequest(req); err != nil {
+		return "", logger.LogPropagate(err)
+	}
+
+	// validate challenge token and extract userID from it
+	userID, err := s.tokenizer.VerifyMFAChallenge(req.GetChallengeToken())
+	if err != nil {
+		return "", logger.LogPropagate(err)
+	}
+
+	// getting the target user agg. by id
+	userAgg, err := s.userService.Get(ctx, dto.NewUserGetRequestDTO(userID, ""))
+	if err != nil {
+		return "", logger.LogPropagate(err)
+	}
+
+	// checking the second factor
+	if err = s.twoFactor.Verify(ctx, userAgg, req.GetCode(), req.GetRecoveryCode()); err != nil {
+		// the challenge token is allowed only for one attempt, otherwise it may be used for the codes brute force
+		if berr := s.tokenizer.Block(req.GetChallengeToken(), mfaChallengeAttemptsFailed); berr != nil {
+			return "", logger.LogPropagate(berr)
+		}
+		return "", logger.LogPropagate(err)
+	}
+
+	// the challenge token is a one-time token
+	if err = s.tokenizer.Block(req.GetChallengeToken(), mfaChallengeWasUsed); err != nil {
+		return "", logger.LogPropagate(err)
+	}
+
+	// generating a new access token string
+	token, err = s.tokenizer.New(userAgg)
+	if err != nil {
+		return "", logger.LogPropagate(err)
 	}
 
 	return token, nil
 }
 
-// IsAuthed with check that token is valid and extract userID from it.
-func (s *AuthService) IsAuthed(r *http.Request) (userID vo.ID, err error) {
-	// validate that token is present into request headers
+// IsAuthed with check that token or API key is valid and extract userID from it.
+// The scopes are not nil only when the request was authed by API key (access token grants all scopes).
+func (s *AuthService) IsAuthed(r *http.Request) (userID vo.ID, scopes []string, err error) {
+	logger := s.logger.WithContext(r.Context())
+
+	// validate that token or API key is present into request headers
 	if err = s.validator.ValidateTokennessRequest(r); err != nil {
-		return vo.ID{}, s.logger.LogPropagate(err)
+		return vo.ID{}, nil, logger.LogPropagate(err)
+	}
+
+	// API key has a priority because it's passed only by machine clients
+	if key := r.Header.Get(enum.APIKeyHeaderKey); key != "" {
+		apiKey, kerr := s.apiKeyService.Authenticate(r.Context(), key)
+		if kerr != nil {
+			return vo.ID{}, nil, logger.LogPropagate(kerr)
+		}
+		return apiKey.GetUserID(), apiKey.GetScopes(), nil
 	}
 
 	// extract token from request
 	token, err := s.extractToken(r)
 	if err != nil {
-		return vo.ID{}, s.logger.LogPropagate(err)
+		return vo.ID{}, nil, logger.LogPropagate(err)
 	}
 
 	// validate token and extract userID from it
 	userID, err = s.tokenizer.Verify(token)
 	if err != nil {
 		if berr := s.tokenizer.Block(token, tokenVerificationFailed); berr != nil {
-			return vo.ID{}, s.logger.LogPropagate(berr)
+			return vo.ID{}, nil, logger.LogPropagate(berr)
 		}
-		return vo.ID{}, s.logger.LogPropagate(err)
+		return vo.ID{}, nil, logger.LogPropagate(err)
 	}
 
-	return userID, nil
+	return userID, nil, nil
 }
 
 func (s *AuthService) extractToken(r *http.Request) (token string, err error) {
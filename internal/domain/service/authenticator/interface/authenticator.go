@@ -1,14 +1,19 @@
 package authenticator_interface
 
 import (
+	"context"
 	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"net/http"
 )
 
 type Authenticator interface {
-	// Auth will check raw credentials and generate a new access token for given user.
-	Auth(reqDTO dto_interface.AuthRequest) (token string, err error)
-	// IsAuthed with check that token is valid and extract userID from it.
-	IsAuthed(r *http.Request) (userID vo.ID, err error)
+	// Auth will check raw credentials and generate a new access token for given user
+	// (or the MFA challenge token if the user has enabled two-factor authentication).
+	Auth(ctx context.Context, reqDTO dto_interface.AuthRequest) (token string, mfaRequired bool, err error)
+	// AuthMFA will exchange the MFA challenge token on a new access token by the TOTP or recovery code.
+	AuthMFA(ctx context.Context, reqDTO dto_interface.AuthMFARequest) (token string, err error)
+	// IsAuthed with check that token or API key is valid and extract userID from it.
+	// The scopes are not nil only when the request was authed by API key (access token grants all scopes).
+	IsAuthed(r *http.Request) (userID vo.ID, scopes []string, err error)
 }
@@ -1,6 +1,13 @@
 package cacher_interface
 
+import "context"
+
 type Cacher interface {
-	Get(key string, fn func(CacheItem) (data interface{}, err error)) (data interface{}, err error)
-	Delete(key string)
+	// Get returns the cached data or computes it by fn. The concurrent calls of a missed key share one fn call
+	// and the expired data may be recomputed in background, so fn must not depend on the caller's cancellation.
+	// The 'entity not found' error of fn may be cached too (it's returned by the following calls as is).
+	Get(ctx context.Context, key string, fn func(CacheItem) (data interface{}, err error)) (data interface{}, err error)
+	Delete(ctx context.Context, key string)
+	// Invalidate removes all items which are marked by any of the given tags.
+	Invalidate(ctx context.Context, tags ...string)
 }
@@ -4,4 +4,9 @@ import "time"
 
 type CacheItem interface {
 	SetTTL(ttl time.Duration)
+	// AddTags marks the item by tags, so it can be invalidated together with other items by any of them.
+	AddTags(tags ...string)
+	// SetLocal keeps the item in memory of the process only (it's not shared through the out-of-process storage),
+	// it must be used for the data which contains credentials.
+	SetLocal()
 }
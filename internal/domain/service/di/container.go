@@ -8,12 +8,19 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	accessor_interface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	analytics_interface "github.com/Borislavv/video-streaming/internal/domain/service/analytics/interface"
+	apikey_interface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	authenticator_interface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
+	playlist_interface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	reconciler_interface "github.com/Borislavv/video-streaming/internal/domain/service/reconciler/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	security_interface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
 	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactor_interface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	uploader_interface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	videoservice "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
@@ -24,12 +31,17 @@ import (
 	cache_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache/interface"
 	mongodb_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
 	reader_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	handler_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	strategy_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	listener_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
 	streamer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
 	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	room_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room/interface"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
 	file_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"go.mongodb.org/mongo-driver/mongo"
 	"reflect"
@@ -45,6 +57,14 @@ func NewServiceContainerManager() *ServiceContainerManager {
 	}
 }
 
+// NewChildServiceContainerManager - creates a container which shares the services of the given parent,
+// so each app can override them (context, servers, etc.) without affecting the rest apps.
+func NewChildServiceContainerManager(parent di_interface.Container) *ServiceContainerManager {
+	return &ServiceContainerManager{
+		Container: di.NewChildServiceContainer(parent),
+	}
+}
+
 func (s *ServiceContainerManager) GetConfig() (*app.Config, error) {
 	key := (*app.Config)(nil)
 	service, err := s.Get(reflect.TypeOf(key))
@@ -149,6 +169,32 @@ func (s *ServiceContainerManager) GetBlockedTokenMongoRepository() (mongodb_inte
 	return repo, nil
 }
 
+func (s *ServiceContainerManager) GetAPIKeyMongoRepository() (mongodb_interface.APIKey, error) {
+	key := (*mongodb_interface.APIKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mongodb_interface.APIKey)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetPlaylistMongoRepository() (mongodb_interface.Playlist, error) {
+	key := (*mongodb_interface.Playlist)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mongodb_interface.Playlist)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainerManager) GetResourceCacheRepository() (cache_interface.Resource, error) {
 	key := (*cache_interface.Resource)(nil)
 	service, err := s.Get(reflect.TypeOf(key))
@@ -409,6 +455,318 @@ func (s *ServiceContainerManager) GetAuthService() (authenticator_interface.Auth
 	return service, nil
 }
 
+func (s *ServiceContainerManager) GetTwoFactorBuilder() (builder_interface.TwoFactor, error) {
+	key := (*builder_interface.TwoFactor)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builder_interface.TwoFactor)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetTwoFactorValidator() (validator_interface.TwoFactor, error) {
+	key := (*validator_interface.TwoFactor)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validator_interface.TwoFactor)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetTwoFactorService() (twofactor_interface.TwoFactor, error) {
+	key := (*twofactor_interface.TwoFactor)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(twofactor_interface.TwoFactor)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetAPIKeyBuilder() (builder_interface.APIKey, error) {
+	key := (*builder_interface.APIKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builder_interface.APIKey)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetAPIKeyValidator() (validator_interface.APIKey, error) {
+	key := (*validator_interface.APIKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validator_interface.APIKey)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetAPIKeyRepository() (repository_interface.APIKey, error) {
+	key := (*repository_interface.APIKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repository_interface.APIKey)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetAPIKeyService() (apikey_interface.APIKey, error) {
+	key := (*apikey_interface.APIKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(apikey_interface.APIKey)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetPlaylistBuilder() (builder_interface.Playlist, error) {
+	key := (*builder_interface.Playlist)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builder_interface.Playlist)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetPlaylistValidator() (validator_interface.Playlist, error) {
+	key := (*validator_interface.Playlist)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validator_interface.Playlist)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetPlaylistRepository() (repository_interface.Playlist, error) {
+	key := (*repository_interface.Playlist)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repository_interface.Playlist)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetPlaylistCRUDService() (playlist_interface.CRUD, error) {
+	key := (*playlist_interface.CRUD)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(playlist_interface.CRUD)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetWatchHistoryBuilder() (builder_interface.WatchHistory, error) {
+	key := (*builder_interface.WatchHistory)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builder_interface.WatchHistory)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetWatchHistoryValidator() (validator_interface.WatchHistory, error) {
+	key := (*validator_interface.WatchHistory)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validator_interface.WatchHistory)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetWatchProgressRepository() (repository_interface.WatchProgress, error) {
+	key := (*repository_interface.WatchProgress)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repository_interface.WatchProgress)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetWatchHistoryService() (history_interface.WatchHistory, error) {
+	key := (*history_interface.WatchHistory)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(history_interface.WatchHistory)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetAnalyticsBuilder() (builder_interface.Analytics, error) {
+	key := (*builder_interface.Analytics)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builder_interface.Analytics)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetAnalyticsValidator() (validator_interface.Analytics, error) {
+	key := (*validator_interface.Analytics)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validator_interface.Analytics)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetPlaySessionRepository() (repository_interface.PlaySession, error) {
+	key := (*repository_interface.PlaySession)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repository_interface.PlaySession)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetVideoStatsRepository() (repository_interface.VideoStats, error) {
+	key := (*repository_interface.VideoStats)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repository_interface.VideoStats)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetAnalyticsService() (analytics_interface.Analytics, error) {
+	key := (*analytics_interface.Analytics)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(analytics_interface.Analytics)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetQuotaBuilder() (builder_interface.Quota, error) {
+	key := (*builder_interface.Quota)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builder_interface.Quota)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetQuotaRepository() (repository_interface.Quota, error) {
+	key := (*repository_interface.Quota)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repository_interface.Quota)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetQuotaService() (quota_interface.Quota, error) {
+	key := (*quota_interface.Quota)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(quota_interface.Quota)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetReconcilerService() (reconciler_interface.Reconciler, error) {
+	key := (*reconciler_interface.Reconciler)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(reconciler_interface.Reconciler)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainerManager) GetLoggerService() (logger_interface.Logger, error) {
 	key := (*logger_interface.Logger)(nil)
 	reflectService, err := s.Get(reflect.TypeOf(key))
@@ -422,6 +780,45 @@ func (s *ServiceContainerManager) GetLoggerService() (logger_interface.Logger, e
 	return service, nil
 }
 
+func (s *ServiceContainerManager) GetMetricsService() (metrics_interface.Metrics, error) {
+	key := (*metrics_interface.Metrics)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(metrics_interface.Metrics)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetTracerService() (tracer_interface.Tracer, error) {
+	key := (*tracer_interface.Tracer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(tracer_interface.Tracer)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetHealthCheckerService() (health_interface.Checker, error) {
+	key := (*health_interface.Checker)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(health_interface.Checker)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainerManager) GetCacheService() (cacher_interface.Cacher, error) {
 	key := (*cacher_interface.Cacher)(nil)
 	reflectService, err := s.Get(reflect.TypeOf(key))
@@ -526,6 +923,19 @@ func (s *ServiceContainerManager) GetPasswordHasherService() (security_interface
 	return service, nil
 }
 
+func (s *ServiceContainerManager) GetOTPService() (security_interface.OTP, error) {
+	key := (*security_interface.OTP)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(security_interface.OTP)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainerManager) GetTokenizerService() (tokenizer_interface.Tokenizer, error) {
 	key := (*tokenizer_interface.Tokenizer)(nil)
 	reflectService, err := s.Get(reflect.TypeOf(key))
@@ -616,3 +1026,42 @@ func (s *ServiceContainerManager) GetStreamingService() (streamer_interface.Stre
 	}
 	return service, nil
 }
+
+func (s *ServiceContainerManager) GetLiveHub() (live_interface.Hub, error) {
+	key := (*live_interface.Hub)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(live_interface.Hub)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetLivePublisher() (live_interface.Publisher, error) {
+	key := (*live_interface.Publisher)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(live_interface.Publisher)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainerManager) GetRoomsService() (room_interface.Rooms, error) {
+	key := (*room_interface.Rooms)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errors.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(room_interface.Rooms)
+	if !ok {
+		return nil, errors.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
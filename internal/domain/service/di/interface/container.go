@@ -7,12 +7,19 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	accessor_interface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	analytics_interface "github.com/Borislavv/video-streaming/internal/domain/service/analytics/interface"
+	apikey_interface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	authenticator_interface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
+	playlist_interface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	reconciler_interface "github.com/Borislavv/video-streaming/internal/domain/service/reconciler/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	security_interface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
 	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactor_interface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	uploader_interface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	videoservice "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
@@ -22,12 +29,17 @@ import (
 	cache_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache/interface"
 	mongodb_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
 	reader_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	handler_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	strategy_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	listener_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
 	streamer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
 	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	room_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room/interface"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
 	file_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -48,6 +60,8 @@ type ContainerManager interface {
 	GetVideoMongoRepository() (mongodb_interface.Video, error)
 	GetUserMongoRepository() (mongodb_interface.User, error)
 	GetBlockedTokenMongoRepository() (mongodb_interface.BlockedToken, error)
+	GetAPIKeyMongoRepository() (mongodb_interface.APIKey, error)
+	GetPlaylistMongoRepository() (mongodb_interface.Playlist, error)
 
 	// Cache repository
 	GetResourceCacheRepository() (cache_interface.Resource, error)
@@ -83,12 +97,46 @@ type ContainerManager interface {
 	GetAuthValidator() (validator_interface.Auth, error)
 	GetAuthService() (authenticator_interface.Authenticator, error)
 
+	// TwoFactor services
+	GetTwoFactorBuilder() (builder_interface.TwoFactor, error)
+	GetTwoFactorValidator() (validator_interface.TwoFactor, error)
+	GetTwoFactorService() (twofactor_interface.TwoFactor, error)
+
+	// APIKey services
+	GetAPIKeyBuilder() (builder_interface.APIKey, error)
+	GetAPIKeyValidator() (validator_interface.APIKey, error)
+	GetAPIKeyRepository() (repository_interface.APIKey, error)
+	GetAPIKeyService() (apikey_interface.APIKey, error)
+
+	// Playlist services
+	GetPlaylistBuilder() (builder_interface.Playlist, error)
+	GetPlaylistValidator() (validator_interface.Playlist, error)
+	GetPlaylistRepository() (repository_interface.Playlist, error)
+	GetPlaylistCRUDService() (playlist_interface.CRUD, error)
+	GetWatchHistoryBuilder() (builder_interface.WatchHistory, error)
+	GetWatchHistoryValidator() (validator_interface.WatchHistory, error)
+	GetWatchProgressRepository() (repository_interface.WatchProgress, error)
+	GetWatchHistoryService() (history_interface.WatchHistory, error)
+	GetAnalyticsBuilder() (builder_interface.Analytics, error)
+	GetAnalyticsValidator() (validator_interface.Analytics, error)
+	GetPlaySessionRepository() (repository_interface.PlaySession, error)
+	GetVideoStatsRepository() (repository_interface.VideoStats, error)
+	GetAnalyticsService() (analytics_interface.Analytics, error)
+	GetQuotaBuilder() (builder_interface.Quota, error)
+	GetQuotaRepository() (repository_interface.Quota, error)
+	GetQuotaService() (quota_interface.Quota, error)
+	GetReconcilerService() (reconciler_interface.Reconciler, error)
+
 	// Infrastructure
 	GetLoggerService() (logger_interface.Logger, error)
+	GetMetricsService() (metrics_interface.Metrics, error)
+	GetTracerService() (tracer_interface.Tracer, error)
+	GetHealthCheckerService() (health_interface.Checker, error)
 	GetCacheService() (cacher_interface.Cacher, error)
 	GetRequestParametersExtractorService() (extractor_interface.RequestParams, error)
 	GetResponderService() (response_interface.Responder, error)
 	GetPasswordHasherService() (security_interface.PasswordHasher, error)
+	GetOTPService() (security_interface.OTP, error)
 	GetTokenizerService() (tokenizer_interface.Tokenizer, error)
 
 	// File
@@ -108,4 +156,7 @@ type ContainerManager interface {
 
 	// Streaming
 	GetStreamingService() (streamer_interface.Streamer, error)
+	GetLiveHub() (live_interface.Hub, error)
+	GetLivePublisher() (live_interface.Publisher, error)
+	GetRoomsService() (room_interface.Rooms, error)
 }
@@ -0,0 +1,19 @@
+package history_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type WatchHistory interface {
+	// Progress will save the playback position of the video reported by client side.
+	Progress(ctx context.Context, reqDTO dto_interface.WatchProgressRequest) (*agg.WatchProgress, error)
+	// Sent will save the number of bytes of the video file which were sent to client side.
+	Sent(ctx context.Context, userID vo.ID, videoID vo.ID, offset int64, size int64) error
+	// Get will fetch the saved progress of the video.
+	Get(ctx context.Context, reqDTO dto_interface.GetWatchProgressRequest) (*agg.WatchProgress, error)
+	// List will fetch the watched videos from the last watched one.
+	List(ctx context.Context, reqDTO dto_interface.ListWatchHistoryRequest) (list []*agg.WatchProgress, total int64, err error)
+}
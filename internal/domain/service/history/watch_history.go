@@ -0,0 +1,162 @@
+package history
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type WatchHistoryService struct {
+	logger          logger_interface.Logger
+	validator       validator_interface.WatchHistory
+	repository      repository_interface.WatchProgress
+	videoRepository repository_interface.Video
+	finishedRatio   float64
+}
+
+func NewWatchHistoryService(serviceContainer di_interface.ContainerManager) (*WatchHistoryService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	watchHistoryValidator, err := serviceContainer.GetWatchHistoryValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressRepository, err := serviceContainer.GetWatchProgressRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &WatchHistoryService{
+		logger:          loggerService,
+		validator:       watchHistoryValidator,
+		repository:      watchProgressRepository,
+		videoRepository: videoRepository,
+		finishedRatio:   cfg.HistoryFinishedRatio,
+	}, nil
+}
+
+// Progress - will save the reported playback position of the video, the video must belong to the user.
+// If the client side does not know the duration yet, the detected duration of the video file is used.
+func (s *WatchHistoryService) Progress(ctx context.Context, req dto_interface.WatchProgressRequest) (*agg.WatchProgress, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateProgressRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// the progress of foreign or removed videos is not stored
+	video, err := s.videoRepository.FindOneByID(ctx, dto.NewVideoGetRequestDTO(req.GetVideoID(), "", vo.ID{}, req.GetUserID()))
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	duration := req.GetDuration()
+	if duration == 0 {
+		duration = video.Resource.Metadata.Duration
+	}
+
+	progress := &agg.WatchProgress{
+		WatchProgress: entity.WatchProgress{
+			UserID:   req.GetUserID(),
+			VideoID:  req.GetVideoID(),
+			Position: req.GetPosition(),
+			Duration: duration,
+			Finished: duration > 0 && req.GetPosition() >= duration*s.finishedRatio,
+		},
+		Timestamp: vo.Timestamp{
+			UpdatedAt: time.Now(),
+		},
+	}
+
+	// saving the position (the offset sent by the server is kept)
+	if err = s.repository.SavePosition(ctx, progress); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return progress, nil
+}
+
+// Sent - will save the offset of the video file which was reached by the streaming.
+func (s *WatchHistoryService) Sent(ctx context.Context, userID vo.ID, videoID vo.ID, offset int64, size int64) error {
+	logger := s.logger.WithContext(ctx)
+
+	progress := &agg.WatchProgress{
+		WatchProgress: entity.WatchProgress{
+			UserID:  userID,
+			VideoID: videoID,
+			Offset:  offset,
+			Size:    size,
+		},
+		Timestamp: vo.Timestamp{
+			UpdatedAt: time.Now(),
+		},
+	}
+
+	// saving the offset (the position reported by client side is kept)
+	if err := s.repository.SaveOffset(ctx, progress); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// Get - will fetch the saved progress of the video by specified user.
+func (s *WatchHistoryService) Get(ctx context.Context, req dto_interface.GetWatchProgressRequest) (*agg.WatchProgress, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateGetRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	progress, err := s.repository.FindOne(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return progress, nil
+}
+
+// List - will fetch the watch history of the user from the last watched video.
+func (s *WatchHistoryService) List(
+	ctx context.Context, req dto_interface.ListWatchHistoryRequest,
+) (
+	list []*agg.WatchProgress, total int64, err error,
+) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err = s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	list, total, err = s.repository.FindList(ctx, req)
+	if err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
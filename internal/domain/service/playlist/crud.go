@@ -0,0 +1,186 @@
+package playlist
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+)
+
+type CRUDService struct {
+	logger        logger_interface.Logger
+	builder       builder_interface.Playlist
+	validator     validator_interface.Playlist
+	repository    repository_interface.Playlist
+	accessService accessor_interface.Accessor
+}
+
+func NewCRUDService(serviceContainer di_interface.ContainerManager) (*CRUDService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	playlistBuilder, err := serviceContainer.GetPlaylistBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistValidator, err := serviceContainer.GetPlaylistValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CRUDService{
+		logger:        loggerService,
+		builder:       playlistBuilder,
+		validator:     playlistValidator,
+		repository:    playlistRepository,
+		accessService: accessService,
+	}, nil
+}
+
+// Get - will fetch a single playlist aggregate by ID and specified user.
+func (s *CRUDService) Get(ctx context.Context, req dto_interface.GetPlaylistRequest) (*agg.Playlist, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateGetRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// fetching a playlist by id and user
+	playlist, err := s.repository.FindOneByID(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// access check to the playlist
+	if err = s.accessService.IsGranted(req.GetUserID(), playlist); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return playlist, nil
+}
+
+// List - will fetch all playlists of specified user.
+// Access check is unnecessary because the query will fetch a playlist list only for specified user.
+func (s *CRUDService) List(ctx context.Context, req dto_interface.ListPlaylistRequest) ([]*agg.Playlist, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// fetching a playlist list by user
+	list, err := s.repository.FindList(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return list, nil
+}
+
+// Create - will make a new playlist by given request for specified user. Have an access check
+// for each video which exists into the request (it's done by aggregate validation).
+func (s *CRUDService) Create(ctx context.Context, req dto_interface.CreatePlaylistRequest) (*agg.Playlist, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateCreateRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	playlistAgg, err := s.builder.BuildAggFromCreateRequestDTO(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(ctx, playlistAgg); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// saving an aggregate into storage
+	playlistAgg, err = s.repository.Insert(ctx, playlistAgg)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return playlistAgg, nil
+}
+
+// Update - will change the playlist by given request (name, description and order of videos).
+// Have an access check for the playlist and each of its videos (it's done by aggregate validation).
+func (s *CRUDService) Update(ctx context.Context, req dto_interface.UpdatePlaylistRequest) (*agg.Playlist, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateUpdateRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	playlistAgg, err := s.builder.BuildAggFromUpdateRequestDTO(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(ctx, playlistAgg); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// saving updated aggregate into storage
+	playlistAgg, err = s.repository.Update(ctx, playlistAgg)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return playlistAgg, nil
+}
+
+// Delete - will remove the playlist from the storage, the videos will be left as is.
+func (s *CRUDService) Delete(ctx context.Context, req dto_interface.DeletePlaylistRequest) (err error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err = s.validator.ValidateDeleteRequestDTO(req); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// fetching a playlist which will be deleted
+	playlistAgg, err := s.repository.FindOneByID(ctx, req)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// access check to the playlist
+	if err = s.accessService.IsGranted(req.GetUserID(), playlistAgg); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// playlist removing
+	if err = s.repository.Remove(ctx, playlistAgg); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,414 @@
+package playlist
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/builder"
+	builder_interface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_interface "github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/accessor"
+	accessor_interface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/validator"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	query_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"testing"
+)
+
+// testPlaylistRepository - stores the playlists in memory, returns copies like a real storage.
+type testPlaylistRepository struct {
+	repository_interface.Playlist
+	playlists map[vo.ID]agg.Playlist
+}
+
+func (r *testPlaylistRepository) find(match func(playlist agg.Playlist) bool) (*agg.Playlist, error) {
+	for _, playlist := range r.playlists {
+		if match(playlist) {
+			playlist.VideoIDs = slices.Clone(playlist.VideoIDs)
+			return &playlist, nil
+		}
+	}
+	return nil, errors.NewEntityNotFoundError("playlist", "query")
+}
+
+func (r *testPlaylistRepository) FindOneByID(ctx context.Context, q query_interface.FindOnePlaylistByID) (*agg.Playlist, error) {
+	return r.find(func(playlist agg.Playlist) bool {
+		return playlist.ID == q.GetID() && playlist.UserID == q.GetUserID()
+	})
+}
+
+func (r *testPlaylistRepository) FindOneByName(ctx context.Context, q query_interface.FindOnePlaylistByName) (*agg.Playlist, error) {
+	return r.find(func(playlist agg.Playlist) bool {
+		return playlist.Name == q.GetName() && playlist.UserID == q.GetUserID()
+	})
+}
+
+func (r *testPlaylistRepository) Insert(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error) {
+	playlist.ID = vo.NewID(primitive.NewObjectID())
+	r.playlists[playlist.ID] = *playlist
+	return playlist, nil
+}
+
+func (r *testPlaylistRepository) Update(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error) {
+	r.playlists[playlist.ID] = *playlist
+	return playlist, nil
+}
+
+func (r *testPlaylistRepository) Remove(ctx context.Context, playlist *agg.Playlist) error {
+	delete(r.playlists, playlist.ID)
+	return nil
+}
+
+// testVideoRepository - stores the videos in memory, a video is found only for its owner.
+type testVideoRepository struct {
+	repository_interface.Video
+	videos map[vo.ID]*agg.Video
+}
+
+func (r *testVideoRepository) FindOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	if video, ok := r.videos[q.GetID()]; ok && video.UserID == q.GetUserID() {
+		return video, nil
+	}
+	return nil, errors.NewEntityNotFoundError("video", "id")
+}
+
+// testContainer - wires the real builder, validator and access service with in-memory repositories.
+type testContainer struct {
+	di_interface.ContainerManager
+	logger             logger_interface.Logger
+	accessService      accessor_interface.Accessor
+	videoRepository    *testVideoRepository
+	playlistRepository *testPlaylistRepository
+}
+
+func (c *testContainer) GetLoggerService() (logger_interface.Logger, error) {
+	return c.logger, nil
+}
+func (c *testContainer) GetAccessService() (accessor_interface.Accessor, error) {
+	return c.accessService, nil
+}
+func (c *testContainer) GetVideoRepository() (repository_interface.Video, error) {
+	return c.videoRepository, nil
+}
+func (c *testContainer) GetPlaylistRepository() (repository_interface.Playlist, error) {
+	return c.playlistRepository, nil
+}
+func (c *testContainer) GetRequestParametersExtractorService() (extractor_interface.RequestParams, error) {
+	return nil, nil
+}
+func (c *testContainer) GetPlaylistBuilder() (builder_interface.Playlist, error) {
+	return builder.NewPlaylistBuilder(c)
+}
+func (c *testContainer) GetPlaylistValidator() (validator_interface.Playlist, error) {
+	return validator.NewPlaylistValidator(c)
+}
+
+func newTestVideo(id vo.ID, userID vo.ID) *agg.Video {
+	return &agg.Video{
+		Video:    entity.Video{ID: id, UserID: userID},
+		Resource: entity.Resource{UserID: userID},
+	}
+}
+
+// testLibrary - the videos and playlists of the owner and a video of the stranger.
+type testLibrary struct {
+	service        *CRUDService
+	playlists      *testPlaylistRepository
+	owner          vo.ID
+	stranger       vo.ID
+	videos         []vo.ID // owned by the owner
+	strangerVideo  vo.ID
+	ownerPlaylist  vo.ID // named "favorites" and contains the first two videos
+	secondPlaylist vo.ID // named "later" and has no videos
+}
+
+func newTestLibrary(t *testing.T) *testLibrary {
+	f := &testLibrary{
+		owner:         vo.NewID(primitive.NewObjectID()),
+		stranger:      vo.NewID(primitive.NewObjectID()),
+		strangerVideo: vo.NewID(primitive.NewObjectID()),
+	}
+
+	videos := &testVideoRepository{videos: map[vo.ID]*agg.Video{}}
+	for i := 0; i < 3; i++ {
+		id := vo.NewID(primitive.NewObjectID())
+		videos.videos[id] = newTestVideo(id, f.owner)
+		f.videos = append(f.videos, id)
+	}
+	videos.videos[f.strangerVideo] = newTestVideo(f.strangerVideo, f.stranger)
+
+	f.ownerPlaylist = vo.NewID(primitive.NewObjectID())
+	f.secondPlaylist = vo.NewID(primitive.NewObjectID())
+	f.playlists = &testPlaylistRepository{playlists: map[vo.ID]agg.Playlist{
+		f.ownerPlaylist: {Playlist: entity.Playlist{
+			ID: f.ownerPlaylist, UserID: f.owner, Name: "favorites", VideoIDs: []vo.ID{f.videos[0], f.videos[1]},
+		}},
+		f.secondPlaylist: {Playlist: entity.Playlist{
+			ID: f.secondPlaylist, UserID: f.owner, Name: "later", VideoIDs: []vo.ID{},
+		}},
+	}}
+
+	logger := logger_stub.NewLogger()
+	accessService, err := accessor.NewAccessService(&testContainer{logger: logger})
+	if err != nil {
+		t.Fatalf("unable to create an access service: %v", err)
+	}
+
+	f.service, err = NewCRUDService(&testContainer{
+		logger:             logger,
+		accessService:      accessService,
+		videoRepository:    videos,
+		playlistRepository: f.playlists,
+	})
+	if err != nil {
+		t.Fatalf("unable to create a playlist service: %v", err)
+	}
+
+	return f
+}
+
+func TestCRUDService_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      func(f *testLibrary) *dto.PlaylistCreateRequestDTO
+		isUnique bool // the uniqueness check must fail
+		notFound bool // a referenced video must not be found
+		isValid  bool
+	}{
+		{
+			name: "ordered own videos",
+			req: func(f *testLibrary) *dto.PlaylistCreateRequestDTO {
+				return &dto.PlaylistCreateRequestDTO{
+					UserID: f.owner, Name: "new", VideoIDs: []vo.ID{f.videos[2], f.videos[0], f.videos[1]},
+				}
+			},
+			isValid: true,
+		},
+		{
+			name: "without videos",
+			req: func(f *testLibrary) *dto.PlaylistCreateRequestDTO {
+				return &dto.PlaylistCreateRequestDTO{UserID: f.owner, Name: "new"}
+			},
+			isValid: true,
+		},
+		{
+			name: "same name of another user",
+			req: func(f *testLibrary) *dto.PlaylistCreateRequestDTO {
+				return &dto.PlaylistCreateRequestDTO{UserID: f.stranger, Name: "favorites"}
+			},
+			isValid: true,
+		},
+		{
+			name: "duplicated name",
+			req: func(f *testLibrary) *dto.PlaylistCreateRequestDTO {
+				return &dto.PlaylistCreateRequestDTO{UserID: f.owner, Name: "favorites"}
+			},
+			isUnique: true,
+		},
+		{
+			name: "duplicated video",
+			req: func(f *testLibrary) *dto.PlaylistCreateRequestDTO {
+				return &dto.PlaylistCreateRequestDTO{
+					UserID: f.owner, Name: "new", VideoIDs: []vo.ID{f.videos[0], f.videos[0]},
+				}
+			},
+			isUnique: true,
+		},
+		{
+			name: "video of another user",
+			req: func(f *testLibrary) *dto.PlaylistCreateRequestDTO {
+				return &dto.PlaylistCreateRequestDTO{
+					UserID: f.owner, Name: "new", VideoIDs: []vo.ID{f.videos[0], f.strangerVideo},
+				}
+			},
+			notFound: true,
+		},
+		{
+			name: "missing video",
+			req: func(f *testLibrary) *dto.PlaylistCreateRequestDTO {
+				return &dto.PlaylistCreateRequestDTO{
+					UserID: f.owner, Name: "new", VideoIDs: []vo.ID{vo.NewID(primitive.NewObjectID())},
+				}
+			},
+			notFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestLibrary(t)
+			req := tt.req(f)
+
+			playlist, err := f.service.Create(context.Background(), req)
+			switch {
+			case tt.isUnique:
+				if _, ok := err.(*errors.UniquenessCheckFailedError); !ok {
+					t.Fatalf("expected UniquenessCheckFailedError, got %v", err)
+				}
+			case tt.notFound:
+				if !errors.IsEntityNotFoundError(err) {
+					t.Fatalf("expected EntityNotFoundError, got %v", err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				stored, found := f.playlists.playlists[playlist.ID]
+				if !found {
+					t.Fatalf("the playlist was not stored")
+				}
+				if stored.UserID != req.UserID || stored.Name != req.Name {
+					t.Errorf("stored playlist = %+v, want of user %v named %q", stored, req.UserID, req.Name)
+				}
+				if len(stored.VideoIDs) != len(req.VideoIDs) || !slices.Equal(stored.VideoIDs, req.VideoIDs) {
+					t.Errorf("videos = %v, want %v in the same order", stored.VideoIDs, req.VideoIDs)
+				}
+			}
+
+			if !tt.isValid && len(f.playlists.playlists) != 2 {
+				t.Errorf("the invalid playlist was stored")
+			}
+		})
+	}
+}
+
+func TestCRUDService_Update(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      func(f *testLibrary) *dto.PlaylistUpdateRequestDTO
+		isUnique bool
+		notFound bool
+		wantName string
+		want     func(f *testLibrary) []vo.ID
+	}{
+		{
+			name: "reorder videos",
+			req: func(f *testLibrary) *dto.PlaylistUpdateRequestDTO {
+				return &dto.PlaylistUpdateRequestDTO{
+					ID: f.ownerPlaylist, UserID: f.owner, VideoIDs: []vo.ID{f.videos[1], f.videos[0]},
+				}
+			},
+			wantName: "favorites",
+			want:     func(f *testLibrary) []vo.ID { return []vo.ID{f.videos[1], f.videos[0]} },
+		},
+		{
+			name: "rename and keep videos",
+			req: func(f *testLibrary) *dto.PlaylistUpdateRequestDTO {
+				return &dto.PlaylistUpdateRequestDTO{ID: f.ownerPlaylist, UserID: f.owner, Name: "best"}
+			},
+			wantName: "best",
+			want:     func(f *testLibrary) []vo.ID { return []vo.ID{f.videos[0], f.videos[1]} },
+		},
+		{
+			name: "same name is not a duplicate of itself",
+			req: func(f *testLibrary) *dto.PlaylistUpdateRequestDTO {
+				return &dto.PlaylistUpdateRequestDTO{
+					ID: f.ownerPlaylist, UserID: f.owner, Name: "favorites", VideoIDs: []vo.ID{},
+				}
+			},
+			wantName: "favorites",
+			want:     func(f *testLibrary) []vo.ID { return []vo.ID{} },
+		},
+		{
+			name: "name of another own playlist",
+			req: func(f *testLibrary) *dto.PlaylistUpdateRequestDTO {
+				return &dto.PlaylistUpdateRequestDTO{ID: f.ownerPlaylist, UserID: f.owner, Name: "later"}
+			},
+			isUnique: true,
+		},
+		{
+			name: "video of another user",
+			req: func(f *testLibrary) *dto.PlaylistUpdateRequestDTO {
+				return &dto.PlaylistUpdateRequestDTO{
+					ID: f.ownerPlaylist, UserID: f.owner, VideoIDs: []vo.ID{f.strangerVideo},
+				}
+			},
+			notFound: true,
+		},
+		{
+			name: "playlist of another user",
+			req: func(f *testLibrary) *dto.PlaylistUpdateRequestDTO {
+				return &dto.PlaylistUpdateRequestDTO{ID: f.ownerPlaylist, UserID: f.stranger, Name: "mine"}
+			},
+			notFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestLibrary(t)
+
+			_, err := f.service.Update(context.Background(), tt.req(f))
+			stored := f.playlists.playlists[f.ownerPlaylist]
+			switch {
+			case tt.isUnique:
+				if _, ok := err.(*errors.UniquenessCheckFailedError); !ok {
+					t.Fatalf("expected UniquenessCheckFailedError, got %v", err)
+				}
+			case tt.notFound:
+				if !errors.IsEntityNotFoundError(err) {
+					t.Fatalf("expected EntityNotFoundError, got %v", err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if stored.Name != tt.wantName {
+					t.Errorf("name = %q, want %q", stored.Name, tt.wantName)
+				}
+				if want := tt.want(f); !slices.Equal(stored.VideoIDs, want) {
+					t.Errorf("videos = %v, want %v", stored.VideoIDs, want)
+				}
+				return
+			}
+
+			// the rejected update must leave the playlist as is
+			if stored.Name != "favorites" || !slices.Equal(stored.VideoIDs, []vo.ID{f.videos[0], f.videos[1]}) {
+				t.Errorf("the rejected update changed the playlist: %+v", stored)
+			}
+		})
+	}
+}
+
+func TestCRUDService_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		isOwner   bool
+		isRemoved bool
+	}{
+		{name: "own playlist", isOwner: true, isRemoved: true},
+		{name: "playlist of another user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestLibrary(t)
+			userID := f.stranger
+			if tt.isOwner {
+				userID = f.owner
+			}
+
+			err := f.service.Delete(context.Background(), &dto.PlaylistDeleteRequestDTO{ID: f.ownerPlaylist, UserID: userID})
+			if tt.isRemoved {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if !errors.IsEntityNotFoundError(err) {
+				t.Fatalf("expected EntityNotFoundError, got %v", err)
+			}
+
+			if _, found := f.playlists.playlists[f.ownerPlaylist]; found == tt.isRemoved {
+				t.Errorf("playlist is found = %v, want %v", found, !tt.isRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package playlist_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type CRUD interface {
+	Get(ctx context.Context, reqDTO dto_interface.GetPlaylistRequest) (*agg.Playlist, error)
+	List(ctx context.Context, reqDTO dto_interface.ListPlaylistRequest) ([]*agg.Playlist, error)
+	Create(ctx context.Context, reqDTO dto_interface.CreatePlaylistRequest) (*agg.Playlist, error)
+	Update(ctx context.Context, reqDTO dto_interface.UpdatePlaylistRequest) (*agg.Playlist, error)
+	Delete(ctx context.Context, reqDTO dto_interface.DeletePlaylistRequest) error
+}
@@ -0,0 +1,20 @@
+package quota_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type Quota interface {
+	// Get will fetch the used storage of the user with the limits.
+	Get(ctx context.Context, reqDTO dto_interface.GetQuotaRequest) (*agg.Quota, error)
+	// Remaining will return the number of bytes which the user may upload yet (zero means unlimited),
+	// the error is returned if the quota is exceeded already.
+	Remaining(ctx context.Context, userID vo.ID) (int64, error)
+	// Consume will add the file of the resource to the used storage if it fits into the quota.
+	Consume(ctx context.Context, resource *agg.Resource) error
+	// Release will subtract the file of the removed resource from the used storage.
+	Release(ctx context.Context, resource *agg.Resource) error
+}
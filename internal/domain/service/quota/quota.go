@@ -0,0 +1,179 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type QuotaService struct {
+	logger             logger_interface.Logger
+	repository         repository_interface.Quota
+	resourceRepository repository_interface.Resource
+	maxBytes           int64
+	maxVideos          int64
+}
+
+func NewQuotaService(serviceContainer di_interface.ContainerManager) (*QuotaService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	quotaRepository, err := serviceContainer.GetQuotaRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceRepository, err := serviceContainer.GetResourceRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &QuotaService{
+		logger:             loggerService,
+		repository:         quotaRepository,
+		resourceRepository: resourceRepository,
+		maxBytes:           cfg.QuotaMaxBytes,
+		maxVideos:          cfg.QuotaMaxVideos,
+	}, nil
+}
+
+// Get - will fetch the used storage of the user, the limits are taken from the configuration.
+func (s *QuotaService) Get(ctx context.Context, req dto_interface.GetQuotaRequest) (*agg.Quota, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if req.GetUserID().Value.IsZero() {
+		return nil, logger.LogPropagate(errors.NewFieldCannotBeEmptyError("userID"))
+	}
+
+	quota, err := s.usage(ctx, req.GetUserID())
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return quota, nil
+}
+
+// Remaining - will check the quota of the user before the uploading of a new file.
+func (s *QuotaService) Remaining(ctx context.Context, userID vo.ID) (int64, error) {
+	logger := s.logger.WithContext(ctx)
+
+	quota, err := s.usage(ctx, userID)
+	if err != nil {
+		return 0, logger.LogPropagate(err)
+	}
+
+	if err = s.exceeded(quota, 1); err != nil {
+		return 0, logger.LogPropagate(err)
+	}
+
+	if s.maxBytes <= 0 {
+		return 0, nil
+	}
+	return s.maxBytes - quota.Bytes, nil
+}
+
+// Consume - will add the uploaded file to the used storage. The limits are checked atomically with the adding,
+// so the concurrent uploads of the user cannot exceed the quota together.
+func (s *QuotaService) Consume(ctx context.Context, resource *agg.Resource) error {
+	logger := s.logger.WithContext(ctx)
+
+	// the record must exist before the adding
+	quota, err := s.usage(ctx, resource.UserID)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	ok, err := s.repository.Consume(ctx, resource.UserID, resource.Filesize, s.maxBytes, s.maxVideos)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+	if !ok {
+		if err = s.exceeded(quota, resource.Filesize); err != nil {
+			return logger.LogPropagate(err)
+		}
+		// the quota was exceeded by a concurrent upload
+		return logger.LogPropagate(errors.NewQuotaExceededError(""))
+	}
+
+	return nil
+}
+
+// Release - will subtract the removed file from the used storage.
+func (s *QuotaService) Release(ctx context.Context, resource *agg.Resource) error {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.repository.Release(ctx, resource.UserID, resource.Filesize); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// usage - fetches the used storage of the user, the record is initialized by the existing files of the user
+// if it does not exist yet (for instance, the files were uploaded before the quotas were introduced).
+func (s *QuotaService) usage(ctx context.Context, userID vo.ID) (*agg.Quota, error) {
+	q := dto.NewQuotaGetRequestDTO(userID)
+
+	quota, err := s.repository.FindOne(ctx, q)
+	if err != nil {
+		if !errors.IsEntityNotFoundError(err) {
+			return nil, err
+		}
+
+		bytes, videos, ferr := s.resourceRepository.FindUsage(ctx, q)
+		if ferr != nil {
+			return nil, ferr
+		}
+
+		now := time.Now()
+		if err = s.repository.Init(ctx, &agg.Quota{
+			Quota: entity.Quota{
+				UserID: userID,
+				Bytes:  bytes,
+				Videos: videos,
+			},
+			Timestamp: vo.Timestamp{
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		if quota, err = s.repository.FindOne(ctx, q); err != nil {
+			return nil, err
+		}
+	}
+
+	quota.MaxBytes = s.maxBytes
+	quota.MaxVideos = s.maxVideos
+
+	return quota, nil
+}
+
+// exceeded - checks whether the file of the given size fits into the quota (zero limits mean unlimited).
+func (s *QuotaService) exceeded(quota *agg.Quota, filesize int64) error {
+	if s.maxVideos > 0 && quota.Videos >= s.maxVideos {
+		return errors.NewQuotaExceededError(fmt.Sprintf("max. number of videos is %d", s.maxVideos))
+	}
+	if s.maxBytes > 0 && quota.Bytes+filesize > s.maxBytes {
+		return errors.NewQuotaExceededError(fmt.Sprintf("max. size of files is %d bytes", s.maxBytes))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package quota
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	query_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// testQuotaRepository - the record of one user, the limits are checked atomically with the adding as by the storage.
+type testQuotaRepository struct {
+	repository_interface.Quota
+	mu    sync.Mutex
+	quota agg.Quota
+}
+
+func (r *testQuotaRepository) FindOne(ctx context.Context, q query_interface.FindOneQuota) (*agg.Quota, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	quota := r.quota
+	return &quota, nil
+}
+
+func (r *testQuotaRepository) Consume(
+	ctx context.Context, userID vo.ID, filesize int64, maxBytes int64, maxVideos int64,
+) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if (maxBytes > 0 && r.quota.Bytes > maxBytes-filesize) || (maxVideos > 0 && r.quota.Videos >= maxVideos) {
+		return false, nil
+	}
+	r.quota.Bytes += filesize
+	r.quota.Videos++
+	return true, nil
+}
+
+func newTestQuotaService(bytes int64, videos int64, maxBytes int64, maxVideos int64) (*QuotaService, *testQuotaRepository) {
+	repository := &testQuotaRepository{quota: agg.Quota{Quota: entity.Quota{Bytes: bytes, Videos: videos}}}
+	return &QuotaService{
+		logger:     logger_stub.NewLogger(),
+		repository: repository,
+		maxBytes:   maxBytes,
+		maxVideos:  maxVideos,
+	}, repository
+}
+
+func newTestResource(filesize int64) *agg.Resource {
+	return &agg.Resource{Resource: entity.Resource{UserID: vo.NewID(primitive.NewObjectID()), Filesize: filesize}}
+}
+
+func TestQuotaService_Consume(t *testing.T) {
+	tests := []struct {
+		name      string
+		bytes     int64
+		videos    int64
+		maxBytes  int64
+		maxVideos int64
+		filesize  int64
+		exceeded  string // the part of error message, empty if the file fits
+	}{
+		{name: "unlimited", bytes: 1 << 40, videos: 1000, filesize: 100},
+		{name: "the file fits", bytes: 50, videos: 1, maxBytes: 100, maxVideos: 2, filesize: 50},
+		{name: "the file crosses the size limit", bytes: 50, maxBytes: 100, filesize: 51, exceeded: "max. size"},
+		{name: "the file is bigger than the limit", maxBytes: 100, filesize: 101, exceeded: "max. size"},
+		{name: "the number of videos is reached", videos: 2, maxVideos: 2, filesize: 1, exceeded: "max. number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repository := newTestQuotaService(tt.bytes, tt.videos, tt.maxBytes, tt.maxVideos)
+
+			err := s.Consume(context.Background(), newTestResource(tt.filesize))
+			if tt.exceeded == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if repository.quota.Bytes != tt.bytes+tt.filesize || repository.quota.Videos != tt.videos+1 {
+					t.Fatalf("the file must be added to the used storage: %+v", repository.quota.Quota)
+				}
+				return
+			}
+
+			if _, ok := err.(*errors.QuotaExceededError); !ok || !strings.Contains(err.Error(), tt.exceeded) {
+				t.Fatalf("expected the quota exceeded error by '%v', got: %v", tt.exceeded, err)
+			}
+			if repository.quota.Bytes != tt.bytes || repository.quota.Videos != tt.videos {
+				t.Fatalf("the rejected file must not be added to the used storage: %+v", repository.quota.Quota)
+			}
+		})
+	}
+}
+
+func TestQuotaService_ConcurrentConsumeDoesNotExceed(t *testing.T) {
+	const (
+		uploads  = 20
+		filesize = 10
+		maxBytes = 55
+	)
+	s, repository := newTestQuotaService(0, 0, maxBytes, 0)
+
+	consumed := atomic.Int32{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < uploads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.Consume(context.Background(), newTestResource(filesize))
+			if err == nil {
+				consumed.Add(1)
+			} else if _, ok := err.(*errors.QuotaExceededError); !ok {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if consumed.Load() != maxBytes/filesize || repository.quota.Bytes > maxBytes {
+		t.Fatalf("expected %d uploads within the quota, got %d (%d bytes)", maxBytes/filesize, consumed.Load(), repository.quota.Bytes)
+	}
+}
@@ -0,0 +1,13 @@
+package reconciler_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Reconciler interface {
+	// Reconcile will find the orphaned files of the resources directory and the records which point at
+	// the missing files, they are fixed only if it's requested (otherwise it's a dry-run).
+	Reconcile(ctx context.Context, reqDTO dto_interface.ReconcileRequest) (*dto.ReconciliationReportDTO, error)
+}
@@ -0,0 +1,60 @@
+package reconciler
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	reconciler_interface "github.com/Borislavv/video-streaming/internal/domain/service/reconciler/interface"
+	"time"
+)
+
+// ReconcileJob - runs the reconciliation periodically, what is fixed by it is defined by the configuration.
+type ReconcileJob struct {
+	logger     logger_interface.Logger
+	reconciler reconciler_interface.Reconciler
+	req        *dto.ReconcileRequestDTO
+	interval   time.Duration
+}
+
+func NewReconcileJob(serviceContainer di_interface.ContainerManager) (*ReconcileJob, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	reconcilerService, err := serviceContainer.GetReconcilerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ReconcileJob{
+		logger:     loggerService,
+		reconciler: reconcilerService,
+		req:        dto.NewReconcileRequestDTO(cfg.ReconcileQuarantine, cfg.ReconcileRepair),
+		interval:   cfg.ReconcileInterval,
+	}, nil
+}
+
+// Run - reconciles once per interval until the context will be canceled. The failed reconciliation
+// is logged only, because the next one will be attempted anyway.
+func (j *ReconcileJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := j.reconciler.Reconcile(ctx, j.req); err != nil && ctx.Err() == nil {
+				j.logger.WithContext(ctx).Error(err)
+			}
+		}
+	}
+}
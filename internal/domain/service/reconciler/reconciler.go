@@ -0,0 +1,236 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	storager_interface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type ReconcilerService struct {
+	logger             logger_interface.Logger
+	storage            storager_interface.Storage
+	resourceRepository repository_interface.Resource
+	videoRepository    repository_interface.Video
+	playlistRepository repository_interface.Playlist
+	historyRepository  repository_interface.WatchProgress
+	sessionRepository  repository_interface.PlaySession
+	statsRepository    repository_interface.VideoStats
+	quota              quota_interface.Quota
+	gracePeriod        time.Duration
+}
+
+func NewReconcilerService(serviceContainer di_interface.ContainerManager) (*ReconcilerService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	fileStorageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceRepository, err := serviceContainer.GetResourceRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressRepository, err := serviceContainer.GetWatchProgressRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playSessionRepository, err := serviceContainer.GetPlaySessionRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoStatsRepository, err := serviceContainer.GetVideoStatsRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	quotaService, err := serviceContainer.GetQuotaService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ReconcilerService{
+		logger:             loggerService,
+		storage:            fileStorageService,
+		resourceRepository: resourceRepository,
+		videoRepository:    videoRepository,
+		playlistRepository: playlistRepository,
+		historyRepository:  watchProgressRepository,
+		sessionRepository:  playSessionRepository,
+		statsRepository:    videoStatsRepository,
+		quota:              quotaService,
+		gracePeriod:        cfg.ReconcileGracePeriod,
+	}, nil
+}
+
+// Reconcile - will scan the resources directory, the resources and the videos. The files and the records which
+// are younger than the grace period are skipped, because the file is stored before its record (uploading,
+// recording) and removed before it, so they may be consistent in a moment.
+func (s *ReconcilerService) Reconcile(
+	ctx context.Context, req dto_interface.ReconcileRequest,
+) (
+	*dto.ReconciliationReportDTO, error,
+) {
+	logger := s.logger.WithContext(ctx)
+
+	report := &dto.ReconciliationReportDTO{
+		OrphanFiles:  []string{},
+		MissingFiles: []vo.ID{},
+		BrokenVideos: []vo.ID{},
+	}
+	deadline := time.Now().Add(-s.gracePeriod)
+
+	// the stored files by names
+	stored := make(map[string]time.Time)
+	files, err := s.storage.List()
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+	for _, file := range files {
+		stored[file.Name()] = file.ModTime()
+	}
+
+	// the files which have resources and the resources which have files
+	referenced := make(map[string]struct{})
+	resources := make(map[primitive.ObjectID]bool)
+	var missing []*agg.Resource
+	if err = s.resourceRepository.Walk(ctx, func(resource *agg.Resource) error {
+		referenced[resource.Filename] = struct{}{}
+
+		_, exists := stored[resource.Filename]
+		resources[resource.ID.Value] = exists
+		if !exists && resource.Timestamp.CreatedAt.Before(deadline) {
+			logger.Warning(fmt.Sprintf("resource '%v' points at the missing file '%v'",
+				resource.ID.Value.Hex(), resource.Filename,
+			))
+			missing = append(missing, resource)
+			report.MissingFiles = append(report.MissingFiles, resource.ID)
+		}
+		return nil
+	}); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// the videos are unplayable if their resources are removed or have no files
+	var broken []*agg.Video
+	if err = s.videoRepository.Walk(ctx, func(video *agg.Video) error {
+		if exists, found := resources[video.Resource.ID.Value]; (!found || !exists) &&
+			video.Timestamp.CreatedAt.Before(deadline) {
+			logger.Warning(fmt.Sprintf("video '%v' points at the missing resource or file '%v'",
+				video.ID.Value.Hex(), video.Resource.ID.Value.Hex(),
+			))
+			broken = append(broken, video)
+			report.BrokenVideos = append(report.BrokenVideos, video.ID)
+		}
+		return nil
+	}); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// the files which have no resources at all
+	var orphans []string
+	for filename, modTime := range stored {
+		if _, found := referenced[filename]; !found && modTime.Before(deadline) {
+			logger.Warning(fmt.Sprintf("file '%v' has no resource", filename))
+			orphans = append(orphans, filename)
+			report.OrphanFiles = append(report.OrphanFiles, filename)
+		}
+	}
+
+	if req.IsRepair() {
+		for _, video := range broken {
+			if err = s.removeVideo(ctx, video); err != nil {
+				logger.Error(err)
+				report.Failed++
+				continue
+			}
+			report.RemovedVideos++
+		}
+		for _, resource := range missing {
+			if err = s.removeResource(ctx, resource); err != nil {
+				logger.Error(err)
+				report.Failed++
+				continue
+			}
+			report.RemovedResources++
+		}
+	}
+
+	if req.IsQuarantine() {
+		for _, filename := range orphans {
+			if err = s.storage.Quarantine(filename); err != nil {
+				logger.Error(err)
+				report.Failed++
+				continue
+			}
+			report.Quarantined++
+		}
+	}
+
+	logger.Info(fmt.Sprintf(
+		"reconciliation is done (dry-run: %v): orphaned files: %d, missing files: %d, broken videos: %d, "+
+			"quarantined: %d, removed resources: %d, removed videos: %d, failed: %d",
+		req.IsDryRun(), len(report.OrphanFiles), len(report.MissingFiles), len(report.BrokenVideos),
+		report.Quarantined, report.RemovedResources, report.RemovedVideos, report.Failed,
+	))
+
+	return report, nil
+}
+
+// removeVideo - removes the broken video with its references the same way as it's deleted by the owner,
+// except its resource (it's removed separately if it exists).
+func (s *ReconcilerService) removeVideo(ctx context.Context, video *agg.Video) error {
+	if err := s.playlistRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+	if err := s.historyRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+	if err := s.sessionRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+	if err := s.statsRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+	return s.videoRepository.Remove(ctx, video)
+}
+
+// removeResource - removes the resource which points at the missing file, the file is not stored anymore,
+// so it's released from the quota of the user.
+func (s *ReconcilerService) removeResource(ctx context.Context, resource *agg.Resource) error {
+	if err := s.resourceRepository.Remove(ctx, resource); err != nil {
+		return err
+	}
+	return s.quota.Release(ctx, resource)
+}
@@ -0,0 +1,314 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	storager_interface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testRemovals - records the removing steps of all doubles as "<step>:<name>", the given step is failed.
+type testRemovals struct {
+	failStep string
+	steps    []string
+}
+
+func (r *testRemovals) remove(step string, name string) error {
+	if step == r.failStep {
+		return errors.New(step + " is not available")
+	}
+	r.steps = append(r.steps, step+":"+name)
+	return nil
+}
+
+type testFileInfo struct {
+	os.FileInfo
+	name    string
+	modTime time.Time
+}
+
+func (f *testFileInfo) Name() string       { return f.name }
+func (f *testFileInfo) ModTime() time.Time { return f.modTime }
+
+type testStorage struct {
+	storager_interface.Storage
+	*testRemovals
+	err   error
+	files []os.FileInfo
+}
+
+func (s *testStorage) List() ([]os.FileInfo, error) {
+	return s.files, s.err
+}
+
+func (s *testStorage) Quarantine(name string) error {
+	return s.remove("quarantine", name)
+}
+
+type testResourceRepository struct {
+	repository_interface.Resource
+	*testRemovals
+	resources []*agg.Resource
+}
+
+func (r *testResourceRepository) Walk(ctx context.Context, fn func(*agg.Resource) error) error {
+	for _, resource := range r.resources {
+		if err := fn(resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *testResourceRepository) Remove(ctx context.Context, resource *agg.Resource) error {
+	return r.remove("resource", resource.Name)
+}
+
+type testVideoRepository struct {
+	repository_interface.Video
+	*testRemovals
+	videos []*agg.Video
+}
+
+func (r *testVideoRepository) Walk(ctx context.Context, fn func(*agg.Video) error) error {
+	for _, video := range r.videos {
+		if err := fn(video); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *testVideoRepository) Remove(ctx context.Context, video *agg.Video) error {
+	return r.remove("video", video.Name)
+}
+
+type testPlaylistRepository struct {
+	repository_interface.Playlist
+	*testRemovals
+}
+
+func (r *testPlaylistRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.remove("playlists", video.Name)
+}
+
+type testWatchProgressRepository struct {
+	repository_interface.WatchProgress
+	*testRemovals
+}
+
+func (r *testWatchProgressRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.remove("history", video.Name)
+}
+
+type testPlaySessionRepository struct {
+	repository_interface.PlaySession
+	*testRemovals
+}
+
+func (r *testPlaySessionRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.remove("sessions", video.Name)
+}
+
+type testVideoStatsRepository struct {
+	repository_interface.VideoStats
+	*testRemovals
+}
+
+func (r *testVideoStatsRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.remove("stats", video.Name)
+}
+
+type testQuota struct {
+	quota_interface.Quota
+	*testRemovals
+}
+
+func (q *testQuota) Release(ctx context.Context, resource *agg.Resource) error {
+	return q.remove("quota", resource.Name)
+}
+
+func newTestResource(name string, filename string, createdAt time.Time) *agg.Resource {
+	return &agg.Resource{
+		Resource: entity.Resource{
+			ID:       vo.NewID(primitive.NewObjectID()),
+			Name:     name,
+			Filename: filename,
+		},
+		Timestamp: vo.Timestamp{CreatedAt: createdAt},
+	}
+}
+
+func newTestVideo(name string, resourceID vo.ID, createdAt time.Time) *agg.Video {
+	return &agg.Video{
+		Video:     entity.Video{ID: vo.NewID(primitive.NewObjectID()), Name: name},
+		Resource:  entity.Resource{ID: resourceID},
+		Timestamp: vo.Timestamp{CreatedAt: createdAt},
+	}
+}
+
+// testReconciliation - the consistent records and files, and the inconsistent ones which are older than
+// the grace period or younger than it (the young ones must be skipped).
+type testReconciliation struct {
+	service      *ReconcilerService
+	storage      *testStorage
+	removals     *testRemovals
+	missing      *agg.Resource // points at the missing file
+	missingVideo *agg.Video    // points at the resource with missing file
+	removedVideo *agg.Video    // points at the removed resource
+}
+
+func newTestReconciliation() *testReconciliation {
+	removals := &testRemovals{}
+	old, recent := time.Now().Add(-2*time.Hour), time.Now()
+
+	stored := newTestResource("stored", "stored.mp4", old)
+	missing := newTestResource("missing", "missing.mp4", old)
+	pending := newTestResource("pending", "pending.mp4", recent) // its file is being stored
+	removedID := vo.NewID(primitive.NewObjectID())
+
+	f := &testReconciliation{
+		storage: &testStorage{
+			testRemovals: removals,
+			files: []os.FileInfo{
+				&testFileInfo{name: "stored.mp4", modTime: old},
+				&testFileInfo{name: "orphan.mp4", modTime: old},
+				&testFileInfo{name: "uploading.mp4", modTime: recent}, // its resource is being inserted
+			},
+		},
+		removals:     removals,
+		missing:      missing,
+		missingVideo: newTestVideo("missingVideo", missing.ID, old),
+		removedVideo: newTestVideo("removedVideo", removedID, old),
+	}
+	f.service = &ReconcilerService{
+		logger:  logger_stub.NewLogger(),
+		storage: f.storage,
+		resourceRepository: &testResourceRepository{
+			testRemovals: removals,
+			resources:    []*agg.Resource{stored, missing, pending},
+		},
+		videoRepository: &testVideoRepository{
+			testRemovals: removals,
+			videos: []*agg.Video{
+				newTestVideo("storedVideo", stored.ID, old),
+				f.missingVideo,
+				f.removedVideo,
+				newTestVideo("pendingVideo", removedID, recent),
+			},
+		},
+		playlistRepository: &testPlaylistRepository{testRemovals: removals},
+		historyRepository:  &testWatchProgressRepository{testRemovals: removals},
+		sessionRepository:  &testPlaySessionRepository{testRemovals: removals},
+		statsRepository:    &testVideoStatsRepository{testRemovals: removals},
+		quota:              &testQuota{testRemovals: removals},
+		gracePeriod:        time.Hour,
+	}
+	return f
+}
+
+func TestReconcilerService_Reconcile(t *testing.T) {
+	removedVideoSteps := []string{
+		"playlists:missingVideo", "history:missingVideo", "sessions:missingVideo", "stats:missingVideo", "video:missingVideo",
+		"playlists:removedVideo", "history:removedVideo", "sessions:removedVideo", "stats:removedVideo", "video:removedVideo",
+	}
+
+	tests := []struct {
+		name       string
+		req        *dto.ReconcileRequestDTO
+		failStep   string
+		listErr    error
+		steps      []string
+		report     dto.ReconciliationReportDTO // the counters only
+		isReported bool                        // the found inconsistencies are reported
+	}{
+		{name: "dry-run", req: dto.NewReconcileRequestDTO(false, false), isReported: true},
+		{
+			name:       "orphaned files are quarantined",
+			req:        dto.NewReconcileRequestDTO(true, false),
+			steps:      []string{"quarantine:orphan.mp4"},
+			report:     dto.ReconciliationReportDTO{Quarantined: 1},
+			isReported: true,
+		},
+		{
+			name:       "broken records are removed with their references",
+			req:        dto.NewReconcileRequestDTO(false, true),
+			steps:      append(slices.Clone(removedVideoSteps), "resource:missing", "quota:missing"),
+			report:     dto.ReconciliationReportDTO{RemovedVideos: 2, RemovedResources: 1},
+			isReported: true,
+		},
+		{
+			name:     "failed removing is counted and the rest is continued",
+			req:      dto.NewReconcileRequestDTO(true, true),
+			failStep: "sessions",
+			steps: []string{
+				"playlists:missingVideo", "history:missingVideo",
+				"playlists:removedVideo", "history:removedVideo",
+				"resource:missing", "quota:missing", "quarantine:orphan.mp4",
+			},
+			report:     dto.ReconciliationReportDTO{RemovedResources: 1, Quarantined: 1, Failed: 2},
+			isReported: true,
+		},
+		{
+			name:       "failed quarantine is counted",
+			req:        dto.NewReconcileRequestDTO(true, false),
+			failStep:   "quarantine",
+			report:     dto.ReconciliationReportDTO{Failed: 1},
+			isReported: true,
+		},
+		{
+			name:    "storage is not available",
+			req:     dto.NewReconcileRequestDTO(true, true),
+			listErr: errors.New("permission denied"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestReconciliation()
+			f.removals.failStep = tt.failStep
+			f.storage.err = tt.listErr
+
+			report, err := f.service.Reconcile(context.Background(), tt.req)
+			if !errors.Is(err, tt.listErr) {
+				t.Fatalf("error = %v, want %v", err, tt.listErr)
+			}
+			if tt.listErr != nil {
+				if len(f.removals.steps) != 0 {
+					t.Errorf("nothing must be fixed, but done %v", f.removals.steps)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(report.OrphanFiles, []string{"orphan.mp4"}) {
+				t.Errorf("orphaned files = %v, want [orphan.mp4]", report.OrphanFiles)
+			}
+			if !reflect.DeepEqual(report.MissingFiles, []vo.ID{f.missing.ID}) {
+				t.Errorf("resources with missing files = %v, want [%v]", report.MissingFiles, f.missing.ID)
+			}
+			if want := []vo.ID{f.missingVideo.ID, f.removedVideo.ID}; !reflect.DeepEqual(report.BrokenVideos, want) {
+				t.Errorf("broken videos = %v, want %v", report.BrokenVideos, want)
+			}
+
+			if report.Quarantined != tt.report.Quarantined || report.RemovedVideos != tt.report.RemovedVideos ||
+				report.RemovedResources != tt.report.RemovedResources || report.Failed != tt.report.Failed {
+				t.Errorf("report = %+v, want counters %+v", *report, tt.report)
+			}
+			if !slices.Equal(f.removals.steps, tt.steps) {
+				t.Errorf("steps = %v, want %v", f.removals.steps, tt.steps)
+			}
+		})
+	}
+}
@@ -2,25 +2,30 @@ package resource
 
 import (
 	"context"
+	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	builder_interface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
 	storager_interface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
 	uploader_interface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	"time"
 )
 
 type CRUDService struct {
-	ctx        context.Context
 	logger     logger_interface.Logger
 	uploader   uploader_interface.Uploader
 	validator  validator_interface.Resource
 	builder    builder_interface.Resource
 	repository repository_interface.Resource
 	storage    storager_interface.Storage
+	detector   detector_interface.Codecs
+	quota      quota_interface.Quota
 }
 
 func NewResourceService(serviceContainer di_interface.ContainerManager) (*CRUDService, error) {
@@ -29,11 +34,6 @@ func NewResourceService(serviceContainer di_interface.ContainerManager) (*CRUDSe
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	uploaderService, err := serviceContainer.GetFileUploaderService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -59,54 +59,89 @@ func NewResourceService(serviceContainer di_interface.ContainerManager) (*CRUDSe
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	codecsDetectorService, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	quotaService, err := serviceContainer.GetQuotaService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &CRUDService{
-		ctx:        ctx,
 		logger:     loggerService,
 		uploader:   uploaderService,
 		validator:  validatorService,
 		builder:    builderService,
 		repository: resourceRepository,
 		storage:    fileStorageService,
+		detector:   codecsDetectorService,
+		quota:      quotaService,
 	}, nil
 }
 
 // Upload - will be prepared and  will be saved a file from the request. Important: the input request's DTO will
 // mutate per uploading. Also, of course will be created a new instance of agg.Resource as the contract says and
 // will be saved into the database.
-func (s *CRUDService) Upload(req dto_interface.UploadResourceRequest) (resource *agg.Resource, err error) {
+func (s *CRUDService) Upload(ctx context.Context, req dto_interface.UploadResourceRequest) (resource *agg.Resource, err error) {
+	logger := s.logger.WithContext(ctx)
+
 	defer func() {
 		if err != nil {
 			if e := s.onUploadingFailed(req); e != nil {
-				s.logger.Log(e)
+				logger.Log(e)
 			}
 		}
 	}()
 
 	// validation of raw uploading request
 	if err = s.validator.ValidateUploadRequestDTO(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
+	}
+
+	// checking the quota of the user, the uploading is aborted as soon as the file crosses the remaining one
+	remaining, err := s.quota.Remaining(ctx, req.GetUserID())
+	if err != nil {
+		return nil, logger.LogPropagate(err)
 	}
+	req.SetQuota(remaining)
 
 	// uploading the target file
 	if err = s.uploader.Upload(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// building resource aggregate
 	resource = s.builder.BuildAggFromUploadRequestDTO(req)
 
+	// detecting media metadata, the resource is still usable without it, so only log the error
+	if resource.Metadata, err = s.detector.DetectMetadata(ctx, resource.Resource); err != nil {
+		logger.Warning(fmt.Sprintf("unable to detect metadata of resource '%v': %v", resource.Filename, err))
+		err = nil
+	}
+
 	// validation of built aggregate
 	if err = s.validator.ValidateAggregate(resource); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
+	}
+
+	// adding the uploaded file to the used storage, the limits are checked atomically with the adding, so the file
+	// is rejected (and removed) if it does not fit the quota anymore (e.g. the concurrent uploads of the user fit first)
+	if err = s.quota.Consume(ctx, resource); err != nil {
+		return nil, logger.LogPropagate(err)
 	}
 
 	// saving the built aggregate
-	resource, err = s.repository.Insert(s.ctx, resource)
+	inserted, err := s.repository.Insert(ctx, resource)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		if e := s.quota.Release(ctx, resource); e != nil {
+			logger.Log(e)
+		}
+		return nil, logger.LogPropagate(err)
 	}
 
-	return resource, nil
+	return inserted, nil
 }
 
 // onUploadingFailed - will check that created file is removed.
@@ -128,27 +163,74 @@ func (s *CRUDService) onUploadingFailed(req dto_interface.UploadResourceRequest)
 	return nil
 }
 
-// Delete - will remove a single video by id with dependencies.
-func (s *CRUDService) Delete(req dto_interface.DeleteResourceRequest) (err error) {
+// Delete - will move the resource into the trash, the file is kept until the resource will be purged.
+func (s *CRUDService) Delete(ctx context.Context, req dto_interface.DeleteResourceRequest) (err error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of raw delete request
 	if err = s.validator.ValidateDeleteRequestDTO(req); err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
 	// fetching the target resource aggregate
-	resourceAgg, err := s.repository.FindOneByID(s.ctx, req)
+	resourceAgg, err := s.repository.FindOneByID(ctx, req)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// moving the resource into the trash (the fetched aggregate may be shared by the cache, so its copy is trashed)
+	deletedAt := time.Now()
+	trashedAgg := *resourceAgg
+	trashedAgg.DeletedAt = &deletedAt
+	if err = s.repository.Trash(ctx, &trashedAgg); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// Restore - will bring the resource back from the trash.
+func (s *CRUDService) Restore(ctx context.Context, req dto_interface.RestoreResourceRequest) (*agg.Resource, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// fetching the trashed resource aggregate
+	resourceAgg, err := s.repository.FindOneTrashedByID(ctx, req)
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
-	// removing the file first
-	if err = s.storage.Remove(resourceAgg.Filename); err != nil {
-		return s.logger.LogPropagate(err)
+	// bringing the resource back
+	if err = s.repository.Restore(ctx, resourceAgg); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+	resourceAgg.DeletedAt = nil
+
+	return resourceAgg, nil
+}
+
+// Purge - will permanently remove the resource with its file, the used storage of the user is released.
+func (s *CRUDService) Purge(ctx context.Context, resource *agg.Resource) error {
+	logger := s.logger.WithContext(ctx)
+
+	// removing the file first (it may be already removed by hand, the record must be purged anyway)
+	has, err := s.storage.Has(resource.Filename)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+	if has {
+		if err = s.storage.Remove(resource.Filename); err != nil {
+			return logger.LogPropagate(err)
+		}
 	}
 
 	// removing the resource
-	if err = s.repository.Remove(s.ctx, resourceAgg); err != nil {
-		return s.logger.LogPropagate(err)
+	if err = s.repository.Remove(ctx, resource); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// the file is not stored anymore
+	if err = s.quota.Release(ctx, resource); err != nil {
+		return logger.LogPropagate(err)
 	}
 
 	return nil
@@ -0,0 +1,164 @@
+package resource
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	builder_interface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	storager_interface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	uploader_interface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	"net/http/httptest"
+	"testing"
+)
+
+const testUploadedFilename = "uploaded.mp4"
+
+type testValidator struct {
+	validator_interface.Resource
+}
+
+func (v *testValidator) ValidateUploadRequestDTO(req dto_interface.UploadResourceRequest) error {
+	return nil
+}
+func (v *testValidator) ValidateAggregate(resource *agg.Resource) error { return nil }
+
+type testBuilder struct {
+	builder_interface.Resource
+}
+
+func (b *testBuilder) BuildAggFromUploadRequestDTO(req dto_interface.UploadResourceRequest) *agg.Resource {
+	return &agg.Resource{Resource: entity.Resource{
+		UserID:   req.GetUserID(),
+		Filename: req.GetUploadedFilename(),
+		Filesize: req.GetUploadedFilesize(),
+	}}
+}
+
+type testDetector struct {
+	detector_interface.Codecs
+}
+
+func (d *testDetector) DetectMetadata(ctx context.Context, resource entity.Resource) (vo.MediaMetadata, error) {
+	return vo.MediaMetadata{}, nil
+}
+
+// testUploader - stores the file of the given size into the storage.
+type testUploader struct {
+	uploader_interface.Uploader
+	storage  *testStorage
+	filesize int64
+}
+
+func (u *testUploader) Upload(req dto_interface.UploadResourceRequest) error {
+	req.SetUploadedFilename(testUploadedFilename)
+	req.SetUploadedFilesize(u.filesize)
+	u.storage.files[testUploadedFilename] = struct{}{}
+	return nil
+}
+
+type testStorage struct {
+	storager_interface.Storage
+	files map[string]struct{}
+}
+
+func (s *testStorage) Has(filename string) (bool, error) {
+	_, found := s.files[filename]
+	return found, nil
+}
+
+func (s *testStorage) Remove(filename string) error {
+	delete(s.files, filename)
+	return nil
+}
+
+// testQuota - the file is consumed if it fits the remaining bytes.
+type testQuota struct {
+	quota_interface.Quota
+	remaining int64
+	consumed  int64
+}
+
+func (q *testQuota) Remaining(ctx context.Context, userID vo.ID) (int64, error) {
+	return q.remaining, nil
+}
+
+func (q *testQuota) Consume(ctx context.Context, resource *agg.Resource) error {
+	if resource.Filesize > q.remaining-q.consumed {
+		return errors.NewQuotaExceededError("")
+	}
+	q.consumed += resource.Filesize
+	return nil
+}
+
+type testRepository struct {
+	repository_interface.Resource
+	inserted []*agg.Resource
+}
+
+func (r *testRepository) Insert(ctx context.Context, resource *agg.Resource) (*agg.Resource, error) {
+	r.inserted = append(r.inserted, resource)
+	return resource, nil
+}
+
+func TestCRUDService_UploadQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		remaining  int64
+		consumed   int64 // the bytes consumed by the concurrent uploads meanwhile
+		filesize   int64
+		isRejected bool
+	}{
+		{name: "the file fits the quota", remaining: 100, filesize: 100},
+		{name: "the concurrent uploads fit first", remaining: 100, consumed: 50, filesize: 60, isRejected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &testStorage{files: make(map[string]struct{})}
+			quota := &testQuota{remaining: tt.remaining, consumed: tt.consumed}
+			repository := &testRepository{}
+			s := &CRUDService{
+				logger:     logger_stub.NewLogger(),
+				uploader:   &testUploader{storage: storage, filesize: tt.filesize},
+				validator:  &testValidator{},
+				builder:    &testBuilder{},
+				repository: repository,
+				storage:    storage,
+				detector:   &testDetector{},
+				quota:      quota,
+			}
+
+			req := dto.NewResourceUploadRequest(httptest.NewRequest("POST", "/resource", nil))
+			_, err := s.Upload(context.Background(), req)
+
+			if !tt.isRejected {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(repository.inserted) != 1 || len(storage.files) != 1 {
+					t.Fatalf("the uploaded file must be stored")
+				}
+				return
+			}
+
+			if _, ok := err.(*errors.QuotaExceededError); !ok {
+				t.Fatalf("expected the quota exceeded error, got: %v", err)
+			}
+			if len(repository.inserted) != 0 {
+				t.Fatalf("the rejected resource must not be inserted")
+			}
+			if len(storage.files) != 0 {
+				t.Fatalf("the rejected file must be removed")
+			}
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package resource_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 )
 
 type CRUD interface {
-	Upload(reqDTO dto_interface.UploadResourceRequest) (*agg.Resource, error)
-	Delete(reqDTO dto_interface.DeleteResourceRequest) (err error)
+	Upload(ctx context.Context, reqDTO dto_interface.UploadResourceRequest) (*agg.Resource, error)
+	Delete(ctx context.Context, reqDTO dto_interface.DeleteResourceRequest) (err error)
+	Restore(ctx context.Context, reqDTO dto_interface.RestoreResourceRequest) (*agg.Resource, error)
+	Purge(ctx context.Context, resource *agg.Resource) error
 }
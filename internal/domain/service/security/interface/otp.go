@@ -0,0 +1,12 @@
+package security_interface
+
+type OTP interface {
+	// GenerateSecret will generate a new random base32 encoded shared secret.
+	GenerateSecret() (secret string, err error)
+	// URI will build an otpauth:// URI which may be scanned by authenticator apps (as a QR code).
+	URI(secret string, account string) (uri string)
+	// Verify will check that the code is valid for given secret at the current moment and was not accepted yet,
+	// so the codes of the time steps which are not later than the lastCounter are rejected. The counter of the code
+	// must be stored as the last one if it's valid.
+	Verify(secret string, code string, lastCounter int64) (counter int64, isValid bool)
+}
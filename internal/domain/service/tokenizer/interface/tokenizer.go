@@ -9,4 +9,8 @@ type Tokenizer interface {
 	New(user *agg.User) (token string, err error)
 	Verify(token string) (userID vo.ID, err error)
 	Block(token string, reason string) error
+	// NewMFAChallenge will generate a short-lived token which can be exchanged only on the access token.
+	NewMFAChallenge(user *agg.User) (token string, err error)
+	// VerifyMFAChallenge will decode the challenge token and return a user ID.
+	VerifyMFAChallenge(token string) (userID vo.ID, err error)
 }
@@ -0,0 +1,20 @@
+package twofactor_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type TwoFactor interface {
+	// Enable will start the enrolment by generating a new secret (2FA stays disabled until confirmation).
+	Enable(ctx context.Context, reqDTO dto_interface.EnableTwoFactorRequest) (secret string, uri string, err error)
+	// Confirm will finish the enrolment by given code and issue the recovery codes.
+	Confirm(ctx context.Context, reqDTO dto_interface.ConfirmTwoFactorRequest) (recoveryCodes []string, err error)
+	// Disable will turn off the 2FA by given code or recovery code.
+	Disable(ctx context.Context, reqDTO dto_interface.DisableTwoFactorRequest) error
+	// RegenerateRecoveryCodes will replace the recovery codes by new ones.
+	RegenerateRecoveryCodes(ctx context.Context, reqDTO dto_interface.RegenerateTwoFactorRequest) (recoveryCodes []string, err error)
+	// Verify will check the code or the recovery code of given user (used recovery code will be burned).
+	Verify(ctx context.Context, user *agg.User, code string, recoveryCode string) error
+}
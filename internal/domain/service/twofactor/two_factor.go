@@ -0,0 +1,315 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	security_interface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"strings"
+	"time"
+)
+
+// recoveryCodeLength is a number of random bytes of the recovery code (10 hex chars).
+const recoveryCodeLength = 5
+
+// recoveryCodeHash is a wrapper which allows to verify the recovery code by the password hasher.
+type recoveryCodeHash string
+
+func (h recoveryCodeHash) GetPassword() string {
+	return string(h)
+}
+
+type TwoFactorService struct {
+	logger              logger_interface.Logger
+	validator           validator_interface.TwoFactor
+	userRepository      repository_interface.User
+	otp                 security_interface.OTP
+	passwordHasher      security_interface.PasswordHasher
+	recoveryCodesNumber int
+}
+
+func NewTwoFactorService(serviceContainer di_interface.ContainerManager) (*TwoFactorService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorValidator, err := serviceContainer.GetTwoFactorValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	userRepository, err := serviceContainer.GetUserRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	otpService, err := serviceContainer.GetOTPService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	passwordHasherService, err := serviceContainer.GetPasswordHasherService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TwoFactorService{
+		logger:              loggerService,
+		validator:           twoFactorValidator,
+		userRepository:      userRepository,
+		otp:                 otpService,
+		passwordHasher:      passwordHasherService,
+		recoveryCodesNumber: cfg.TOTPRecoveryCodesNumber,
+	}, nil
+}
+
+// Enable will start the enrolment by generating a new secret (2FA stays disabled until confirmation).
+func (s *TwoFactorService) Enable(ctx context.Context, req dto_interface.EnableTwoFactorRequest) (secret string, uri string, err error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err = s.validator.ValidateEnableRequestDTO(req); err != nil {
+		return "", "", logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.userRepository.FindOneByID(ctx, dto.NewUserGetRequestDTO(req.GetUserID(), ""))
+	if err != nil {
+		return "", "", logger.LogPropagate(err)
+	}
+
+	if userAgg.IsTwoFactorEnabled() {
+		return "", "", logger.LogPropagate(errors.NewTwoFactorIsAlreadyEnabledError())
+	}
+
+	// generating a new secret (the previous not confirmed secret will be replaced)
+	secret, err = s.otp.GenerateSecret()
+	if err != nil {
+		return "", "", logger.LogPropagate(err)
+	}
+
+	userAgg.TwoFactor = vo.TwoFactor{Secret: secret}
+	userAgg.Timestamp.UpdatedAt = time.Now()
+
+	if _, err = s.userRepository.Update(ctx, userAgg); err != nil {
+		return "", "", logger.LogPropagate(err)
+	}
+
+	return secret, s.otp.URI(secret, userAgg.GetEmail()), nil
+}
+
+// Confirm will finish the enrolment by given code and issue the recovery codes.
+func (s *TwoFactorService) Confirm(ctx context.Context, req dto_interface.ConfirmTwoFactorRequest) (recoveryCodes []string, err error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err = s.validator.ValidateConfirmRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.userRepository.FindOneByID(ctx, dto.NewUserGetRequestDTO(req.GetUserID(), ""))
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	if userAgg.IsTwoFactorEnabled() {
+		return nil, logger.LogPropagate(errors.NewTwoFactorIsAlreadyEnabledError())
+	}
+	if !userAgg.TwoFactor.IsEnrolled() {
+		return nil, logger.LogPropagate(errors.NewTwoFactorIsNotEnrolledError())
+	}
+
+	// checking that user's authenticator was set up properly
+	counter, isValid := s.otp.Verify(userAgg.TwoFactor.Secret, req.GetCode(), userAgg.TwoFactor.LastCounter)
+	if !isValid {
+		return nil, logger.LogPropagate(errors.NewTwoFactorCodeIsInvalidError())
+	}
+
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	userAgg.TwoFactor.Enabled = true
+	userAgg.TwoFactor.ConfirmedAt = time.Now()
+	userAgg.TwoFactor.RecoveryCodes = hashes
+	userAgg.TwoFactor.LastCounter = counter
+	userAgg.Timestamp.UpdatedAt = time.Now()
+
+	if _, err = s.userRepository.Update(ctx, userAgg); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// Disable will turn off the 2FA by given code or recovery code.
+func (s *TwoFactorService) Disable(ctx context.Context, req dto_interface.DisableTwoFactorRequest) error {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.validator.ValidateDisableRequestDTO(req); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.userRepository.FindOneByID(ctx, dto.NewUserGetRequestDTO(req.GetUserID(), ""))
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	if !userAgg.IsTwoFactorEnabled() {
+		return logger.LogPropagate(errors.NewTwoFactorIsNotEnabledError())
+	}
+
+	if err = s.Verify(ctx, userAgg, req.GetCode(), req.GetRecoveryCode()); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	userAgg.TwoFactor = vo.TwoFactor{}
+	userAgg.Timestamp.UpdatedAt = time.Now()
+
+	if _, err = s.userRepository.Update(ctx, userAgg); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes will replace the recovery codes by new ones.
+func (s *TwoFactorService) RegenerateRecoveryCodes(
+	ctx context.Context,
+	req dto_interface.RegenerateTwoFactorRequest,
+) (
+	recoveryCodes []string,
+	err error,
+) {
+	logger := s.logger.WithContext(ctx)
+
+	if err = s.validator.ValidateRegenerateRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.userRepository.FindOneByID(ctx, dto.NewUserGetRequestDTO(req.GetUserID(), ""))
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	if !userAgg.IsTwoFactorEnabled() {
+		return nil, logger.LogPropagate(errors.NewTwoFactorIsNotEnabledError())
+	}
+
+	// only the code is accepted here, otherwise the stolen recovery code gives a new full set
+	counter, isValid := s.otp.Verify(userAgg.TwoFactor.Secret, req.GetCode(), userAgg.TwoFactor.LastCounter)
+	if !isValid {
+		return nil, logger.LogPropagate(errors.NewTwoFactorCodeIsInvalidError())
+	}
+
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	userAgg.TwoFactor.RecoveryCodes = hashes
+	userAgg.TwoFactor.LastCounter = counter
+	userAgg.Timestamp.UpdatedAt = time.Now()
+
+	if _, err = s.userRepository.Update(ctx, userAgg); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// Verify will check the code or the recovery code of given user (the accepted code cannot be used again
+// and used recovery code will be burned). The user is changed only if the changes were stored.
+func (s *TwoFactorService) Verify(ctx context.Context, user *agg.User, code string, recoveryCode string) error {
+	logger := s.logger.WithContext(ctx)
+
+	if !user.IsTwoFactorEnabled() {
+		return logger.LogPropagate(errors.NewTwoFactorIsNotEnabledError())
+	}
+
+	if code != "" {
+		if counter, isValid := s.otp.Verify(user.TwoFactor.Secret, code, user.TwoFactor.LastCounter); isValid {
+			twoFactor := user.TwoFactor
+			twoFactor.LastCounter = counter
+
+			return s.updateTwoFactor(ctx, user, twoFactor)
+		}
+	}
+
+	if recoveryCode != "" {
+		recoveryCode = strings.ToLower(strings.TrimSpace(recoveryCode))
+
+		for i, hash := range user.TwoFactor.RecoveryCodes {
+			if err := s.passwordHasher.Verify(recoveryCodeHash(hash), recoveryCode); err != nil {
+				if errors.IsAuthFailedError(err) {
+					continue
+				}
+				return logger.LogPropagate(err)
+			}
+
+			// burning the used recovery code (the slice is copied, it may be shared with the cached user)
+			twoFactor := user.TwoFactor
+			twoFactor.RecoveryCodes = make([]string, 0, len(user.TwoFactor.RecoveryCodes)-1)
+			twoFactor.RecoveryCodes = append(twoFactor.RecoveryCodes, user.TwoFactor.RecoveryCodes[:i]...)
+			twoFactor.RecoveryCodes = append(twoFactor.RecoveryCodes, user.TwoFactor.RecoveryCodes[i+1:]...)
+
+			return s.updateTwoFactor(ctx, user, twoFactor)
+		}
+	}
+
+	return logger.LogPropagate(errors.NewTwoFactorCodeIsInvalidError())
+}
+
+// updateTwoFactor will store the 2FA settings of given user, the user is changed after the settings were stored,
+// so the failed update does not affect it (e.g. the recovery code which was not burned stays in the list).
+func (s *TwoFactorService) updateTwoFactor(ctx context.Context, user *agg.User, twoFactor vo.TwoFactor) error {
+	updated := *user
+	updated.TwoFactor = twoFactor
+	updated.Timestamp.UpdatedAt = time.Now()
+
+	if _, err := s.userRepository.Update(ctx, &updated); err != nil {
+		return s.logger.WithContext(ctx).LogPropagate(err)
+	}
+
+	user.TwoFactor = updated.TwoFactor
+	user.Timestamp.UpdatedAt = updated.Timestamp.UpdatedAt
+	return nil
+}
+
+// generateRecoveryCodes will return the raw codes (must be shown to a user once) and their hashes (must be stored).
+func (s *TwoFactorService) generateRecoveryCodes() (codes []string, hashes []string, err error) {
+	codes = make([]string, 0, s.recoveryCodesNumber)
+	hashes = make([]string, 0, s.recoveryCodesNumber)
+
+	for i := 0; i < s.recoveryCodesNumber; i++ {
+		b := make([]byte, recoveryCodeLength)
+		if _, err = rand.Read(b); err != nil {
+			return nil, nil, s.logger.LogPropagate(err)
+		}
+		raw := hex.EncodeToString(b)
+		code := raw[:len(raw)/2] + "-" + raw[len(raw)/2:]
+
+		hash, herr := s.passwordHasher.Hash(code)
+		if herr != nil {
+			return nil, nil, s.logger.LogPropagate(herr)
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, hash)
+	}
+
+	return codes, hashes, nil
+}
@@ -0,0 +1,133 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	domain_errors "github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	security_interface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"reflect"
+	"testing"
+)
+
+// testUserRepository - records the updated users or fails the update by the given error.
+type testUserRepository struct {
+	repository_interface.User
+	err     error
+	updated []agg.User
+}
+
+func (r *testUserRepository) Update(ctx context.Context, user *agg.User) (*agg.User, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.updated = append(r.updated, *user)
+	return user, nil
+}
+
+// testOTP - the code is valid if it's equal to the secret, the counter is always the same.
+type testOTP struct {
+	security_interface.OTP
+	counter int64
+}
+
+func (o *testOTP) Verify(secret string, code string, lastCounter int64) (counter int64, isValid bool) {
+	if code != secret || o.counter <= lastCounter {
+		return 0, false
+	}
+	return o.counter, true
+}
+
+// testHasher - the hash is equal to the password.
+type testHasher struct {
+	security_interface.PasswordHasher
+}
+
+func (h *testHasher) Verify(user security_interface.Passwordness, password string) error {
+	if user.GetPassword() != password {
+		return domain_errors.NewAuthFailedError("")
+	}
+	return nil
+}
+
+func TestTwoFactorService_Verify(t *testing.T) {
+	const (
+		secret  = "secret"
+		counter = 10
+	)
+	updateErr := errors.New("update failed")
+
+	tests := []struct {
+		name          string
+		code          string
+		recoveryCode  string
+		lastCounter   int64
+		updateErr     error
+		err           error
+		isInvalid     bool
+		lastCounterOf int64
+		recoveryCodes []string
+	}{
+		{name: "the code", code: secret, lastCounterOf: counter, recoveryCodes: []string{"a", "b", "c"}},
+		{name: "the replayed code", code: secret, lastCounter: counter, isInvalid: true, lastCounterOf: counter, recoveryCodes: []string{"a", "b", "c"}},
+		{name: "the wrong code", code: "wrong", isInvalid: true, recoveryCodes: []string{"a", "b", "c"}},
+		{name: "the recovery code", recoveryCode: " B ", recoveryCodes: []string{"a", "c"}},
+		{name: "the wrong recovery code", recoveryCode: "d", isInvalid: true, recoveryCodes: []string{"a", "b", "c"}},
+		{name: "the code is not stored", code: secret, updateErr: updateErr, err: updateErr, recoveryCodes: []string{"a", "b", "c"}},
+		{name: "the burned recovery code is not stored", recoveryCode: "b", updateErr: updateErr, err: updateErr, recoveryCodes: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &testUserRepository{err: tt.updateErr}
+			s := &TwoFactorService{
+				logger:         logger_stub.NewLogger(),
+				userRepository: repository,
+				otp:            &testOTP{counter: counter},
+				passwordHasher: &testHasher{},
+			}
+
+			// the codes are shared with another copy of the user (e.g. the cached one)
+			recoveryCodes := []string{"a", "b", "c"}
+			shared := &agg.User{User: entity.User{TwoFactor: vo.TwoFactor{
+				Enabled:       true,
+				Secret:        secret,
+				RecoveryCodes: recoveryCodes,
+				LastCounter:   tt.lastCounter,
+			}}}
+			user := *shared
+
+			err := s.Verify(context.Background(), &user, tt.code, tt.recoveryCode)
+			switch {
+			case tt.isInvalid:
+				if !domain_errors.IsTwoFactorCodeIsInvalidError(err) {
+					t.Fatalf("expected the invalid code error, got: %v", err)
+				}
+			case !errors.Is(err, tt.err):
+				t.Fatalf("expected error '%v', got '%v'", tt.err, err)
+			}
+
+			if !reflect.DeepEqual(user.TwoFactor.RecoveryCodes, tt.recoveryCodes) {
+				t.Fatalf("expected recovery codes %v, got %v", tt.recoveryCodes, user.TwoFactor.RecoveryCodes)
+			}
+			if user.TwoFactor.LastCounter != tt.lastCounterOf {
+				t.Fatalf("expected last counter %d, got %d", tt.lastCounterOf, user.TwoFactor.LastCounter)
+			}
+			if !reflect.DeepEqual(shared.TwoFactor.RecoveryCodes, []string{"a", "b", "c"}) {
+				t.Fatalf("the shared recovery codes are changed: %v", shared.TwoFactor.RecoveryCodes)
+			}
+
+			if err == nil {
+				if len(repository.updated) != 1 || !reflect.DeepEqual(repository.updated[0].TwoFactor, user.TwoFactor) {
+					t.Fatalf("the changes must be stored: %+v", repository.updated)
+				}
+			} else if len(repository.updated) != 0 {
+				t.Fatalf("the failed verification must not be stored: %+v", repository.updated)
+			}
+		})
+	}
+}
@@ -16,7 +16,6 @@ import (
 )
 
 type CRUDService struct {
-	ctx          context.Context
 	logger       logger_interface.Logger
 	builder      builder_interface.User
 	validator    validator_interface.User
@@ -30,11 +29,6 @@ func NewCRUDService(serviceContainer di_interface.ContainerManager) (*CRUDServic
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	userBuilder, err := serviceContainer.GetUserBuilder()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -56,7 +50,6 @@ func NewCRUDService(serviceContainer di_interface.ContainerManager) (*CRUDServic
 	}
 
 	return &CRUDService{
-		ctx:          ctx,
 		logger:       loggerService,
 		builder:      userBuilder,
 		validator:    userValidator,
@@ -65,102 +58,110 @@ func NewCRUDService(serviceContainer di_interface.ContainerManager) (*CRUDServic
 	}, nil
 }
 
-func (s *CRUDService) Get(req dto_interface.GetUserRequest) (user *agg.User, err error) {
+func (s *CRUDService) Get(ctx context.Context, req dto_interface.GetUserRequest) (user *agg.User, err error) {
+	logger := s.logger.WithContext(ctx)
+
 	if err = s.validator.ValidateGetRequestDTO(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	if !req.GetID().Value.IsZero() {
-		user, err = s.repository.FindOneByID(s.ctx, req)
+		user, err = s.repository.FindOneByID(ctx, req)
 		if err != nil {
-			return nil, s.logger.LogPropagate(err)
+			return nil, logger.LogPropagate(err)
 		}
 	} else if req.GetEmail() != "" {
-		user, err = s.repository.FindOneByEmail(s.ctx, req)
+		user, err = s.repository.FindOneByEmail(ctx, req)
 		if err != nil {
-			return nil, s.logger.LogPropagate(err)
+			return nil, logger.LogPropagate(err)
 		}
 	}
 
 	return user, nil
 }
 
-func (s *CRUDService) Create(req dto_interface.CreateUserRequest) (*agg.User, error) {
+func (s *CRUDService) Create(ctx context.Context, req dto_interface.CreateUserRequest) (*agg.User, error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err := s.validator.ValidateCreateRequestDTO(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// building an aggregate
-	userAgg, err := s.builder.BuildAggFromCreateRequestDTO(req)
+	userAgg, err := s.builder.BuildAggFromCreateRequestDTO(ctx, req)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// validation of an aggregate
 	if err = s.validator.ValidateAggregate(userAgg); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// saving an aggregate into storage
-	userAgg, err = s.repository.Insert(s.ctx, userAgg)
+	userAgg, err = s.repository.Insert(ctx, userAgg)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return userAgg, nil
 }
 
-func (s *CRUDService) Update(req dto_interface.UpdateUserRequest) (*agg.User, error) {
+func (s *CRUDService) Update(ctx context.Context, req dto_interface.UpdateUserRequest) (*agg.User, error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err := s.validator.ValidateUpdateRequestDTO(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// building an aggregate
-	userAgg, err := s.builder.BuildAggFromUpdateRequestDTO(req)
+	userAgg, err := s.builder.BuildAggFromUpdateRequestDTO(ctx, req)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// validation an aggregate
 	if err = s.validator.ValidateAggregate(userAgg); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// saving the updated aggregate into storage
-	userAgg, err = s.repository.Update(s.ctx, userAgg)
+	userAgg, err = s.repository.Update(ctx, userAgg)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return userAgg, nil
 }
 
-func (s *CRUDService) Delete(req dto_interface.DeleteUserRequest) (err error) {
+func (s *CRUDService) Delete(ctx context.Context, req dto_interface.DeleteUserRequest) (err error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err = s.validator.ValidateDeleteRequestDTO(req); err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
 	// fetching a user which will be deleted
-	userAgg, err := s.repository.FindOneByID(s.ctx, req)
+	userAgg, err := s.repository.FindOneByID(ctx, req)
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
 	// fetching a video list which will be deleted
-	videoAggs, total, err := s.videoService.List(&dto.VideoListRequestDTO{UserID: userAgg.ID})
+	videoAggs, total, err := s.videoService.List(ctx, &dto.VideoListRequestDTO{UserID: userAgg.ID})
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
 	// removing the references video first
 	if total > 0 {
 		for _, videoAgg := range videoAggs {
-			if err = s.videoService.Delete(dto.NewVideoDeleteRequestDto(videoAgg.ID, userAgg.ID)); err != nil {
+			if err = s.videoService.Delete(ctx, dto.NewVideoDeleteRequestDto(videoAgg.ID, userAgg.ID)); err != nil {
 				if errors.IsEntityNotFoundError(err) {
-					s.logger.Warning(
+					logger.Warning(
 						fmt.Sprintf("user delete warning: reference video '%v' is not exists", videoAgg.ID.Value),
 					)
 					continue
@@ -170,8 +171,8 @@ func (s *CRUDService) Delete(req dto_interface.DeleteUserRequest) (err error) {
 	}
 
 	// user removing
-	if err = s.repository.Remove(s.ctx, userAgg); err != nil {
-		return s.logger.LogPropagate(err)
+	if err = s.repository.Remove(ctx, userAgg); err != nil {
+		return logger.LogPropagate(err)
 	}
 
 	return nil
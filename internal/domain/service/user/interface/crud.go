@@ -1,13 +1,14 @@
 package user_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 )
 
 type CRUD interface {
-	Get(reqDTO dto_interface.GetUserRequest) (*agg.User, error)
-	Create(reqDTO dto_interface.CreateUserRequest) (*agg.User, error)
-	Update(reqDTO dto_interface.UpdateUserRequest) (*agg.User, error)
-	Delete(reqDTO dto_interface.DeleteUserRequest) error
+	Get(ctx context.Context, reqDTO dto_interface.GetUserRequest) (*agg.User, error)
+	Create(ctx context.Context, reqDTO dto_interface.CreateUserRequest) (*agg.User, error)
+	Update(ctx context.Context, reqDTO dto_interface.UpdateUserRequest) (*agg.User, error)
+	Delete(ctx context.Context, reqDTO dto_interface.DeleteUserRequest) error
 }
@@ -2,24 +2,34 @@ package video
 
 import (
 	"context"
+	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	resource_interface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"time"
 )
 
+// purgeBatchSize is a number of the trashed videos which are fetched at once by the purging.
+const purgeBatchSize = 500
+
 type CRUDService struct {
-	ctx             context.Context
-	logger          logger_interface.Logger
-	builder         builder_interface.Video
-	validator       validator_interface.Video
-	repository      repository_interface.Video
-	resourceService resource_interface.CRUD
+	logger             logger_interface.Logger
+	builder            builder_interface.Video
+	validator          validator_interface.Video
+	repository         repository_interface.Video
+	playlistRepository repository_interface.Playlist
+	historyRepository  repository_interface.WatchProgress
+	sessionRepository  repository_interface.PlaySession
+	statsRepository    repository_interface.VideoStats
+	resourceRepository repository_interface.Resource
+	resourceService    resource_interface.CRUD
 }
 
 func NewCRUDService(serviceContainer di_interface.ContainerManager) (*CRUDService, error) {
@@ -28,22 +38,42 @@ func NewCRUDService(serviceContainer di_interface.ContainerManager) (*CRUDServic
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	videoValidator, err := serviceContainer.GetVideoValidator()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	videoValidator, err := serviceContainer.GetVideoValidator()
+	videoRepository, err := serviceContainer.GetVideoRepository()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	videoRepository, err := serviceContainer.GetVideoRepository()
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressRepository, err := serviceContainer.GetWatchProgressRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playSessionRepository, err := serviceContainer.GetPlaySessionRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoStatsRepository, err := serviceContainer.GetVideoStatsRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceRepository, err := serviceContainer.GetResourceRepository()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
@@ -54,27 +84,33 @@ func NewCRUDService(serviceContainer di_interface.ContainerManager) (*CRUDServic
 	}
 
 	return &CRUDService{
-		ctx:             ctx,
-		logger:          loggerService,
-		builder:         videoBuilder,
-		validator:       videoValidator,
-		repository:      videoRepository,
-		resourceService: resourceCRUDService,
+		logger:             loggerService,
+		builder:            videoBuilder,
+		validator:          videoValidator,
+		repository:         videoRepository,
+		playlistRepository: playlistRepository,
+		historyRepository:  watchProgressRepository,
+		sessionRepository:  playSessionRepository,
+		statsRepository:    videoStatsRepository,
+		resourceRepository: resourceRepository,
+		resourceService:    resourceCRUDService,
 	}, nil
 }
 
 // Get - will fetch a single video aggregate by ID and specified user.
 // Access check to video is unnecessary because the query will fetch video only for specified user.
-func (s *CRUDService) Get(req dto_interface.GetVideoRequest) (*agg.Video, error) {
+func (s *CRUDService) Get(ctx context.Context, req dto_interface.GetVideoRequest) (*agg.Video, error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err := s.validator.ValidateGetRequestDTO(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// fetching a video by id and user
-	video, err := s.repository.FindOneByID(s.ctx, req)
+	video, err := s.repository.FindOneByID(ctx, req)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return video, nil
@@ -82,16 +118,18 @@ func (s *CRUDService) Get(req dto_interface.GetVideoRequest) (*agg.Video, error)
 
 // List - will fetch a video list of aggregates by given request and specified user.
 // Access check to video is unnecessary because the query will fetch a video list only for specified user.
-func (s *CRUDService) List(req dto_interface.ListVideoRequest) (list []*agg.Video, total int64, err error) {
+func (s *CRUDService) List(ctx context.Context, req dto_interface.ListVideoRequest) (list []*agg.Video, total int64, err error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err = s.validator.ValidateListRequestDTO(req); err != nil {
-		return nil, 0, s.logger.LogPropagate(err)
+		return nil, 0, logger.LogPropagate(err)
 	}
 
 	// fetching a video list by request params. and user
-	list, total, err = s.repository.FindList(s.ctx, req)
+	list, total, err = s.repository.FindList(ctx, req)
 	if err != nil {
-		return nil, 0, s.logger.LogPropagate(err)
+		return nil, 0, logger.LogPropagate(err)
 	}
 
 	return list, total, err
@@ -99,82 +137,227 @@ func (s *CRUDService) List(req dto_interface.ListVideoRequest) (list []*agg.Vide
 
 // Create - will make a new video by given request for specified user. Have an access check for resource
 // which exists into the request.
-func (s *CRUDService) Create(req dto_interface.CreateVideoRequest) (*agg.Video, error) {
+func (s *CRUDService) Create(ctx context.Context, req dto_interface.CreateVideoRequest) (*agg.Video, error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err := s.validator.ValidateCreateRequestDTO(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// building an aggregate
-	videoAgg, err := s.builder.BuildAggFromCreateRequestDTO(req)
+	videoAgg, err := s.builder.BuildAggFromCreateRequestDTO(ctx, req)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// validation of an aggregate
-	if err = s.validator.ValidateAggregate(videoAgg); err != nil {
-		return nil, s.logger.LogPropagate(err)
+	if err = s.validator.ValidateAggregate(ctx, videoAgg); err != nil {
+		return nil, logger.LogPropagate(err)
 	}
 
 	// saving an aggregate into storage
-	videoAgg, err = s.repository.Insert(s.ctx, videoAgg)
+	videoAgg, err = s.repository.Insert(ctx, videoAgg)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return videoAgg, nil
 }
 
 // Update - will change the video by given request. Have an access check for video.resource.
-func (s *CRUDService) Update(req dto_interface.UpdateVideoRequest) (*agg.Video, error) {
+func (s *CRUDService) Update(ctx context.Context, req dto_interface.UpdateVideoRequest) (*agg.Video, error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err := s.validator.ValidateUpdateRequestDTO(req); err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// building an aggregate
-	videoAgg, err := s.builder.BuildAggFromUpdateRequestDTO(req)
+	videoAgg, err := s.builder.BuildAggFromUpdateRequestDTO(ctx, req)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// validation of an aggregate
-	if err = s.validator.ValidateAggregate(videoAgg); err != nil {
-		return nil, s.logger.LogPropagate(err)
+	if err = s.validator.ValidateAggregate(ctx, videoAgg); err != nil {
+		return nil, logger.LogPropagate(err)
 	}
 
 	// saving updated aggregate into storage
-	videoAgg, err = s.repository.Update(s.ctx, videoAgg)
+	videoAgg, err = s.repository.Update(ctx, videoAgg)
 	if err != nil {
-		return nil, s.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return videoAgg, nil
 }
 
-// Delete - will remove the video from the storage.
-func (s *CRUDService) Delete(req dto_interface.DeleteVideoRequest) (err error) {
+// Delete - will move the video with its resource into the trash, it may be restored until it will be purged.
+// The references of the video (playlists, history, analytics) are kept, so the restored video is back in place.
+func (s *CRUDService) Delete(ctx context.Context, req dto_interface.DeleteVideoRequest) (err error) {
+	logger := s.logger.WithContext(ctx)
+
 	// validation of input request
 	if err = s.validator.ValidateDeleteRequestDTO(req); err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
 	// fetching a video which will be deleted
-	videoAgg, err := s.repository.FindOneByID(s.ctx, req)
+	videoAgg, err := s.repository.FindOneByID(ctx, req)
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
-	// the resource must be removing first
-	q := dto.NewResourceDeleteRequestDTO(videoAgg.Resource.ID, req.GetUserID())
-	if err = s.resourceService.Delete(q); err != nil {
-		return s.logger.LogPropagate(err)
+	// the video must be hidden first, it cannot be streamed without the resource
+	// (the fetched aggregate may be shared by the cache, so its copy is trashed)
+	deletedAt := time.Now()
+	trashedAgg := *videoAgg
+	trashedAgg.DeletedAt = &deletedAt
+	if err = s.repository.Trash(ctx, &trashedAgg); err != nil {
+		return logger.LogPropagate(err)
 	}
 
-	// video removing
-	if err = s.repository.Remove(s.ctx, videoAgg); err != nil {
-		return s.logger.LogPropagate(err)
+	// the resource is moved into the trash with the video, the video is brought back if it's failed,
+	// so the deletion may be retried (the compensation does not depend on the request which may be gone)
+	q := dto.NewResourceDeleteRequestDTO(videoAgg.Resource.ID, req.GetUserID())
+	if err = s.resourceService.Delete(ctx, q); err != nil {
+		if rerr := s.repository.Restore(context.WithoutCancel(ctx), videoAgg); rerr != nil {
+			// the trashed video with live resource is handled by the restoring and the purging anyway
+			logger.Error(fmt.Sprintf("unable to restore the video '%v' after the failed deletion: %v",
+				videoAgg.ID.Value.Hex(), rerr))
+		}
+		return logger.LogPropagate(err)
 	}
 
 	return nil
 }
+
+// ListTrash - will fetch the deleted videos of specified user which may be restored yet.
+func (s *CRUDService) ListTrash(
+	ctx context.Context, req dto_interface.ListTrashedVideoRequest,
+) (
+	list []*agg.Video, total int64, err error,
+) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err = s.validator.ValidateTrashListRequestDTO(req); err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	// fetching the trashed videos of the user
+	list, total, err = s.repository.FindTrashedList(ctx, req)
+	if err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+// Restore - will bring the video with its resource back from the trash. The name of the video must be still
+// unique, because the user may have created another video with the same name since the deletion.
+func (s *CRUDService) Restore(ctx context.Context, req dto_interface.RestoreVideoRequest) (*agg.Video, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// validation of input request
+	if err := s.validator.ValidateRestoreRequestDTO(req); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// fetching the trashed video
+	videoAgg, err := s.repository.FindOneTrashedByID(ctx, req)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+	videoAgg.DeletedAt = nil
+
+	// validation of an aggregate which will be visible again
+	if err = s.validator.ValidateAggregate(ctx, videoAgg); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// the resource must be restored first, it's not trashed if the deletion was interrupted
+	q := dto.NewResourceRestoreRequestDTO(videoAgg.Resource.ID, req.GetUserID())
+	if _, err = s.resourceService.Restore(ctx, q); err != nil && !errors.IsEntityNotFoundError(err) {
+		return nil, logger.LogPropagate(err)
+	}
+
+	// video restoring
+	if err = s.repository.Restore(ctx, videoAgg); err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+
+	return videoAgg, nil
+}
+
+// Purge - will permanently remove the videos which were trashed before the given time with their resources
+// and references. The failed videos are skipped (they will be purged next time), the number of purged ones is returned.
+func (s *CRUDService) Purge(ctx context.Context, before time.Time) (purged int, err error) {
+	logger := s.logger.WithContext(ctx)
+
+	for {
+		list, ferr := s.repository.FindTrashedBefore(ctx, before, purgeBatchSize)
+		if ferr != nil {
+			return purged, logger.LogPropagate(ferr)
+		}
+
+		batchPurged := 0
+		for _, videoAgg := range list {
+			if ctx.Err() != nil {
+				return purged, ctx.Err()
+			}
+			if err = s.purge(ctx, videoAgg); err != nil {
+				logger.Error(fmt.Sprintf("unable to purge the video '%v': %v", videoAgg.ID.Value.Hex(), err))
+				continue
+			}
+			batchPurged++
+		}
+		purged += batchPurged
+
+		// the failed videos are left at the start of the next batch, so it's stopped if nothing was purged
+		if len(list) < purgeBatchSize || batchPurged == 0 {
+			return purged, nil
+		}
+	}
+}
+
+// purge - removes the video with its references and resource the same way as it was deleted before the trash.
+func (s *CRUDService) purge(ctx context.Context, video *agg.Video) error {
+	// the video references must be removed from playlists too
+	if err := s.playlistRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+
+	// the watch history of the video is useless without it
+	if err := s.historyRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+
+	// the analytics of the video is useless without it too
+	if err := s.sessionRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+	if err := s.statsRepository.RemoveVideo(ctx, video); err != nil {
+		return err
+	}
+
+	// the resource is usually trashed with the video, but it's live if the deletion was interrupted
+	// and it does not exist if it was removed by the reconciliation
+	q := dto.NewResourceGetRequestDTO(video.Resource.ID, video.UserID)
+	resource, err := s.resourceRepository.FindOneTrashedByID(ctx, q)
+	if err != nil && errors.IsEntityNotFoundError(err) {
+		resource, err = s.resourceRepository.FindOneByID(ctx, q)
+	}
+	if err != nil {
+		if !errors.IsEntityNotFoundError(err) {
+			return err
+		}
+	} else if err = s.resourceService.Purge(ctx, resource); err != nil {
+		return err
+	}
+
+	// video removing
+	return s.repository.Remove(ctx, video)
+}
@@ -0,0 +1,441 @@
+package video
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	domain_errors "github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	resource_interface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	query_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+var (
+	videoNotFoundError    = domain_errors.NewEntityNotFoundError("video", "id")
+	resourceNotFoundError = domain_errors.NewEntityNotFoundError("resource", "id")
+)
+
+// testStore - the videos and resources which are shared by the repositories and the resource service,
+// the trashed records are marked by DeletedAt the same way as they are stored.
+type testStore struct {
+	videos    map[primitive.ObjectID]*agg.Video
+	resources map[primitive.ObjectID]*agg.Resource
+	// removed is a list of the removed references of videos (by the repository name)
+	removed map[string]int
+}
+
+func newTestStore() *testStore {
+	return &testStore{
+		videos:    make(map[primitive.ObjectID]*agg.Video),
+		resources: make(map[primitive.ObjectID]*agg.Resource),
+		removed:   make(map[string]int),
+	}
+}
+
+// add - stores the video with its resource, the trashedAt is applied on both if it's not zero.
+func (s *testStore) add(trashedAt time.Time, isResourceTrashed bool) *agg.Video {
+	userID := vo.NewID(primitive.NewObjectID())
+	resource := &agg.Resource{Resource: entity.Resource{ID: vo.NewID(primitive.NewObjectID()), UserID: userID}}
+	video := &agg.Video{
+		Video:    entity.Video{ID: vo.NewID(primitive.NewObjectID()), UserID: userID},
+		Resource: resource.Resource,
+	}
+	if !trashedAt.IsZero() {
+		video.DeletedAt = &trashedAt
+		if isResourceTrashed {
+			resource.DeletedAt = &trashedAt
+		}
+	}
+	s.videos[video.ID.Value] = video
+	s.resources[resource.ID.Value] = resource
+	return video
+}
+
+func (s *testStore) isVideoTrashed(video *agg.Video) (isTrashed bool, isFound bool) {
+	stored, found := s.videos[video.ID.Value]
+	return found && stored.DeletedAt != nil, found
+}
+
+func (s *testStore) isResourceTrashed(video *agg.Video) (isTrashed bool, isFound bool) {
+	stored, found := s.resources[video.Resource.ID.Value]
+	return found && stored.DeletedAt != nil, found
+}
+
+// testValidator - the requests are valid, the aggregate is validated by the given error.
+type testValidator struct {
+	validator_interface.Video
+	err error
+}
+
+func (v *testValidator) ValidateDeleteRequestDTO(req dto_interface.DeleteVideoRequest) error {
+	return nil
+}
+func (v *testValidator) ValidateRestoreRequestDTO(req dto_interface.RestoreVideoRequest) error {
+	return nil
+}
+func (v *testValidator) ValidateAggregate(ctx context.Context, video *agg.Video) error { return v.err }
+
+// testVideoRepository - the queries fail if the context is done like the real ones.
+type testVideoRepository struct {
+	repository_interface.Video
+	store *testStore
+	// restoreErr is returned by Restore (the failed compensation)
+	restoreErr error
+	// onTrash is called after the video was trashed (e.g. the request is gone)
+	onTrash func()
+	// fetched are the found videos, they may be shared by the cache, so they must not be changed
+	fetched []*agg.Video
+}
+
+func (r *testVideoRepository) find(ctx context.Context, id vo.ID, isTrashed bool) (*agg.Video, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	video, found := r.store.videos[id.Value]
+	if !found || (video.DeletedAt != nil) != isTrashed {
+		return nil, videoNotFoundError
+	}
+	copied := *video
+	r.fetched = append(r.fetched, &copied)
+	return &copied, nil
+}
+
+func (r *testVideoRepository) FindOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	return r.find(ctx, q.GetID(), false)
+}
+
+func (r *testVideoRepository) FindOneTrashedByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	return r.find(ctx, q.GetID(), true)
+}
+
+func (r *testVideoRepository) FindTrashedBefore(ctx context.Context, before time.Time, limit int64) ([]*agg.Video, error) {
+	list := []*agg.Video{}
+	for _, video := range r.store.videos {
+		if video.DeletedAt != nil && video.DeletedAt.Before(before) && int64(len(list)) < limit {
+			list = append(list, video)
+		}
+	}
+	return list, nil
+}
+
+func (r *testVideoRepository) Trash(ctx context.Context, video *agg.Video) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.videos[video.ID.Value].DeletedAt = video.DeletedAt
+	if r.onTrash != nil {
+		r.onTrash()
+	}
+	return nil
+}
+
+func (r *testVideoRepository) Restore(ctx context.Context, video *agg.Video) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.restoreErr != nil {
+		return r.restoreErr
+	}
+	r.store.videos[video.ID.Value].DeletedAt = nil
+	return nil
+}
+
+func (r *testVideoRepository) Remove(ctx context.Context, video *agg.Video) error {
+	delete(r.store.videos, video.ID.Value)
+	return nil
+}
+
+type testResourceRepository struct {
+	repository_interface.Resource
+	store *testStore
+}
+
+func (r *testResourceRepository) find(id vo.ID, isTrashed bool) (*agg.Resource, error) {
+	resource, found := r.store.resources[id.Value]
+	if !found || (resource.DeletedAt != nil) != isTrashed {
+		return nil, resourceNotFoundError
+	}
+	return resource, nil
+}
+
+func (r *testResourceRepository) FindOneByID(ctx context.Context, q query_interface.FindOneResourceByID) (*agg.Resource, error) {
+	return r.find(q.GetID(), false)
+}
+
+func (r *testResourceRepository) FindOneTrashedByID(ctx context.Context, q query_interface.FindOneResourceByID) (*agg.Resource, error) {
+	return r.find(q.GetID(), true)
+}
+
+// testResourceService - the deletion is failed by the given error or by the done context.
+type testResourceService struct {
+	resource_interface.CRUD
+	store     *testStore
+	deleteErr error
+}
+
+func (s *testResourceService) Delete(ctx context.Context, req dto_interface.DeleteResourceRequest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	deletedAt := time.Now()
+	s.store.resources[req.GetID().Value].DeletedAt = &deletedAt
+	return nil
+}
+
+func (s *testResourceService) Restore(ctx context.Context, req dto_interface.RestoreResourceRequest) (*agg.Resource, error) {
+	resource, found := s.store.resources[req.GetID().Value]
+	if !found || resource.DeletedAt == nil {
+		return nil, resourceNotFoundError
+	}
+	resource.DeletedAt = nil
+	return resource, nil
+}
+
+func (s *testResourceService) Purge(ctx context.Context, resource *agg.Resource) error {
+	delete(s.store.resources, resource.ID.Value)
+	return nil
+}
+
+// testReferences - removes the references of videos, the video with failedID cannot be purged.
+type testReferences struct {
+	store    *testStore
+	name     string
+	failedID vo.ID
+}
+
+func (r testReferences) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	if video.ID == r.failedID {
+		return errors.New("the references were not removed")
+	}
+	r.store.removed[r.name]++
+	return nil
+}
+
+type testPlaylistRepository struct {
+	repository_interface.Playlist
+	testReferences
+}
+
+func (r testPlaylistRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.testReferences.RemoveVideo(ctx, video)
+}
+
+type testWatchProgressRepository struct {
+	repository_interface.WatchProgress
+	testReferences
+}
+
+func (r testWatchProgressRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.testReferences.RemoveVideo(ctx, video)
+}
+
+type testPlaySessionRepository struct {
+	repository_interface.PlaySession
+	testReferences
+}
+
+func (r testPlaySessionRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.testReferences.RemoveVideo(ctx, video)
+}
+
+type testVideoStatsRepository struct {
+	repository_interface.VideoStats
+	testReferences
+}
+
+func (r testVideoStatsRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	return r.testReferences.RemoveVideo(ctx, video)
+}
+
+type testCRUDService struct {
+	*CRUDService
+	store           *testStore
+	videoRepository *testVideoRepository
+	resourceService *testResourceService
+	validator       *testValidator
+}
+
+func newTestCRUDService(failedID vo.ID) *testCRUDService {
+	store := newTestStore()
+	s := &testCRUDService{
+		store:           store,
+		videoRepository: &testVideoRepository{store: store},
+		resourceService: &testResourceService{store: store},
+		validator:       &testValidator{},
+	}
+	s.CRUDService = &CRUDService{
+		logger:             logger_stub.NewLogger(),
+		validator:          s.validator,
+		repository:         s.videoRepository,
+		playlistRepository: testPlaylistRepository{testReferences: testReferences{store, "playlist", failedID}},
+		historyRepository:  testWatchProgressRepository{testReferences: testReferences{store, "history", failedID}},
+		sessionRepository:  testPlaySessionRepository{testReferences: testReferences{store, "session", failedID}},
+		statsRepository:    testVideoStatsRepository{testReferences: testReferences{store, "stats", failedID}},
+		resourceRepository: &testResourceRepository{store: store},
+		resourceService:    s.resourceService,
+	}
+	return s
+}
+
+func TestCRUDService_Delete(t *testing.T) {
+	resourceErr := errors.New("the resource was not trashed")
+
+	tests := []struct {
+		name              string
+		isInterrupted     bool
+		resourceErr       error
+		restoreErr        error
+		err               error
+		isVideoTrashed    bool
+		isResourceTrashed bool
+	}{
+		{name: "the video is trashed with its resource", isVideoTrashed: true, isResourceTrashed: true},
+		{name: "the video is restored if the resource was not trashed", resourceErr: resourceErr, err: resourceErr},
+		{
+			name:           "the video stays trashed if it cannot be restored",
+			resourceErr:    resourceErr,
+			restoreErr:     errors.New("the video was not restored"),
+			err:            resourceErr,
+			isVideoTrashed: true,
+		},
+		{name: "the video is restored if the deletion was interrupted", isInterrupted: true, err: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestCRUDService(vo.ID{})
+			s.resourceService.deleteErr = tt.resourceErr
+			s.videoRepository.restoreErr = tt.restoreErr
+			video := s.store.add(time.Time{}, false)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.isInterrupted {
+				// the request is gone right after the video was trashed
+				s.videoRepository.onTrash = cancel
+			}
+
+			err := s.Delete(ctx, &dto.VideoDeleteRequestDto{ID: video.ID, UserID: video.UserID})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error '%v', got '%v'", tt.err, err)
+			}
+
+			if isTrashed, _ := s.store.isVideoTrashed(video); isTrashed != tt.isVideoTrashed {
+				t.Fatalf("expected the video trashed=%v, got %v", tt.isVideoTrashed, isTrashed)
+			}
+			if isTrashed, _ := s.store.isResourceTrashed(video); isTrashed != tt.isResourceTrashed {
+				t.Fatalf("expected the resource trashed=%v, got %v", tt.isResourceTrashed, isTrashed)
+			}
+			for _, fetched := range s.videoRepository.fetched {
+				if fetched.DeletedAt != nil {
+					t.Errorf("the fetched video was changed, deletedAt=%v", *fetched.DeletedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestCRUDService_Restore(t *testing.T) {
+	nameErr := errors.New("the name is already used")
+
+	tests := []struct {
+		name              string
+		isResourceTrashed bool
+		validationErr     error
+		err               error
+		isVideoTrashed    bool
+	}{
+		{name: "the video is restored with its resource", isResourceTrashed: true},
+		{name: "the video of interrupted deletion is restored", isResourceTrashed: false},
+		{
+			name:              "the video with the used name is not restored",
+			isResourceTrashed: true,
+			validationErr:     nameErr,
+			err:               nameErr,
+			isVideoTrashed:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestCRUDService(vo.ID{})
+			s.validator.err = tt.validationErr
+			video := s.store.add(time.Now(), tt.isResourceTrashed)
+
+			restored, err := s.Restore(context.Background(), dto.NewVideoRestoreRequestDTO(video.ID, video.UserID))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error '%v', got '%v'", tt.err, err)
+			}
+			if err == nil && restored.DeletedAt != nil {
+				t.Fatalf("the restored video must not be marked as deleted")
+			}
+
+			if isTrashed, _ := s.store.isVideoTrashed(video); isTrashed != tt.isVideoTrashed {
+				t.Fatalf("expected the video trashed=%v, got %v", tt.isVideoTrashed, isTrashed)
+			}
+			if isTrashed, _ := s.store.isResourceTrashed(video); isTrashed != (tt.isVideoTrashed && tt.isResourceTrashed) {
+				t.Fatalf("expected the resource trashed=%v, got %v", tt.isVideoTrashed && tt.isResourceTrashed, isTrashed)
+			}
+		})
+	}
+}
+
+func TestCRUDService_Purge(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	s := newTestCRUDService(vo.ID{})
+	expired := s.store.add(before.Add(-time.Minute), true)
+	interrupted := s.store.add(before.Add(-time.Minute), false)
+	reconciled := s.store.add(before.Add(-time.Minute), true)
+	delete(s.store.resources, reconciled.Resource.ID.Value)
+	retained := s.store.add(now, true)
+	live := s.store.add(time.Time{}, false)
+
+	failed := s.store.add(before.Add(-time.Minute), true)
+	s.CRUDService.playlistRepository = testPlaylistRepository{
+		testReferences: testReferences{store: s.store, name: "playlist", failedID: failed.ID},
+	}
+
+	purged, err := s.Purge(context.Background(), before)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purged != 3 {
+		t.Fatalf("expected 3 purged videos, got %d", purged)
+	}
+
+	for _, video := range []*agg.Video{expired, interrupted, reconciled} {
+		if _, found := s.store.isVideoTrashed(video); found {
+			t.Fatalf("the video '%v' must be purged", video.ID.Value.Hex())
+		}
+		if _, found := s.store.isResourceTrashed(video); found {
+			t.Fatalf("the resource of video '%v' must be purged", video.ID.Value.Hex())
+		}
+	}
+	for _, video := range []*agg.Video{retained, live, failed} {
+		if _, found := s.store.isVideoTrashed(video); !found {
+			t.Fatalf("the video '%v' must not be purged", video.ID.Value.Hex())
+		}
+		if _, found := s.store.isResourceTrashed(video); !found {
+			t.Fatalf("the resource of video '%v' must not be purged", video.ID.Value.Hex())
+		}
+	}
+	for _, name := range []string{"playlist", "history", "session", "stats"} {
+		if s.store.removed[name] != 3 {
+			t.Fatalf("expected the %v references of 3 videos are removed, got %d", name, s.store.removed[name])
+		}
+	}
+}
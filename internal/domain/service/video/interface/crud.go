@@ -1,14 +1,20 @@
 package video_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"time"
 )
 
 type CRUD interface {
-	Get(reqDTO dto_interface.GetVideoRequest) (*agg.Video, error)
-	List(reqDTO dto_interface.ListVideoRequest) (list []*agg.Video, total int64, err error)
-	Create(reqDTO dto_interface.CreateVideoRequest) (*agg.Video, error)
-	Update(reqDTO dto_interface.UpdateVideoRequest) (*agg.Video, error)
-	Delete(reqDTO dto_interface.DeleteVideoRequest) error
+	Get(ctx context.Context, reqDTO dto_interface.GetVideoRequest) (*agg.Video, error)
+	List(ctx context.Context, reqDTO dto_interface.ListVideoRequest) (list []*agg.Video, total int64, err error)
+	Create(ctx context.Context, reqDTO dto_interface.CreateVideoRequest) (*agg.Video, error)
+	Update(ctx context.Context, reqDTO dto_interface.UpdateVideoRequest) (*agg.Video, error)
+	Delete(ctx context.Context, reqDTO dto_interface.DeleteVideoRequest) error
+	ListTrash(ctx context.Context, reqDTO dto_interface.ListTrashedVideoRequest) (list []*agg.Video, total int64, err error)
+	Restore(ctx context.Context, reqDTO dto_interface.RestoreVideoRequest) (*agg.Video, error)
+	// Purge will permanently remove the videos which were trashed before the given time.
+	Purge(ctx context.Context, before time.Time) (purged int, err error)
 }
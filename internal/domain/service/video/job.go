@@ -0,0 +1,64 @@
+package video
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	video_interface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	"time"
+)
+
+// PurgeJob - permanently removes the videos which were kept in the trash longer than the retention period.
+type PurgeJob struct {
+	logger    logger_interface.Logger
+	crud      video_interface.CRUD
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewPurgeJob(serviceContainer di_interface.ContainerManager) (*PurgeJob, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PurgeJob{
+		logger:    loggerService,
+		crud:      videoCRUDService,
+		retention: cfg.TrashRetention,
+		interval:  cfg.TrashPurgeInterval,
+	}, nil
+}
+
+// Run - purges the trash once per interval until the context will be canceled. The failed purging
+// is logged only, because the next one will be attempted anyway.
+func (j *PurgeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := j.crud.Purge(ctx, time.Now().Add(-j.retention))
+			if err != nil && ctx.Err() == nil {
+				j.logger.WithContext(ctx).Error(err)
+			}
+			if purged > 0 {
+				j.logger.WithContext(ctx).Info(fmt.Sprintf("%d videos were purged from the trash", purged))
+			}
+		}
+	}
+}
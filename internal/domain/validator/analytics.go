@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+const (
+	fromField = "from"
+	toField   = "to"
+	// analyticsListMaxLimit is a max. number of videos of one page of the analytics
+	analyticsListMaxLimit = 100
+)
+
+type AnalyticsValidator struct {
+	logger logger_interface.Logger
+}
+
+func NewAnalyticsValidator(serviceContainer di_interface.ContainerManager) (*AnalyticsValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnalyticsValidator{
+		logger: loggerService,
+	}, nil
+}
+
+func (v *AnalyticsValidator) ValidateGetRequestDTO(req dto_interface.GetVideoAnalyticsRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetVideoID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(idField)
+	}
+	return v.validateRange(req.GetFrom(), req.GetTo())
+}
+
+func (v *AnalyticsValidator) ValidateListRequestDTO(req dto_interface.ListVideoAnalyticsRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetPage() < 1 {
+		return errors.NewValueMustBePositiveError(pageField)
+	}
+	if req.GetLimit() < 1 {
+		return errors.NewValueMustBePositiveError(limitField)
+	}
+	if req.GetLimit() > analyticsListMaxLimit {
+		return errors.NewTooManyValuesError(limitField, analyticsListMaxLimit)
+	}
+	if req.GetCursor() != "" {
+		if _, err := vo.DecodeCursor(req.GetCursor()); err != nil {
+			return errors.NewCursorIsInvalidError()
+		}
+	}
+	return v.validateRange(req.GetFrom(), req.GetTo())
+}
+
+func (v *AnalyticsValidator) validateRange(from time.Time, to time.Time) error {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return errors.NewRangeIsInvalidError(fromField, toField)
+	}
+	return nil
+}
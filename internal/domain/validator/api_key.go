@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+const (
+	scopesField         = "scopes"
+	apiKeyNameMaxLength = 128
+	apiKeyHashLength    = 64 // hex encoded sha256
+)
+
+type APIKeyValidator struct {
+	logger logger_interface.Logger
+}
+
+func NewAPIKeyValidator(serviceContainer di_interface.ContainerManager) (*APIKeyValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &APIKeyValidator{
+		logger: loggerService,
+	}, nil
+}
+
+func (v *APIKeyValidator) ValidateCreateRequestDTO(req dto_interface.CreateAPIKeyRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetName() == "" {
+		return errors.NewFieldCannotBeEmptyError(nameField)
+	}
+	if len(req.GetName()) > apiKeyNameMaxLength {
+		return errors.NewFieldLengthMustBeMoreOrLessError(nameField, false, apiKeyNameMaxLength)
+	}
+	if len(req.GetScopes()) == 0 {
+		return errors.NewFieldCannotBeEmptyError(scopesField)
+	}
+	for _, scope := range req.GetScopes() {
+		if !v.isSupportedScope(scope) {
+			return errors.NewScopeIsNotSupportedError(scope, enum.Scopes)
+		}
+	}
+	return nil
+}
+
+func (v *APIKeyValidator) ValidateListRequestDTO(req dto_interface.ListAPIKeyRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *APIKeyValidator) ValidateRevokeRequestDTO(req dto_interface.RevokeAPIKeyRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(idField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *APIKeyValidator) ValidateAggregate(agg *agg.APIKey) error {
+	if agg.UserID.Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if len(agg.Hash) != apiKeyHashLength {
+		return errors.NewInternalValidationError("api key hash is empty or has an unexpected length")
+	}
+	return nil
+}
+
+func (v *APIKeyValidator) isSupportedScope(scope string) bool {
+	for _, supported := range enum.Scopes {
+		if scope == supported {
+			return true
+		}
+	}
+	return false
+}
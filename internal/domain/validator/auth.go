@@ -44,12 +44,29 @@ func (v *AuthValidator) ValidateAuthRequest(req dto_interface.AuthRequest) error
 	return nil
 }
 
-// ValidateTokennessRequest is method which will check that access token header exists.
+// ValidateAuthMFARequest is method which will check the second step auth request DTO on valid.
+func (v *AuthValidator) ValidateAuthMFARequest(req dto_interface.AuthMFARequest) error {
+	if req.GetChallengeToken() == "" {
+		return errors.NewFieldCannotBeEmptyError(challengeTokenField)
+	}
+
+	if req.GetCode() == "" && req.GetRecoveryCode() == "" {
+		return errors.NewAtLeastOneFieldMustBeDefinedError(codeField, recoveryCodeField)
+	}
+
+	return nil
+}
+
+// ValidateTokennessRequest is method which will check that access token or API key header exists.
 func (v *AuthValidator) ValidateTokennessRequest(r *http.Request) error {
 	if token := r.Header.Get(enum.AccessTokenHeaderKey); token != "" {
 		return nil
 	}
 
+	if key := r.Header.Get(enum.APIKeyHeaderKey); key != "" {
+		return nil
+	}
+
 	if _, err := r.Cookie(enum.AccessTokenHeaderKey); err == nil {
 		return nil
 	}
@@ -0,0 +1,8 @@
+package validator_interface
+
+import dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+
+type Analytics interface {
+	ValidateGetRequestDTO(req dto_interface.GetVideoAnalyticsRequest) error
+	ValidateListRequestDTO(req dto_interface.ListVideoAnalyticsRequest) error
+}
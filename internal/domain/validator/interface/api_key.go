@@ -0,0 +1,13 @@
+package validator_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type APIKey interface {
+	ValidateCreateRequestDTO(req dto_interface.CreateAPIKeyRequest) error
+	ValidateListRequestDTO(req dto_interface.ListAPIKeyRequest) error
+	ValidateRevokeRequestDTO(req dto_interface.RevokeAPIKeyRequest) error
+	ValidateAggregate(agg *agg.APIKey) error
+}
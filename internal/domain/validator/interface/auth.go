@@ -7,5 +7,6 @@ import (
 
 type Auth interface {
 	ValidateAuthRequest(reqDTO dto_interface.AuthRequest) error
+	ValidateAuthMFARequest(reqDTO dto_interface.AuthMFARequest) error
 	ValidateTokennessRequest(r *http.Request) error
 }
@@ -0,0 +1,16 @@
+package validator_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Playlist interface {
+	ValidateGetRequestDTO(req dto_interface.GetPlaylistRequest) error
+	ValidateListRequestDTO(req dto_interface.ListPlaylistRequest) error
+	ValidateCreateRequestDTO(req dto_interface.CreatePlaylistRequest) error
+	ValidateUpdateRequestDTO(req dto_interface.UpdatePlaylistRequest) error
+	ValidateDeleteRequestDTO(req dto_interface.DeletePlaylistRequest) error
+	ValidateAggregate(ctx context.Context, agg *agg.Playlist) error
+}
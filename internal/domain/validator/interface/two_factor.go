@@ -0,0 +1,10 @@
+package validator_interface
+
+import dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+
+type TwoFactor interface {
+	ValidateEnableRequestDTO(reqDTO dto_interface.EnableTwoFactorRequest) error
+	ValidateConfirmRequestDTO(reqDTO dto_interface.ConfirmTwoFactorRequest) error
+	ValidateDisableRequestDTO(reqDTO dto_interface.DisableTwoFactorRequest) error
+	ValidateRegenerateRequestDTO(reqDTO dto_interface.RegenerateTwoFactorRequest) error
+}
@@ -1,6 +1,7 @@
 package validator_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 )
@@ -11,5 +12,7 @@ type Video interface {
 	ValidateCreateRequestDTO(req dto_interface.CreateVideoRequest) error
 	ValidateUpdateRequestDTO(req dto_interface.UpdateVideoRequest) error
 	ValidateDeleteRequestDTO(req dto_interface.DeleteVideoRequest) error
-	ValidateAggregate(agg *agg.Video) error
+	ValidateTrashListRequestDTO(req dto_interface.ListTrashedVideoRequest) error
+	ValidateRestoreRequestDTO(req dto_interface.RestoreVideoRequest) error
+	ValidateAggregate(ctx context.Context, agg *agg.Video) error
 }
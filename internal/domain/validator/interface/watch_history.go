@@ -0,0 +1,9 @@
+package validator_interface
+
+import dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+
+type WatchHistory interface {
+	ValidateProgressRequestDTO(req dto_interface.WatchProgressRequest) error
+	ValidateGetRequestDTO(req dto_interface.GetWatchProgressRequest) error
+	ValidateListRequestDTO(req dto_interface.ListWatchHistoryRequest) error
+}
@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	agg_interface "github.com/Borislavv/video-streaming/internal/domain/agg/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+const (
+	descriptionField          = "description"
+	videosField               = "videos"
+	playlistNameMaxLength     = 128
+	playlistVideosMaxNumber   = 1000
+	playlistDescriptionMaxLen = 1024
+)
+
+type PlaylistValidator struct {
+	logger             logger_interface.Logger
+	accessService      accessor_interface.Accessor
+	videoRepository    repository_interface.Video
+	playlistRepository repository_interface.Playlist
+}
+
+func NewPlaylistValidator(serviceContainer di_interface.ContainerManager) (*PlaylistValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PlaylistValidator{
+		logger:             loggerService,
+		accessService:      accessService,
+		videoRepository:    videoRepository,
+		playlistRepository: playlistRepository,
+	}, nil
+}
+
+func (v *PlaylistValidator) ValidateGetRequestDTO(req dto_interface.GetPlaylistRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(idField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *PlaylistValidator) ValidateListRequestDTO(req dto_interface.ListPlaylistRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *PlaylistValidator) ValidateCreateRequestDTO(req dto_interface.CreatePlaylistRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetName() == "" {
+		return errors.NewFieldCannotBeEmptyError(nameField)
+	}
+	return v.validateFields(req.GetName(), req.GetDescription(), req.GetVideoIDs())
+}
+
+func (v *PlaylistValidator) ValidateUpdateRequestDTO(req dto_interface.UpdatePlaylistRequest) error {
+	if err := v.ValidateGetRequestDTO(req); err != nil {
+		return err
+	}
+	return v.validateFields(req.GetName(), req.GetDescription(), req.GetVideoIDs())
+}
+
+func (v *PlaylistValidator) ValidateDeleteRequestDTO(req dto_interface.DeletePlaylistRequest) error {
+	return v.ValidateGetRequestDTO(req)
+}
+
+func (v *PlaylistValidator) validateFields(name string, description string, videoIDs []vo.ID) error {
+	if len(name) > playlistNameMaxLength {
+		return errors.NewFieldLengthMustBeMoreOrLessError(nameField, false, playlistNameMaxLength)
+	}
+	if len(description) > playlistDescriptionMaxLen {
+		return errors.NewFieldLengthMustBeMoreOrLessError(descriptionField, false, playlistDescriptionMaxLen)
+	}
+	if len(videoIDs) > playlistVideosMaxNumber {
+		return errors.NewTooManyValuesError(videosField, playlistVideosMaxNumber)
+	}
+
+	unique := make(map[vo.ID]struct{}, len(videoIDs))
+	for _, id := range videoIDs {
+		if id.Value.IsZero() {
+			return errors.NewFieldCannotBeEmptyError(videosField)
+		}
+		if _, found := unique[id]; found {
+			return errors.NewUniquenessCheckFailedError(videosField)
+		}
+		unique[id] = struct{}{}
+	}
+
+	return nil
+}
+
+func (v *PlaylistValidator) ValidateAggregate(ctx context.Context, agg *agg.Playlist) error {
+	logger := v.logger.WithContext(ctx)
+
+	// playlist fields validation
+	if agg.Name == "" {
+		return errors.NewInternalValidationError("'name' cannot be empty")
+	}
+	if agg.UserID.Value.IsZero() {
+		return errors.NewInternalValidationError("'userID' cannot be empty")
+	}
+
+	// playlist validation by name which must be unique
+	q := &dto.PlaylistGetRequestDTO{Name: agg.Name, UserID: agg.UserID}
+	playlist, err := v.playlistRepository.FindOneByName(ctx, q)
+	if err != nil {
+		if !errors.IsEntityNotFoundError(err) {
+			return logger.LogPropagate(err)
+		}
+	} else if agg.ID.Value.IsZero() || playlist.ID.Value != agg.ID.Value {
+		return errors.NewUniquenessCheckFailedError(nameField)
+	}
+
+	// each referenced video must exist and be available for the playlist owner
+	aggregates := make([]agg_interface.Aggregate, 0, len(agg.VideoIDs)+1)
+	aggregates = append(aggregates, agg)
+	for _, videoID := range agg.VideoIDs {
+		video, ferr := v.videoRepository.FindOneByID(ctx, dto.NewVideoGetRequestDTO(videoID, "", vo.ID{}, agg.UserID))
+		if ferr != nil {
+			return logger.LogPropagate(ferr)
+		}
+		aggregates = append(aggregates, video)
+	}
+	if err = v.accessService.IsGranted(agg.UserID, aggregates...); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+const (
+	codeField           = "code"
+	recoveryCodeField   = "recoveryCode"
+	challengeTokenField = "challengeToken"
+)
+
+type TwoFactorValidator struct {
+	logger logger_interface.Logger
+}
+
+func NewTwoFactorValidator(serviceContainer di_interface.ContainerManager) (*TwoFactorValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorValidator{
+		logger: loggerService,
+	}, nil
+}
+
+func (v *TwoFactorValidator) ValidateEnableRequestDTO(req dto_interface.EnableTwoFactorRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *TwoFactorValidator) ValidateConfirmRequestDTO(req dto_interface.ConfirmTwoFactorRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetCode() == "" {
+		return errors.NewFieldCannotBeEmptyError(codeField)
+	}
+	return nil
+}
+
+func (v *TwoFactorValidator) ValidateDisableRequestDTO(req dto_interface.DisableTwoFactorRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetCode() == "" && req.GetRecoveryCode() == "" {
+		return errors.NewAtLeastOneFieldMustBeDefinedError(codeField, recoveryCodeField)
+	}
+	return nil
+}
+
+func (v *TwoFactorValidator) ValidateRegenerateRequestDTO(req dto_interface.RegenerateTwoFactorRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetCode() == "" {
+		return errors.NewFieldCannotBeEmptyError(codeField)
+	}
+	return nil
+}
@@ -2,9 +2,11 @@ package validator
 
 import (
 	"context"
+	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
@@ -12,6 +14,7 @@ import (
 	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	validator_interface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"strings"
 )
 
 const (
@@ -19,10 +22,21 @@ const (
 	userIDField     = "userID"
 	nameField       = "name"
 	resourceIDField = "resourceID"
+
+	queryField       = "query"
+	tagsField        = "tags"
+	tagMaxLength     = 32
+	tagsMaxNumber    = 32
+	minDurationField = "minDuration"
+	maxDurationField = "maxDuration"
+	minFilesizeField = "minFilesize"
+	maxFilesizeField = "maxFilesize"
+
+	// trashListMaxLimit is a max. number of records of one page of the trash
+	trashListMaxLimit = 100
 )
 
 type VideoValidator struct {
-	ctx                context.Context
 	logger             logger_interface.Logger
 	resourceValidator  validator_interface.Resource
 	accessService      accessor_interface.Accessor
@@ -36,11 +50,6 @@ func NewVideoValidator(serviceContainer di_interface.ContainerManager) (*VideoVa
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	resourceValidatorService, err := serviceContainer.GetResourceValidator()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -62,7 +71,6 @@ func NewVideoValidator(serviceContainer di_interface.ContainerManager) (*VideoVa
 	}
 
 	return &VideoValidator{
-		ctx:                ctx,
 		logger:             loggerService,
 		resourceValidator:  resourceValidatorService,
 		accessService:      accessService,
@@ -88,9 +96,63 @@ func (v *VideoValidator) ValidateListRequestDTO(req dto_interface.ListVideoReque
 	if !req.GetCreatedAt().IsZero() && (!req.GetFrom().IsZero() || !req.GetTo().IsZero()) {
 		return errors.NewInternalValidationError("field 'from' or 'to' cannot be passed with 'createdAt'")
 	}
+	if req.GetQuery() != "" && len(req.GetQuery()) < 2 {
+		return errors.NewFieldLengthMustBeMoreOrLessError(queryField, true, 1)
+	}
+	if err := v.validateTags(req.GetTags()); err != nil {
+		return err
+	}
+	if req.GetMinDuration() < 0 {
+		return errors.NewValueMustBeNonNegativeError(minDurationField)
+	}
+	if req.GetMaxDuration() < 0 {
+		return errors.NewValueMustBeNonNegativeError(maxDurationField)
+	}
+	if req.GetMaxDuration() > 0 && req.GetMinDuration() > req.GetMaxDuration() {
+		return errors.NewRangeIsInvalidError(minDurationField, maxDurationField)
+	}
+	if req.GetMinFilesize() < 0 {
+		return errors.NewValueMustBeNonNegativeError(minFilesizeField)
+	}
+	if req.GetMaxFilesize() < 0 {
+		return errors.NewValueMustBeNonNegativeError(maxFilesizeField)
+	}
+	if req.GetMaxFilesize() > 0 && req.GetMinFilesize() > req.GetMaxFilesize() {
+		return errors.NewRangeIsInvalidError(minFilesizeField, maxFilesizeField)
+	}
+	if req.GetCursor() != "" {
+		if _, err := vo.DecodeCursor(req.GetCursor()); err != nil {
+			return errors.NewCursorIsInvalidError()
+		}
+		if !req.IsCursorPaginationAvailable() {
+			return errors.NewParametersCombinationIsInvalidError(
+				fmt.Sprintf("field 'cursor' can be used only with ordering by '%v'", enum.VideoSortByCreatedAt),
+			)
+		}
+	}
+	for _, field := range req.GetSort() {
+		field = strings.TrimPrefix(field, enum.SortDescPrefix)
+		if !v.isSupportedSortField(field) {
+			return errors.NewSortFieldIsNotSupportedError(field, enum.VideoSortFields)
+		}
+		if field == enum.VideoSortByRelevance && req.GetQuery() == "" {
+			return errors.NewParametersCombinationIsInvalidError(
+				fmt.Sprintf("sort by '%v' cannot be used without '%v'", enum.VideoSortByRelevance, queryField),
+			)
+		}
+	}
 	return nil
 }
 
+func (v *VideoValidator) isSupportedSortField(field string) bool {
+	for _, supported := range enum.VideoSortFields {
+		if field == supported {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *VideoValidator) ValidateCreateRequestDTO(req dto_interface.CreateVideoRequest) error {
 	if req.GetUserID().Value.IsZero() {
 		return errors.NewFieldCannotBeEmptyError(userIDField)
@@ -101,6 +163,9 @@ func (v *VideoValidator) ValidateCreateRequestDTO(req dto_interface.CreateVideoR
 	if req.GetResourceID().Value.IsZero() {
 		return errors.NewFieldCannotBeEmptyError(resourceIDField)
 	}
+	if err := v.validateTags(req.GetTags()); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -108,6 +173,21 @@ func (v *VideoValidator) ValidateUpdateRequestDTO(req dto_interface.UpdateVideoR
 	if err := v.ValidateGetRequestDTO(req); err != nil {
 		return err
 	}
+	if err := v.validateTags(req.GetTags()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *VideoValidator) validateTags(tags []string) error {
+	if len(tags) > tagsMaxNumber {
+		return errors.NewTooManyValuesError(tagsField, tagsMaxNumber)
+	}
+	for _, tag := range tags {
+		if len(tag) > tagMaxLength {
+			return errors.NewFieldLengthMustBeMoreOrLessError(tagsField, false, tagMaxLength)
+		}
+	}
 	return nil
 }
 
@@ -115,7 +195,34 @@ func (v *VideoValidator) ValidateDeleteRequestDTO(req dto_interface.DeleteVideoR
 	return v.ValidateGetRequestDTO(req)
 }
 
-func (v *VideoValidator) ValidateAggregate(agg *agg.Video) error {
+func (v *VideoValidator) ValidateTrashListRequestDTO(req dto_interface.ListTrashedVideoRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetPage() < 1 {
+		return errors.NewValueMustBePositiveError(pageField)
+	}
+	if req.GetLimit() < 1 {
+		return errors.NewValueMustBePositiveError(limitField)
+	}
+	if req.GetLimit() > trashListMaxLimit {
+		return errors.NewTooManyValuesError(limitField, trashListMaxLimit)
+	}
+	if req.GetCursor() != "" {
+		if _, err := vo.DecodeCursor(req.GetCursor()); err != nil {
+			return errors.NewCursorIsInvalidError()
+		}
+	}
+	return nil
+}
+
+func (v *VideoValidator) ValidateRestoreRequestDTO(req dto_interface.RestoreVideoRequest) error {
+	return v.ValidateGetRequestDTO(req)
+}
+
+func (v *VideoValidator) ValidateAggregate(ctx context.Context, agg *agg.Video) error {
+	logger := v.logger.WithContext(ctx)
+
 	// video fields validation
 	if agg.Name == "" {
 		return errors.NewInternalValidationError("'name' cannot be empty")
@@ -134,10 +241,10 @@ func (v *VideoValidator) ValidateAggregate(agg *agg.Video) error {
 
 	// video validation by name which must be unique
 	q := dto.NewVideoGetRequestDTO(vo.ID{}, agg.Name, vo.ID{}, agg.UserID)
-	video, err := v.videoRepository.FindOneByName(v.ctx, q)
+	video, err := v.videoRepository.FindOneByName(ctx, q)
 	if err != nil {
 		if !errors.IsEntityNotFoundError(err) {
-			return v.logger.LogPropagate(err)
+			return logger.LogPropagate(err)
 		}
 	} else {
 		if !agg.ID.Value.IsZero() {
@@ -151,10 +258,10 @@ func (v *VideoValidator) ValidateAggregate(agg *agg.Video) error {
 
 	// video validation by resource.id which must be unique too
 	q = dto.NewVideoGetRequestDTO(vo.ID{}, "", agg.Resource.ID, agg.UserID)
-	video, err = v.videoRepository.FindOneByResourceID(v.ctx, q)
+	video, err = v.videoRepository.FindOneByResourceID(ctx, q)
 	if err != nil {
 		if !errors.IsEntityNotFoundError(err) {
-			return v.logger.LogPropagate(err)
+			return logger.LogPropagate(err)
 		}
 	} else {
 		if !agg.ID.Value.IsZero() {
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+const (
+	videoIDField  = "videoID"
+	positionField = "position"
+	durationField = "duration"
+	pageField     = "page"
+	limitField    = "limit"
+	// historyListMaxLimit is a max. number of records of one page of the watch history
+	historyListMaxLimit = 100
+)
+
+type WatchHistoryValidator struct {
+	logger logger_interface.Logger
+}
+
+func NewWatchHistoryValidator(serviceContainer di_interface.ContainerManager) (*WatchHistoryValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &WatchHistoryValidator{
+		logger: loggerService,
+	}, nil
+}
+
+func (v *WatchHistoryValidator) ValidateProgressRequestDTO(req dto_interface.WatchProgressRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetVideoID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(videoIDField)
+	}
+	if req.GetPosition() < 0 {
+		return errors.NewValueMustBeNonNegativeError(positionField)
+	}
+	if req.GetDuration() < 0 {
+		return errors.NewValueMustBeNonNegativeError(durationField)
+	}
+	return nil
+}
+
+func (v *WatchHistoryValidator) ValidateGetRequestDTO(req dto_interface.GetWatchProgressRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetVideoID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(videoIDField)
+	}
+	return nil
+}
+
+func (v *WatchHistoryValidator) ValidateListRequestDTO(req dto_interface.ListWatchHistoryRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errors.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetPage() < 1 {
+		return errors.NewValueMustBePositiveError(pageField)
+	}
+	if req.GetLimit() < 1 {
+		return errors.NewValueMustBePositiveError(limitField)
+	}
+	if req.GetLimit() > historyListMaxLimit {
+		return errors.NewTooManyValuesError(limitField, historyListMaxLimit)
+	}
+	if req.GetCursor() != "" {
+		if _, err := vo.DecodeCursor(req.GetCursor()); err != nil {
+			return errors.NewCursorIsInvalidError()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package vo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Cursor is a position of a record into the list ordered by the sort key and _id (the time or the number
+// is set by the type of the key, e.g. createdAt or the number of plays). It is passed to the clients
+// as an opaque string and used for the keyset pagination.
+type Cursor struct {
+	ID     primitive.ObjectID `json:"id"`
+	Time   time.Time          `json:"time"`
+	Number int64              `json:"number,omitempty"`
+	IsPrev bool               `json:"prev,omitempty"` // the page before the position is requested
+}
+
+// NewCursor will make the position into the list which is ordered by the time (e.g. createdAt).
+func NewCursor(id ID, t time.Time, isPrev bool) Cursor {
+	return Cursor{ID: id.Value, Time: t, IsPrev: isPrev}
+}
+
+// NewNumberCursor will make the position into the list which is ordered by the number (e.g. plays).
+func NewNumberCursor(id ID, n int64, isPrev bool) Cursor {
+	return Cursor{ID: id.Value, Number: n, IsPrev: isPrev}
+}
+
+// DecodeCursor will make a Cursor from the opaque string.
+func DecodeCursor(cursor string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	c := Cursor{}
+	if err = json.Unmarshal(b, &c); err != nil {
+		return Cursor{}, err
+	}
+
+	return c, nil
+}
+
+// Encode will make the opaque string from the Cursor.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,8 @@
+package vo
+
+// MediaMetadata is a description of media streams of a resource which was detected after uploading.
+type MediaMetadata struct {
+	Duration   float64 `json:"duration" bson:"duration"`     // in seconds
+	AudioCodec string  `json:"audioCodec" bson:"audioCodec"` // codec name, e.g. "aac"
+	VideoCodec string  `json:"videoCodec" bson:"videoCodec"` // codec name, e.g. "h264"
+}
@@ -0,0 +1,17 @@
+package vo
+
+import "time"
+
+type TwoFactor struct {
+	Enabled       bool      `json:"enabled" bson:"enabled"`
+	Secret        string    `json:"-" bson:"secret"`
+	RecoveryCodes []string  `json:"-" bson:"recoveryCodes"` // hashes
+	ConfirmedAt   time.Time `json:"confirmedAt" bson:"confirmedAt"`
+	// LastCounter is a time step of the last accepted code, the codes of the same and earlier steps are replays.
+	LastCounter int64 `json:"-" bson:"lastCounter"`
+}
+
+// IsEnrolled will tell whether the secret was already generated (the enrolment may be not confirmed yet).
+func (t TwoFactor) IsEnrolled() bool {
+	return t.Secret != ""
+}
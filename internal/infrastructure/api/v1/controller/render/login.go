@@ -37,22 +37,24 @@ func NewLoginController(serviceContainer di_interface.ContainerManager) (*LoginC
 	}, nil
 }
 
-func (c *LoginController) Login(w http.ResponseWriter, _ *http.Request) {
+func (c *LoginController) Login(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	tplPath, err := helper.TemplatePath(LoginTemplateName)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
 	tpl, err := template.ParseFiles(tplPath)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
 	if err = tpl.Execute(w, nil); err != nil {
 		if err != nil {
-			c.responder.Respond(w, c.logger.LogPropagate(err))
+			c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 			return
 		}
 	}
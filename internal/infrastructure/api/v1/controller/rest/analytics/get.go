@@ -0,0 +1,74 @@
+package analytics
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	analytics_interface "github.com/Borislavv/video-streaming/internal/domain/service/analytics/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const GetPath = "/analytics/video/{id}"
+
+type GetController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.Analytics
+	service   analytics_interface.Analytics
+	responder response_interface.Responder
+}
+
+func NewGetController(serviceContainer di_interface.ContainerManager) (*GetController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	analyticsBuilder, err := serviceContainer.GetAnalyticsBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	analyticsService, err := serviceContainer.GetAnalyticsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &GetController{
+		logger:    loggerService,
+		builder:   analyticsBuilder,
+		service:   analyticsService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	totals, daily, err := c.service.Get(r.Context(), reqDTO)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, c.builder.BuildGetResponseDTO(totals, daily))
+}
+
+func (c *GetController) AddRoute(router *mux.Router) {
+	router.
+		Path(GetPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
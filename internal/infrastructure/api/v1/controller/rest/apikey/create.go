@@ -0,0 +1,75 @@
+package apikey
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	apikey_interface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const CreatePath = "/api-key"
+
+type CreateController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.APIKey
+	service   apikey_interface.APIKey
+	responder response_interface.Responder
+}
+
+func NewCreateController(serviceContainer di_interface.ContainerManager) (*CreateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyBuilder, err := serviceContainer.GetAPIKeyBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyService, err := serviceContainer.GetAPIKeyService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CreateController{
+		logger:    loggerService,
+		builder:   apiKeyBuilder,
+		service:   apiKeyService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	key, apiKeyAgg, err := c.service.Create(r.Context(), reqDTO)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, dto.NewAPIKeyCreateResponseDTO(key, apiKeyAgg))
+}
+
+func (c *CreateController) AddRoute(router *mux.Router) {
+	router.
+		Path(CreatePath).
+		HandlerFunc(c.Create).
+		Methods(http.MethodPost)
+}
@@ -0,0 +1,73 @@
+package apikey
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	apikey_interface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const RevokePath = "/api-key/{id}"
+
+type RevokeController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.APIKey
+	service   apikey_interface.APIKey
+	responder response_interface.Responder
+}
+
+func NewRevokeController(serviceContainer di_interface.ContainerManager) (*RevokeController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyBuilder, err := serviceContainer.GetAPIKeyBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyService, err := serviceContainer.GetAPIKeyService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &RevokeController{
+		logger:    loggerService,
+		builder:   apiKeyBuilder,
+		service:   apiKeyService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *RevokeController) Revoke(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, err := c.builder.BuildRevokeRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.Revoke(r.Context(), reqDTO); err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *RevokeController) AddRoute(router *mux.Router) {
+	router.
+		Path(RevokePath).
+		HandlerFunc(c.Revoke).
+		Methods(http.MethodDelete)
+}
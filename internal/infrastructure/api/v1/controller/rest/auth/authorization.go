@@ -2,6 +2,7 @@ package auth
 
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	authenticator_interface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
@@ -49,21 +50,23 @@ func NewAuthorizationController(serviceContainer di_interface.ContainerManager)
 }
 
 func (c *AuthorizationController) GetAccessToken(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	// building an auth. request DTO
 	req, err := c.builder.BuildAuthRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	// getting access token
-	token, err := c.authenticator.Auth(req)
+	// getting access token (or MFA challenge token)
+	token, mfaRequired, err := c.authenticator.Auth(r.Context(), req)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, token)
+	c.responder.Respond(r.Context(), w, dto.NewAuthResponseDTO(token, mfaRequired))
 }
 
 func (c *AuthorizationController) AddRoute(router *mux.Router) {
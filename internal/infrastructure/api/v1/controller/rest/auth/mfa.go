@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	authenticator_interface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const MFAAuthorizationPath = "/authorization/mfa"
+
+type MFAAuthorizationController struct {
+	logger        logger_interface.Logger
+	builder       builder_interface.Auth
+	authenticator authenticator_interface.Authenticator
+	responder     response_interface.Responder
+}
+
+func NewMFAAuthorizationController(serviceContainer di_interface.ContainerManager) (*MFAAuthorizationController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	authBuilder, err := serviceContainer.GetAuthBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &MFAAuthorizationController{
+		logger:        loggerService,
+		builder:       authBuilder,
+		authenticator: authService,
+		responder:     responseService,
+	}, nil
+}
+
+func (c *MFAAuthorizationController) ExchangeChallenge(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	// building a second step auth. request DTO
+	req, err := c.builder.BuildAuthMFARequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	// exchanging the challenge token on access token
+	token, err := c.authenticator.AuthMFA(r.Context(), req)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, dto.NewAuthResponseDTO(token, false))
+}
+
+func (c *MFAAuthorizationController) AddRoute(router *mux.Router) {
+	router.
+		Path(MFAAuthorizationPath).
+		HandlerFunc(c.ExchangeChallenge).
+		Methods(http.MethodPost)
+}
@@ -50,27 +50,29 @@ func NewRegistrationController(serviceContainer di_interface.ContainerManager) (
 
 // Registration - is an endpoint for create a new user.
 func (c *RegistrationController) Registration(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	// building a create user request DTO
 	userReqDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
 	// creating user by appropriate service
-	userAgg, err := c.service.Create(userReqDTO)
+	userAgg, err := c.service.Create(r.Context(), userReqDTO)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
 	userRespDTO, err := c.builder.BuildResponseDTO(userAgg)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, userRespDTO)
+	c.responder.Respond(r.Context(), w, userRespDTO)
 	w.WriteHeader(http.StatusCreated)
 }
 
@@ -0,0 +1,74 @@
+package history
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ListPath = "/history"
+
+type ListController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.WatchHistory
+	service   history_interface.WatchHistory
+	responder response_interface.Responder
+}
+
+func NewListController(serviceContainer di_interface.ContainerManager) (*ListController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	watchHistoryBuilder, err := serviceContainer.GetWatchHistoryBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchHistoryService, err := serviceContainer.GetWatchHistoryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ListController{
+		logger:    loggerService,
+		builder:   watchHistoryBuilder,
+		service:   watchHistoryService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, e := c.builder.BuildListRequestDTOFromRequest(r)
+	if e != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(e))
+		return
+	}
+
+	aggList, total, err := c.service.List(r.Context(), reqDTO)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, c.builder.BuildListResponseDTO(reqDTO, aggList, total))
+}
+
+func (c *ListController) AddRoute(router *mux.Router) {
+	router.
+		Path(ListPath).
+		HandlerFunc(c.List).
+		Methods(http.MethodGet)
+}
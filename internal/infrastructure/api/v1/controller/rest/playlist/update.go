@@ -0,0 +1,74 @@
+package playlist
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	playlist_interface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const UpdatePath = "/playlist/{id}"
+
+type UpdateController struct {
+	logger   logger_interface.Logger
+	builder  builder_interface.Playlist
+	service  playlist_interface.CRUD
+	response response_interface.Responder
+}
+
+func NewUpdateController(serviceContainer di_interface.ContainerManager) (*UpdateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	playlistBuilder, err := serviceContainer.GetPlaylistBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistCRUDService, err := serviceContainer.GetPlaylistCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &UpdateController{
+		logger:   loggerService,
+		builder:  playlistBuilder,
+		service:  playlistCRUDService,
+		response: responseService,
+	}, nil
+}
+
+func (c *UpdateController) Update(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	playlistDTO, err := c.builder.BuildUpdateRequestDTOFromRequest(r)
+	if err != nil {
+		c.response.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	playlistAgg, err := c.service.Update(r.Context(), playlistDTO)
+	if err != nil {
+		c.response.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.response.Respond(r.Context(), w, playlistAgg)
+}
+
+func (c *UpdateController) AddRoute(router *mux.Router) {
+	router.
+		Path(UpdatePath).
+		HandlerFunc(c.Update).
+		Methods(http.MethodPatch)
+}
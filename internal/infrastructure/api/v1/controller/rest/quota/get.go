@@ -0,0 +1,68 @@
+package quota
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const GetPath = "/quota"
+
+type GetController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.Quota
+	service   quota_interface.Quota
+	responder response_interface.Responder
+}
+
+func NewGetController(serviceContainer di_interface.ContainerManager) (*GetController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	quotaBuilder, err := serviceContainer.GetQuotaBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	quotaService, err := serviceContainer.GetQuotaService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &GetController{
+		logger:    loggerService,
+		builder:   quotaBuilder,
+		service:   quotaService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	quotaAgg, err := c.service.Get(r.Context(), c.builder.BuildGetRequestDTOFromRequest(r))
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, quotaAgg)
+}
+
+func (c *GetController) AddRoute(router *mux.Router) {
+	router.
+		Path(GetPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
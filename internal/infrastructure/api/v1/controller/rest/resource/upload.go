@@ -49,19 +49,21 @@ func NewUploadController(serviceContainer di_interface.ContainerManager) (*Uploa
 }
 
 func (c *UploadResourceController) Upload(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	reqDTO, err := c.builder.BuildUploadRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	resourceAgg, err := c.service.Upload(reqDTO)
+	resourceAgg, err := c.service.Upload(r.Context(), reqDTO)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, resourceAgg)
+	c.responder.Respond(r.Context(), w, resourceAgg)
 }
 
 func (c *UploadResourceController) AddRoute(router *mux.Router) {
@@ -0,0 +1,73 @@
+package twofactor
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	twofactor_interface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const DisablePath = "/two-factor"
+
+type DisableController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.TwoFactor
+	service   twofactor_interface.TwoFactor
+	responder response_interface.Responder
+}
+
+func NewDisableController(serviceContainer di_interface.ContainerManager) (*DisableController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorBuilder, err := serviceContainer.GetTwoFactorBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	twoFactorService, err := serviceContainer.GetTwoFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &DisableController{
+		logger:    loggerService,
+		builder:   twoFactorBuilder,
+		service:   twoFactorService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *DisableController) Disable(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, err := c.builder.BuildDisableRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.Disable(r.Context(), reqDTO); err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *DisableController) AddRoute(router *mux.Router) {
+	router.
+		Path(DisablePath).
+		HandlerFunc(c.Disable).
+		Methods(http.MethodDelete)
+}
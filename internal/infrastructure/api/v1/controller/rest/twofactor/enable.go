@@ -0,0 +1,75 @@
+package twofactor
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	twofactor_interface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const EnablePath = "/two-factor"
+
+type EnableController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.TwoFactor
+	service   twofactor_interface.TwoFactor
+	responder response_interface.Responder
+}
+
+func NewEnableController(serviceContainer di_interface.ContainerManager) (*EnableController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorBuilder, err := serviceContainer.GetTwoFactorBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	twoFactorService, err := serviceContainer.GetTwoFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &EnableController{
+		logger:    loggerService,
+		builder:   twoFactorBuilder,
+		service:   twoFactorService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *EnableController) Enable(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, err := c.builder.BuildEnableRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	secret, uri, err := c.service.Enable(r.Context(), reqDTO)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, dto.NewTwoFactorEnrolmentResponseDTO(secret, uri))
+}
+
+func (c *EnableController) AddRoute(router *mux.Router) {
+	router.
+		Path(EnablePath).
+		HandlerFunc(c.Enable).
+		Methods(http.MethodPost)
+}
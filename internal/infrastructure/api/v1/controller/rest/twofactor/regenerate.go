@@ -0,0 +1,75 @@
+package twofactor
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	twofactor_interface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const RegeneratePath = "/two-factor/recovery-codes"
+
+type RegenerateController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.TwoFactor
+	service   twofactor_interface.TwoFactor
+	responder response_interface.Responder
+}
+
+func NewRegenerateController(serviceContainer di_interface.ContainerManager) (*RegenerateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorBuilder, err := serviceContainer.GetTwoFactorBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	twoFactorService, err := serviceContainer.GetTwoFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &RegenerateController{
+		logger:    loggerService,
+		builder:   twoFactorBuilder,
+		service:   twoFactorService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *RegenerateController) Regenerate(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, err := c.builder.BuildRegenerateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	recoveryCodes, err := c.service.RegenerateRecoveryCodes(r.Context(), reqDTO)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, dto.NewTwoFactorRecoveryCodesResponseDTO(recoveryCodes))
+}
+
+func (c *RegenerateController) AddRoute(router *mux.Router) {
+	router.
+		Path(RegeneratePath).
+		HandlerFunc(c.Regenerate).
+		Methods(http.MethodPost)
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
@@ -67,44 +68,51 @@ func NewGetController(serviceContainer di_interface.ContainerManager) (*GetContr
 }
 
 func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	userReqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	userAgg, err := c.getCached(userReqDTO)
+	userAgg, err := c.getCached(r.Context(), userReqDTO)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
 	userRespDTO, err := c.builder.BuildResponseDTO(userAgg)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, userRespDTO)
+	c.responder.Respond(r.Context(), w, userRespDTO)
 }
 
-func (c *GetController) getCached(reqDTO *dto.UserGetRequestDTO) (*agg.User, error) {
+func (c *GetController) getCached(ctx context.Context, reqDTO *dto.UserGetRequestDTO) (*agg.User, error) {
+	logger := c.logger.WithContext(ctx)
+
 	key, err := json.Marshal(reqDTO)
 	if err != nil {
-		return nil, c.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	cacheKey := helper.MD5(key)
 
 	data, err := c.cacher.Get(
+		ctx,
 		cacheKey,
 		func(item cacher_interface.CacheItem) (data interface{}, err error) {
 			item.SetTTL(cacheTTL)
-			return c.service.Get(reqDTO)
+			// the user contains credentials, so it's not shared through the out-of-process storage
+			item.SetLocal()
+			return c.service.Get(ctx, reqDTO)
 		},
 	)
 	if err != nil {
-		return nil, c.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	userAgg, ok := data.(*agg.User)
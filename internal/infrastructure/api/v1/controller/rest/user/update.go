@@ -49,25 +49,27 @@ func NewUpdateUserController(serviceContainer di_interface.ContainerManager) (*U
 }
 
 func (c *UpdateController) Update(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	userReqDTO, err := c.builder.BuildUpdateRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	userAgg, err := c.service.Update(userReqDTO)
+	userAgg, err := c.service.Update(r.Context(), userReqDTO)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
 	userRespDTO, err := c.builder.BuildResponseDTO(userAgg)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, userRespDTO)
+	c.responder.Respond(r.Context(), w, userRespDTO)
 }
 
 func (c *UpdateController) AddRoute(router *mux.Router) {
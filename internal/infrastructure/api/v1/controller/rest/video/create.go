@@ -57,19 +57,21 @@ func NewCreateController(serviceContainer di_interface.ContainerManager) (*Creat
 }
 
 func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	videoDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	videoAgg, err := c.service.Create(videoDTO)
+	videoAgg, err := c.service.Create(r.Context(), videoDTO)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, videoAgg)
+	c.responder.Respond(r.Context(), w, videoAgg)
 	w.WriteHeader(http.StatusCreated)
 }
 
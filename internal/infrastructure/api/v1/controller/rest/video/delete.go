@@ -49,14 +49,16 @@ func NewDeleteController(serviceContainer di_interface.ContainerManager) (*Delet
 }
 
 func (c *DeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	reqDTO, err := c.builder.BuildDeleteRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	if err = c.service.Delete(reqDTO); err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+	if err = c.service.Delete(r.Context(), reqDTO); err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
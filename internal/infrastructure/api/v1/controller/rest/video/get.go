@@ -49,19 +49,21 @@ func NewGetController(serviceContainer di_interface.ContainerManager) (*GetContr
 }
 
 func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	videoAgg, err := c.service.Get(reqDTO)
+	videoAgg, err := c.service.Get(r.Context(), reqDTO)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, videoAgg)
+	c.responder.Respond(r.Context(), w, videoAgg)
 }
 
 func (c *GetController) AddRoute(router *mux.Router) {
@@ -49,29 +49,21 @@ func NewListController(serviceContainer di_interface.ContainerManager) (*ListCon
 }
 
 func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	reqDTO, e := c.builder.BuildListRequestDTOFromRequest(r)
 	if e != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(e))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(e))
 		return
 	}
 
-	aggList, total, err := c.service.List(reqDTO)
+	aggList, total, err := c.service.List(r.Context(), reqDTO)
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	// TODO must be refactored to paginated list DTO.
-	c.responder.Respond(w,
-		map[string]interface{}{
-			"list": aggList,
-			"pagination": map[string]interface{}{
-				"page":  reqDTO.Page,
-				"limit": reqDTO.Limit,
-				"total": total,
-			},
-		},
-	)
+	c.responder.Respond(r.Context(), w, c.builder.BuildListResponseDTO(reqDTO, aggList, total))
 }
 
 func (c *ListController) AddRoute(router *mux.Router) {
@@ -0,0 +1,74 @@
+package video
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	video_interface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const RestorePath = "/trash/video/{id}/restore"
+
+type RestoreController struct {
+	logger   logger_interface.Logger
+	builder  builder_interface.Video
+	service  video_interface.CRUD
+	response response_interface.Responder
+}
+
+func NewRestoreController(serviceContainer di_interface.ContainerManager) (*RestoreController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &RestoreController{
+		logger:   loggerService,
+		builder:  videoBuilder,
+		service:  videoCRUDService,
+		response: responseService,
+	}, nil
+}
+
+func (c *RestoreController) Restore(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	videoDTO, err := c.builder.BuildRestoreRequestDTOFromRequest(r)
+	if err != nil {
+		c.response.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.Restore(r.Context(), videoDTO)
+	if err != nil {
+		c.response.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.response.Respond(r.Context(), w, videoAgg)
+}
+
+func (c *RestoreController) AddRoute(router *mux.Router) {
+	router.
+		Path(RestorePath).
+		HandlerFunc(c.Restore).
+		Methods(http.MethodPost)
+}
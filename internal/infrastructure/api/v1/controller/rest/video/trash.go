@@ -0,0 +1,74 @@
+package video
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	video_interface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const TrashPath = "/trash/video"
+
+type TrashController struct {
+	logger    logger_interface.Logger
+	builder   builder_interface.Video
+	service   video_interface.CRUD
+	responder response_interface.Responder
+}
+
+func NewTrashController(serviceContainer di_interface.ContainerManager) (*TrashController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TrashController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *TrashController) List(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
+	reqDTO, e := c.builder.BuildTrashListRequestDTOFromRequest(r)
+	if e != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(e))
+		return
+	}
+
+	aggList, total, err := c.service.ListTrash(r.Context(), reqDTO)
+	if err != nil {
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(r.Context(), w, c.builder.BuildTrashListResponseDTO(reqDTO, aggList, total))
+}
+
+func (c *TrashController) AddRoute(router *mux.Router) {
+	router.
+		Path(TrashPath).
+		HandlerFunc(c.List).
+		Methods(http.MethodGet)
+}
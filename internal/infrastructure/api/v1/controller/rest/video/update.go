@@ -49,19 +49,21 @@ func NewUpdateController(serviceContainer di_interface.ContainerManager) (*Updat
 }
 
 func (c *UpdateController) Update(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	videoDTO, err := c.builder.BuildUpdateRequestDTOFromRequest(r)
 	if err != nil {
-		c.response.Respond(w, c.logger.LogPropagate(err))
+		c.response.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	videoAgg, err := c.service.Update(videoDTO)
+	videoAgg, err := c.service.Update(r.Context(), videoDTO)
 	if err != nil {
-		c.response.Respond(w, c.logger.LogPropagate(err))
+		c.response.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
-	c.response.Respond(w, videoAgg)
+	c.response.Respond(r.Context(), w, videoAgg)
 }
 
 func (c *UpdateController) AddRoute(router *mux.Router) {
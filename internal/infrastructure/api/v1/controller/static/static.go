@@ -35,9 +35,11 @@ func NewFilesController(serviceContainer di_interface.ContainerManager) (*FilesC
 }
 
 func (c *FilesController) Serve(w http.ResponseWriter, r *http.Request) {
+	logger := c.logger.WithContext(r.Context())
+
 	dir, err := helper.StaticFilesDir()
 	if err != nil {
-		c.responder.Respond(w, c.logger.LogPropagate(err))
+		c.responder.Respond(r.Context(), w, logger.LogPropagate(err))
 		return
 	}
 
@@ -1,8 +1,11 @@
 package response_interface
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 // Responder - response service interface
 type Responder interface {
-	Respond(w io.Writer, dataOrErr any)
+	Respond(ctx context.Context, w io.Writer, dataOrErr any)
 }
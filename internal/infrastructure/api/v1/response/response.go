@@ -1,6 +1,7 @@
 package response
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	error_interface "github.com/Borislavv/video-streaming/internal/domain/errors/interface"
@@ -43,10 +44,14 @@ func NewResponseService(serviceContainer di_interface.ContainerManager) (*Respon
 	}, nil
 }
 
-func (r *Response) Respond(w io.Writer, dataOrErr any) {
+// Respond - writes the data or error into the given writer. The given context
+// is used for logging, so the records will be bound to the current request.
+func (r *Response) Respond(ctx context.Context, w io.Writer, dataOrErr any) {
+	logger := r.logger.WithContext(ctx)
+
 	err, isErr := dataOrErr.(error)
 	if isErr {
-		r.logger.Log(err)
+		logger.Log(err)
 		publicErr, isPublicErr := err.(error_interface.PublicError)
 		if isPublicErr {
 			// handle the case when write is http.ResponseWriter
@@ -59,7 +64,7 @@ func (r *Response) Respond(w io.Writer, dataOrErr any) {
 					NewErrorResponse(publicErr),
 				),
 			); err != nil {
-				r.logger.Emergency(err)
+				logger.Emergency(err)
 			}
 		} else {
 			// handle the case when write is http.ResponseWriter
@@ -74,7 +79,7 @@ func (r *Response) Respond(w io.Writer, dataOrErr any) {
 					),
 				),
 			); err != nil {
-				r.logger.Emergency(err)
+				logger.Emergency(err)
 			}
 		}
 		return
@@ -84,14 +89,14 @@ func (r *Response) Respond(w io.Writer, dataOrErr any) {
 	resp := NewDataResponse(dataOrErr)
 	// writing a response data
 	if _, err = w.Write(r.toBytes(resp)); err != nil {
-		r.logger.Emergency(err)
+		logger.Emergency(err)
 	}
 	// logging a response
-	r.logResponse(resp)
+	r.logResponse(logger, resp)
 }
 
-func (r *Response) logResponse(resp DataResponse) {
-	r.logger.LogData(
+func (r *Response) logResponse(logger logger_interface.Logger, resp DataResponse) {
+	logger.LogData(
 		&LoggableData{
 			Date:         time.Now(),
 			Type:         LogType,
@@ -7,6 +7,7 @@ import (
 )
 
 type ServiceContainer struct {
+	parent    di_interface.Container
 	container map[reflect.Type]reflect.Value
 }
 
@@ -24,6 +25,14 @@ func NewServiceContainer(services ...any) *ServiceContainer {
 	return s
 }
 
+// NewChildServiceContainer - creates a container which looks up the services in the given parent
+// if they were not found in itself. The services which are set into the child are not visible for the parent.
+func NewChildServiceContainer(parent di_interface.Container) *ServiceContainer {
+	s := NewServiceContainer()
+	s.parent = parent
+	return s
+}
+
 func (s *ServiceContainer) Set(service any, alias reflect.Type) (self di_interface.Container) {
 	if alias == nil || alias == reflect.TypeOf(nil) {
 		alias = reflect.TypeOf(service)
@@ -33,12 +42,17 @@ func (s *ServiceContainer) Set(service any, alias reflect.Type) (self di_interfa
 }
 
 func (s *ServiceContainer) Has(key reflect.Type) (has bool) {
-	_, has = s.container[key]
+	if _, has = s.container[key]; !has && s.parent != nil {
+		return s.parent.Has(key)
+	}
 	return has
 }
 
 func (s *ServiceContainer) Get(key reflect.Type) (service reflect.Value, notFoundErr error) {
 	service, found := s.container[key]
+	if !found && s.parent != nil {
+		return s.parent.Get(key)
+	}
 	if !found {
 		return reflect.Value{}, fmt.Errorf("service not found by key '%s'", key)
 	}
@@ -10,6 +10,8 @@ const (
 	StaticDirPath    = "/internal/infrastructure/static/"
 	TemplatesDirPath = "/html/"
 	ResourcesDirPath = "/public/resources/"
+	// QuarantineDirPath is a dir. of the files which were moved out of the resources one by the reconciliation.
+	QuarantineDirPath = "/public/quarantine/"
 )
 
 func TemplatePath(template string, dirs ...string) (string, error) {
@@ -32,6 +34,10 @@ func ResourcesDir() (string, error) {
 	return path(ResourcesDirPath)
 }
 
+func QuarantineDir() (string, error) {
+	return path(QuarantineDirPath)
+}
+
 // path is a function which builts any path from root dir.
 func path(additionalPath string) (string, error) {
 	root, err := os.Getwd()
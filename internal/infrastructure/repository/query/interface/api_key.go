@@ -0,0 +1,16 @@
+package query_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneAPIKeyByID interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type FindOneAPIKeyByHash interface {
+	GetHash() string
+}
+
+type FindAPIKeyList interface {
+	GetUserID() vo.ID
+}
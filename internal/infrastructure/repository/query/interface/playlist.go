@@ -0,0 +1,17 @@
+package query_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOnePlaylistByID interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type FindOnePlaylistByName interface {
+	GetName() string
+	GetUserID() vo.ID
+}
+
+type FindPlaylistList interface {
+	GetUserID() vo.ID
+}
@@ -0,0 +1,12 @@
+package query_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneQuota interface {
+	GetUserID() vo.ID
+}
+
+// FindResourcesUsage - the total size and number of resources of the user.
+type FindResourcesUsage interface {
+	GetUserID() vo.ID
+}
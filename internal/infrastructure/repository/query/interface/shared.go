@@ -3,4 +3,5 @@ package query_interface
 type Pagination interface {
 	GetPage() int
 	GetLimit() int
+	GetCursor() string
 }
@@ -26,5 +26,21 @@ type FindVideoList interface {
 	GetCreatedAt() time.Time // concrete search date point
 	GetFrom() time.Time      // search date limit from
 	GetTo() time.Time        // search date limit to
+	GetQuery() string        // full-text search query
+	GetTags() []string       // tags which video must be marked by
+	GetMinDuration() float64 // duration limit from (seconds)
+	GetMaxDuration() float64 // duration limit to (seconds)
+	GetVideoCodec() string   // video stream codec name
+	GetAudioCodec() string   // audio stream codec name
+	GetMinFilesize() int64   // file size limit from (bytes)
+	GetMaxFilesize() int64   // file size limit to (bytes)
+	GetSort() []string       // sort fields, "-" prefix means desc. order
+	// IsCursorPaginationAvailable - whether the requested ordering allows the cursor pagination.
+	IsCursorPaginationAvailable() bool
+	Pagination
+}
+
+type FindTrashedVideoList interface {
+	GetUserID() vo.ID
 	Pagination
 }
@@ -0,0 +1,22 @@
+package query_interface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// FindOneVideoStats - the zero range means the totals of all time.
+type FindOneVideoStats interface {
+	GetUserID() vo.ID
+	GetVideoID() vo.ID
+	GetFrom() time.Time
+	GetTo() time.Time
+}
+
+// FindVideoStatsList - the zero range means the totals of all time.
+type FindVideoStatsList interface {
+	GetUserID() vo.ID
+	GetFrom() time.Time
+	GetTo() time.Time
+	Pagination
+}
@@ -0,0 +1,14 @@
+package query_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneWatchProgress interface {
+	GetUserID() vo.ID
+	GetVideoID() vo.ID
+}
+
+type FindWatchProgressList interface {
+	GetUserID() vo.ID
+	IsUnfinished() bool // only the records which may be continued
+	Pagination
+}
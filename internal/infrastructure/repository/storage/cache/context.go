@@ -0,0 +1,9 @@
+package cache
+
+import "context"
+
+// detached - returns the context of the value computation. The computation is shared by the coalesced requests
+// and the expired value is recomputed in background, so it must not be canceled together with the request.
+func detached(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
@@ -19,6 +19,7 @@ type ResourceRepository struct {
 	mongodb_interface.Resource
 	logger logger_interface.Logger
 	cache  cacher_interface.Cacher
+	ttl    time.Duration
 }
 
 func NewResourceRepository(serviceContainer di_interface.ContainerManager) (*ResourceRepository, error) {
@@ -37,41 +38,52 @@ func NewResourceRepository(serviceContainer di_interface.ContainerManager) (*Res
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &ResourceRepository{
 		Resource: mongoRepository,
 		logger:   loggerService,
 		cache:    cacheService,
+		ttl:      cfg.CacheResourceTTL,
 	}, nil
 }
 
 func (r *ResourceRepository) FindOneByID(ctx context.Context, q query_interface.FindOneResourceByID) (*agg.Resource, error) {
 	// attempt to fetch data from cache
-	if resource, err := r.findOneByID(ctx, q); err == nil {
-		return resource, nil
+	// the cached 'not found' is returned as is (negative caching)
+	if resource, err := r.findOneByID(ctx, q); err == nil || errors.IsEntityNotFoundError(err) {
+		return resource, err
 	}
-	// fetch data from storage if an error occurred
+	// fetch data from storage if another error occurred
 	return r.Resource.FindOneByID(ctx, q)
 }
 
 func (r *ResourceRepository) findOneByID(ctx context.Context, q query_interface.FindOneResourceByID) (*agg.Resource, error) {
+	logger := r.logger.WithContext(ctx)
+
 	p, err := json.Marshal(q)
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 	cacheKey := helper.MD5(p)
 
-	resourceInterface, err := r.cache.Get(cacheKey, func(item cacher_interface.CacheItem) (data interface{}, err error) {
-		item.SetTTL(time.Hour)
+	resourceInterface, err := r.cache.Get(ctx, cacheKey, func(item cacher_interface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(r.ttl)
+		ctx := detached(ctx)
 
 		resourceAgg, err := r.Resource.FindOneByID(ctx, q)
 		if err != nil {
-			return nil, r.logger.LogPropagate(err)
+			return nil, logger.LogPropagate(err)
 		}
+		item.AddTags(resourceTag(resourceAgg.ID))
 
 		return resourceAgg, nil
 	})
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	resourceAgg, ok := resourceInterface.(*agg.Resource)
@@ -83,3 +95,28 @@ func (r *ResourceRepository) findOneByID(ctx context.Context, q query_interface.
 
 	return resourceAgg, nil
 }
+
+func (r *ResourceRepository) Remove(ctx context.Context, resource *agg.Resource) error {
+	if err := r.Resource.Remove(ctx, resource); err != nil {
+		return r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	// the videos which embed the resource are invalidated too
+	r.cache.Invalidate(ctx, resourceTag(resource.ID))
+	return nil
+}
+
+func (r *ResourceRepository) Trash(ctx context.Context, resource *agg.Resource) error {
+	if err := r.Resource.Trash(ctx, resource); err != nil {
+		return r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, resourceTag(resource.ID))
+	return nil
+}
+
+func (r *ResourceRepository) Restore(ctx context.Context, resource *agg.Resource) error {
+	if err := r.Resource.Restore(ctx, resource); err != nil {
+		return r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, resourceTag(resource.ID))
+	return nil
+}
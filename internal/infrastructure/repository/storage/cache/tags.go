@@ -0,0 +1,31 @@
+package cache
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// The tags of cached entries, the write methods of repositories invalidate them, so the entries
+// which are keyed by the queries (not by identifiers) do not outlive the changed aggregates.
+
+// videoTag - marks the entries which contain the video.
+func videoTag(id vo.ID) string {
+	return "video:" + id.Value.Hex()
+}
+
+// userVideosTag - marks the lists of user videos, they are changed by any write of the user's video.
+func userVideosTag(userID vo.ID) string {
+	return "user-videos:" + userID.Value.Hex()
+}
+
+// userTag - marks the entries which contain the user.
+func userTag(id vo.ID) string {
+	return "user:" + id.Value.Hex()
+}
+
+// userEmailTag - marks the entries which were found (or not found) by the email.
+func userEmailTag(email string) string {
+	return "user-email:" + email
+}
+
+// resourceTag - marks the entries which contain the resource (the video aggregates embed it too).
+func resourceTag(id vo.ID) string {
+	return "resource:" + id.Value.Hex()
+}
@@ -19,6 +19,7 @@ type UserRepository struct {
 	mongodb_interface.User
 	logger logger_interface.Logger
 	cache  cacher_interface.Cacher
+	ttl    time.Duration
 }
 
 func NewUserRepository(serviceContainer di_interface.ContainerManager) (*UserRepository, error) {
@@ -37,44 +38,58 @@ func NewUserRepository(serviceContainer di_interface.ContainerManager) (*UserRep
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &UserRepository{
 		logger: loggerService,
 		cache:  cacheService,
 		User:   userMongoDbRepository,
+		ttl:    cfg.CacheUserTTL,
 	}, nil
 }
 
 func (r *UserRepository) FindOneByID(ctx context.Context, q query_interface.FindOneUserByID) (*agg.User, error) {
 	// attempt to fetch data from cache
-	if user, err := r.findOneByID(ctx, q); err == nil {
-		return user, nil
+	// the cached 'not found' is returned as is (negative caching)
+	if user, err := r.findOneByID(ctx, q); err == nil || errors.IsEntityNotFoundError(err) {
+		return user, err
 	}
-	// fetch data from storage if an error occurred
+	// fetch data from storage if another error occurred
 	return r.User.FindOneByID(ctx, q)
 }
 
 func (r *UserRepository) findOneByID(ctx context.Context, q query_interface.FindOneUserByID) (*agg.User, error) {
+	logger := r.logger.WithContext(ctx)
+
 	// building a cache key
 	p, err := json.Marshal(q)
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 	cacheKey := helper.MD5(p)
 
 	// fetching data from cache/storage
 	userInterface, err := r.cache.Get(
+		ctx,
 		cacheKey,
 		func(item cacher_interface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
+			item.SetTTL(r.ttl)
+			// the user contains the password hash and the two-factor secrets, so it's not shared through Redis
+			item.SetLocal()
+			ctx := detached(ctx)
 
 			userAgg, err := r.User.FindOneByID(ctx, q)
 			if err != nil {
-				return false, r.logger.LogPropagate(err)
+				return false, logger.LogPropagate(err)
 			}
+			item.AddTags(userTag(userAgg.ID))
 			return userAgg, nil
 		})
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// casting found data to struct
@@ -90,35 +105,45 @@ func (r *UserRepository) findOneByID(ctx context.Context, q query_interface.Find
 
 func (r *UserRepository) FindOneByEmail(ctx context.Context, q query_interface.FindOneUserByEmail) (*agg.User, error) {
 	// attempt to fetch data from cache
-	if user, err := r.findOneByEmail(ctx, q); err == nil {
-		return user, nil
+	// the cached 'not found' is returned as is (negative caching)
+	if user, err := r.findOneByEmail(ctx, q); err == nil || errors.IsEntityNotFoundError(err) {
+		return user, err
 	}
-	// fetch data from storage if an error occurred
+	// fetch data from storage if another error occurred
 	return r.User.FindOneByEmail(ctx, q)
 }
 
 func (r *UserRepository) findOneByEmail(ctx context.Context, q query_interface.FindOneUserByEmail) (user *agg.User, err error) {
+	logger := r.logger.WithContext(ctx)
+
 	// building a cache key
 	p, err := json.Marshal(q)
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 	cacheKey := helper.MD5(p)
 
 	// fetching data from cache/storage
 	userInterface, err := r.cache.Get(
+		ctx,
 		cacheKey,
 		func(item cacher_interface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
+			item.SetTTL(r.ttl)
+			// the user contains the password hash and the two-factor secrets, so it's not shared through Redis
+			item.SetLocal()
+			ctx := detached(ctx)
 
 			userAgg, err := r.User.FindOneByEmail(ctx, q)
 			if err != nil {
-				return nil, r.logger.LogPropagate(err)
+				// the cached 'not found' must be invalidated when the user with this email is created
+				item.AddTags(userEmailTag(q.GetEmail()))
+				return nil, logger.LogPropagate(err)
 			}
+			item.AddTags(userTag(userAgg.ID))
 			return userAgg, nil
 		})
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// casting found data to struct
@@ -131,3 +156,34 @@ func (r *UserRepository) findOneByEmail(ctx context.Context, q query_interface.F
 
 	return userAgg, nil
 }
+
+func (r *UserRepository) Insert(ctx context.Context, user *agg.User) (*agg.User, error) {
+	userAgg, err := r.User.Insert(ctx, user)
+	if err != nil {
+		return nil, r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, userEmailTag(userAgg.Email))
+	return userAgg, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *agg.User) (*agg.User, error) {
+	logger := r.logger.WithContext(ctx)
+
+	userAgg, err := r.User.Update(ctx, user)
+	if err != nil {
+		return nil, logger.LogPropagate(err)
+	}
+	// the cached user must not outlive the changes (for example, stale 2FA settings)
+	r.cache.Invalidate(ctx, userTag(user.ID), userEmailTag(user.Email))
+	return userAgg, nil
+}
+
+func (r *UserRepository) Remove(ctx context.Context, user *agg.User) error {
+	logger := r.logger.WithContext(ctx)
+
+	if err := r.User.Remove(ctx, user); err != nil {
+		return logger.LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, userTag(user.ID))
+	return nil
+}
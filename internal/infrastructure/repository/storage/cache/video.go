@@ -19,6 +19,7 @@ type VideoRepository struct {
 	mongodb_interface.Video
 	logger logger_interface.Logger
 	cache  cacher_interface.Cacher
+	ttl    time.Duration
 }
 
 func NewVideoRepository(serviceContainer di_interface.ContainerManager) (*VideoRepository, error) {
@@ -37,43 +38,56 @@ func NewVideoRepository(serviceContainer di_interface.ContainerManager) (*VideoR
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &VideoRepository{
 		cache:  cacheService,
 		logger: loggerService,
 		Video:  videoMongoDbRepository,
+		ttl:    cfg.CacheVideoTTL,
 	}, nil
 }
 
 func (r *VideoRepository) FindOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
 	// attempt to fetch data from cache
-	if video, err := r.findOneByID(ctx, q); err == nil {
-		return video, nil
+	// the cached 'not found' is returned as is (negative caching)
+	if video, err := r.findOneByID(ctx, q); err == nil || errors.IsEntityNotFoundError(err) {
+		return video, err
 	}
-	// fetch data from storage if an error occurred
+	// fetch data from storage if another error occurred
 	return r.Video.FindOneByID(ctx, q)
 }
 
 func (r *VideoRepository) findOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	p, err := json.Marshal(q)
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 	cacheKey := helper.MD5(p)
 
 	// fetching data from cache/storage
 	videoInterface, err := r.cache.Get(
+		ctx,
 		cacheKey,
 		func(item cacher_interface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
+			item.SetTTL(r.ttl)
+			ctx := detached(ctx)
 
 			videoAgg, err := r.Video.FindOneByID(ctx, q)
 			if err != nil {
-				return nil, r.logger.LogPropagate(err)
+				return nil, logger.LogPropagate(err)
 			}
+			item.AddTags(videoTags(videoAgg)...)
+
 			return videoAgg, nil
 		})
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	// casting found data to struct
@@ -97,9 +111,11 @@ func (r *VideoRepository) FindList(ctx context.Context, q query_interface.FindVi
 }
 
 func (r *VideoRepository) findList(ctx context.Context, q query_interface.FindVideoList) (list []*agg.Video, total int64, err error) {
+	logger := r.logger.WithContext(ctx)
+
 	p, err := json.Marshal(q)
 	if err != nil {
-		return nil, 0, r.logger.LogPropagate(err)
+		return nil, 0, logger.LogPropagate(err)
 	}
 	cacheKey := helper.MD5(p)
 
@@ -109,20 +125,24 @@ func (r *VideoRepository) findList(ctx context.Context, q query_interface.FindVi
 	}
 
 	responseInterface, err := r.cache.Get(
+		ctx,
 		cacheKey,
 		func(item cacher_interface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
+			item.SetTTL(r.ttl)
+			ctx := detached(ctx)
 
 			l, t, e := r.Video.FindList(ctx, q)
-			if err != nil {
-				return nil, r.logger.LogPropagate(e)
+			if e != nil {
+				return nil, logger.LogPropagate(e)
 			}
+			// the list is changed by any write of the user's video (not only of the listed ones)
+			item.AddTags(userVideosTag(q.GetUserID()))
 
 			return response{List: l, Total: t}, nil
 		},
 	)
 	if err != nil {
-		return nil, 0, r.logger.LogPropagate(err)
+		return nil, 0, logger.LogPropagate(err)
 	}
 
 	listResponse, ok := responseInterface.(response)
@@ -137,32 +157,39 @@ func (r *VideoRepository) findList(ctx context.Context, q query_interface.FindVi
 
 func (r *VideoRepository) FindOneByName(ctx context.Context, q query_interface.FindOneVideoByName) (*agg.Video, error) {
 	// attempt to fetch data from cache
-	if video, err := r.findOneByName(ctx, q); err == nil {
-		return video, nil
+	// the cached 'not found' is returned as is (negative caching)
+	if video, err := r.findOneByName(ctx, q); err == nil || errors.IsEntityNotFoundError(err) {
+		return video, err
 	}
-	// fetch data from storage if an error occurred
+	// fetch data from storage if another error occurred
 	return r.Video.FindOneByName(ctx, q)
 }
 
 func (r *VideoRepository) findOneByName(ctx context.Context, q query_interface.FindOneVideoByName) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	p, err := json.Marshal(q)
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 	cacheKey := helper.MD5(p)
 
-	videoInterface, err := r.cache.Get(cacheKey, func(item cacher_interface.CacheItem) (data interface{}, err error) {
-		item.SetTTL(time.Hour)
+	videoInterface, err := r.cache.Get(ctx, cacheKey, func(item cacher_interface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(r.ttl)
+		ctx := detached(ctx)
 
 		videoAgg, err := r.Video.FindOneByName(ctx, q)
 		if err != nil {
-			return nil, r.logger.LogPropagate(err)
+			// the cached 'not found' must be invalidated when the user creates or renames a video
+			item.AddTags(userVideosTag(q.GetUserID()))
+			return nil, logger.LogPropagate(err)
 		}
+		item.AddTags(videoTags(videoAgg)...)
 
 		return videoAgg, nil
 	})
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	videoAgg, ok := videoInterface.(*agg.Video)
@@ -177,32 +204,39 @@ func (r *VideoRepository) findOneByName(ctx context.Context, q query_interface.F
 
 func (r *VideoRepository) FindOneByResourceID(ctx context.Context, q query_interface.FindOneVideoByResourceID) (*agg.Video, error) {
 	// attempt to fetch data from cache
-	if video, err := r.findOneByResourceID(ctx, q); err == nil {
-		return video, nil
+	// the cached 'not found' is returned as is (negative caching)
+	if video, err := r.findOneByResourceID(ctx, q); err == nil || errors.IsEntityNotFoundError(err) {
+		return video, err
 	}
-	// fetch data from storage if an error occurred
+	// fetch data from storage if another error occurred
 	return r.Video.FindOneByResourceID(ctx, q)
 }
 
 func (r *VideoRepository) findOneByResourceID(ctx context.Context, q query_interface.FindOneVideoByResourceID) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	p, err := json.Marshal(q)
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 	cacheKey := helper.MD5(p)
 
-	videoInterface, err := r.cache.Get(cacheKey, func(item cacher_interface.CacheItem) (data interface{}, err error) {
-		item.SetTTL(time.Hour)
+	videoInterface, err := r.cache.Get(ctx, cacheKey, func(item cacher_interface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(r.ttl)
+		ctx := detached(ctx)
 
 		videoAgg, err := r.Video.FindOneByResourceID(ctx, q)
 		if err != nil {
-			return nil, r.logger.LogPropagate(err)
+			// the cached 'not found' must be invalidated when the user creates or renames a video
+			item.AddTags(userVideosTag(q.GetUserID()))
+			return nil, logger.LogPropagate(err)
 		}
+		item.AddTags(videoTags(videoAgg)...)
 
 		return videoAgg, nil
 	})
 	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	videoAgg, ok := videoInterface.(*agg.Video)
@@ -214,3 +248,50 @@ func (r *VideoRepository) findOneByResourceID(ctx context.Context, q query_inter
 
 	return videoAgg, nil
 }
+
+func (r *VideoRepository) Insert(ctx context.Context, video *agg.Video) (*agg.Video, error) {
+	videoAgg, err := r.Video.Insert(ctx, video)
+	if err != nil {
+		return nil, r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, userVideosTag(videoAgg.UserID))
+	return videoAgg, nil
+}
+
+func (r *VideoRepository) Update(ctx context.Context, video *agg.Video) (*agg.Video, error) {
+	videoAgg, err := r.Video.Update(ctx, video)
+	if err != nil {
+		return nil, r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, videoTag(video.ID), userVideosTag(video.UserID))
+	return videoAgg, nil
+}
+
+func (r *VideoRepository) Remove(ctx context.Context, video *agg.Video) error {
+	if err := r.Video.Remove(ctx, video); err != nil {
+		return r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, videoTag(video.ID), userVideosTag(video.UserID))
+	return nil
+}
+
+func (r *VideoRepository) Trash(ctx context.Context, video *agg.Video) error {
+	if err := r.Video.Trash(ctx, video); err != nil {
+		return r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, videoTag(video.ID), userVideosTag(video.UserID))
+	return nil
+}
+
+func (r *VideoRepository) Restore(ctx context.Context, video *agg.Video) error {
+	if err := r.Video.Restore(ctx, video); err != nil {
+		return r.logger.WithContext(ctx).LogPropagate(err)
+	}
+	r.cache.Invalidate(ctx, videoTag(video.ID), userVideosTag(video.UserID))
+	return nil
+}
+
+// videoTags - returns the tags of the entry which contains the video aggregate.
+func videoTags(video *agg.Video) []string {
+	return []string{videoTag(video.ID), userVideosTag(video.UserID), resourceTag(video.Resource.ID)}
+}
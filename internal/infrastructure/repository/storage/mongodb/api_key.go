@@ -0,0 +1,188 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const APIKeysCollection = "apiKeys"
+
+var (
+	APIKeyNotFoundByIdError       = errors.NewEntityNotFoundError("api key", "id")
+	APIKeyNotFoundByHashError     = errors.NewEntityNotFoundError("api key", "key")
+	APIKeyInsertingFailedError    = errors.NewInternalValidationError("unable to store 'api key' or get inserted 'id'")
+	APIKeyWasNotUpdatedError      = errors.NewInternalValidationError("api key was not updated")
+	APIKeyListFetchingFailedError = errors.NewInternalValidationError("unable to fetch 'api key' list")
+)
+
+type APIKeyRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  logger_interface.Logger
+	timeout time.Duration
+}
+
+func NewAPIKeyRepository(serviceContainer di_interface.ContainerManager) (*APIKeyRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &APIKeyRepository{
+		db:      mongodb.Collection(APIKeysCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *APIKeyRepository) FindOneByID(ctx context.Context, q query_interface.FindOneAPIKeyByID) (*agg.APIKey, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":      q.GetID().Value,
+		"user._id": q.GetUserID().Value,
+	}
+
+	apiKey := &agg.APIKey{}
+	if err := r.db.FindOne(qCtx, filter).Decode(apiKey); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(APIKeyNotFoundByIdError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return apiKey, nil
+}
+
+func (r *APIKeyRepository) FindOneByHash(ctx context.Context, q query_interface.FindOneAPIKeyByHash) (*agg.APIKey, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"hash": q.GetHash()}
+
+	apiKey := &agg.APIKey{}
+	if err := r.db.FindOne(qCtx, filter).Decode(apiKey); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(APIKeyNotFoundByHashError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return apiKey, nil
+}
+
+func (r *APIKeyRepository) FindList(ctx context.Context, q query_interface.FindAPIKeyList) (list []*agg.APIKey, err error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user._id": q.GetUserID().Value}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	c, err := r.db.Find(qCtx, filter, opts)
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list = []*agg.APIKey{}
+	if err = c.All(qCtx, &list); err != nil {
+		logger.Error(err)
+		return nil, logger.LogPropagate(APIKeyListFetchingFailedError)
+	}
+
+	return list, nil
+}
+
+func (r *APIKeyRepository) Insert(ctx context.Context, apiKey *agg.APIKey) (*agg.APIKey, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, apiKey, options.InsertOne())
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	if oID, ok := res.InsertedID.(primitive.ObjectID); ok {
+		q := dto.NewAPIKeyGetRequestDTO(vo.NewID(oID), apiKey.UserID, "")
+		return r.FindOneByID(qCtx, q)
+	}
+
+	return nil, logger.CriticalPropagate(APIKeyInsertingFailedError)
+}
+
+func (r *APIKeyRepository) Update(ctx context.Context, apiKey *agg.APIKey) (*agg.APIKey, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, apiKey.ID.Value, bson.M{"$set": apiKey})
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	// check the record is really updated
+	if res.ModifiedCount > 0 {
+		q := dto.NewAPIKeyGetRequestDTO(apiKey.ID, apiKey.UserID, "")
+		return r.FindOneByID(qCtx, q)
+	}
+
+	// if changes is not exists, then return the original data
+	return apiKey, nil
+}
+
+// UpdateLastUsedAt - sets only the lastUsedAt field, so it will not overwrite concurrently revoked key.
+func (r *APIKeyRepository) UpdateLastUsedAt(ctx context.Context, apiKey *agg.APIKey, usedAt time.Time) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, apiKey.ID.Value, bson.M{"$set": bson.M{"lastUsedAt": usedAt}})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return logger.LogPropagate(APIKeyWasNotUpdatedError)
+	}
+
+	return nil
+}
@@ -54,16 +54,18 @@ func NewBlockedTokenRepository(serviceContainer di_interface.ContainerManager) (
 }
 
 func (r *BlockedTokenRepository) Insert(ctx context.Context, token *agg.BlockedToken) error {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.InsertOne(qCtx, token, options.InsertOne())
 	if err != nil {
-		return r.logger.ErrorPropagate(err)
+		return logger.ErrorPropagate(err)
 	}
 
 	if _, ok := res.InsertedID.(primitive.ObjectID); !ok {
-		return r.logger.LogPropagate(
+		return logger.LogPropagate(
 			errors.NewInternalValidationError(
 				fmt.Sprintf("error occurred while inserting a blocked token '%v'", token),
 			),
@@ -74,6 +76,8 @@ func (r *BlockedTokenRepository) Insert(ctx context.Context, token *agg.BlockedT
 }
 
 func (r *BlockedTokenRepository) Has(ctx context.Context, token string) (found bool, err error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
@@ -83,7 +87,7 @@ func (r *BlockedTokenRepository) Has(ctx context.Context, token string) (found b
 		if err == mongo.ErrNoDocuments {
 			return false, nil
 		}
-		return false, r.logger.ErrorPropagate(err)
+		return false, logger.ErrorPropagate(err)
 	}
 
 	return true, nil
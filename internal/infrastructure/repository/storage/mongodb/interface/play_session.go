@@ -0,0 +1,12 @@
+package mongodb_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+)
+
+type PlaySession interface {
+	Insert(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error)
+	// RemoveVideo will remove the play sessions of the video.
+	RemoveVideo(ctx context.Context, video *agg.Video) error
+}
@@ -0,0 +1,18 @@
+package mongodb_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type Playlist interface {
+	FindOneByID(ctx context.Context, q query_interface.FindOnePlaylistByID) (*agg.Playlist, error)
+	FindOneByName(ctx context.Context, q query_interface.FindOnePlaylistByName) (*agg.Playlist, error)
+	FindList(ctx context.Context, q query_interface.FindPlaylistList) ([]*agg.Playlist, error)
+	Insert(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error)
+	Update(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error)
+	Remove(ctx context.Context, playlist *agg.Playlist) error
+	// RemoveVideo will pull the video references out of all playlists of the video owner.
+	RemoveVideo(ctx context.Context, video *agg.Video) error
+}
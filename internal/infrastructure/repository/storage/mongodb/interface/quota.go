@@ -0,0 +1,19 @@
+package mongodb_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type Quota interface {
+	FindOne(ctx context.Context, q query_interface.FindOneQuota) (*agg.Quota, error)
+	// Init will create the record of the user if it does not exist yet (the existing one is kept).
+	Init(ctx context.Context, quota *agg.Quota) error
+	// Consume will add the file to the used storage if it does not cross the limits (zero means unlimited),
+	// false is returned if the limits would be crossed.
+	Consume(ctx context.Context, userID vo.ID, filesize int64, maxBytes int64, maxVideos int64) (bool, error)
+	// Release will subtract the file from the used storage.
+	Release(ctx context.Context, userID vo.ID, filesize int64) error
+}
@@ -0,0 +1,20 @@
+package mongodb_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type VideoStats interface {
+	// FindOne will fetch the totals of the video, they are summed up from the daily rollups if the range is given.
+	FindOne(ctx context.Context, q query_interface.FindOneVideoStats) (*agg.VideoStats, error)
+	// FindDaily will fetch the daily rollups of the video within the range (from the earliest day).
+	FindDaily(ctx context.Context, q query_interface.FindOneVideoStats) ([]*agg.VideoStats, error)
+	// FindList will fetch the totals of the videos of the user (from the most played one).
+	FindList(ctx context.Context, q query_interface.FindVideoStatsList) (list []*agg.VideoStats, total int64, err error)
+	// Increment will add the play session to the totals and to the rollup of its day.
+	Increment(ctx context.Context, session *agg.PlaySession) error
+	// RemoveVideo will remove the totals and the rollups of the video.
+	RemoveVideo(ctx context.Context, video *agg.Video) error
+}
@@ -0,0 +1,18 @@
+package mongodb_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type WatchProgress interface {
+	FindOne(ctx context.Context, q query_interface.FindOneWatchProgress) (*agg.WatchProgress, error)
+	FindList(ctx context.Context, q query_interface.FindWatchProgressList) (list []*agg.WatchProgress, total int64, err error)
+	// SavePosition will upsert the position reported by client side (the sent offset is kept).
+	SavePosition(ctx context.Context, progress *agg.WatchProgress) error
+	// SaveOffset will upsert the offset sent by the server (the reported position is kept).
+	SaveOffset(ctx context.Context, progress *agg.WatchProgress) error
+	// RemoveVideo will remove the progress of the video by all users.
+	RemoveVideo(ctx context.Context, video *agg.Video) error
+}
@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
+	"go.mongodb.org/mongo-driver/event"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"sync"
+	"time"
+)
+
+// unknownCollection is used when the command is not addressed to a collection (for example: ping, endSessions).
+const unknownCollection = "none"
+
+// startedCommand - the state of command which is kept between the started and finished events.
+type startedCommand struct {
+	collection string
+	span       trace.Span
+}
+
+// NewCommandMonitor - creates the mongo client monitor which observes latency and errors of each command
+// and traces it as a child span of the operation context. The started commands are matched by the request id
+// because the collection and the operation context are passed only into the started event.
+func NewCommandMonitor(metrics metrics_interface.Metrics, tracerService tracer_interface.Tracer) *event.CommandMonitor {
+	commands := &sync.Map{}
+
+	finished := func(requestID int64, command string, duration time.Duration, failure error) {
+		collection := unknownCollection
+		if value, found := commands.LoadAndDelete(requestID); found {
+			started := value.(startedCommand)
+			collection = started.collection
+			if failure != nil {
+				tracer.Fail(started.span, failure)
+			}
+			started.span.End()
+		}
+		metrics.ObserveMongoCommand(collection, command, duration, failure != nil)
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK()
+			if !ok {
+				collection = unknownCollection
+			}
+
+			_, span := tracerService.Start(ctx, "mongo "+collection+"."+evt.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.DBSystemMongoDB,
+					semconv.DBName(evt.DatabaseName),
+					semconv.DBOperation(evt.CommandName),
+					semconv.DBMongoDBCollection(collection),
+				),
+			)
+
+			commands.Store(evt.RequestID, startedCommand{collection: collection, span: span})
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finished(evt.RequestID, evt.CommandName, evt.Duration, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finished(evt.RequestID, evt.CommandName, evt.Duration, errors.New(evt.Failure))
+		},
+	}
+}
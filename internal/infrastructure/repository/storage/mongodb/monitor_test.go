@@ -0,0 +1,162 @@
+package mongodb
+
+import (
+	"context"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"testing"
+	"time"
+)
+
+// testMetrics - records the observed mongo commands.
+type testMetrics struct {
+	metrics_interface.Metrics
+	commands []testCommand
+}
+
+type testCommand struct {
+	collection string
+	command    string
+	failed     bool
+}
+
+func (m *testMetrics) ObserveMongoCommand(collection string, command string, duration time.Duration, failed bool) {
+	m.commands = append(m.commands, testCommand{collection: collection, command: command, failed: failed})
+}
+
+// testSpan - records whether the span was ended and failed.
+type testSpan struct {
+	trace.Span
+	name     string
+	isEnded  bool
+	isFailed bool
+}
+
+func (s *testSpan) End(...trace.SpanEndOption)              { s.isEnded = true }
+func (s *testSpan) RecordError(error, ...trace.EventOption) {}
+func (s *testSpan) SetStatus(code codes.Code, _ string)     { s.isFailed = code == codes.Error }
+
+// testTracer - starts the recording spans.
+type testTracer struct {
+	tracer_interface.Tracer
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	_, span := noop.NewTracerProvider().Tracer("").Start(ctx, name)
+	recording := &testSpan{Span: span, name: name}
+	t.spans = append(t.spans, recording)
+	return ctx, recording
+}
+
+func TestNewCommandMonitor(t *testing.T) {
+	// the command document holds the collection name by the command name key (or any other value, e.g. {ping: 1})
+	started := func(requestID int64, command string, collection interface{}) *event.CommandStartedEvent {
+		raw, err := bson.Marshal(bson.D{{Key: command, Value: collection}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &event.CommandStartedEvent{Command: raw, DatabaseName: "db", CommandName: command, RequestID: requestID}
+	}
+	finished := func(requestID int64, command string) event.CommandFinishedEvent {
+		return event.CommandFinishedEvent{CommandName: command, RequestID: requestID, Duration: time.Millisecond}
+	}
+
+	tests := []struct {
+		name     string
+		run      func(monitor *event.CommandMonitor)
+		commands []testCommand
+		spans    []string
+		failed   []bool
+	}{
+		{
+			name: "succeeded command",
+			run: func(monitor *event.CommandMonitor) {
+				monitor.Started(context.Background(), started(1, "find", "videos"))
+				monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(1, "find")})
+			},
+			commands: []testCommand{{collection: "videos", command: "find"}},
+			spans:    []string{"mongo videos.find"},
+			failed:   []bool{false},
+		},
+		{
+			name: "failed command",
+			run: func(monitor *event.CommandMonitor) {
+				monitor.Started(context.Background(), started(1, "update", "users"))
+				monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished(1, "update"), Failure: "duplicate key"})
+			},
+			commands: []testCommand{{collection: "users", command: "update", failed: true}},
+			spans:    []string{"mongo users.update"},
+			failed:   []bool{true},
+		},
+		{
+			name: "interleaved commands matched by request id",
+			run: func(monitor *event.CommandMonitor) {
+				monitor.Started(context.Background(), started(1, "find", "videos"))
+				monitor.Started(context.Background(), started(2, "insert", "resources"))
+				monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(2, "insert")})
+				monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished(1, "find")})
+			},
+			commands: []testCommand{
+				{collection: "resources", command: "insert"},
+				{collection: "videos", command: "find", failed: true},
+			},
+			spans:  []string{"mongo videos.find", "mongo resources.insert"},
+			failed: []bool{true, false},
+		},
+		{
+			name: "command without collection",
+			run: func(monitor *event.CommandMonitor) {
+				monitor.Started(context.Background(), started(1, "ping", 1))
+				monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(1, "ping")})
+			},
+			commands: []testCommand{{collection: unknownCollection, command: "ping"}},
+			spans:    []string{"mongo none.ping"},
+			failed:   []bool{false},
+		},
+		{
+			name: "finished command which was not started",
+			run: func(monitor *event.CommandMonitor) {
+				monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished(1, "endSessions")})
+			},
+			commands: []testCommand{{collection: unknownCollection, command: "endSessions"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &testMetrics{}
+			tracer := &testTracer{}
+			tt.run(NewCommandMonitor(metrics, tracer))
+
+			if len(metrics.commands) != len(tt.commands) {
+				t.Fatalf("commands = %+v, want %+v", metrics.commands, tt.commands)
+			}
+			for i, command := range tt.commands {
+				if metrics.commands[i] != command {
+					t.Errorf("command #%d = %+v, want %+v", i, metrics.commands[i], command)
+				}
+			}
+
+			if len(tracer.spans) != len(tt.spans) {
+				t.Fatalf("spans = %d, want %d", len(tracer.spans), len(tt.spans))
+			}
+			for i, span := range tracer.spans {
+				if span.name != tt.spans[i] {
+					t.Errorf("span #%d = %q, want %q", i, span.name, tt.spans[i])
+				}
+				if !span.isEnded {
+					t.Errorf("span %q was not ended", span.name)
+				}
+				if span.isFailed != tt.failed[i] {
+					t.Errorf("span %q is failed = %v, want %v", span.name, span.isFailed, tt.failed[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package mongodb
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sortKey - the field by which a list is ordered, the idField makes the order stable for records with equal values.
+// The value func returns the position of the cursor by the field.
+type sortKey struct {
+	field   string
+	idField string
+	isDesc  bool
+	value   func(cursor vo.Cursor) interface{}
+}
+
+// byTime - the list ordered by the time field (e.g. createdAt).
+func byTime(field string, idField string, isDesc bool) sortKey {
+	return sortKey{field: field, idField: idField, isDesc: isDesc, value: func(cursor vo.Cursor) interface{} {
+		return cursor.Time
+	}}
+}
+
+// byNumber - the list ordered by the numeric field (e.g. plays).
+func byNumber(field string, idField string, isDesc bool) sortKey {
+	return sortKey{field: field, idField: idField, isDesc: isDesc, value: func(cursor vo.Cursor) interface{} {
+		return cursor.Number
+	}}
+}
+
+func (k sortKey) sort(order int) bson.D {
+	return bson.D{{Key: k.field, Value: order}, {Key: k.idField, Value: order}}
+}
+
+// paginate - will apply the pagination on a list query. If the cursor is passed, then the keyset pagination
+// by the sort key will be used (the given filter stays untouched, so it may be used for count the total),
+// otherwise the page/limit one (the list is ordered by the sort key if the sort is not set yet). The isReversed
+// means that fetched list must be reversed by caller because the previous page was requested and the records
+// were fetched in the opposite order.
+func paginate(
+	filter bson.M, opts *options.FindOptions, q query_interface.Pagination, key sortKey,
+) (
+	paginatedFilter bson.M, isReversed bool, err error,
+) {
+	opts.SetLimit(int64(q.GetLimit()))
+
+	if q.GetCursor() == "" {
+		opts.SetSkip((int64(q.GetPage()) - 1) * int64(q.GetLimit()))
+		if opts.Sort == nil {
+			order := 1
+			if key.isDesc {
+				order = -1
+			}
+			opts.SetSort(key.sort(order))
+		}
+		return filter, false, nil
+	}
+
+	cursor, err := vo.DecodeCursor(q.GetCursor())
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the previous page is fetched in the opposite order starting from the cursor position
+	order, operator := 1, "$gt"
+	if key.isDesc != cursor.IsPrev {
+		order, operator = -1, "$lt"
+	}
+
+	position := bson.A{
+		bson.M{key.field: bson.M{operator: key.value(cursor)}},
+		bson.M{key.field: key.value(cursor), key.idField: bson.M{operator: cursor.ID}},
+	}
+
+	paginatedFilter = bson.M{}
+	for k, v := range filter {
+		paginatedFilter[k] = v
+	}
+	if or, found := filter["$or"]; found {
+		// the filter condition must not be replaced by the position one
+		delete(paginatedFilter, "$or")
+		paginatedFilter["$and"] = bson.A{bson.M{"$or": or}, bson.M{"$or": position}}
+	} else {
+		paginatedFilter["$or"] = position
+	}
+
+	opts.SetSort(key.sort(order))
+
+	return paginatedFilter, cursor.IsPrev, nil
+}
@@ -0,0 +1,117 @@
+package mongodb
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPaginate(t *testing.T) {
+	id := primitive.NewObjectID()
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	unfinished := bson.A{bson.M{"position": bson.M{"$gt": 0}}, bson.M{"offset": bson.M{"$gt": 0}}}
+
+	positionOf := func(key sortKey, operator string, value interface{}) bson.A {
+		return bson.A{
+			bson.M{key.field: bson.M{operator: value}},
+			bson.M{key.field: value, key.idField: bson.M{operator: id}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		filter     bson.M
+		page       int
+		cursor     string
+		key        sortKey
+		expected   bson.M
+		sort       bson.D
+		skip       int64
+		isReversed bool
+	}{
+		{
+			name:     "the page is skipped by the sort key",
+			filter:   bson.M{"user._id": id},
+			page:     3,
+			key:      byTime("updatedAt", "_id", true),
+			expected: bson.M{"user._id": id},
+			sort:     bson.D{{Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}},
+			skip:     20,
+		},
+		{
+			name:   "the next page by time",
+			filter: bson.M{"user._id": id},
+			cursor: vo.NewCursor(vo.NewID(id), at, false).Encode(),
+			key:    byTime(DeletedAtField, "_id", true),
+			expected: bson.M{
+				"user._id": id,
+				"$or":      positionOf(byTime(DeletedAtField, "_id", true), "$lt", at),
+			},
+			sort: bson.D{{Key: DeletedAtField, Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name:   "the previous page by number",
+			filter: bson.M{},
+			cursor: vo.NewNumberCursor(vo.NewID(id), 42, true).Encode(),
+			key:    byNumber("plays", "video._id", true),
+			expected: bson.M{
+				"$or": positionOf(byNumber("plays", "video._id", true), "$gt", int64(42)),
+			},
+			sort:       bson.D{{Key: "plays", Value: 1}, {Key: "video._id", Value: 1}},
+			isReversed: true,
+		},
+		{
+			name:   "the filter condition is kept",
+			filter: bson.M{"$or": unfinished},
+			cursor: vo.NewCursor(vo.NewID(id), at, false).Encode(),
+			key:    byTime("updatedAt", "_id", true),
+			expected: bson.M{
+				"$and": bson.A{
+					bson.M{"$or": unfinished},
+					bson.M{"$or": positionOf(byTime("updatedAt", "_id", true), "$lt", at)},
+				},
+			},
+			sort: bson.D{{Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options.Find()
+			q := dto.PaginationRequestDTO{Page: tt.page, Limit: 10, Cursor: tt.cursor}
+
+			filter, isReversed, err := paginate(tt.filter, opts, q, tt.key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Fatalf("expected filter %v, got %v", tt.expected, filter)
+			}
+			if !reflect.DeepEqual(opts.Sort, tt.sort) {
+				t.Fatalf("expected sort %v, got %v", tt.sort, opts.Sort)
+			}
+			if (opts.Skip != nil && *opts.Skip != tt.skip) || (opts.Skip == nil && tt.skip != 0) {
+				t.Fatalf("expected skip %d, got %v", tt.skip, opts.Skip)
+			}
+			if isReversed != tt.isReversed {
+				t.Fatalf("expected reversed=%v, got %v", tt.isReversed, isReversed)
+			}
+			if _, found := tt.filter["$and"]; found {
+				t.Fatalf("the given filter must stay untouched")
+			}
+		})
+	}
+}
+
+func TestPaginate_InvalidCursor(t *testing.T) {
+	q := dto.PaginationRequestDTO{Page: 1, Limit: 10, Cursor: "invalid"}
+	if _, _, err := paginate(bson.M{}, options.Find(), q, byTime("createdAt", "_id", true)); err == nil {
+		t.Fatalf("the invalid cursor must be rejected")
+	}
+}
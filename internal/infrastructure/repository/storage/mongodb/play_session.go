@@ -0,0 +1,219 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const (
+	PlaySessionCollection = "playSessions"
+	// PlaySessionVideoIndex is a name of index which is used by fetching the sessions of a video from the last one.
+	PlaySessionVideoIndex = "play_sessions_video_started_at"
+	// PlaySessionViewerIndex is a name of index which is used by fetching the last session of a video by the viewer.
+	PlaySessionViewerIndex = "play_sessions_viewer_video_started_at"
+)
+
+var (
+	PlaySessionNotFoundError        = errors.NewEntityNotFoundError("play session", "id")
+	PlaySessionInsertingFailedError = errors.NewInternalValidationError("unable to store 'play session' or get inserted 'id'")
+)
+
+type PlaySessionRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  logger_interface.Logger
+	timeout time.Duration
+}
+
+func NewPlaySessionRepository(serviceContainer di_interface.ContainerManager) (*PlaySessionRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &PlaySessionRepository{
+		db:      mongodb.Collection(PlaySessionCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+// createIndexes - will create indexes required by queries (existing indexes will be left as is).
+func (r *PlaySessionRepository) createIndexes(ctx context.Context) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "video._id", Value: 1},
+				{Key: "startedAt", Value: -1},
+			},
+			Options: options.Index().SetName(PlaySessionVideoIndex),
+		},
+		{
+			Keys: bson.D{
+				{Key: "viewer._id", Value: 1},
+				{Key: "video._id", Value: 1},
+				{Key: "startedAt", Value: -1},
+			},
+			Options: options.Index().SetName(PlaySessionViewerIndex),
+		},
+	})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *PlaySessionRepository) Insert(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, session, options.InsertOne())
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	// the inserted session is not changed yet, so there is no reason to fetch it
+	if oID, ok := res.InsertedID.(primitive.ObjectID); ok {
+		session.ID = vo.NewID(oID)
+		return session, nil
+	}
+
+	return nil, logger.CriticalPropagate(PlaySessionInsertingFailedError)
+}
+
+func (r *PlaySessionRepository) Stop(ctx context.Context, session *agg.PlaySession) (*agg.PlaySession, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"bytes":      session.Bytes,
+			"stopReason": session.StopReason,
+			"stoppedAt":  session.StoppedAt,
+			"updatedAt":  session.Timestamp.UpdatedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	// the stopped session is fetched with the completion which was reported by client side till now
+	stopped := &agg.PlaySession{}
+	if err := r.db.FindOneAndUpdate(qCtx, bson.M{"_id": session.ID.Value}, update, opts).Decode(stopped); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(PlaySessionNotFoundError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return stopped, nil
+}
+
+func (r *PlaySessionRepository) Watch(
+	ctx context.Context, viewerID vo.ID, videoID vo.ID, completion float64, isFinished bool,
+) (*agg.PlaySession, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"viewer._id": viewerID.Value,
+		"video._id":  videoID.Value,
+	}
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "startedAt", Value: -1}}).
+		SetProjection(bson.M{"_id": 1})
+
+	last := &agg.PlaySession{}
+	if err := r.db.FindOne(qCtx, filter, opts).Decode(last); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(PlaySessionNotFoundError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	set := bson.M{
+		"completion": completion,
+		"updatedAt":  time.Now(),
+	}
+	if isFinished {
+		set["finished"] = true
+	}
+
+	// the completion is never decreased (the client side may seek back), the session is fetched
+	// as it was before the update, so the caller can tell what was changed
+	before := &agg.PlaySession{}
+	err := r.db.FindOneAndUpdate(
+		qCtx,
+		bson.M{"_id": last.ID.Value, "completion": bson.M{"$lt": completion}},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(before)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return before, nil
+}
+
+func (r *PlaySessionRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.DeleteMany(qCtx, bson.M{"video._id": video.ID.Value}); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
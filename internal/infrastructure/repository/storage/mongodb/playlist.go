@@ -0,0 +1,212 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const PlaylistsCollection = "playlists"
+
+var (
+	PlaylistNotFoundByIdError       = errors.NewEntityNotFoundError("playlist", "id")
+	PlaylistNotFoundByNameError     = errors.NewEntityNotFoundError("playlist", "name")
+	PlaylistInsertingFailedError    = errors.NewInternalValidationError("unable to store 'playlist' or get inserted 'id'")
+	PlaylistWasNotDeletedError      = errors.NewInternalValidationError("playlist was not deleted")
+	PlaylistListFetchingFailedError = errors.NewInternalValidationError("unable to fetch 'playlist' list")
+)
+
+type PlaylistRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  logger_interface.Logger
+	timeout time.Duration
+}
+
+func NewPlaylistRepository(serviceContainer di_interface.ContainerManager) (*PlaylistRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PlaylistRepository{
+		db:      mongodb.Collection(PlaylistsCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *PlaylistRepository) FindOneByID(ctx context.Context, q query_interface.FindOnePlaylistByID) (*agg.Playlist, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":      q.GetID().Value,
+		"user._id": q.GetUserID().Value,
+	}
+
+	playlist := &agg.Playlist{}
+	if err := r.db.FindOne(qCtx, filter).Decode(playlist); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(PlaylistNotFoundByIdError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return playlist, nil
+}
+
+func (r *PlaylistRepository) FindOneByName(ctx context.Context, q query_interface.FindOnePlaylistByName) (*agg.Playlist, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"name":     q.GetName(),
+		"user._id": q.GetUserID().Value,
+	}
+
+	playlist := &agg.Playlist{}
+	if err := r.db.FindOne(qCtx, filter).Decode(playlist); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, PlaylistNotFoundByNameError
+		}
+		return nil, logger.LogPropagate(err)
+	}
+
+	return playlist, nil
+}
+
+func (r *PlaylistRepository) FindList(ctx context.Context, q query_interface.FindPlaylistList) (list []*agg.Playlist, err error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user._id": q.GetUserID().Value}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	c, err := r.db.Find(qCtx, filter, opts)
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list = []*agg.Playlist{}
+	if err = c.All(qCtx, &list); err != nil {
+		logger.Error(err)
+		return nil, logger.LogPropagate(PlaylistListFetchingFailedError)
+	}
+
+	return list, nil
+}
+
+func (r *PlaylistRepository) Insert(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, playlist, options.InsertOne())
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	if oID, ok := res.InsertedID.(primitive.ObjectID); ok {
+		q := dto.NewPlaylistGetRequestDTO(vo.NewID(oID), playlist.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	return nil, logger.CriticalPropagate(PlaylistInsertingFailedError)
+}
+
+func (r *PlaylistRepository) Update(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, playlist.ID.Value, bson.M{"$set": playlist})
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	// check the record is really updated
+	if res.ModifiedCount > 0 {
+		q := dto.NewPlaylistGetRequestDTO(playlist.ID, playlist.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	// if changes is not exists, then return the original data
+	return playlist, nil
+}
+
+func (r *PlaylistRepository) Remove(ctx context.Context, playlist *agg.Playlist) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": playlist.ID.Value})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the playlist is really deleted
+		return logger.CriticalPropagate(PlaylistWasNotDeletedError)
+	}
+
+	return nil
+}
+
+func (r *PlaylistRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":   video.UserID.Value,
+		"videos._id": video.ID.Value,
+	}
+	update := bson.M{
+		"$pull": bson.M{"videos": bson.M{"_id": video.ID.Value}},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	if _, err := r.db.UpdateMany(qCtx, filter, update); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const (
+	QuotaCollection = "quotas"
+	// QuotaUniqueIndex is a name of unique index, only one record exists for each user.
+	QuotaUniqueIndex = "quotas_user"
+)
+
+var QuotaNotFoundError = errors.NewEntityNotFoundError("quota", "userID")
+
+type QuotaRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  logger_interface.Logger
+	timeout time.Duration
+}
+
+func NewQuotaRepository(serviceContainer di_interface.ContainerManager) (*QuotaRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &QuotaRepository{
+		db:      mongodb.Collection(QuotaCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+// createIndexes - will create indexes required by queries (existing indexes will be left as is).
+func (r *QuotaRepository) createIndexes(ctx context.Context) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateOne(qCtx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user._id", Value: 1}},
+		Options: options.Index().SetName(QuotaUniqueIndex).SetUnique(true),
+	})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *QuotaRepository) FindOne(ctx context.Context, q query_interface.FindOneQuota) (*agg.Quota, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	quota := &agg.Quota{}
+	if err := r.db.FindOne(qCtx, bson.M{"user._id": q.GetUserID().Value}).Decode(quota); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(QuotaNotFoundError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return quota, nil
+}
+
+func (r *QuotaRepository) Init(ctx context.Context, quota *agg.Quota) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"bytes":     quota.Bytes,
+			"videos":    quota.Videos,
+			"createdAt": quota.Timestamp.CreatedAt,
+			"updatedAt": quota.Timestamp.UpdatedAt,
+		},
+	}
+
+	// the concurrent initialization is resolved by the unique index, the first record wins
+	_, err := r.db.UpdateOne(qCtx, bson.M{"user._id": quota.UserID.Value}, update, options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *QuotaRepository) Consume(
+	ctx context.Context, userID vo.ID, filesize int64, maxBytes int64, maxVideos int64,
+) (bool, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$inc": bson.M{"bytes": filesize, "videos": int64(1)},
+		"$set": bson.M{"updatedAt": time.Now()},
+	}
+
+	res, err := r.db.UpdateOne(qCtx, r.consumeFilter(userID, filesize, maxBytes, maxVideos), update)
+	if err != nil {
+		return false, logger.ErrorPropagate(err)
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+// consumeFilter - matches the record of the user only if the file fits the limits, so the conditional $inc
+// cannot cross them even by the concurrent uploads (the file which is bigger than the limit never matches).
+func (r *QuotaRepository) consumeFilter(userID vo.ID, filesize int64, maxBytes int64, maxVideos int64) bson.M {
+	filter := bson.M{"user._id": userID.Value}
+	if maxBytes > 0 {
+		filter["bytes"] = bson.M{"$lte": maxBytes - filesize}
+	}
+	if maxVideos > 0 {
+		filter["videos"] = bson.M{"$lt": maxVideos}
+	}
+	return filter
+}
+
+func (r *QuotaRepository) Release(ctx context.Context, userID vo.ID, filesize int64) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// the used storage cannot be negative (the files may be removed before the record was initialized)
+	update := mongo.Pipeline{
+		bson.D{{Key: "$set", Value: bson.M{
+			"bytes":     bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{"$bytes", filesize}}}},
+			"videos":    bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{"$videos", 1}}}},
+			"updatedAt": time.Now(),
+		}}},
+	}
+
+	if _, err := r.db.UpdateOne(qCtx, bson.M{"user._id": userID.Value}, update); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package mongodb
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"testing"
+)
+
+func TestQuotaRepository_ConsumeFilter(t *testing.T) {
+	userID := vo.NewID(primitive.NewObjectID())
+
+	tests := []struct {
+		name      string
+		filesize  int64
+		maxBytes  int64
+		maxVideos int64
+		expected  bson.M
+	}{
+		{name: "unlimited", filesize: 10, expected: bson.M{"user._id": userID.Value}},
+		{
+			name:     "the used bytes must leave the room for the file",
+			filesize: 10,
+			maxBytes: 100,
+			expected: bson.M{"user._id": userID.Value, "bytes": bson.M{"$lte": int64(90)}},
+		},
+		{
+			name:     "the file bigger than the limit never matches",
+			filesize: 110,
+			maxBytes: 100,
+			expected: bson.M{"user._id": userID.Value, "bytes": bson.M{"$lte": int64(-10)}},
+		},
+		{
+			name:      "the number of videos must be below the limit",
+			filesize:  10,
+			maxBytes:  100,
+			maxVideos: 5,
+			expected: bson.M{
+				"user._id": userID.Value,
+				"bytes":    bson.M{"$lte": int64(90)},
+				"videos":   bson.M{"$lt": int64(5)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := (&QuotaRepository{}).consumeFilter(userID, tt.filesize, tt.maxBytes, tt.maxVideos)
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Fatalf("expected filter %v, got %v", tt.expected, filter)
+			}
+		})
+	}
+}
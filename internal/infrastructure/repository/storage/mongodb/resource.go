@@ -21,6 +21,7 @@ const ResourcesCollection = "resources"
 
 var (
 	ResourceNotFoundByIdError    = errors.NewEntityNotFoundError("resource", "id")
+	ResourceNotFoundInTrashError = errors.NewEntityNotFoundError("trashed resource", "id")
 	ResourceInsertingFailedError = errors.NewInternalRepositoryError("unable to store 'resource' or retrieve inserted 'id'")
 )
 
@@ -61,32 +62,37 @@ func NewResourceRepository(serviceContainer di_interface.ContainerManager) (*Res
 }
 
 func (r *ResourceRepository) FindOneByID(ctx context.Context, q query_interface.FindOneResourceByID) (*agg.Resource, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	filter := bson.M{
-		"_id":      q.GetID().Value,
-		"user._id": q.GetUserID().Value,
+		"_id":          q.GetID().Value,
+		"user._id":     q.GetUserID().Value,
+		DeletedAtField: notTrashed(),
 	}
 
 	resourceAgg := &agg.Resource{}
 	if err := r.db.FindOne(qCtx, filter).Decode(resourceAgg); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, r.logger.InfoPropagate(ResourceNotFoundByIdError)
+			return nil, logger.InfoPropagate(ResourceNotFoundByIdError)
 		}
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	return resourceAgg, nil
 }
 
 func (r *ResourceRepository) Insert(ctx context.Context, resource *agg.Resource) (*agg.Resource, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.InsertOne(qCtx, resource, options.InsertOne())
 	if err != nil {
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
@@ -94,20 +100,162 @@ func (r *ResourceRepository) Insert(ctx context.Context, resource *agg.Resource)
 		return r.FindOneByID(qCtx, q)
 	}
 
-	return nil, r.logger.CriticalPropagate(ResourceInsertingFailedError)
+	return nil, logger.CriticalPropagate(ResourceInsertingFailedError)
 }
 
 func (r *ResourceRepository) Remove(ctx context.Context, resource *agg.Resource) error {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": resource.ID.Value})
 	if err != nil {
-		return r.logger.ErrorPropagate(err)
+		return logger.ErrorPropagate(err)
 	}
 
 	if res.DeletedCount == 0 { // checking the resource was deleted
-		return r.logger.CriticalPropagate(UserWasNotDeletedError)
+		return logger.CriticalPropagate(UserWasNotDeletedError)
+	}
+
+	return nil
+}
+
+func (r *ResourceRepository) Trash(ctx context.Context, resource *agg.Resource) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, resource.ID.Value, bson.M{"$set": bson.M{DeletedAtField: resource.DeletedAt}})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return logger.InfoPropagate(ResourceNotFoundByIdError)
+	}
+
+	return nil
+}
+
+func (r *ResourceRepository) Restore(ctx context.Context, resource *agg.Resource) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":          resource.ID.Value,
+		DeletedAtField: trashed(),
+	}
+
+	res, err := r.db.UpdateOne(qCtx, filter, bson.M{"$unset": bson.M{DeletedAtField: ""}})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return logger.InfoPropagate(ResourceNotFoundInTrashError)
+	}
+
+	return nil
+}
+
+func (r *ResourceRepository) FindOneTrashedByID(ctx context.Context, q query_interface.FindOneResourceByID) (*agg.Resource, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":          q.GetID().Value,
+		"user._id":     q.GetUserID().Value,
+		DeletedAtField: trashed(),
+	}
+
+	resourceAgg := &agg.Resource{}
+	if err := r.db.FindOne(qCtx, filter).Decode(resourceAgg); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(ResourceNotFoundInTrashError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return resourceAgg, nil
+}
+
+func (r *ResourceRepository) FindUsage(
+	ctx context.Context, q query_interface.FindResourcesUsage,
+) (
+	size int64, count int64, err error,
+) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	c, err := r.db.Aggregate(qCtx, mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.M{"user._id": q.GetUserID().Value}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":   nil,
+			"size":  bson.M{"$sum": "$filesize"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return 0, 0, logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	usage := struct {
+		Size  int64 `bson:"size"`
+		Count int64 `bson:"count"`
+	}{}
+	// the user without resources has no group at all
+	if c.Next(qCtx) {
+		if err = c.Decode(&usage); err != nil {
+			return 0, 0, logger.ErrorPropagate(err)
+		}
+	}
+	if err = c.Err(); err != nil {
+		return 0, 0, logger.ErrorPropagate(err)
+	}
+
+	return usage.Size, usage.Count, nil
+}
+
+func (r *ResourceRepository) Walk(ctx context.Context, fn func(*agg.Resource) error) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	c, err := r.db.Find(qCtx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	for {
+		// the whole collection may be walked for a long time, so the timeout is applied to each batch
+		nCtx, nCancel := context.WithTimeout(ctx, r.timeout)
+		found := c.Next(nCtx)
+		nCancel()
+		if !found {
+			break
+		}
+
+		resourceAgg := &agg.Resource{}
+		if err = c.Decode(resourceAgg); err != nil {
+			return logger.ErrorPropagate(err)
+		}
+		if err = fn(resourceAgg); err != nil {
+			return err
+		}
+	}
+	if err = c.Err(); err != nil {
+		return logger.ErrorPropagate(err)
 	}
 
 	return nil
@@ -0,0 +1,16 @@
+package mongodb
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// DeletedAtField is set on the records which are in the trash, they are skipped by the usual queries.
+const DeletedAtField = "deletedAt"
+
+// notTrashed - the condition of DeletedAtField which matches the records out of the trash.
+func notTrashed() bson.M {
+	return bson.M{"$exists": false}
+}
+
+// trashed - the condition of DeletedAtField which matches the records in the trash.
+func trashed() bson.M {
+	return bson.M{"$exists": true}
+}
@@ -63,6 +63,8 @@ func NewUserRepository(serviceContainer di_interface.ContainerManager) (*UserRep
 }
 
 func (r *UserRepository) FindOneByID(ctx context.Context, q query_interface.FindOneUserByID) (user *agg.User, err error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
@@ -71,15 +73,17 @@ func (r *UserRepository) FindOneByID(ctx context.Context, q query_interface.Find
 	user = &agg.User{}
 	if err = r.db.FindOne(qCtx, filter).Decode(user); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, r.logger.InfoPropagate(UserNotFoundByIdError)
+			return nil, logger.InfoPropagate(UserNotFoundByIdError)
 		}
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	return user, nil
 }
 
 func (r *UserRepository) FindOneByEmail(ctx context.Context, q query_interface.FindOneUserByEmail) (user *agg.User, err error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
@@ -88,21 +92,23 @@ func (r *UserRepository) FindOneByEmail(ctx context.Context, q query_interface.F
 	user = &agg.User{}
 	if err = r.db.FindOne(qCtx, filter).Decode(user); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, r.logger.InfoPropagate(UserNotFoundByEmailError)
+			return nil, logger.InfoPropagate(UserNotFoundByEmailError)
 		}
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	return user, nil
 }
 
 func (r *UserRepository) Insert(ctx context.Context, user *agg.User) (*agg.User, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.InsertOne(qCtx, user, options.InsertOne())
 	if err != nil {
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	if oID, ok := res.InsertedID.(primitive.ObjectID); ok {
@@ -110,16 +116,18 @@ func (r *UserRepository) Insert(ctx context.Context, user *agg.User) (*agg.User,
 		return r.FindOneByID(qCtx, q)
 	}
 
-	return nil, r.logger.CriticalPropagate(UserInsertingFailedError)
+	return nil, logger.CriticalPropagate(UserInsertingFailedError)
 }
 
 func (r *UserRepository) Update(ctx context.Context, user *agg.User) (*agg.User, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.UpdateByID(qCtx, user.ID.Value, bson.M{"$set": user})
 	if err != nil {
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	// check the record is really updated
@@ -133,16 +141,18 @@ func (r *UserRepository) Update(ctx context.Context, user *agg.User) (*agg.User,
 }
 
 func (r *UserRepository) Remove(ctx context.Context, user *agg.User) error {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": user.ID.Value})
 	if err != nil {
-		return r.logger.ErrorPropagate(err)
+		return logger.ErrorPropagate(err)
 	}
 
 	if res.DeletedCount == 0 { // checking the user was deleted
-		return r.logger.CriticalPropagate(UserWasNotDeletedError)
+		return logger.CriticalPropagate(UserWasNotDeletedError)
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
@@ -13,18 +14,32 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
 	"sync"
 	"time"
 )
 
-const VideosCollection = "videos"
+const (
+	VideosCollection = "videos"
+	// VideosTextIndex is a name of full-text search index over name, description and tags.
+	VideosTextIndex = "videos_text_search"
+	// VideosTagsIndex is a name of multikey index which is used by filtering videos by tags.
+	VideosTagsIndex = "videos_user_tags"
+	// VideosTrashIndex is a name of partial index which is used by listing the trash of the user.
+	VideosTrashIndex = "videos_user_deleted_at"
+	// VideosPurgeIndex is a name of partial index which is used by purging the expired videos of the trash.
+	VideosPurgeIndex = "videos_deleted_at"
+)
 
 var (
-	VideoNotFoundByIdError         = errors.NewEntityNotFoundError("video", "id")
-	VideoNotFoundByNameError       = errors.NewEntityNotFoundError("video", "name")
-	VideoNotFoundByResourceIdError = errors.NewEntityNotFoundError("video", "resource.id")
-	VideoInsertingFailedError      = errors.NewInternalValidationError("unable to store 'video' or get inserted 'id'")
-	VideoWasNotDeletedError        = errors.NewInternalValidationError("video was not deleted")
+	VideoNotFoundByIdError            = errors.NewEntityNotFoundError("video", "id")
+	VideoNotFoundByNameError          = errors.NewEntityNotFoundError("video", "name")
+	VideoNotFoundByResourceIdError    = errors.NewEntityNotFoundError("video", "resource.id")
+	VideoInsertingFailedError         = errors.NewInternalValidationError("unable to store 'video' or get inserted 'id'")
+	VideoWasNotDeletedError           = errors.NewInternalValidationError("video was not deleted")
+	VideoNotFoundInTrashError         = errors.NewEntityNotFoundError("trashed video", "id")
+	VideoListFetchingFailedError      = errors.NewInternalValidationError("unable to fetch 'video' list")
+	VideoTrashListFetchingFailedError = errors.NewInternalValidationError("unable to fetch 'trashed video' list")
 )
 
 type VideoRepository struct {
@@ -55,39 +70,107 @@ func NewVideoRepository(serviceContainer di_interface.ContainerManager) (*VideoR
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	return &VideoRepository{
+	r := &VideoRepository{
 		db:      mongodb.Collection(VideosCollection),
 		logger:  loggerService,
 		mu:      &sync.Mutex{},
 		timeout: timeout,
-	}, nil
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+// createIndexes - will create indexes required by queries (existing indexes will be left as is).
+func (r *VideoRepository) createIndexes(ctx context.Context) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "tags", Value: "text"},
+			},
+			Options: options.Index().
+				SetName(VideosTextIndex).
+				SetWeights(bson.D{
+					{Key: "name", Value: 10},
+					{Key: "tags", Value: 5},
+					{Key: "description", Value: 1},
+				}),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user._id", Value: 1},
+				{Key: "tags", Value: 1},
+			},
+			Options: options.Index().SetName(VideosTagsIndex),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user._id", Value: 1},
+				{Key: DeletedAtField, Value: -1},
+			},
+			Options: options.Index().
+				SetName(VideosTrashIndex).
+				SetPartialFilterExpression(bson.M{DeletedAtField: trashed()}),
+		},
+		{
+			Keys: bson.D{{Key: DeletedAtField, Value: 1}},
+			Options: options.Index().
+				SetName(VideosPurgeIndex).
+				SetPartialFilterExpression(bson.M{DeletedAtField: trashed()}),
+		},
+	})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
 }
 
 func (r *VideoRepository) FindOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	filter := bson.M{
-		"_id":      q.GetID().Value,
-		"user._id": q.GetUserID().Value,
+		"_id":          q.GetID().Value,
+		"user._id":     q.GetUserID().Value,
+		DeletedAtField: notTrashed(),
 	}
 
 	video := &agg.Video{}
 	if err := r.db.FindOne(qCtx, filter).Decode(video); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, r.logger.InfoPropagate(VideoNotFoundByIdError)
+			return nil, logger.InfoPropagate(VideoNotFoundByIdError)
 		}
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	return video, nil
 }
 
 func (r *VideoRepository) FindList(ctx context.Context, q query_interface.FindVideoList) (list []*agg.Video, total int64, err error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	filter := bson.M{"user._id": q.GetUserID().Value}
+	filter := bson.M{"user._id": q.GetUserID().Value, DeletedAtField: notTrashed()}
 
 	if q.GetName() != "" {
 		filter["name"] = primitive.Regex{Pattern: q.GetName(), Options: "i"}
@@ -112,26 +195,80 @@ func (r *VideoRepository) FindList(ctx context.Context, q query_interface.FindVi
 		filter["createdAt"] = createdAtFilter
 	}
 
-	opts := options.Find().
-		SetSkip((int64(q.GetPage()) - 1) * int64(q.GetLimit())).
-		SetLimit(int64(q.GetLimit()))
+	if q.GetQuery() != "" {
+		filter["$text"] = bson.M{"$search": q.GetQuery()}
+	}
+	if len(q.GetTags()) > 0 {
+		filter["tags"] = bson.M{"$all": q.GetTags()}
+	}
+	if q.GetMinDuration() > 0 || q.GetMaxDuration() > 0 {
+		durationFilter := bson.M{}
+		if q.GetMinDuration() > 0 {
+			durationFilter["$gte"] = q.GetMinDuration()
+		}
+		if q.GetMaxDuration() > 0 {
+			durationFilter["$lte"] = q.GetMaxDuration()
+		}
+		filter["resource.metadata.duration"] = durationFilter
+	}
+	if q.GetVideoCodec() != "" {
+		filter["resource.metadata.videoCodec"] = q.GetVideoCodec()
+	}
+	if q.GetAudioCodec() != "" {
+		filter["resource.metadata.audioCodec"] = q.GetAudioCodec()
+	}
+	if q.GetMinFilesize() > 0 || q.GetMaxFilesize() > 0 {
+		filesizeFilter := bson.M{}
+		if q.GetMinFilesize() > 0 {
+			filesizeFilter["$gte"] = q.GetMinFilesize()
+		}
+		if q.GetMaxFilesize() > 0 {
+			filesizeFilter["$lte"] = q.GetMaxFilesize()
+		}
+		filter["resource.filesize"] = filesizeFilter
+	}
+
+	opts := options.Find()
+	if sort := r.buildSort(q); len(sort) > 0 {
+		opts.SetSort(sort)
+	}
+
+	// the cursor condition is not applied on the filter, it is still used for count the total
+	paginatedFilter, isReversed, err := paginate(filter, opts, q, byTime("createdAt", "_id", r.isDescByCreatedAt(q)))
+	if err != nil {
+		logger.Log(err)
+		return nil, 0, logger.LogPropagate(errors.NewCursorIsInvalidError())
+	}
 
 	wg := sync.WaitGroup{}
 	wg.Add(2)
 
+	// the errors are set by separate goroutines, so each of them has its own one
+	var findErr, countErr error
+
 	list = []*agg.Video{}
 	go func() {
 		defer wg.Done()
 
-		c, e := r.db.Find(qCtx, filter, opts)
-		if e != nil && e != mongo.ErrNoDocuments {
-			r.logger.Error(e)
+		c, e := r.db.Find(qCtx, paginatedFilter, opts)
+		if e != nil {
+			logger.Error(e)
+			findErr = VideoListFetchingFailedError
 			return
 		}
 		defer func() { _ = c.Close(qCtx) }()
 
 		if e = c.All(qCtx, &list); e != nil {
-			r.logger.Error(e)
+			logger.Error(e)
+			findErr = VideoListFetchingFailedError
+			return
+		}
+
+		// the previous page was fetched in the opposite order
+		if isReversed {
+			for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+				list[i], list[j] = list[j], list[i]
+			}
 		}
 	}()
 
@@ -140,8 +277,9 @@ func (r *VideoRepository) FindList(ctx context.Context, q query_interface.FindVi
 		defer wg.Done()
 
 		c, e := r.db.CountDocuments(qCtx, filter)
-		if err != nil {
-			r.logger.Error(e)
+		if e != nil {
+			logger.Error(e)
+			countErr = VideoListFetchingFailedError
 			return
 		}
 
@@ -150,16 +288,70 @@ func (r *VideoRepository) FindList(ctx context.Context, q query_interface.FindVi
 
 	wg.Wait()
 
+	if findErr != nil {
+		return nil, 0, logger.LogPropagate(findErr)
+	}
+	if countErr != nil {
+		return nil, 0, logger.LogPropagate(countErr)
+	}
+
 	return list, total, nil
 }
 
+// buildSort - will make a sort document by requested fields. If sort is not requested,
+// then the full-text search results will be ordered by relevance and others by createdAt.
+func (r *VideoRepository) buildSort(q query_interface.FindVideoList) bson.D {
+	fields := q.GetSort()
+	if len(fields) == 0 {
+		if q.GetQuery() != "" {
+			fields = []string{enum.VideoSortByRelevance}
+		} else {
+			fields = []string{enum.VideoSortByCreatedAt}
+		}
+	}
+
+	sort := bson.D{}
+	for _, field := range fields {
+		order := 1
+		if strings.HasPrefix(field, enum.SortDescPrefix) {
+			field = strings.TrimPrefix(field, enum.SortDescPrefix)
+			order = -1
+		}
+
+		switch field {
+		case enum.VideoSortByRelevance:
+			// relevance is always sorted from the most relevant
+			sort = append(sort, bson.E{Key: "score", Value: bson.M{"$meta": "textScore"}})
+		case enum.VideoSortByName:
+			sort = append(sort, bson.E{Key: "name", Value: order})
+		case enum.VideoSortByCreatedAt:
+			// _id makes the order stable for records which were created at the same time
+			sort = append(sort, bson.E{Key: "createdAt", Value: order}, bson.E{Key: "_id", Value: order})
+		case enum.VideoSortByDuration:
+			sort = append(sort, bson.E{Key: "resource.metadata.duration", Value: order})
+		case enum.VideoSortByFilesize:
+			sort = append(sort, bson.E{Key: "resource.filesize", Value: order})
+		}
+	}
+
+	return sort
+}
+
+// isDescByCreatedAt - whether the list is ordered by createdAt from the newest records.
+func (r *VideoRepository) isDescByCreatedAt(q query_interface.FindVideoList) bool {
+	return len(q.GetSort()) == 1 && q.GetSort()[0] == enum.SortDescPrefix+enum.VideoSortByCreatedAt
+}
+
 func (r *VideoRepository) FindOneByName(ctx context.Context, q query_interface.FindOneVideoByName) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	filter := bson.M{
-		"name":     q.GetName(),
-		"user._id": q.GetUserID().Value,
+		"name":         q.GetName(),
+		"user._id":     q.GetUserID().Value,
+		DeletedAtField: notTrashed(),
 	}
 
 	video := &agg.Video{}
@@ -167,19 +359,22 @@ func (r *VideoRepository) FindOneByName(ctx context.Context, q query_interface.F
 		if err == mongo.ErrNoDocuments {
 			return nil, VideoNotFoundByNameError
 		}
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return video, nil
 }
 
 func (r *VideoRepository) FindOneByResourceID(ctx context.Context, q query_interface.FindOneVideoByResourceID) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	filter := bson.M{
 		"resource._id": q.GetResourceID().Value,
 		"user._id":     q.GetUserID().Value,
+		DeletedAtField: notTrashed(),
 	}
 
 	video := &agg.Video{}
@@ -187,19 +382,21 @@ func (r *VideoRepository) FindOneByResourceID(ctx context.Context, q query_inter
 		if err == mongo.ErrNoDocuments {
 			return nil, VideoNotFoundByResourceIdError
 		}
-		return nil, r.logger.LogPropagate(err)
+		return nil, logger.LogPropagate(err)
 	}
 
 	return video, nil
 }
 
 func (r *VideoRepository) Insert(ctx context.Context, video *agg.Video) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.InsertOne(qCtx, video, options.InsertOne())
 	if err != nil {
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
@@ -207,16 +404,18 @@ func (r *VideoRepository) Insert(ctx context.Context, video *agg.Video) (*agg.Vi
 		return r.FindOneByID(qCtx, q)
 	}
 
-	return nil, r.logger.CriticalPropagate(VideoInsertingFailedError)
+	return nil, logger.CriticalPropagate(VideoInsertingFailedError)
 }
 
 func (r *VideoRepository) Update(ctx context.Context, video *agg.Video) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.UpdateByID(qCtx, video.ID.Value, bson.M{"$set": video})
 	if err != nil {
-		return nil, r.logger.ErrorPropagate(err)
+		return nil, logger.ErrorPropagate(err)
 	}
 
 	// check the record is really updated
@@ -230,16 +429,215 @@ func (r *VideoRepository) Update(ctx context.Context, video *agg.Video) (*agg.Vi
 }
 
 func (r *VideoRepository) Remove(ctx context.Context, video *agg.Video) error {
+	logger := r.logger.WithContext(ctx)
+
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": video.ID.Value})
 	if err != nil {
-		return r.logger.ErrorPropagate(err)
+		return logger.ErrorPropagate(err)
 	}
 
 	if res.DeletedCount == 0 { // checking the video is really deleted
-		return r.logger.CriticalPropagate(VideoWasNotDeletedError)
+		return logger.CriticalPropagate(VideoWasNotDeletedError)
+	}
+
+	return nil
+}
+
+func (r *VideoRepository) Trash(ctx context.Context, video *agg.Video) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, video.ID.Value, bson.M{"$set": bson.M{DeletedAtField: video.DeletedAt}})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return logger.InfoPropagate(VideoNotFoundByIdError)
+	}
+
+	return nil
+}
+
+func (r *VideoRepository) Restore(ctx context.Context, video *agg.Video) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":          video.ID.Value,
+		DeletedAtField: trashed(),
+	}
+
+	res, err := r.db.UpdateOne(qCtx, filter, bson.M{"$unset": bson.M{DeletedAtField: ""}})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return logger.InfoPropagate(VideoNotFoundInTrashError)
+	}
+
+	return nil
+}
+
+func (r *VideoRepository) FindOneTrashedByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":          q.GetID().Value,
+		"user._id":     q.GetUserID().Value,
+		DeletedAtField: trashed(),
+	}
+
+	video := &agg.Video{}
+	if err := r.db.FindOne(qCtx, filter).Decode(video); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(VideoNotFoundInTrashError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return video, nil
+}
+
+func (r *VideoRepository) FindTrashedList(
+	ctx context.Context, q query_interface.FindTrashedVideoList,
+) (
+	list []*agg.Video, total int64, err error,
+) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":     q.GetUserID().Value,
+		DeletedAtField: trashed(),
+	}
+
+	// _id makes the order stable for records which were deleted at the same time
+	opts := options.Find()
+	paginatedFilter, isReversed, err := paginate(filter, opts, q, byTime(DeletedAtField, "_id", true))
+	if err != nil {
+		logger.Log(err)
+		return nil, 0, logger.LogPropagate(errors.NewCursorIsInvalidError())
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	list = []*agg.Video{}
+	go func() {
+		defer wg.Done()
+
+		c, e := r.db.Find(qCtx, paginatedFilter, opts)
+		if e != nil {
+			logger.Error(e)
+			err = VideoTrashListFetchingFailedError
+			return
+		}
+		defer func() { _ = c.Close(qCtx) }()
+
+		if e = c.All(qCtx, &list); e != nil {
+			logger.Error(e)
+			err = VideoTrashListFetchingFailedError
+			return
+		}
+
+		// the previous page was fetched in the opposite order
+		if isReversed {
+			for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+				list[i], list[j] = list[j], list[i]
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		c, e := r.db.CountDocuments(qCtx, filter)
+		if e != nil {
+			logger.Error(e)
+			return
+		}
+
+		total = c
+	}()
+
+	wg.Wait()
+
+	if err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+func (r *VideoRepository) FindTrashedBefore(ctx context.Context, before time.Time, limit int64) ([]*agg.Video, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: DeletedAtField, Value: 1}}).
+		SetLimit(limit)
+
+	c, err := r.db.Find(qCtx, bson.M{DeletedAtField: bson.M{"$lte": before}}, opts)
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list := []*agg.Video{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+func (r *VideoRepository) Walk(ctx context.Context, fn func(*agg.Video) error) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	c, err := r.db.Find(qCtx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	for {
+		// the whole collection may be walked for a long time, so the timeout is applied to each batch
+		nCtx, nCancel := context.WithTimeout(ctx, r.timeout)
+		found := c.Next(nCtx)
+		nCancel()
+		if !found {
+			break
+		}
+
+		video := &agg.Video{}
+		if err = c.Decode(video); err != nil {
+			return logger.ErrorPropagate(err)
+		}
+		if err = fn(video); err != nil {
+			return err
+		}
+	}
+	if err = c.Err(); err != nil {
+		return logger.ErrorPropagate(err)
 	}
 
 	return nil
@@ -0,0 +1,486 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const (
+	// VideoStatsCollection contains the totals of all time, one record for each video.
+	VideoStatsCollection = "videoStats"
+	// VideoDailyStatsCollection contains the rollups of days, one record for each video and day.
+	VideoDailyStatsCollection = "videoDailyStats"
+	// VideoStatsUniqueIndex is a name of unique index of the totals.
+	VideoStatsUniqueIndex = "video_stats_video"
+	// VideoStatsPlaysIndex is a name of index which is used by listing the totals from the most played video.
+	VideoStatsPlaysIndex = "video_stats_user_plays"
+	// VideoDailyStatsUniqueIndex is a name of unique index of the rollups.
+	VideoDailyStatsUniqueIndex = "video_daily_stats_video_day"
+	// VideoDailyStatsUserIndex is a name of index which is used by summing up the rollups of the user within a range.
+	VideoDailyStatsUserIndex = "video_daily_stats_user_day"
+
+	day = 24 * time.Hour
+)
+
+var (
+	VideoStatsNotFoundError           = errors.NewEntityNotFoundError("video stats", "videoID")
+	VideoStatsListFetchingFailedError = errors.NewInternalValidationError("unable to fetch 'video stats' list")
+)
+
+type VideoStatsRepository struct {
+	db      *mongo.Collection
+	daily   *mongo.Collection
+	mu      *sync.Mutex
+	logger  logger_interface.Logger
+	timeout time.Duration
+}
+
+func NewVideoStatsRepository(serviceContainer di_interface.ContainerManager) (*VideoStatsRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &VideoStatsRepository{
+		db:      mongodb.Collection(VideoStatsCollection),
+		daily:   mongodb.Collection(VideoDailyStatsCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+// createIndexes - will create indexes required by queries (existing indexes will be left as is).
+func (r *VideoStatsRepository) createIndexes(ctx context.Context) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "video._id", Value: 1}},
+			Options: options.Index().SetName(VideoStatsUniqueIndex).SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user._id", Value: 1},
+				{Key: "plays", Value: -1},
+			},
+			Options: options.Index().SetName(VideoStatsPlaysIndex),
+		},
+	})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	_, err = r.daily.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "video._id", Value: 1},
+				{Key: "day", Value: 1},
+			},
+			Options: options.Index().SetName(VideoDailyStatsUniqueIndex).SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user._id", Value: 1},
+				{Key: "day", Value: 1},
+			},
+			Options: options.Index().SetName(VideoDailyStatsUserIndex),
+		},
+	})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *VideoStatsRepository) FindOne(ctx context.Context, q query_interface.FindOneVideoStats) (*agg.VideoStats, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  q.GetUserID().Value,
+		"video._id": q.GetVideoID().Value,
+	}
+
+	stats := &agg.VideoStats{}
+	if q.GetFrom().IsZero() && q.GetTo().IsZero() {
+		if err := r.db.FindOne(qCtx, filter).Decode(stats); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, logger.InfoPropagate(VideoStatsNotFoundError)
+			}
+			return nil, logger.ErrorPropagate(err)
+		}
+		return stats, nil
+	}
+
+	// the totals of the range are summed up from the rollups of its days
+	c, err := r.daily.Aggregate(qCtx, r.sumPipeline(filter, q.GetFrom(), q.GetTo()))
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	if !c.Next(qCtx) {
+		if err = c.Err(); err != nil {
+			return nil, logger.ErrorPropagate(err)
+		}
+		return nil, logger.InfoPropagate(VideoStatsNotFoundError)
+	}
+	if err = c.Decode(stats); err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return stats, nil
+}
+
+func (r *VideoStatsRepository) FindDaily(ctx context.Context, q query_interface.FindOneVideoStats) ([]*agg.VideoStats, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  q.GetUserID().Value,
+		"video._id": q.GetVideoID().Value,
+	}
+	if days := r.daysFilter(q.GetFrom(), q.GetTo()); days != nil {
+		filter["day"] = days
+	}
+
+	c, err := r.daily.Find(qCtx, filter, options.Find().SetSort(bson.D{{Key: "day", Value: 1}}))
+	if err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list := []*agg.VideoStats{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+func (r *VideoStatsRepository) FindList(
+	ctx context.Context, q query_interface.FindVideoStatsList,
+) (
+	list []*agg.VideoStats, total int64, err error,
+) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user._id": q.GetUserID().Value}
+
+	// video._id makes the order stable for videos which were played the same number of times
+	opts := options.Find()
+	position, isReversed, err := paginate(bson.M{}, opts, q, byNumber("plays", "video._id", true))
+	if err != nil {
+		logger.Log(err)
+		return nil, 0, logger.LogPropagate(errors.NewCursorIsInvalidError())
+	}
+
+	var (
+		find  func() (*mongo.Cursor, error)
+		count func() (int64, error)
+	)
+	if q.GetFrom().IsZero() && q.GetTo().IsZero() {
+		find = func() (*mongo.Cursor, error) {
+			paginatedFilter := bson.M{}
+			for k, v := range filter {
+				paginatedFilter[k] = v
+			}
+			for k, v := range position {
+				paginatedFilter[k] = v
+			}
+			return r.db.Find(qCtx, paginatedFilter, opts)
+		}
+		count = func() (int64, error) {
+			return r.db.CountDocuments(qCtx, filter)
+		}
+	} else {
+		// the totals of the range are summed up from the rollups of its days for each video,
+		// so the position is matched by the sums
+		find = func() (*mongo.Cursor, error) {
+			pipeline := r.sumPipeline(filter, q.GetFrom(), q.GetTo())
+			if len(position) > 0 {
+				pipeline = append(pipeline, bson.D{{Key: "$match", Value: position}})
+			}
+			pipeline = append(pipeline, bson.D{{Key: "$sort", Value: opts.Sort}})
+			if opts.Skip != nil && *opts.Skip > 0 {
+				pipeline = append(pipeline, bson.D{{Key: "$skip", Value: *opts.Skip}})
+			}
+			return r.daily.Aggregate(qCtx, append(pipeline, bson.D{{Key: "$limit", Value: *opts.Limit}}))
+		}
+		count = func() (int64, error) {
+			return r.countVideos(qCtx, r.sumPipeline(filter, q.GetFrom(), q.GetTo()))
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	list = []*agg.VideoStats{}
+	go func() {
+		defer wg.Done()
+
+		c, e := find()
+		if e != nil {
+			logger.Error(e)
+			err = VideoStatsListFetchingFailedError
+			return
+		}
+		defer func() { _ = c.Close(qCtx) }()
+
+		if e = c.All(qCtx, &list); e != nil {
+			logger.Error(e)
+			err = VideoStatsListFetchingFailedError
+			return
+		}
+
+		// the previous page was fetched in the opposite order
+		if isReversed {
+			for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+				list[i], list[j] = list[j], list[i]
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		c, e := count()
+		if e != nil {
+			logger.Error(e)
+			return
+		}
+
+		total = c
+	}()
+
+	wg.Wait()
+
+	if err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+// countVideos - will count the number of videos of the summed up rollups.
+func (r *VideoStatsRepository) countVideos(ctx context.Context, pipeline mongo.Pipeline) (int64, error) {
+	c, err := r.daily.Aggregate(ctx, append(pipeline, bson.D{{Key: "$count", Value: "total"}}))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	res := struct {
+		Total int64 `bson:"total"`
+	}{}
+	if c.Next(ctx) {
+		if err = c.Decode(&res); err != nil {
+			return 0, err
+		}
+	}
+
+	return res.Total, c.Err()
+}
+
+// sumPipeline - will sum up the rollups matched by the filter within the range of days into the totals of each video.
+func (r *VideoStatsRepository) sumPipeline(filter bson.M, from time.Time, to time.Time) mongo.Pipeline {
+	match := bson.M{}
+	for k, v := range filter {
+		match[k] = v
+	}
+	if days := r.daysFilter(from, to); days != nil {
+		match["day"] = days
+	}
+
+	return mongo.Pipeline{
+		bson.D{{Key: "$match", Value: match}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":            "$video._id",
+			"user":           bson.M{"$first": "$user"},
+			"plays":          bson.M{"$sum": "$plays"},
+			"finished":       bson.M{"$sum": "$finished"},
+			"bytes":          bson.M{"$sum": "$bytes"},
+			"completionSum":  bson.M{"$sum": "$completionSum"},
+			"stopsCompleted": bson.M{"$sum": "$stops.completed"},
+			"stopsFailed":    bson.M{"$sum": "$stops.failed"},
+			"stopsShutdown":  bson.M{"$sum": "$stops.shutdown"},
+			"stopsReplaced":  bson.M{"$sum": "$stops.replaced"},
+			"createdAt":      bson.M{"$min": "$createdAt"},
+			"updatedAt":      bson.M{"$max": "$updatedAt"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":           0,
+			"video":         bson.M{"_id": "$_id"},
+			"user":          1,
+			"plays":         1,
+			"finished":      1,
+			"bytes":         1,
+			"completionSum": 1,
+			"stops": bson.M{
+				"completed": "$stopsCompleted",
+				"failed":    "$stopsFailed",
+				"shutdown":  "$stopsShutdown",
+				"replaced":  "$stopsReplaced",
+			},
+			"createdAt": 1,
+			"updatedAt": 1,
+		}}},
+	}
+}
+
+// daysFilter - will build the filter of days which are touched by the range (nil if the range is not given).
+func (r *VideoStatsRepository) daysFilter(from time.Time, to time.Time) bson.M {
+	days := bson.M{}
+	if !from.IsZero() {
+		days["$gte"] = from.UTC().Truncate(day)
+	}
+	if !to.IsZero() {
+		days["$lte"] = to.UTC()
+	}
+	if len(days) == 0 {
+		return nil
+	}
+	return days
+}
+
+func (r *VideoStatsRepository) Increment(ctx context.Context, session *agg.PlaySession) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var finished int64
+	if session.Finished {
+		finished = 1
+	}
+
+	update := bson.M{
+		"$inc": bson.M{
+			"plays":                       int64(1),
+			"finished":                    finished,
+			"bytes":                       session.Bytes,
+			"completionSum":               session.Completion,
+			"stops." + session.StopReason: int64(1),
+		},
+		"$max": bson.M{
+			"updatedAt": session.StoppedAt,
+		},
+		"$setOnInsert": bson.M{
+			"user":      session.UserID,
+			"createdAt": session.StartedAt,
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+
+	// the session is bound to the day of its start
+	totalsFilter := bson.M{"video._id": session.VideoID.Value}
+	dailyFilter := bson.M{"video._id": session.VideoID.Value, "day": session.StartedAt.UTC().Truncate(day)}
+
+	if _, err := r.db.UpdateOne(qCtx, totalsFilter, update, opts); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+	if _, err := r.daily.UpdateOne(qCtx, dailyFilter, update, opts); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *VideoStatsRepository) AddCompletion(ctx context.Context, session *agg.PlaySession, delta float64, isFinished bool) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var finished int64
+	if isFinished {
+		finished = 1
+	}
+
+	update := bson.M{
+		"$inc": bson.M{
+			"finished":      finished,
+			"completionSum": delta,
+		},
+		"$max": bson.M{
+			"updatedAt": time.Now(),
+		},
+	}
+
+	// the session was added to the totals and to the rollup of its day by its stop
+	totalsFilter := bson.M{"video._id": session.VideoID.Value}
+	dailyFilter := bson.M{"video._id": session.VideoID.Value, "day": session.StartedAt.UTC().Truncate(day)}
+
+	if _, err := r.db.UpdateOne(qCtx, totalsFilter, update); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+	if _, err := r.daily.UpdateOne(qCtx, dailyFilter, update); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *VideoStatsRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"video._id": video.ID.Value}
+	if _, err := r.db.DeleteMany(qCtx, filter); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+	if _, err := r.daily.DeleteMany(qCtx, filter); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const (
+	WatchHistoryCollection = "watchHistory"
+	// WatchHistoryUniqueIndex is a name of unique index, only one record exists for each user and video.
+	WatchHistoryUniqueIndex = "watch_history_user_video"
+	// WatchHistoryRecentIndex is a name of index which is used by listing the history from the last watched video.
+	WatchHistoryRecentIndex = "watch_history_user_updated_at"
+)
+
+var (
+	WatchProgressNotFoundError           = errors.NewEntityNotFoundError("watch progress", "videoID")
+	WatchProgressListFetchingFailedError = errors.NewInternalValidationError("unable to fetch 'watch progress' list")
+)
+
+type WatchProgressRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  logger_interface.Logger
+	timeout time.Duration
+}
+
+func NewWatchProgressRepository(serviceContainer di_interface.ContainerManager) (*WatchProgressRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &WatchProgressRepository{
+		db:      mongodb.Collection(WatchHistoryCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+// createIndexes - will create indexes required by queries (existing indexes will be left as is).
+func (r *WatchProgressRepository) createIndexes(ctx context.Context) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user._id", Value: 1},
+				{Key: "video._id", Value: 1},
+			},
+			Options: options.Index().SetName(WatchHistoryUniqueIndex).SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "user._id", Value: 1},
+				{Key: "updatedAt", Value: -1},
+			},
+			Options: options.Index().SetName(WatchHistoryRecentIndex),
+		},
+	})
+	if err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *WatchProgressRepository) FindOne(ctx context.Context, q query_interface.FindOneWatchProgress) (*agg.WatchProgress, error) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  q.GetUserID().Value,
+		"video._id": q.GetVideoID().Value,
+	}
+
+	progress := &agg.WatchProgress{}
+	if err := r.db.FindOne(qCtx, filter).Decode(progress); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, logger.InfoPropagate(WatchProgressNotFoundError)
+		}
+		return nil, logger.ErrorPropagate(err)
+	}
+
+	return progress, nil
+}
+
+func (r *WatchProgressRepository) FindList(
+	ctx context.Context, q query_interface.FindWatchProgressList,
+) (
+	list []*agg.WatchProgress, total int64, err error,
+) {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user._id": q.GetUserID().Value}
+	if q.IsUnfinished() {
+		// the video was started (by the client report or at least by the sent bytes), but not finished yet
+		filter["finished"] = false
+		filter["$or"] = bson.A{
+			bson.M{"position": bson.M{"$gt": 0}},
+			bson.M{"offset": bson.M{"$gt": 0}},
+		}
+	}
+
+	// _id makes the order stable for records which were watched at the same time
+	opts := options.Find()
+	paginatedFilter, isReversed, err := paginate(filter, opts, q, byTime("updatedAt", "_id", true))
+	if err != nil {
+		logger.Log(err)
+		return nil, 0, logger.LogPropagate(errors.NewCursorIsInvalidError())
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	list = []*agg.WatchProgress{}
+	go func() {
+		defer wg.Done()
+
+		c, e := r.db.Find(qCtx, paginatedFilter, opts)
+		if e != nil {
+			logger.Error(e)
+			err = WatchProgressListFetchingFailedError
+			return
+		}
+		defer func() { _ = c.Close(qCtx) }()
+
+		if e = c.All(qCtx, &list); e != nil {
+			logger.Error(e)
+			err = WatchProgressListFetchingFailedError
+			return
+		}
+
+		// the previous page was fetched in the opposite order
+		if isReversed {
+			for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+				list[i], list[j] = list[j], list[i]
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		c, e := r.db.CountDocuments(qCtx, filter)
+		if e != nil {
+			logger.Error(e)
+			return
+		}
+
+		total = c
+	}()
+
+	wg.Wait()
+
+	if err != nil {
+		return nil, 0, logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+func (r *WatchProgressRepository) SavePosition(ctx context.Context, progress *agg.WatchProgress) error {
+	return r.upsert(ctx, progress,
+		bson.M{
+			"position":  progress.Position,
+			"duration":  progress.Duration,
+			"finished":  progress.Finished,
+			"updatedAt": progress.Timestamp.UpdatedAt,
+		},
+		bson.M{
+			"offset":    int64(0),
+			"size":      int64(0),
+			"createdAt": progress.Timestamp.UpdatedAt,
+		},
+	)
+}
+
+func (r *WatchProgressRepository) SaveOffset(ctx context.Context, progress *agg.WatchProgress) error {
+	return r.upsert(ctx, progress,
+		bson.M{
+			"offset":    progress.Offset,
+			"size":      progress.Size,
+			"updatedAt": progress.Timestamp.UpdatedAt,
+		},
+		bson.M{
+			"position":  float64(0),
+			"duration":  float64(0),
+			"finished":  false,
+			"createdAt": progress.Timestamp.UpdatedAt,
+		},
+	)
+}
+
+// upsert - will set the given fields of the progress record of the user and video (the rest fields are kept),
+// the record is created with the insert fields if it does not exist yet.
+func (r *WatchProgressRepository) upsert(ctx context.Context, progress *agg.WatchProgress, set bson.M, insert bson.M) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  progress.UserID.Value,
+		"video._id": progress.VideoID.Value,
+	}
+	update := bson.M{
+		"$set":         set,
+		"$setOnInsert": insert,
+	}
+
+	if _, err := r.db.UpdateOne(qCtx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *WatchProgressRepository) RemoveVideo(ctx context.Context, video *agg.Video) error {
+	logger := r.logger.WithContext(ctx)
+
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.DeleteMany(qCtx, bson.M{"video._id": video.ID.Value}); err != nil {
+		return logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
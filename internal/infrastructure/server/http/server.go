@@ -2,8 +2,11 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accessor_interface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
 	authenticator_interface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	extractor_interface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
@@ -12,9 +15,17 @@ import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/request"
 	response_interface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/helper/ruid"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/health"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
@@ -29,6 +40,8 @@ type Server struct {
 	apiVersionPrefix    string // example: "/api/v1"
 	renderVersionPrefix string // example: ""
 	staticVersionPrefix string // example: ""
+	metricsPath         string // example: "/metrics"
+	drainPeriod         time.Duration
 
 	restAuthedControllers     []controller.Controller
 	restUnauthedControllers   []controller.Controller
@@ -37,7 +50,11 @@ type Server struct {
 	staticControllers         []controller.Controller
 
 	logger             logger_interface.Logger
+	metrics            metrics_interface.Metrics
+	tracer             tracer_interface.Tracer
+	health             health_interface.Checker
 	authService        authenticator_interface.Authenticator
+	accessService      accessor_interface.Accessor
 	reqParamsExtractor extractor_interface.RequestParams
 	responder          response_interface.Responder
 }
@@ -60,11 +77,31 @@ func NewHttpServer(
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	metricsService, err := serviceContainer.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := serviceContainer.GetTracerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	healthChecker, err := serviceContainer.GetHealthCheckerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	authService, err := serviceContainer.GetAuthService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	requestParametersExtractorService, err := serviceContainer.GetRequestParametersExtractorService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -88,24 +125,29 @@ func NewHttpServer(
 		apiVersionPrefix:          cfg.ResourcesApiVersionPrefix,
 		renderVersionPrefix:       cfg.ResourcesRenderVersionPrefix,
 		staticVersionPrefix:       cfg.ResourcesStaticVersionPrefix,
+		metricsPath:               cfg.MetricsPath,
+		drainPeriod:               cfg.ShutdownDrainPeriod,
 		restAuthedControllers:     restAuthedControllers,
 		restUnauthedControllers:   restUnauthedControllers,
 		renderAuthedControllers:   renderAuthedControllers,
 		renderUnauthedControllers: renderUnauthedControllers,
 		staticControllers:         staticControllers,
 		logger:                    loggerService,
+		metrics:                   metricsService,
+		tracer:                    tracerService,
+		health:                    healthChecker,
 		authService:               authService,
+		accessService:             accessService,
 		reqParamsExtractor:        requestParametersExtractorService,
 		responder:                 responderService,
 	}, nil
 }
 
-func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) {
+func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) error {
 	defer wg.Done()
 	addr, err := net.ResolveTCPAddr(s.transportProto, net.JoinHostPort(s.host, s.port))
 	if err != nil {
-		s.logger.Error(err)
-		return
+		return s.logger.LogPropagate(err)
 	}
 
 	server := http.Server{
@@ -113,38 +155,66 @@ func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) {
 		Handler: s.addRoutes(),
 	}
 
+	listenErrCh := make(chan error, 1)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		defer s.logger.Info("stopped")
-		if err = server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			s.logger.Error(err)
-			return
+		if lsErr := server.ListenAndServe(); lsErr != nil && lsErr != http.ErrServerClosed {
+			listenErrCh <- lsErr
 		}
 	}()
 
 	s.logger.Info("running...")
-	<-ctx.Done()
+	select {
+	case <-ctx.Done():
+	case err = <-listenErrCh:
+		return s.logger.CriticalPropagate(err)
+	}
 	s.logger.Info("shutting down...")
 
-	serverCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	// the parent context is already canceled, so the timeout must be bound to a new one
+	serverCtx, cancel := context.WithTimeout(context.Background(), s.drainPeriod)
 	defer cancel()
 
-	if shErr := server.Shutdown(serverCtx); shErr != nil && shErr != context.Canceled {
-		s.logger.Critical(err)
-		return
+	// waits for the in-flight requests (uploads included) within the drain period and interrupts the rest ones
+	if shErr := server.Shutdown(serverCtx); shErr != nil {
+		s.logger.Warning(fmt.Sprintf("in-flight requests were interrupted after the drain period: %v", shErr))
+		if clErr := server.Close(); clErr != nil {
+			s.logger.Error(clErr)
+		}
 	}
+
+	return nil
 }
 
 func (s *Server) addRoutes() *mux.Router {
 	router := mux.NewRouter()
 
+	// metrics scraping endpoint (registered first for avoid matching by the prefixed subrouters)
+	router.
+		Path(s.metricsPath).
+		Methods(http.MethodGet).
+		Handler(s.metrics.Handler())
+
+	// orchestrator probes
+	router.
+		Path(health.LivenessPath).
+		Methods(http.MethodGet, http.MethodHead).
+		Handler(s.health.LivenessHandler())
+	router.
+		Path(health.ReadinessPath).
+		Methods(http.MethodGet, http.MethodHead).
+		Handler(s.health.ReadinessHandler())
+
 	// [AUTHED] rest api controllers which requires authorization token
 	restAuthedRouterV1 := router.
 		PathPrefix(s.apiVersionPrefix).
 		Subrouter()
 	restAuthedRouterV1.
 		Use(
+			s.tracingMiddleware,
+			s.metricsMiddleware,
 			s.restApiHeaderMiddleware,
 			s.requestsLoggingMiddleware,
 			s.restAuthorizationMiddleware,
@@ -160,6 +230,8 @@ func (s *Server) addRoutes() *mux.Router {
 		Subrouter()
 	restUnauthedRouterV1.
 		Use(
+			s.tracingMiddleware,
+			s.metricsMiddleware,
 			s.requestsLoggingMiddleware,
 			s.restApiHeaderMiddleware,
 		)
@@ -174,6 +246,8 @@ func (s *Server) addRoutes() *mux.Router {
 		Subrouter()
 	renderAuthedRouterV1.
 		Use(
+			s.tracingMiddleware,
+			s.metricsMiddleware,
 			s.requestsLoggingMiddleware,
 			s.renderAuthorizationMiddleware,
 		)
@@ -188,6 +262,8 @@ func (s *Server) addRoutes() *mux.Router {
 		Subrouter()
 	renderUnauthedRouterV1.
 		Use(
+			s.tracingMiddleware,
+			s.metricsMiddleware,
 			s.requestsLoggingMiddleware,
 		)
 
@@ -199,6 +275,11 @@ func (s *Server) addRoutes() *mux.Router {
 	staticRouterV1 := router.
 		PathPrefix(s.staticVersionPrefix).
 		Subrouter()
+	staticRouterV1.
+		Use(
+			s.tracingMiddleware,
+			s.metricsMiddleware,
+		)
 
 	for _, c := range s.staticControllers {
 		c.AddRoute(staticRouterV1)
@@ -212,11 +293,18 @@ func (s *Server) addRoutes() *mux.Router {
 func (s *Server) restAuthorizationMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
-			userID, err := s.authService.IsAuthed(r)
+			userID, scopes, err := s.authService.IsAuthed(r)
 			if err != nil {
-				s.responder.Respond(w, s.logger.LogPropagate(err))
+				s.responder.Respond(r.Context(), w, s.logger.WithContext(r.Context()).LogPropagate(err))
 				return
 			}
+			// request was authed by API key, checking the key scopes
+			if scopes != nil {
+				if err = s.accessService.IsGrantedScope(scopes, s.requiredScope(r)); err != nil {
+					s.responder.Respond(r.Context(), w, s.logger.WithContext(r.Context()).LogPropagate(err))
+					return
+				}
+			}
 			// create a new context with userID value
 			ctx := context.WithValue(r.Context(), enum.UserIDContextKey, userID)
 			// serve the next layer
@@ -230,12 +318,17 @@ func (s *Server) restAuthorizationMiddleware(handler http.Handler) http.Handler
 func (s *Server) renderAuthorizationMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
-			userID, err := s.authService.IsAuthed(r)
+			userID, scopes, err := s.authService.IsAuthed(r)
+			if err == nil && scopes != nil {
+				// API keys are intended only for the rest api
+				err = errors.NewAccessDeniedError("pages are not available for API keys")
+			}
 			if err != nil {
+				logger := s.logger.WithContext(r.Context())
 				// error logging
-				s.logger.Log(err)
+				logger.Log(err)
 				// info action logging
-				s.logger.Info("redirect to login page")
+				logger.Info("redirect to login page")
 				// redirecting a client to the login page
 				http.Redirect(w, r, render.LoginPath, http.StatusSeeOther)
 				return
@@ -248,6 +341,67 @@ func (s *Server) renderAuthorizationMiddleware(handler http.Handler) http.Handle
 	)
 }
 
+// route returns the method and path template of the matched route (e.g. "GET /api/v1/video/{id}").
+func (s *Server) route(r *http.Request) string {
+	return r.Method + " " + s.routePath(r)
+}
+
+// routePath returns the path template of the matched route or the raw path if the route was not matched.
+func (s *Server) routePath(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return r.URL.Path
+}
+
+// requiredScope returns a scope which must be granted to API key for access the matched rest api route.
+// An empty string means that the route is not available for API keys.
+func (s *Server) requiredScope(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+
+	path, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+
+	// the first segment of the route path is a target resource name (e.g. "/video/{id}" => "video")
+	path = strings.TrimPrefix(strings.TrimPrefix(path, s.apiVersionPrefix), "/")
+	if i := strings.Index(path, "/"); i != -1 {
+		path = path[:i]
+	}
+
+	isReadOnly := r.Method == http.MethodGet || r.Method == http.MethodHead
+
+	switch path {
+	case "video", "playlist":
+		if isReadOnly {
+			return enum.VideoReadScope
+		}
+		return enum.VideoWriteScope
+	case "audio":
+		if isReadOnly {
+			return enum.AudioReadScope
+		}
+		return enum.AudioWriteScope
+	case "resource":
+		if r.Method == http.MethodPost {
+			return enum.ResourceUploadScope
+		}
+	case "user":
+		if isReadOnly {
+			return enum.UserReadScope
+		}
+		return enum.UserWriteScope
+	}
+
+	return ""
+}
+
 func (s *Server) restApiHeaderMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -259,6 +413,50 @@ func (s *Server) restApiHeaderMiddleware(handler http.Handler) http.Handler {
 	)
 }
 
+// tracingMiddleware starts the server span of the matched route, the parent trace context
+// is extracted from the W3C 'traceparent' and 'tracestate' headers of the request.
+func (s *Server) tracingMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			path := s.routePath(r)
+
+			ctx, span := s.tracer.Start(
+				s.tracer.Extract(r.Context(), r.Header),
+				r.Method+" "+path,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPMethodKey.String(r.Method),
+					semconv.HTTPRoute(path),
+				),
+			)
+			defer span.End()
+
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			// serve the next layer
+			handler.ServeHTTP(recorder, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPStatusCode(recorder.status))
+			if recorder.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(recorder.status))
+			}
+		},
+	)
+}
+
+// metricsMiddleware observes the number and duration of requests by the matched route template and response status.
+func (s *Server) metricsMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			from := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			// serve the next layer
+			handler.ServeHTTP(recorder, r)
+			// the path template is used instead of the raw path for avoid the labels cardinality explosion
+			s.metrics.ObserveHTTPRequest(s.routePath(r), r.Method, recorder.status, time.Since(from))
+		},
+	)
+}
+
 func (s *Server) requestsLoggingMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -273,12 +471,29 @@ func (s *Server) requestsLoggingMiddleware(handler http.Handler) http.Handler {
 				RemoteAddr: r.RemoteAddr,
 				Params:     s.reqParamsExtractor.Parameters(r),
 			}
+			// pass a requestID and route through entire app. by the request context
+			ctx := context.WithValue(r.Context(), enum.UniqueRequestIDKey, uniqueReqID)
+			ctx = context.WithValue(ctx, enum.RouteContextKey, s.route(r))
 			// request logging
-			s.logger.LogData(requestData)
-			// pass a requestID through entire app.
-			s.logger.SetContext(context.WithValue(s.ctx, enum.UniqueRequestIDKey, uniqueReqID))
+			s.logger.WithContext(ctx).LogData(requestData)
 			// serve the next layer
-			handler.ServeHTTP(w, r)
+			handler.ServeHTTP(w, r.WithContext(ctx))
 		},
 	)
 }
+
+// statusRecorder - wrapper of http.ResponseWriter which remembers the written status code.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap - gives access to the original writer for http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
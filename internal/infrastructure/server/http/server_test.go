@@ -0,0 +1,83 @@
+package http
+
+import (
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testMetrics - records the observed http requests.
+type testMetrics struct {
+	metrics_interface.Metrics
+	requests []testRequest
+}
+
+type testRequest struct {
+	route  string
+	method string
+	status int
+}
+
+func (m *testMetrics) ObserveHTTPRequest(route string, method string, status int, duration time.Duration) {
+	m.requests = append(m.requests, testRequest{route: route, method: method, status: status})
+}
+
+func TestServer_MetricsMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		request testRequest
+	}{
+		{
+			name:    "route template instead of raw path",
+			method:  http.MethodGet,
+			target:  "/api/v1/video/65f1c0de0000000000000001",
+			request: testRequest{route: "/api/v1/video/{id}", method: http.MethodGet, status: http.StatusOK},
+		},
+		{
+			name:    "status written by handler",
+			method:  http.MethodDelete,
+			target:  "/api/v1/video/65f1c0de0000000000000001",
+			request: testRequest{route: "/api/v1/video/{id}", method: http.MethodDelete, status: http.StatusNoContent},
+		},
+		{
+			name:    "failed request",
+			method:  http.MethodPost,
+			target:  "/api/v1/video",
+			request: testRequest{route: "/api/v1/video", method: http.MethodPost, status: http.StatusInternalServerError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := &testMetrics{}
+			s := &Server{metrics: metrics}
+
+			router := mux.NewRouter()
+			api := router.PathPrefix("/api/v1").Subrouter()
+			api.Use(s.metricsMiddleware)
+			api.Path("/video/{id}").Methods(http.MethodGet).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("{}"))
+			})
+			api.Path("/video/{id}").Methods(http.MethodDelete).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			})
+			api.Path("/video").Methods(http.MethodPost).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.target, nil))
+
+			if len(metrics.requests) != 1 {
+				t.Fatalf("observed requests = %+v, want exactly one", metrics.requests)
+			}
+			if metrics.requests[0] != tt.request {
+				t.Errorf("observed request = %+v, want %+v", metrics.requests[0], tt.request)
+			}
+		})
+	}
+}
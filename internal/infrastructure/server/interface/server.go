@@ -6,5 +6,7 @@ import (
 )
 
 type Server interface {
-	Listen(ctx context.Context, wg *sync.WaitGroup)
+	// Listen - serves the requests until the context will be canceled. The error is returned
+	// if the server could not be started or stopped unexpectedly.
+	Listen(ctx context.Context, wg *sync.WaitGroup) error
 }
@@ -0,0 +1,85 @@
+package ws
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// connectionsCancelTimeout is a max. duration of waiting for the connections which were canceled after the drain period
+// (they must send the 'going away' message and close the socket, so it doesn't take much time).
+const connectionsCancelTimeout = time.Second * 5
+
+// connections - the registry of the active websocket connections. The hijacked connections are not tracked by
+// http.Server, so the registry is used for drain them on shutdown.
+type connections struct {
+	mu      *sync.Mutex
+	wg      *sync.WaitGroup
+	nextID  uint64
+	cancels map[uint64]context.CancelFunc
+}
+
+func newConnections() *connections {
+	return &connections{
+		mu:      &sync.Mutex{},
+		wg:      &sync.WaitGroup{},
+		cancels: make(map[uint64]context.CancelFunc),
+	}
+}
+
+// add - registers the connection, the returned context will be canceled if the connection is not finished
+// within the drain period. The returned function must be called when the connection is finished.
+func (c *connections) add(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	defer c.mu.Unlock()
+	c.mu.Lock()
+
+	id := c.nextID
+	c.nextID++
+	c.cancels[id] = cancel
+	c.wg.Add(1)
+
+	return ctx, func() {
+		defer c.wg.Done()
+		defer c.mu.Unlock()
+		c.mu.Lock()
+		cancel()
+		delete(c.cancels, id)
+	}
+}
+
+// drain - waits for the connections to finish within the given period. The rest ones will be canceled,
+// so the clients receive the 'going away' message with the current offset and can resume elsewhere.
+func (c *connections) drain(period time.Duration) (canceled int) {
+	if c.wait(period) {
+		return 0
+	}
+
+	c.mu.Lock()
+	canceled = len(c.cancels)
+	for _, cancel := range c.cancels {
+		cancel()
+	}
+	c.mu.Unlock()
+
+	c.wait(connectionsCancelTimeout)
+
+	return canceled
+}
+
+// wait - waits for all the connections to finish, returns false if the timeout was reached.
+func (c *connections) wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.wg.Wait()
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
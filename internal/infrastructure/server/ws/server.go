@@ -2,10 +2,18 @@ package ws
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper/ruid"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/health"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
 	streamer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
 	"github.com/gorilla/websocket"
 	"net"
 	"net/http"
@@ -17,9 +25,17 @@ type Server struct {
 	host           string // example: "0.0.0.0"
 	port           string // example: "9988"
 	transportProto string // example: "tcp"
+	publishPath    string // example: "/live/publish"
+	drainPeriod    time.Duration
 
-	streamer streamer_interface.Streamer
-	logger   logger_interface.Logger
+	connections *connections
+
+	streamer  streamer_interface.Streamer
+	publisher live_interface.Publisher
+	logger    logger_interface.Logger
+	metrics   metrics_interface.Metrics
+	tracer    tracer_interface.Tracer
+	health    health_interface.Checker
 }
 
 func NewWebSocketServer(serviceContainer di_interface.ContainerManager) (*Server, error) {
@@ -28,11 +44,31 @@ func NewWebSocketServer(serviceContainer di_interface.ContainerManager) (*Server
 		return nil, err
 	}
 
+	metricsService, err := serviceContainer.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := serviceContainer.GetTracerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	healthChecker, err := serviceContainer.GetHealthCheckerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	streamingService, err := serviceContainer.GetStreamingService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	livePublisher, err := serviceContainer.GetLivePublisher()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	cfg, err := serviceContainer.GetConfig()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -42,70 +78,133 @@ func NewWebSocketServer(serviceContainer di_interface.ContainerManager) (*Server
 		host:           cfg.StreamingHost,
 		port:           cfg.StreamingPort,
 		transportProto: cfg.StreamingTransport,
+		publishPath:    cfg.LivePublishPath,
+		drainPeriod:    cfg.ShutdownDrainPeriod,
+		connections:    newConnections(),
 		streamer:       streamingService,
+		publisher:      livePublisher,
 		logger:         loggerService,
+		metrics:        metricsService,
+		tracer:         tracerService,
+		health:         healthChecker,
 	}, nil
 }
 
 // Listen is method which running a websocket server
-func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) {
+func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) error {
 	defer wg.Done()
 
 	addr, err := net.ResolveTCPAddr(s.transportProto, net.JoinHostPort(s.host, s.port))
 	if err != nil {
-		s.logger.Error(err)
-		return
+		return s.logger.LogPropagate(err)
 	}
 
 	server := &http.Server{
 		Addr:    addr.String(),
-		Handler: http.HandlerFunc(s.handleConnection),
+		Handler: s.handler(),
 	}
 
+	listenErrCh := make(chan error, 1)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		defer s.logger.Info("stopped")
 		if lsErr := server.ListenAndServe(); lsErr != nil && lsErr != http.ErrServerClosed {
-			s.logger.Error(lsErr)
-			return
+			listenErrCh <- lsErr
 		}
 	}()
 
 	s.logger.Info("running...")
-	<-ctx.Done()
+	select {
+	case <-ctx.Done():
+	case err = <-listenErrCh:
+		return s.logger.CriticalPropagate(err)
+	}
 	s.logger.Info("shutting down...")
 
-	serverCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	// the parent context is already canceled, so the timeout must be bound to a new one
+	serverCtx, cancel := context.WithTimeout(context.Background(), s.drainPeriod)
 	defer cancel()
 
-	if sdErr := server.Shutdown(serverCtx); sdErr != nil && sdErr != context.Canceled {
+	// stops accepting the new connections, the hijacked ones are not tracked by the server
+	if sdErr := server.Shutdown(serverCtx); sdErr != nil {
 		s.logger.Error(sdErr)
-		return
 	}
+
+	if canceled := s.connections.drain(s.drainPeriod); canceled > 0 {
+		s.logger.Warning(fmt.Sprintf("%d websocket connections were interrupted after the drain period", canceled))
+	}
+
+	return nil
+}
+
+// handler is method which routes the orchestrator probes, the live publishers
+// and upgrades all the rest requests to websocket
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(health.LivenessPath, s.health.LivenessHandler())
+	mux.Handle(health.ReadinessPath, s.health.ReadinessHandler())
+	mux.HandleFunc(s.publishPath, func(w http.ResponseWriter, r *http.Request) {
+		s.handleConnection(w, r, s.publisher.HandleConn)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.handleConnection(w, r, s.streamer.HandleConn)
+	})
+	return mux
 }
 
-// handleConnection is method which handle each websocket connection
-func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
+// handleConnection is method which handle each websocket connection by the given func
+func (s *Server) handleConnection(
+	w http.ResponseWriter,
+	r *http.Request,
+	handleConn func(ctx context.Context, conn *websocket.Conn),
+) {
 	upgrader := websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
 			return true
 		},
 	}
 
+	// the spans of actions will be bound to the trace of client side (if the trace context was passed on handshake)
+	ctx := s.tracer.Extract(r.Context(), r.Header)
+	// the connection identifier is passed through the entire connection lifecycle as a request id
+	ctx = context.WithValue(ctx, enum.UniqueRequestIDKey, ruid.RequestUniqueID(r))
+	ctx = context.WithValue(ctx, enum.RouteContextKey, "WS "+r.URL.Path)
+	// the client of the upgrade request is recorded by the play sessions
+	ctx = context.WithValue(ctx, enum.ClientIPContextKey, clientIP(r))
+	ctx = context.WithValue(ctx, enum.UserAgentContextKey, r.UserAgent())
+	logger := s.logger.WithContext(ctx)
+
+	// the connection must be registered before upgrading, otherwise it may be missed by the drain on shutdown
+	ctx, done := s.connections.add(ctx)
+	defer done()
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		s.logger.Error(err)
+		logger.Error(err)
 		return
 	}
 	defer func() {
-		if err = conn.Close(); err != nil {
-			s.logger.Error(err)
+		// the drained connection is already closed by the streamer
+		if err = conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			logger.Error(err)
 			return
 		}
 	}()
 
-	s.logger.Info(fmt.Sprintf("[%v]: accpted a new connection", conn.RemoteAddr()))
+	logger.Info(fmt.Sprintf("[%v]: accpted a new connection", conn.RemoteAddr()))
+
+	s.metrics.IncActiveConnections()
+	defer s.metrics.DecActiveConnections()
 
-	s.streamer.HandleConn(conn)
+	handleConn(ctx, conn)
+}
+
+// clientIP - the host of the remote address of the request (the address as is if it has no port).
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
@@ -1,28 +1,67 @@
 package cacher
 
 import (
+	"context"
 	domain_cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/enum"
 	cacher_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Cache struct {
 	storage   cacher_interface.Storage
 	displacer cacher_interface.Displacer
+	tracer    tracer_interface.Tracer
 }
 
-func NewCache(storage cacher_interface.Storage, displacer cacher_interface.Displacer) *Cache {
+func NewCache(
+	storage cacher_interface.Storage,
+	displacer cacher_interface.Displacer,
+	tracer tracer_interface.Tracer,
+) *Cache {
 	c := &Cache{
 		storage:   storage,
 		displacer: displacer,
+		tracer:    tracer,
 	}
 	c.displacer.Run(storage)
 	return c
 }
 
-func (c *Cache) Get(key string, fn func(item domain_cacher_interface.CacheItem) (data interface{}, err error)) (data interface{}, err error) {
-	return c.storage.Get(key, fn)
+func (c *Cache) Get(
+	ctx context.Context,
+	key string,
+	fn func(item domain_cacher_interface.CacheItem) (data interface{}, err error),
+) (data interface{}, err error) {
+	ctx, span := c.tracer.Start(ctx, "cache.Get", trace.WithAttributes(attribute.String("cache.key", key)))
+	defer span.End()
+
+	// the compute function is called only on a cache miss (or in background when the stale item is served)
+	data, status, err := c.storage.Get(key, fn)
+	span.SetAttributes(
+		attribute.Bool("cache.hit", status != enum.Miss),
+		attribute.String("cache.status", string(status)),
+	)
+	if err != nil {
+		tracer.Fail(span, err)
+	}
+
+	return data, err
 }
 
-func (c *Cache) Delete(key string) {
+func (c *Cache) Delete(ctx context.Context, key string) {
+	_, span := c.tracer.Start(ctx, "cache.Delete", trace.WithAttributes(attribute.String("cache.key", key)))
+	defer span.End()
+
 	c.storage.Delete(key)
 }
+
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) {
+	_, span := c.tracer.Start(ctx, "cache.Invalidate", trace.WithAttributes(attribute.StringSlice("cache.tags", tags)))
+	defer span.End()
+
+	c.storage.Invalidate(tags...)
+}
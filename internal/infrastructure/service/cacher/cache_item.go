@@ -8,6 +8,10 @@ type Item struct {
 	data      interface{}
 	addedAt   time.Time
 	expiresAt time.Time
+	tags      []string
+	size      int64 // approx. size in bytes, it's computed when the item is stored
+	err       error // the cached 'not found' error (negative caching), data is nil if it's set
+	local     bool  // the item is not written into the out-of-process storage
 }
 
 func NewCacheItem() *Item {
@@ -21,3 +25,16 @@ func NewCacheItem() *Item {
 func (i *Item) SetTTL(ttl time.Duration) {
 	i.expiresAt = time.Now().Add(ttl)
 }
+
+func (i *Item) AddTags(tags ...string) {
+	i.tags = append(i.tags, tags...)
+}
+
+func (i *Item) SetLocal() {
+	i.local = true
+}
+
+// expired - checks whether the item is not fresh at the moment (it still may be served as stale).
+func (i *Item) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !i.expiresAt.After(now)
+}
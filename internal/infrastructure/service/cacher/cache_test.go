@@ -0,0 +1,78 @@
+package cacher
+
+import (
+	"context"
+	domain_cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	cacher_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testTracer - records the finished spans in memory.
+type testTracer struct {
+	recorder *tracetest.SpanRecorder
+	tracer   trace.Tracer
+}
+
+func newTestTracer() *testTracer {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return &testTracer{recorder: recorder, tracer: provider.Tracer("test")}
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, name, opts...)
+}
+func (t *testTracer) Extract(ctx context.Context, header http.Header) context.Context { return ctx }
+func (t *testTracer) Shutdown(ctx context.Context) error                              { return nil }
+
+// testDisplacer - the items are not displaced by tests.
+type testDisplacer struct{}
+
+func (d testDisplacer) Run(storage cacher_interface.Storage) {}
+func (d testDisplacer) Stop()                                {}
+
+func TestCache_GetSpanStatus(t *testing.T) {
+	tracer := newTestTracer()
+	c := NewCache(newTestMapStorage(MapCacheOptions{StaleWhileRevalidate: time.Hour}), testDisplacer{}, tracer)
+
+	// miss, hit, then stale (while the revalidation runs in background)
+	_, _ = c.Get(context.Background(), "key", computeOf("data", 10*time.Millisecond))
+	_, _ = c.Get(context.Background(), "key", computeOf("data", 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	_, _ = c.Get(context.Background(), "key", func(item domain_cacher_interface.CacheItem) (interface{}, error) {
+		// the revalidation is slowed down, so it overlaps the setting of span attributes
+		time.Sleep(5 * time.Millisecond)
+		item.SetTTL(time.Hour)
+		return "data", nil
+	})
+
+	expected := []struct {
+		hit    bool
+		status string
+	}{
+		{hit: false, status: "miss"},
+		{hit: true, status: "hit"},
+		{hit: true, status: "stale"},
+	}
+
+	spans := tracer.recorder.Ended()
+	if len(spans) != len(expected) {
+		t.Fatalf("expected %d spans, got %d", len(expected), len(spans))
+	}
+	for i, span := range spans {
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, attr := range span.Attributes() {
+			attrs[attr.Key] = attr.Value
+		}
+		if attrs["cache.hit"].AsBool() != expected[i].hit || attrs["cache.status"].AsString() != expected[i].status {
+			t.Fatalf("span %d: expected hit=%v status=%v, got hit=%v status=%v", i,
+				expected[i].hit, expected[i].status, attrs["cache.hit"].AsBool(), attrs["cache.status"].AsString())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cacher
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// envelope - the wrapper of the cached value, the concrete type of the data is encoded together with it.
+type envelope struct {
+	Data      interface{}
+	ExpiresAt time.Time
+	Tags      []string
+}
+
+// GobCodec - serializes the cached values for the storages which keep them out of process. The gob is used
+// instead of json because it encodes all exported fields of aggregates (json tags hide some of them), so the values
+// with credentials (e.g. secrets and password hashes of users) must be cached as local items, they are not encoded.
+// The types are registered lazily on encoding, so a value which was not computed on the node yet cannot be decoded
+// there (it's considered as a miss and computed, so the type will be registered).
+type GobCodec struct {
+	mu         *sync.RWMutex
+	registered map[reflect.Type]struct{}
+}
+
+func NewGobCodec() *GobCodec {
+	return &GobCodec{
+		mu:         &sync.RWMutex{},
+		registered: make(map[reflect.Type]struct{}),
+	}
+}
+
+func (c *GobCodec) Encode(item *Item) ([]byte, error) {
+	c.register(item.data)
+
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(&envelope{Data: item.data, ExpiresAt: item.expiresAt, Tags: item.tags}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *GobCodec) Decode(b []byte) (*Item, error) {
+	e := &envelope{}
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(e); err != nil {
+		return nil, err
+	}
+	return &Item{data: e.Data, addedAt: time.Now(), expiresAt: e.ExpiresAt, tags: e.Tags}, nil
+}
+
+// register - registers the type of data by the full name (with package path), so the function-local
+// types with equal names from different packages do not collide.
+func (c *GobCodec) register(data interface{}) {
+	t := reflect.TypeOf(data)
+	if t == nil {
+		return
+	}
+
+	c.mu.RLock()
+	_, found := c.registered[t]
+	c.mu.RUnlock()
+	if found {
+		return
+	}
+
+	defer c.mu.Unlock()
+	c.mu.Lock()
+
+	func() {
+		// the type is already registered under another name (e.g. builtin types), so it can be encoded anyway
+		defer func() { _ = recover() }()
+		gob.RegisterName(typeName(t), data)
+	}()
+	c.registered[t] = struct{}{}
+}
+
+func typeName(t reflect.Type) string {
+	star := ""
+	if t.Kind() == reflect.Pointer {
+		star = "*"
+		t = t.Elem()
+	}
+	if t.Name() == "" || t.PkgPath() == "" {
+		return star + t.String()
+	}
+	return star + t.PkgPath() + "." + t.Name()
+}
@@ -0,0 +1,57 @@
+package cacher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testValue - the cached value of the type which is not registered in gob before the encoding.
+type testValue struct {
+	ID   string
+	Tags []string
+}
+
+// testAgg - the value which is cached by pointer (like aggregates), the gob registry is global,
+// so the type is not shared with the values to keep the registered pointer type.
+type testAgg struct {
+	ID string
+}
+
+func TestGobCodec_EncodeDecode(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Round(0)
+
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{name: "builtin type", data: "data"},
+		{name: "struct", data: testValue{ID: "1", Tags: []string{"a", "b"}}},
+		{name: "pointer to struct", data: &testAgg{ID: "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := NewGobCodec()
+
+			b, err := codec.Encode(&Item{data: tt.data, expiresAt: expiresAt, tags: []string{"tag"}})
+			if err != nil {
+				t.Fatalf("unexpected encoding error: %v", err)
+			}
+			item, err := codec.Decode(b)
+			if err != nil {
+				t.Fatalf("unexpected decoding error: %v", err)
+			}
+
+			if !reflect.DeepEqual(item.data, tt.data) {
+				t.Fatalf("expected data %#v, got %#v", tt.data, item.data)
+			}
+			if !item.expiresAt.Equal(expiresAt) {
+				t.Fatalf("expected expiration %v, got %v", expiresAt, item.expiresAt)
+			}
+			if !reflect.DeepEqual(item.tags, []string{"tag"}) {
+				t.Fatalf("expected tags [tag], got %v", item.tags)
+			}
+		})
+	}
+}
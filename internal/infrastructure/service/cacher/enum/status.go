@@ -0,0 +1,13 @@
+package enum
+
+const (
+	// Hit - the data was served from the cache (the local one or Redis).
+	Hit Status = "hit"
+	// Stale - the expired data was served within the stale window, it's being recomputed in background.
+	Stale Status = "stale"
+	// Miss - the data was computed (or the caller waited for the concurrent computing of it).
+	Miss Status = "miss"
+)
+
+// Status - tells how the data of the key was obtained by the storage.
+type Status string
@@ -0,0 +1,161 @@
+package cacher
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+const (
+	// LRUEvictionPolicy - the least recently used item is evicted first.
+	LRUEvictionPolicy = "lru"
+	// LFUEvictionPolicy - the least frequently used item is evicted first (the least recently used one of equals).
+	LFUEvictionPolicy = "lfu"
+	// NoneEvictionPolicy - the items are removed on expiration only, the limits are not applied.
+	NoneEvictionPolicy = "none"
+)
+
+// evictionPolicy - chooses the item which will be removed when the limits of the shard are reached.
+// The methods are called under the lock of shard.
+type evictionPolicy interface {
+	// added - registers the new key.
+	added(key string)
+	// accessed - registers the hit of the key.
+	accessed(key string)
+	// removed - unregisters the key (it's deleted, invalidated, expired or evicted).
+	removed(key string)
+	// victim - returns the key which must be evicted first.
+	victim() (key string, found bool)
+}
+
+func newEvictionPolicy(name string) evictionPolicy {
+	switch name {
+	case LFUEvictionPolicy:
+		return newLFUPolicy()
+	case NoneEvictionPolicy:
+		return noneEvictionPolicy{}
+	default:
+		return newLRUPolicy()
+	}
+}
+
+type lruPolicy struct {
+	order    *list.List // the front is the most recently used key
+	elements map[string]*list.Element
+}
+
+func newLRUPolicy() *lruPolicy {
+	return &lruPolicy{
+		order:    list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+func (p *lruPolicy) added(key string) {
+	p.elements[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy) accessed(key string) {
+	if element, found := p.elements[key]; found {
+		p.order.MoveToFront(element)
+	}
+}
+
+func (p *lruPolicy) removed(key string) {
+	if element, found := p.elements[key]; found {
+		p.order.Remove(element)
+		delete(p.elements, key)
+	}
+}
+
+func (p *lruPolicy) victim() (key string, found bool) {
+	if element := p.order.Back(); element != nil {
+		return element.Value.(string), true
+	}
+	return "", false
+}
+
+type lfuPolicy struct {
+	entries lfuHeap
+	index   map[string]*lfuEntry
+	tick    uint64 // the logical time of accesses, breaks the ties of frequencies
+}
+
+type lfuEntry struct {
+	key       string
+	frequency uint64
+	tick      uint64
+	pos       int
+}
+
+func newLFUPolicy() *lfuPolicy {
+	return &lfuPolicy{index: make(map[string]*lfuEntry)}
+}
+
+func (p *lfuPolicy) added(key string) {
+	p.tick++
+	entry := &lfuEntry{key: key, frequency: 1, tick: p.tick}
+	p.index[key] = entry
+	heap.Push(&p.entries, entry)
+}
+
+func (p *lfuPolicy) accessed(key string) {
+	if entry, found := p.index[key]; found {
+		p.tick++
+		entry.frequency++
+		entry.tick = p.tick
+		heap.Fix(&p.entries, entry.pos)
+	}
+}
+
+func (p *lfuPolicy) removed(key string) {
+	if entry, found := p.index[key]; found {
+		heap.Remove(&p.entries, entry.pos)
+		delete(p.index, key)
+	}
+}
+
+func (p *lfuPolicy) victim() (key string, found bool) {
+	if len(p.entries) == 0 {
+		return "", false
+	}
+	return p.entries[0].key, true
+}
+
+// lfuHeap - the min-heap of entries by frequency and then by the last access.
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].frequency != h[j].frequency {
+		return h[i].frequency < h[j].frequency
+	}
+	return h[i].tick < h[j].tick
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos = i
+	h[j].pos = j
+}
+
+func (h *lfuHeap) Push(x any) {
+	entry := x.(*lfuEntry)
+	entry.pos = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
+
+type noneEvictionPolicy struct{}
+
+func (noneEvictionPolicy) added(string)                  {}
+func (noneEvictionPolicy) accessed(string)               {}
+func (noneEvictionPolicy) removed(string)                {}
+func (noneEvictionPolicy) victim() (key string, ok bool) { return "", false }
@@ -0,0 +1,99 @@
+package cacher
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testPolicyOp - the operation of the policy: "+" adds, "*" accesses and "-" removes the key.
+type testPolicyOp struct {
+	op  string
+	key string
+}
+
+func TestEvictionPolicy_Victim(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		ops     []testPolicyOp
+		victims []string // the keys in order of eviction
+	}{
+		{
+			name:    "lru by insertion",
+			policy:  LRUEvictionPolicy,
+			ops:     []testPolicyOp{{"+", "a"}, {"+", "b"}, {"+", "c"}},
+			victims: []string{"a", "b", "c"},
+		},
+		{
+			name:    "lru access moves the key to the end",
+			policy:  LRUEvictionPolicy,
+			ops:     []testPolicyOp{{"+", "a"}, {"+", "b"}, {"+", "c"}, {"*", "a"}, {"*", "b"}},
+			victims: []string{"c", "a", "b"},
+		},
+		{
+			name:    "lru removed key is not a victim",
+			policy:  LRUEvictionPolicy,
+			ops:     []testPolicyOp{{"+", "a"}, {"+", "b"}, {"-", "a"}, {"*", "x"}, {"-", "x"}},
+			victims: []string{"b"},
+		},
+		{
+			name:    "lfu by frequency",
+			policy:  LFUEvictionPolicy,
+			ops:     []testPolicyOp{{"+", "a"}, {"+", "b"}, {"+", "c"}, {"*", "a"}, {"*", "a"}, {"*", "c"}},
+			victims: []string{"b", "c", "a"},
+		},
+		{
+			name:    "lfu ties are broken by recency",
+			policy:  LFUEvictionPolicy,
+			ops:     []testPolicyOp{{"+", "a"}, {"+", "b"}, {"*", "b"}, {"*", "a"}},
+			victims: []string{"b", "a"},
+		},
+		{
+			name:    "lfu removed key is not a victim",
+			policy:  LFUEvictionPolicy,
+			ops:     []testPolicyOp{{"+", "a"}, {"+", "b"}, {"+", "c"}, {"-", "a"}, {"*", "x"}},
+			victims: []string{"b", "c"},
+		},
+		{
+			name:   "none has no victims",
+			policy: NoneEvictionPolicy,
+			ops:    []testPolicyOp{{"+", "a"}, {"+", "b"}},
+		},
+		{
+			name:    "undefined policy is lru",
+			policy:  "fifo",
+			ops:     []testPolicyOp{{"+", "a"}, {"+", "b"}, {"*", "a"}},
+			victims: []string{"b", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newEvictionPolicy(tt.policy)
+			for _, op := range tt.ops {
+				switch op.op {
+				case "+":
+					p.added(op.key)
+				case "*":
+					p.accessed(op.key)
+				case "-":
+					p.removed(op.key)
+				}
+			}
+
+			var victims []string
+			for {
+				key, found := p.victim()
+				if !found {
+					break
+				}
+				victims = append(victims, key)
+				p.removed(key)
+			}
+
+			if !reflect.DeepEqual(victims, tt.victims) {
+				t.Errorf("victims = %v, want %v", victims, tt.victims)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package cacher
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/enum"
+	"sync"
+)
+
+// flightGroup - deduplicates the concurrent loadings of the same key, so a missed popular key
+// is computed once and the result is shared by all the callers which are waiting for it.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	wg     sync.WaitGroup
+	item   *Item
+	status enum.Status
+	err    error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{flights: make(map[string]*flight)}
+}
+
+// do - calls the load func or waits for the result of the call which is already in progress.
+// The status of the loading is shared by the waiters too.
+func (g *flightGroup) do(key string, load func() (*Item, enum.Status, error)) (*Item, enum.Status, error) {
+	g.mu.Lock()
+	if f, found := g.flights[key]; found {
+		g.mu.Unlock()
+		f.wg.Wait()
+		return f.item, f.status, f.err
+	}
+	f := g.start(key)
+	g.mu.Unlock()
+
+	g.run(key, f, load)
+	return f.item, f.status, f.err
+}
+
+// doAsync - calls the load func in background unless the call is already in progress.
+func (g *flightGroup) doAsync(key string, load func() (*Item, enum.Status, error)) {
+	g.mu.Lock()
+	if _, found := g.flights[key]; found {
+		g.mu.Unlock()
+		return
+	}
+	f := g.start(key)
+	g.mu.Unlock()
+
+	go g.run(key, f, load)
+}
+
+// start - registers the flight (the lock must be held by the caller).
+func (g *flightGroup) start(key string) *flight {
+	f := &flight{}
+	f.wg.Add(1)
+	g.flights[key] = f
+	return f
+}
+
+func (g *flightGroup) run(key string, f *flight, load func() (*Item, enum.Status, error)) {
+	defer func() {
+		// the waiters must not receive an empty result, the panic is not propagated because the call may be async
+		if r := recover(); r != nil {
+			f.item, f.status, f.err = nil, enum.Miss, fmt.Errorf("cache: loading of key '%v' panicked: %v", key, r)
+		}
+
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		f.wg.Done()
+	}()
+
+	f.item, f.status, f.err = load()
+}
@@ -0,0 +1,93 @@
+package cacher
+
+import "sync"
+
+// generations - the sequence of invalidations which lets to drop the loaded item if any of its tags was
+// invalidated while it was loading: the load may read the value before the write which invalidated
+// the tag, so storing it afterwards would serve the stale value for the full TTL. The tags are known
+// only when the item is loaded, so the sequence number is taken before the load and compared with
+// the numbers of the last invalidations of its tags. The invalidations are kept only while there are
+// loads which were started before them.
+type generations struct {
+	mu      sync.Mutex
+	seq     uint64
+	purged  uint64            // seq of the last invalidation of all tags
+	tags    map[string]uint64 // seq of the last invalidation by tag
+	loading map[uint64]int    // number of in-flight loads by the seq they were started at
+}
+
+func newGenerations() *generations {
+	return &generations{
+		tags:    map[string]uint64{},
+		loading: map[uint64]int{},
+	}
+}
+
+// begin - registers the load and returns the seq which must be passed to end.
+func (g *generations) begin() (started uint64) {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	g.loading[g.seq]++
+	return g.seq
+}
+
+// end - unregisters the load, returns false if any of tags was invalidated after the load was started.
+func (g *generations) end(started uint64, tags []string) (isValid bool) {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	isValid = g.purged <= started
+	for _, tag := range tags {
+		if g.tags[tag] > started {
+			isValid = false
+		}
+	}
+
+	if g.loading[started]--; g.loading[started] <= 0 {
+		delete(g.loading, started)
+	}
+	g.prune()
+
+	return isValid
+}
+
+// invalidate - marks the tags as invalidated, the loads which are in-flight at now will not be stored.
+func (g *generations) invalidate(tags ...string) {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	g.seq++
+	for _, tag := range tags {
+		g.tags[tag] = g.seq
+	}
+	g.prune()
+}
+
+// invalidateAll - marks all the tags as invalidated (used when the invalidations may be missed).
+func (g *generations) invalidateAll() {
+	defer g.mu.Unlock()
+	g.mu.Lock()
+
+	g.seq++
+	g.purged = g.seq
+	g.prune()
+}
+
+// prune - forgets the invalidations which cannot affect the in-flight loads (the lock must be held by the caller).
+func (g *generations) prune() {
+	if len(g.loading) == 0 {
+		clear(g.tags)
+		return
+	}
+
+	oldest := g.seq
+	for started := range g.loading {
+		oldest = min(oldest, started)
+	}
+	for tag, seq := range g.tags {
+		if seq <= oldest {
+			delete(g.tags, tag)
+		}
+	}
+}
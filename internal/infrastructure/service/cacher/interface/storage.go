@@ -1,9 +1,17 @@
 package cacher_interface
 
-import cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+import (
+	cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/enum"
+)
 
 type Storage interface {
-	Get(key string, fn func(cacher_interface.CacheItem) (data interface{}, err error)) (data interface{}, err error)
+	// Get will return the stored data of the key or compute it by the func, the status tells which one was done.
+	Get(
+		key string,
+		fn func(cacher_interface.CacheItem) (data interface{}, err error),
+	) (data interface{}, status enum.Status, err error)
 	Delete(key string)
+	Invalidate(tags ...string)
 	Displace()
 }
@@ -0,0 +1,362 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Options - the settings of the client.
+type Options struct {
+	// Address is a host and port of the server, for example: localhost:6379.
+	Address string
+	// Password is used for AUTH command if it's not empty.
+	Password string
+	// DB is a number of the database which will be selected on each connection.
+	DB int
+	// PoolSize is a max. number of idle connections.
+	PoolSize int
+	// Timeout is a timeout of dialing and of each command (the deadline of the context is used if it's earlier).
+	Timeout time.Duration
+}
+
+// Client - the minimal client of Redis protocol (RESP2) with a pool of connections.
+// It's safe for concurrent use.
+type Client struct {
+	opts Options
+	idle chan *conn
+
+	mu     *sync.Mutex
+	closed bool
+}
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+func NewClient(opts Options) *Client {
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = 10
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Second
+	}
+
+	return &Client{
+		opts: opts,
+		idle: make(chan *conn, opts.PoolSize),
+		mu:   &sync.Mutex{},
+	}
+}
+
+// Do - sends the command and returns its reply (see readReply for the types mapping).
+// The error reply of the server is returned as Error.
+func (c *Client) Do(ctx context.Context, args ...string) (reply interface{}, err error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// the connection state is unknown after the network error, so it cannot be reused
+		if _, isReplyErr := err.(Error); err != nil && !isReplyErr {
+			_ = cn.Close()
+			return
+		}
+		c.put(cn)
+	}()
+
+	if err = cn.SetDeadline(c.deadline(ctx)); err != nil {
+		return nil, err
+	}
+	if err = writeCommand(cn.w, args...); err != nil {
+		return nil, err
+	}
+
+	reply, err = readReply(cn.r)
+	if err != nil {
+		return nil, err
+	}
+	if replyErr, isReplyErr := reply.(Error); isReplyErr {
+		return nil, replyErr
+	}
+
+	return reply, nil
+}
+
+// Ping - checks the availability of the server.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Get - returns the value of the key or Nil if it does not exist.
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := c.Do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, Nil
+	}
+
+	b, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected reply %T of GET command", reply)
+	}
+	return b, nil
+}
+
+// Set - sets the value of the key, zero ttl means that the key will not be expired.
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+
+	_, err := c.Do(ctx, args...)
+	return err
+}
+
+// MGet - returns the values of the keys in the same order, the value of missing key is nil.
+func (c *Client) MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	reply, err := c.Do(ctx, append([]string{"MGET"}, keys...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := reply.([]interface{})
+	if !ok || len(items) != len(keys) {
+		return nil, fmt.Errorf("redis: unexpected reply %T of MGET command", reply)
+	}
+	values := make([][]byte, len(items))
+	for i, item := range items {
+		if b, isBytes := item.([]byte); isBytes {
+			values[i] = b
+		}
+	}
+	return values, nil
+}
+
+// Incr - increments the number of the key (a missing key is considered as zero), returns the new value.
+func (c *Client) Incr(ctx context.Context, key string) (int64, error) {
+	reply, err := c.Do(ctx, "INCR", key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected reply %T of INCR command", reply)
+	}
+	return n, nil
+}
+
+// Del - removes the keys, returns the number of removed ones.
+func (c *Client) Del(ctx context.Context, keys ...string) (int64, error) {
+	reply, err := c.Do(ctx, append([]string{"DEL"}, keys...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected reply %T of DEL command", reply)
+	}
+	return n, nil
+}
+
+// SAdd - adds the members into the set.
+func (c *Client) SAdd(ctx context.Context, key string, members ...string) error {
+	_, err := c.Do(ctx, append([]string{"SADD", key}, members...)...)
+	return err
+}
+
+// SMembers - returns all members of the set (an empty slice if it does not exist).
+func (c *Client) SMembers(ctx context.Context, key string) ([]string, error) {
+	reply, err := c.Do(ctx, "SMEMBERS", key)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected reply %T of SMEMBERS command", reply)
+	}
+	members := make([]string, 0, len(items))
+	for _, item := range items {
+		if b, isBytes := item.([]byte); isBytes {
+			members = append(members, string(b))
+		}
+	}
+	return members, nil
+}
+
+// PExpire - sets the ttl of the key.
+func (c *Client) PExpire(ctx context.Context, key string, ttl time.Duration) error {
+	_, err := c.Do(ctx, "PEXPIRE", key, strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Publish - sends the message into the channel.
+func (c *Client) Publish(ctx context.Context, channel string, message string) error {
+	_, err := c.Do(ctx, "PUBLISH", channel, message)
+	return err
+}
+
+// Subscribe - listens to the channel on a dedicated connection and passes each message into the given func.
+// The method blocks until the context will be canceled (nil is returned) or the connection will be broken.
+func (c *Client) Subscribe(ctx context.Context, channel string, fn func(message string)) error {
+	cn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cn.Close() }()
+
+	if err = cn.SetDeadline(c.deadline(ctx)); err != nil {
+		return err
+	}
+	if err = writeCommand(cn.w, "SUBSCRIBE", channel); err != nil {
+		return err
+	}
+	if _, err = readReply(cn.r); err != nil {
+		return err
+	}
+
+	// the messages are awaited without a deadline, the connection is closed on the context cancellation
+	if err = cn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { _ = cn.Close() })
+	defer stop()
+
+	for {
+		reply, rerr := readReply(cn.r)
+		if rerr != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return rerr
+		}
+
+		// the message is an array: ["message", channel, payload]
+		items, ok := reply.([]interface{})
+		if !ok || len(items) != 3 {
+			continue
+		}
+		if kind, isBytes := items[0].([]byte); !isBytes || string(kind) != "message" {
+			continue
+		}
+		if payload, isBytes := items[2].([]byte); isBytes {
+			fn(string(payload))
+		}
+	}
+}
+
+// Close - closes the idle connections, the client cannot be used after that.
+func (c *Client) Close() error {
+	defer c.mu.Unlock()
+	c.mu.Lock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	close(c.idle)
+	for cn := range c.idle {
+		_ = cn.Close()
+	}
+
+	return nil
+}
+
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn, ok := <-c.idle:
+		if ok {
+			return cn, nil
+		}
+		return nil, ErrClosed
+	default:
+		return c.dial(ctx)
+	}
+}
+
+func (c *Client) put(cn *conn) {
+	defer c.mu.Unlock()
+	c.mu.Lock()
+
+	if c.closed {
+		_ = cn.Close()
+		return
+	}
+
+	select {
+	case c.idle <- cn:
+	default:
+		// the pool is full
+		_ = cn.Close()
+	}
+}
+
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	dialer := &net.Dialer{Timeout: c.opts.Timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.opts.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	cn := &conn{
+		Conn: netConn,
+		r:    bufio.NewReader(netConn),
+		w:    bufio.NewWriter(netConn),
+	}
+
+	if err = cn.SetDeadline(c.deadline(ctx)); err != nil {
+		_ = cn.Close()
+		return nil, err
+	}
+	if c.opts.Password != "" {
+		if err = c.handshake(cn, "AUTH", c.opts.Password); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+	if c.opts.DB != 0 {
+		if err = c.handshake(cn, "SELECT", strconv.Itoa(c.opts.DB)); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+
+	return cn, nil
+}
+
+func (c *Client) handshake(cn *conn, args ...string) error {
+	if err := writeCommand(cn.w, args...); err != nil {
+		return err
+	}
+	reply, err := readReply(cn.r)
+	if err != nil {
+		return err
+	}
+	if replyErr, isReplyErr := reply.(Error); isReplyErr {
+		return replyErr
+	}
+	return nil
+}
+
+// deadline - returns the earliest of the context deadline and the command timeout.
+func (c *Client) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(c.opts.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
@@ -0,0 +1,375 @@
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeServer - the in-process server which speaks Redis protocol and supports the subset of commands
+// used by the cache storage (PING, AUTH, SELECT, GET, SET, DEL, SADD, SMEMBERS, PEXPIRE, PUBLISH, SUBSCRIBE, FLUSHALL).
+// It's intended for local development and tests, when the real server is not available.
+type FakeServer struct {
+	listener net.Listener
+	wg       *sync.WaitGroup
+
+	mu          *sync.Mutex
+	values      map[string]fakeValue
+	subscribers map[string]map[*fakeConn]struct{}
+	conns       map[*fakeConn]struct{}
+}
+
+type fakeValue struct {
+	data      []byte
+	members   map[string]struct{} // not nil if the value is a set
+	expiresAt time.Time
+}
+
+type fakeConn struct {
+	net.Conn
+	w  *bufio.Writer
+	mu *sync.Mutex
+}
+
+// NewFakeServer - starts the server on the given address (use "127.0.0.1:0" for a random port).
+func NewFakeServer(address string) (*FakeServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FakeServer{
+		listener:    listener,
+		wg:          &sync.WaitGroup{},
+		mu:          &sync.Mutex{},
+		values:      make(map[string]fakeValue),
+		subscribers: make(map[string]map[*fakeConn]struct{}),
+		conns:       make(map[*fakeConn]struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.accept()
+
+	return s, nil
+}
+
+// Addr - returns the address on which the server is listening.
+func (s *FakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close - stops the server and closes all the connections.
+func (s *FakeServer) Close() error {
+	err := s.listener.Close()
+
+	s.mu.Lock()
+	for cn := range s.conns {
+		_ = cn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *FakeServer) accept() {
+	defer s.wg.Done()
+
+	for {
+		netConn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		cn := &fakeConn{Conn: netConn, w: bufio.NewWriter(netConn), mu: &sync.Mutex{}}
+		s.mu.Lock()
+		s.conns[cn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.serve(cn)
+	}
+}
+
+func (s *FakeServer) serve(cn *fakeConn) {
+	defer s.wg.Done()
+	defer s.disconnect(cn)
+
+	r := bufio.NewReader(cn)
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+
+		items, ok := reply.([]interface{})
+		if !ok || len(items) == 0 {
+			s.write(cn, Error("ERR protocol error"))
+			continue
+		}
+		args := make([]string, 0, len(items))
+		for _, item := range items {
+			if b, isBytes := item.([]byte); isBytes {
+				args = append(args, string(b))
+			}
+		}
+		if len(args) != len(items) {
+			s.write(cn, Error("ERR protocol error"))
+			continue
+		}
+
+		s.write(cn, s.exec(cn, args))
+	}
+}
+
+func (s *FakeServer) exec(cn *fakeConn, args []string) interface{} {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "PONG"
+	case "AUTH", "SELECT":
+		return "OK"
+	case "FLUSHALL":
+		s.values = make(map[string]fakeValue)
+		return "OK"
+	case "GET":
+		if len(args) != 2 {
+			return wrongArgs(args[0])
+		}
+		if v, found := s.lookup(args[1]); found {
+			if v.members != nil {
+				return wrongType()
+			}
+			return v.data
+		}
+		return nil
+	case "MGET":
+		if len(args) < 2 {
+			return wrongArgs(args[0])
+		}
+		values := make([]interface{}, 0, len(args)-1)
+		for _, key := range args[1:] {
+			if v, found := s.lookup(key); found && v.members == nil {
+				values = append(values, v.data)
+				continue
+			}
+			values = append(values, nil)
+		}
+		return values
+	case "INCR":
+		if len(args) != 2 {
+			return wrongArgs(args[0])
+		}
+		v, found := s.lookup(args[1])
+		if found && v.members != nil {
+			return wrongType()
+		}
+		var n int64
+		if found {
+			var err error
+			if n, err = strconv.ParseInt(string(v.data), 10, 64); err != nil {
+				return Error("ERR value is not an integer or out of range")
+			}
+		}
+		n++
+		v.data = []byte(strconv.FormatInt(n, 10))
+		s.values[args[1]] = v
+		return n
+	case "SADD":
+		if len(args) < 3 {
+			return wrongArgs(args[0])
+		}
+		v, found := s.lookup(args[1])
+		if found && v.members == nil {
+			return wrongType()
+		}
+		if !found {
+			v = fakeValue{members: make(map[string]struct{})}
+		}
+		var n int64
+		for _, member := range args[2:] {
+			if _, exists := v.members[member]; !exists {
+				v.members[member] = struct{}{}
+				n++
+			}
+		}
+		s.values[args[1]] = v
+		return n
+	case "SMEMBERS":
+		if len(args) != 2 {
+			return wrongArgs(args[0])
+		}
+		v, found := s.lookup(args[1])
+		if found && v.members == nil {
+			return wrongType()
+		}
+		members := make([]interface{}, 0, len(v.members))
+		for member := range v.members {
+			members = append(members, []byte(member))
+		}
+		return members
+	case "PEXPIRE":
+		if len(args) != 3 {
+			return wrongArgs(args[0])
+		}
+		ms, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return Error("ERR value is not an integer or out of range")
+		}
+		v, found := s.lookup(args[1])
+		if !found {
+			return int64(0)
+		}
+		v.expiresAt = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		s.values[args[1]] = v
+		return int64(1)
+	case "SET":
+		return s.set(args)
+	case "DEL":
+		if len(args) < 2 {
+			return wrongArgs(args[0])
+		}
+		var n int64
+		for _, key := range args[1:] {
+			if _, found := s.lookup(key); found {
+				delete(s.values, key)
+				n++
+			}
+		}
+		return n
+	case "PUBLISH":
+		if len(args) != 3 {
+			return wrongArgs(args[0])
+		}
+		var n int64
+		for subscriber := range s.subscribers[args[1]] {
+			// the subscriber is written asynchronously, so the lock is not held while writing into the socket
+			go s.write(subscriber, []interface{}{[]byte("message"), []byte(args[1]), []byte(args[2])})
+			n++
+		}
+		return n
+	case "SUBSCRIBE":
+		if len(args) < 2 {
+			return wrongArgs(args[0])
+		}
+		confirmations := make([]interface{}, 0, len(args)-1)
+		for i, channel := range args[1:] {
+			if s.subscribers[channel] == nil {
+				s.subscribers[channel] = make(map[*fakeConn]struct{})
+			}
+			s.subscribers[channel][cn] = struct{}{}
+			confirmations = append(confirmations, []interface{}{[]byte("subscribe"), []byte(channel), int64(i + 1)})
+		}
+		if len(confirmations) == 1 {
+			return confirmations[0]
+		}
+		return multiReply(confirmations)
+	}
+
+	return Error(fmt.Sprintf("ERR unknown command '%v'", args[0]))
+}
+
+func (s *FakeServer) set(args []string) interface{} {
+	if len(args) < 3 {
+		return wrongArgs(args[0])
+	}
+
+	v := fakeValue{data: []byte(args[2])}
+	for i := 3; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "PX", "EX":
+			if i+1 >= len(args) {
+				return Error("ERR syntax error")
+			}
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return Error("ERR invalid expire time in 'set' command")
+			}
+			unit := time.Millisecond
+			if strings.ToUpper(args[i]) == "EX" {
+				unit = time.Second
+			}
+			v.expiresAt = time.Now().Add(time.Duration(n) * unit)
+			i++
+		default:
+			return Error("ERR syntax error")
+		}
+	}
+	s.values[args[1]] = v
+
+	return "OK"
+}
+
+// lookup - returns the value if it exists and not expired (the expired one is removed).
+func (s *FakeServer) lookup(key string) (fakeValue, bool) {
+	v, found := s.values[key]
+	if found && !v.expiresAt.IsZero() && !time.Now().Before(v.expiresAt) {
+		delete(s.values, key)
+		return fakeValue{}, false
+	}
+	return v, found
+}
+
+func (s *FakeServer) disconnect(cn *fakeConn) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+
+	_ = cn.Close()
+	delete(s.conns, cn)
+	for _, subscribers := range s.subscribers {
+		delete(subscribers, cn)
+	}
+}
+
+// multiReply - the replies which are written one by one (not as a nested array).
+type multiReply []interface{}
+
+func (s *FakeServer) write(cn *fakeConn, reply interface{}) {
+	defer cn.mu.Unlock()
+	cn.mu.Lock()
+
+	if replies, isMulti := reply.(multiReply); isMulti {
+		for _, r := range replies {
+			writeReply(cn.w, r)
+		}
+	} else {
+		writeReply(cn.w, reply)
+	}
+	_ = cn.w.Flush()
+}
+
+func writeReply(w *bufio.Writer, reply interface{}) {
+	switch v := reply.(type) {
+	case nil:
+		_, _ = w.WriteString("$-1\r\n")
+	case string:
+		_, _ = fmt.Fprintf(w, "+%s\r\n", v)
+	case Error:
+		_, _ = fmt.Fprintf(w, "-%s\r\n", v)
+	case int64:
+		_, _ = fmt.Fprintf(w, ":%d\r\n", v)
+	case []byte:
+		_, _ = fmt.Fprintf(w, "$%d\r\n", len(v))
+		_, _ = w.Write(v)
+		_, _ = w.WriteString("\r\n")
+	case []interface{}:
+		_, _ = fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	}
+}
+
+func wrongArgs(command string) Error {
+	return Error(fmt.Sprintf("ERR wrong number of arguments for '%v' command", strings.ToLower(command)))
+}
+
+func wrongType() Error {
+	return Error("WRONGTYPE Operation against a key holding the wrong kind of value")
+}
@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Nil - the reply of the Redis server when the key does not exist.
+var Nil = errors.New("redis: nil")
+
+// ErrClosed - the error of the command which was sent after the client had been closed.
+var ErrClosed = errors.New("redis: client is closed")
+
+// Error - the error reply of the Redis server (for example: "WRONGTYPE Operation against a key...").
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// writeCommand - writes the command as an array of bulk strings (the only form of requests which the server accepts).
+func writeCommand(w *bufio.Writer, args ...string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if err := writeBulk(w, arg); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func writeBulk(w *bufio.Writer, s string) error {
+	_, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(s), s)
+	return err
+}
+
+// readReply - reads one reply. The types are mapped as follows: simple string - string, error - Error,
+// integer - int64, bulk string - []byte, array - []interface{}, null bulk string and null array - nil.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, fmt.Errorf("redis: empty reply line")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return Error(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, perr := strconv.Atoi(line[1:])
+		if perr != nil {
+			return nil, perr
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		b := make([]byte, n+2)
+		if _, err = io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	case '*':
+		n, perr := strconv.Atoi(line[1:])
+		if perr != nil {
+			return nil, perr
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			item, ierr := readReply(r)
+			if ierr != nil {
+				return nil, ierr
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("redis: unexpected reply type '%c'", line[0])
+}
+
+// readLine - reads the line without the trailing CRLF.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
@@ -0,0 +1,107 @@
+package cacher
+
+import (
+	"reflect"
+	"time"
+)
+
+const (
+	// itemOverhead is an approximate size of the item bookkeeping (the map entry, the item and the policy entry).
+	itemOverhead = 128
+	// maxSizeDepth limits the walking through the nested values.
+	maxSizeDepth = 32
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// approxSize - estimates the memory which is held by the cached item. The shared pointers are counted once
+// and the maps are estimated by their entries only, so the result is approximate (it's enough for limiting).
+func approxSize(key string, data interface{}) int64 {
+	return itemOverhead + int64(len(key)) + sizeOf(reflect.ValueOf(data), make(map[uintptr]struct{}), 0)
+}
+
+// sizeOf - returns the size of value itself and of the memory which is referenced by it.
+func sizeOf(v reflect.Value, seen map[uintptr]struct{}, depth int) int64 {
+	if !v.IsValid() {
+		return 0
+	}
+	return int64(v.Type().Size()) + referencedSizeOf(v, seen, depth)
+}
+
+func referencedSizeOf(v reflect.Value, seen map[uintptr]struct{}, depth int) int64 {
+	if depth > maxSizeDepth {
+		return 0
+	}
+	depth++
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return 0
+		}
+		if _, found := seen[v.Pointer()]; found {
+			return 0
+		}
+		seen[v.Pointer()] = struct{}{}
+		return sizeOf(v.Elem(), seen, depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return sizeOf(v.Elem(), seen, depth)
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		if hasReferences(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += referencedSizeOf(v.Index(i), seen, depth)
+			}
+		}
+		return size
+	case reflect.Array:
+		var size int64
+		if hasReferences(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += referencedSizeOf(v.Index(i), seen, depth)
+			}
+		}
+		return size
+	case reflect.Struct:
+		// the location of time is shared by all the values, so it's not counted
+		if v.Type() == timeType {
+			return 0
+		}
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += referencedSizeOf(v.Field(i), seen, depth)
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() {
+			return 0
+		}
+		var size int64
+		iter := v.MapRange()
+		for iter.Next() {
+			size += sizeOf(iter.Key(), seen, depth) + sizeOf(iter.Value(), seen, depth)
+		}
+		return size
+	}
+
+	return 0
+}
+
+// hasReferences - checks whether the values of type may reference the memory outside them.
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.String, reflect.Slice, reflect.Map, reflect.Struct:
+		return true
+	case reflect.Array:
+		return hasReferences(t.Elem())
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package cacher
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testSizedValue struct {
+	Name      string
+	Tags      []string
+	CreatedAt time.Time
+	Next      *testSizedValue
+}
+
+func TestApproxSize(t *testing.T) {
+	shared := &testSizedValue{Name: strings.Repeat("s", 1000)}
+
+	tests := []struct {
+		name    string
+		smaller interface{}
+		bigger  interface{}
+		delta   int64 // the min. difference of the sizes
+	}{
+		{name: "longer string", smaller: "a", bigger: strings.Repeat("a", 1001), delta: 1000},
+		{name: "bigger slice", smaller: make([]byte, 10), bigger: make([]byte, 0, 1010), delta: 1000},
+		{
+			name:    "strings of slice",
+			smaller: &testSizedValue{Tags: []string{"a"}},
+			bigger:  &testSizedValue{Tags: []string{strings.Repeat("a", 1001)}},
+			delta:   1000,
+		},
+		{
+			name:    "referenced value",
+			smaller: &testSizedValue{},
+			bigger:  &testSizedValue{Next: shared},
+			delta:   1000,
+		},
+		{
+			name:    "map entries",
+			smaller: map[string]string{},
+			bigger:  map[string]string{"key": strings.Repeat("v", 1000)},
+			delta:   1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			smaller, bigger := approxSize("key", tt.smaller), approxSize("key", tt.bigger)
+			if bigger-smaller < tt.delta {
+				t.Errorf("size of bigger value = %d, smaller = %d, want difference at least %d", bigger, smaller, tt.delta)
+			}
+		})
+	}
+}
+
+func TestApproxSize_SharedAndCyclicValues(t *testing.T) {
+	shared := &testSizedValue{Name: strings.Repeat("s", 1000)}
+	once := approxSize("key", []*testSizedValue{shared})
+	twice := approxSize("key", []*testSizedValue{shared, shared})
+
+	// the shared pointer is counted once, only the slice element is added
+	if twice-once >= 1000 {
+		t.Errorf("shared value is counted twice: %d and %d", once, twice)
+	}
+
+	cyclic := &testSizedValue{Name: "cyclic"}
+	cyclic.Next = cyclic
+	if size := approxSize("key", cyclic); size <= itemOverhead {
+		t.Errorf("size of cyclic value = %d, want more than overhead %d", size, itemOverhead)
+	}
+
+	if size := approxSize("key", nil); size != itemOverhead+3 {
+		t.Errorf("size of nil = %d, want %d", size, itemOverhead+3)
+	}
+}
@@ -2,57 +2,183 @@ package cacher
 
 import (
 	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/enum"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// MapCacheOptions - the limits and the eviction policy of MapCacheStorage.
+type MapCacheOptions struct {
+	// Policy chooses the item which will be evicted when the limits are reached: lru, lfu or none.
+	Policy string
+	// MaxEntries is a max. number of items (zero means unlimited).
+	MaxEntries int
+	// MaxBytes is an approximate max. size of items in bytes (zero means unlimited).
+	MaxBytes int64
+	// Shards is a number of independently locked parts of the storage, the limits are divided between them.
+	Shards int
+	// StaleWhileRevalidate is a period after expiration within which the expired item is still served
+	// while it's recomputed in background (zero means the expired item is recomputed synchronously).
+	StaleWhileRevalidate time.Duration
+	// NegativeTTL is a lifetime of the cached 'entity not found' errors (zero means they are not cached).
+	NegativeTTL time.Duration
+}
+
+// MapCacheShardStats - the statistics of one shard of MapCacheStorage.
+type MapCacheShardStats struct {
+	Items       int
+	Bytes       int64
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64 // the items which were removed by the eviction policy
+	Expirations uint64 // the items which were removed by TTL
+}
+
 type MapCacheStorage struct {
 	*mapCacheStorage
 }
 type mapCacheStorage struct {
-	ctx      context.Context
-	mu       sync.RWMutex
-	storage  map[string]*Item
-	capacity int64
+	ctx         context.Context
+	metrics     metrics_interface.Metrics
+	shards      []*mapCacheShard
+	size        *atomic.Int64
+	flights     *flightGroup
+	generations *generations
+	staleWindow time.Duration
+	negativeTTL time.Duration
+}
+
+// mapCacheShard - the part of storage with own lock, items, tags index and eviction policy.
+type mapCacheShard struct {
+	mu         sync.Mutex
+	storage    map[string]*Item
+	tags       map[string]map[string]struct{} // tags is an index of keys by tags of the items
+	policy     evictionPolicy
+	policyName string
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	stats      MapCacheShardStats
 }
 
 // NewMapCacheStorage is a constructor of MapCacheStorage structure.
-func NewMapCacheStorage(ctx context.Context) *MapCacheStorage {
+func NewMapCacheStorage(ctx context.Context, metrics metrics_interface.Metrics, opts MapCacheOptions) *MapCacheStorage {
+	if opts.Shards <= 0 {
+		opts.Shards = 1
+	}
+	if opts.Policy == NoneEvictionPolicy {
+		opts.MaxEntries, opts.MaxBytes = 0, 0
+	}
+
+	shards := make([]*mapCacheShard, opts.Shards)
+	for i := range shards {
+		shards[i] = &mapCacheShard{
+			storage:    map[string]*Item{},
+			tags:       map[string]map[string]struct{}{},
+			policy:     newEvictionPolicy(opts.Policy),
+			policyName: opts.Policy,
+			maxEntries: (opts.MaxEntries + opts.Shards - 1) / opts.Shards,
+			maxBytes:   (opts.MaxBytes + int64(opts.Shards) - 1) / int64(opts.Shards),
+		}
+	}
+
 	return &MapCacheStorage{
 		mapCacheStorage: &mapCacheStorage{
-			ctx:     ctx,
-			mu:      sync.RWMutex{},
-			storage: map[string]*Item{},
+			ctx:         ctx,
+			metrics:     metrics,
+			shards:      shards,
+			size:        &atomic.Int64{},
+			flights:     newFlightGroup(),
+			generations: newGenerations(),
+			staleWindow: opts.StaleWhileRevalidate,
+			negativeTTL: opts.NegativeTTL,
 		},
 	}
 }
 
-func (c *MapCacheStorage) Get(key string, fn func(cacher_interface.CacheItem) (data interface{}, err error)) (data interface{}, err error) {
+func (c *MapCacheStorage) Get(
+	key string,
+	fn func(cacher_interface.CacheItem) (data interface{}, err error),
+) (data interface{}, status enum.Status, err error) {
+	return c.getOrLoad(key, func() (*Item, enum.Status, error) {
+		c.metrics.IncCacheMisses()
+		item, err := c.compute(fn)
+		return item, enum.Miss, err
+	})
+}
+
+// getOrLoad - returns the data of the stored item or loads it. The concurrent loadings of the key are coalesced,
+// the expired item is served within the stale window while it's loaded in background. The load func must not
+// store the item, it's done here, and it returns the status of the loaded item (it may be found in other storage).
+func (c *MapCacheStorage) getOrLoad(
+	key string,
+	load func() (*Item, enum.Status, error),
+) (data interface{}, status enum.Status, err error) {
+	now := time.Now()
+
 	item, found := c.get(key)
-	if found {
-		return item.data, nil
+	if found && !item.expired(now) {
+		c.metrics.IncCacheHits()
+		return item.data, enum.Hit, item.err
 	}
 
-	item, err = c.compute(fn)
-	if err != nil {
-		return nil, err
+	if found && now.Before(item.expiresAt.Add(c.staleWindow)) {
+		c.metrics.IncCacheHits()
+		// the failed revalidation keeps the stale item, it's removed at the end of window
+		c.flights.doAsync(key, func() (*Item, enum.Status, error) {
+			return c.loadAndSet(key, load)
+		})
+		return item.data, enum.Stale, item.err
 	}
 
-	return c.set(key, item), nil
+	if item, status, err = c.flights.do(key, func() (*Item, enum.Status, error) {
+		return c.loadAndSet(key, load)
+	}); err != nil {
+		return nil, enum.Miss, err
+	}
+	return item.data, status, item.err
+}
+
+func (c *MapCacheStorage) loadAndSet(key string, load func() (*Item, enum.Status, error)) (*Item, enum.Status, error) {
+	started := c.generations.begin()
+	item, status, err := load()
+	if err != nil {
+		c.generations.end(started, nil)
+		return nil, status, err
+	}
+	return c.set(key, item, started), status, nil
 }
 
 func (c *MapCacheStorage) get(key string) (item *Item, found bool) {
-	defer c.mu.RUnlock()
-	c.mu.RLock()
-	item, found = c.storage[key]
-	return item, found
+	shard := c.shard(key)
+
+	defer shard.mu.Unlock()
+	shard.mu.Lock()
+	item, found = shard.storage[key]
+	if !found {
+		shard.stats.Misses++
+		return nil, false
+	}
+	shard.stats.Hits++
+	shard.policy.accessed(key)
+	return item, true
 }
 
+// compute - calls the func and wraps its result into the item, the 'entity not found' error is wrapped too
+// if the negative caching is enabled (the item has the tags which were added before the error occurred).
 func (c *MapCacheStorage) compute(fn func(cacher_interface.CacheItem) (data interface{}, err error)) (item *Item, err error) {
 	item = NewCacheItem()
 	data, err := fn(item)
 	if err != nil {
+		if c.negativeTTL > 0 && errors.IsEntityNotFoundError(err) {
+			item.SetTTL(c.negativeTTL)
+			item.data, item.err = nil, err
+			return item, nil
+		}
 		return nil, err
 	}
 	item.data = data
@@ -60,37 +186,181 @@ func (c *MapCacheStorage) compute(fn func(cacher_interface.CacheItem) (data inte
 	return item, nil
 }
 
-func (c *MapCacheStorage) set(key string, item *Item) (data interface{}) {
-	defer c.mu.Unlock()
-	c.mu.Lock()
-	cacheItem, found := c.storage[key]
-	if found {
-		return cacheItem.data
+// set - stores the item if the fresh one is not stored yet (otherwise the stored item is returned) and evicts
+// the items over the limits of shard. The item which exceeds the bytes limit alone is not stored at all, as well
+// as the item which tags were invalidated since the load was started (it's checked under the lock of shard,
+// so the invalidation either drops the item here or removes it from the shard after it's stored).
+func (c *MapCacheStorage) set(key string, item *Item, started uint64) *Item {
+	shard := c.shard(key)
+	item.size = approxSize(key, item.data)
+
+	shard.mu.Lock()
+	isValid := c.generations.end(started, item.tags)
+	if cacheItem, found := shard.storage[key]; found && !cacheItem.expired(time.Now()) {
+		shard.mu.Unlock()
+		return cacheItem
+	}
+	if !isValid {
+		shard.mu.Unlock()
+		return item
+	}
+	replaced := shard.delete(key)
+	if shard.maxBytes > 0 && item.size > shard.maxBytes {
+		shard.mu.Unlock()
+		c.updated(-replaced, 0)
+		return item
+	}
+
+	shard.storage[key] = item
+	shard.bytes += item.size
+	shard.policy.added(key)
+	for _, tag := range item.tags {
+		if shard.tags[tag] == nil {
+			shard.tags[tag] = map[string]struct{}{}
+		}
+		shard.tags[tag][key] = struct{}{}
 	}
-	c.storage[key] = item
-	return item.data
+	evicted := shard.evict()
+	shard.mu.Unlock()
+
+	c.updated(1-replaced-evicted, evicted)
+	return item
 }
 
 func (c *MapCacheStorage) Delete(key string) {
-	defer c.mu.Unlock()
-	c.mu.Lock()
-	delete(c.storage, key)
+	shard := c.shard(key)
+
+	shard.mu.Lock()
+	removed := shard.delete(key)
+	shard.mu.Unlock()
+
+	c.updated(-removed, 0)
+}
+
+func (c *MapCacheStorage) Invalidate(tags ...string) {
+	// the in-flight loads are marked before the items are removed, so the stale ones are not stored after it
+	c.generations.invalidate(tags...)
+
+	removed := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		for _, tag := range tags {
+			for key := range shard.tags[tag] {
+				removed += shard.delete(key)
+			}
+			delete(shard.tags, tag)
+		}
+		shard.mu.Unlock()
+	}
+
+	c.updated(-removed, 0)
 }
 
+// Displace - removes the expired items (after the stale window) and exposes the statistics of shards.
 func (c *MapCacheStorage) Displace() {
-	var keys []string
+	expired := 0
+	now := time.Now().Add(-c.staleWindow)
+
+	for i, shard := range c.shards {
+		shard.mu.Lock()
+		for key, item := range shard.storage {
+			if item.expired(now) {
+				expired += shard.delete(key)
+				shard.stats.Expirations++
+			}
+		}
+		items, bytes := len(shard.storage), shard.bytes
+		shard.mu.Unlock()
+
+		c.metrics.SetCacheShardStats(i, items, bytes)
+	}
+
+	c.updated(-expired, expired)
+}
+
+// Stats - returns the statistics of each shard.
+func (c *MapCacheStorage) Stats() []MapCacheShardStats {
+	stats := make([]MapCacheShardStats, 0, len(c.shards))
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		s := shard.stats
+		s.Items, s.Bytes = len(shard.storage), shard.bytes
+		shard.mu.Unlock()
+
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// purge - removes all the items (used when the items may be stale, e.g. the invalidations were missed).
+func (c *MapCacheStorage) purge() {
+	c.generations.invalidateAll()
+
+	evicted := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		evicted += len(shard.storage)
+		shard.storage = map[string]*Item{}
+		shard.tags = map[string]map[string]struct{}{}
+		shard.policy = newEvictionPolicy(shard.policyName)
+		shard.bytes = 0
+		shard.mu.Unlock()
+	}
+
+	c.updated(-evicted, evicted)
+}
+
+// updated - updates the total size by delta and counts the removed items.
+func (c *MapCacheStorage) updated(delta int, removed int) {
+	size := c.size.Add(int64(delta))
+	if removed > 0 {
+		c.metrics.AddCacheEvictions(removed)
+	}
+	c.metrics.SetCacheSize(int(size))
+}
+
+func (c *MapCacheStorage) shard(key string) *mapCacheShard {
+	if len(c.shards) == 1 {
+		return c.shards[0]
+	}
+	// FNV-1a
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return c.shards[h%uint32(len(c.shards))]
+}
 
-	c.mu.RLock()
-	for key, item := range c.storage {
-		if !item.expiresAt.IsZero() && item.expiresAt.UnixNano() <= time.Now().UnixNano() {
-			keys = append(keys, key)
+// evict - removes the items chosen by the policy until the shard fits the limits, returns the number
+// of removed items (the lock must be held by the caller).
+func (s *mapCacheShard) evict() (evicted int) {
+	for (s.maxEntries > 0 && len(s.storage) > s.maxEntries) || (s.maxBytes > 0 && s.bytes > s.maxBytes) {
+		key, found := s.policy.victim()
+		if !found {
+			break
 		}
+		evicted += s.delete(key)
+		s.stats.Evictions++
 	}
-	c.mu.RUnlock()
+	return evicted
+}
 
-	c.mu.Lock()
-	for _, key := range keys {
-		delete(c.storage, key)
+// delete - removes the item and its keys from the tags index and the policy, returns the number of
+// removed items (the lock must be held by the caller).
+func (s *mapCacheShard) delete(key string) int {
+	item, found := s.storage[key]
+	if !found {
+		return 0
+	}
+	for _, tag := range item.tags {
+		delete(s.tags[tag], key)
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
 	}
-	c.mu.Unlock()
+	delete(s.storage, key)
+	s.bytes -= item.size
+	s.policy.removed(key)
+	return 1
 }
@@ -0,0 +1,345 @@
+package cacher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	domain_errors "github.com/Borislavv/video-streaming/internal/domain/errors"
+	cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/enum"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testMetrics - the metrics which are used by the cache only, the rest methods are not expected to be called.
+type testMetrics struct {
+	metrics_interface.Metrics
+}
+
+func (m *testMetrics) IncCacheHits()                                        {}
+func (m *testMetrics) IncCacheMisses()                                      {}
+func (m *testMetrics) AddCacheEvictions(number int)                         {}
+func (m *testMetrics) SetCacheSize(size int)                                {}
+func (m *testMetrics) SetCacheShardStats(shard int, items int, bytes int64) {}
+
+func newTestMapStorage(opts MapCacheOptions) *MapCacheStorage {
+	return NewMapCacheStorage(context.Background(), &testMetrics{}, opts)
+}
+
+func computeOf(data interface{}, ttl time.Duration, tags ...string) func(cacher_interface.CacheItem) (interface{}, error) {
+	return func(item cacher_interface.CacheItem) (interface{}, error) {
+		item.SetTTL(ttl)
+		item.AddTags(tags...)
+		return data, nil
+	}
+}
+
+func TestMapCacheStorage_GetStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   MapCacheOptions
+		ttl    time.Duration
+		wait   time.Duration
+		status enum.Status
+		data   interface{}
+	}{
+		{name: "fresh item", ttl: time.Hour, status: enum.Hit, data: "first"},
+		{name: "expired item", ttl: time.Millisecond, wait: 5 * time.Millisecond, status: enum.Miss, data: "second"},
+		{
+			name:   "expired item within the stale window",
+			opts:   MapCacheOptions{StaleWhileRevalidate: time.Hour},
+			ttl:    time.Millisecond,
+			wait:   5 * time.Millisecond,
+			status: enum.Stale,
+			data:   "first",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMapStorage(tt.opts)
+
+			data, status, err := s.Get("key", computeOf("first", tt.ttl))
+			if err != nil || status != enum.Miss || data != "first" {
+				t.Fatalf("the first get must compute the data: %v, %v, %v", data, status, err)
+			}
+
+			time.Sleep(tt.wait)
+
+			data, status, err = s.Get("key", computeOf("second", tt.ttl))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status != tt.status {
+				t.Fatalf("expected status '%v', got '%v'", tt.status, status)
+			}
+			if data != tt.data {
+				t.Fatalf("expected data '%v', got '%v'", tt.data, data)
+			}
+		})
+	}
+}
+
+func TestMapCacheStorage_StaleIsRevalidated(t *testing.T) {
+	s := newTestMapStorage(MapCacheOptions{StaleWhileRevalidate: time.Hour})
+
+	if _, _, err := s.Get("key", computeOf("first", time.Millisecond)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	revalidated := make(chan struct{})
+	_, status, _ := s.Get("key", func(item cacher_interface.CacheItem) (interface{}, error) {
+		defer close(revalidated)
+		item.SetTTL(time.Hour)
+		return "second", nil
+	})
+	if status != enum.Stale {
+		t.Fatalf("expected stale status, got '%v'", status)
+	}
+	<-revalidated
+
+	// the revalidated item is stored after the func returns
+	deadline := time.Now().Add(time.Second)
+	for {
+		data, status, _ := s.Get("key", computeOf("third", time.Hour))
+		if data == "second" && status == enum.Hit {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the stale item was not revalidated: %v, %v", data, status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMapCacheStorage_ConcurrentMissesAreCoalesced(t *testing.T) {
+	s := newTestMapStorage(MapCacheOptions{})
+
+	computed := atomic.Int32{}
+	release := make(chan struct{})
+	fn := func(item cacher_interface.CacheItem) (interface{}, error) {
+		computed.Add(1)
+		<-release
+		return "data", nil
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if data, status, err := s.Get("key", fn); err != nil || data != "data" || status != enum.Miss {
+				t.Errorf("unexpected result: %v, %v, %v", data, status, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if computed.Load() != 1 {
+		t.Fatalf("the data must be computed once, computed %d times", computed.Load())
+	}
+}
+
+func TestMapCacheStorage_NegativeCaching(t *testing.T) {
+	notFound := domain_errors.NewEntityNotFoundError("video", "id")
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		err      error
+		computed int
+	}{
+		{name: "not found is cached", ttl: time.Hour, err: notFound, computed: 1},
+		{name: "not found is not cached if disabled", err: notFound, computed: 2},
+		{name: "other errors are not cached", ttl: time.Hour, err: errors.New("failed"), computed: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMapStorage(MapCacheOptions{NegativeTTL: tt.ttl})
+
+			computed := 0
+			fn := func(item cacher_interface.CacheItem) (interface{}, error) {
+				computed++
+				return nil, tt.err
+			}
+			for i := 0; i < 2; i++ {
+				if _, _, err := s.Get("key", fn); !errors.Is(err, tt.err) {
+					t.Fatalf("expected error '%v', got '%v'", tt.err, err)
+				}
+			}
+
+			if computed != tt.computed {
+				t.Fatalf("expected %d computations, got %d", tt.computed, computed)
+			}
+		})
+	}
+}
+
+func TestMapCacheStorage_Invalidate(t *testing.T) {
+	s := newTestMapStorage(MapCacheOptions{Shards: 4})
+
+	_, _, _ = s.Get("first", computeOf(1, time.Hour, "video:1", "user:1"))
+	_, _, _ = s.Get("second", computeOf(2, time.Hour, "user:1"))
+	_, _, _ = s.Get("third", computeOf(3, time.Hour, "user:2"))
+
+	s.Invalidate("user:1")
+
+	for key, expected := range map[string]enum.Status{"first": enum.Miss, "second": enum.Miss, "third": enum.Hit} {
+		if _, status, _ := s.Get(key, computeOf(0, time.Hour)); status != expected {
+			t.Fatalf("key '%v': expected status '%v', got '%v'", key, expected, status)
+		}
+	}
+}
+
+func TestMapCacheStorage_InvalidateWhileLoading(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		status enum.Status // of the next request
+	}{
+		{name: "item of the invalidated tag is dropped", tag: "user:1", status: enum.Miss},
+		{name: "item of another tag is stored", tag: "user:2", status: enum.Hit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMapStorage(MapCacheOptions{})
+
+			loading, release := make(chan struct{}), make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				data, _, err := s.Get("key", func(item cacher_interface.CacheItem) (interface{}, error) {
+					close(loading)
+					<-release
+					return computeOf("stale", time.Hour, "user:1")(item)
+				})
+				if err != nil || data != "stale" {
+					t.Errorf("the loaded data must be returned to the caller: %v, %v", data, err)
+				}
+			}()
+
+			<-loading
+			s.Invalidate(tt.tag)
+			close(release)
+			<-done
+
+			if _, status, _ := s.Get("key", computeOf("fresh", time.Hour, "user:1")); status != tt.status {
+				t.Fatalf("expected status '%v', got '%v'", tt.status, status)
+			}
+		})
+	}
+}
+
+func TestMapCacheStorage_Eviction(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		access  []string
+		evicted string
+	}{
+		{name: "lru evicts the least recently used", policy: LRUEvictionPolicy, access: []string{"a"}, evicted: "b"},
+		{name: "lfu evicts the least frequently used", policy: LFUEvictionPolicy, access: []string{"b", "b", "a"}, evicted: "c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMapStorage(MapCacheOptions{Policy: tt.policy, MaxEntries: 3})
+
+			for _, key := range []string{"a", "b", "c"} {
+				_, _, _ = s.Get(key, computeOf(key, time.Hour))
+			}
+			for _, key := range tt.access {
+				_, _, _ = s.Get(key, computeOf(key, time.Hour))
+			}
+			_, _, _ = s.Get("d", computeOf("d", time.Hour))
+
+			if _, status, _ := s.Get(tt.evicted, computeOf(tt.evicted, time.Hour)); status != enum.Miss {
+				t.Fatalf("key '%v' must be evicted", tt.evicted)
+			}
+		})
+	}
+}
+
+func TestMapCacheStorage_MaxBytes(t *testing.T) {
+	value := strings.Repeat("v", 1000)
+	itemSize := approxSize("k0", value)
+
+	tests := []struct {
+		name     string
+		opts     MapCacheOptions
+		keys     int
+		items    int
+		stored   []string // the keys which must be kept
+		oversize bool     // the item is bigger than the limit
+	}{
+		{
+			name:   "lru keeps the last items within the limit",
+			opts:   MapCacheOptions{Policy: LRUEvictionPolicy, MaxBytes: 3 * itemSize},
+			keys:   5,
+			items:  3,
+			stored: []string{"k2", "k3", "k4"},
+		},
+		{
+			name:  "limit is divided between shards",
+			opts:  MapCacheOptions{Policy: LRUEvictionPolicy, MaxBytes: 4 * itemSize, Shards: 2},
+			keys:  10,
+			items: 4,
+		},
+		{
+			name:  "none policy ignores the limit",
+			opts:  MapCacheOptions{Policy: NoneEvictionPolicy, MaxBytes: itemSize},
+			keys:  5,
+			items: 5,
+		},
+		{
+			name:     "item bigger than limit is not stored",
+			opts:     MapCacheOptions{Policy: LRUEvictionPolicy, MaxBytes: itemSize / 2},
+			keys:     2,
+			items:    0,
+			oversize: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestMapStorage(tt.opts)
+			for i := 0; i < tt.keys; i++ {
+				key := fmt.Sprintf("k%d", i)
+				data, _, err := s.Get(key, computeOf(value, time.Hour))
+				if err != nil || data != value {
+					t.Fatalf("key '%v': unexpected result %v, %v", key, data, err)
+				}
+			}
+
+			items, bytes, evictions := 0, int64(0), uint64(0)
+			for _, stats := range s.Stats() {
+				items += stats.Items
+				bytes += stats.Bytes
+				evictions += stats.Evictions
+			}
+			if items != tt.items {
+				t.Errorf("items = %d, want %d", items, tt.items)
+			}
+			if tt.opts.Policy != NoneEvictionPolicy && bytes > tt.opts.MaxBytes {
+				t.Errorf("bytes = %d, want at most %d", bytes, tt.opts.MaxBytes)
+			}
+			if !tt.oversize && evictions != uint64(tt.keys-tt.items) {
+				t.Errorf("evictions = %d, want %d", evictions, tt.keys-tt.items)
+			}
+			for _, key := range tt.stored {
+				if _, status, _ := s.Get(key, computeOf(value, time.Hour)); status != enum.Hit {
+					t.Errorf("key '%v' must be kept, got '%v'", key, status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package cacher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/redis"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// subscriptionRetryInterval is a delay between attempts to restore the broken invalidation subscription.
+	subscriptionRetryInterval = time.Second
+	// generationTTL is a lifetime of the last invalidation of tag, it must exceed the longest computation of value.
+	generationTTL = 10 * time.Minute
+	// the invalidation messages are the deleted key or the invalidated tag with the appropriate prefix
+	keyMessagePrefix = "key:"
+	tagMessagePrefix = "tag:"
+)
+
+// RedisCacheStorage - the storage which keeps the values in Redis, so they are shared between the replicas.
+// The decoded values are also kept in the local map (the near cache), which is invalidated through pub/sub
+// when any replica deletes the key. If Redis is unavailable, the values are computed and kept locally only.
+// The commands are not bound to the app context (they are limited by the client timeout), so the requests
+// which are drained on shutdown still can use the cache until the client will be closed.
+type RedisCacheStorage struct {
+	ctx     context.Context
+	logger  logger_interface.Logger
+	metrics metrics_interface.Metrics
+	client  *redis.Client
+	codec   *GobCodec
+	local   *MapCacheStorage
+	prefix  string
+	channel string
+}
+
+func NewRedisCacheStorage(serviceContainer di_interface.ContainerManager) (*RedisCacheStorage, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	metricsService, err := serviceContainer.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	client := redis.NewClient(redis.Options{
+		Address:  cfg.CacheRedisAddress,
+		Password: cfg.CacheRedisPassword,
+		DB:       cfg.CacheRedisDB,
+		PoolSize: cfg.CacheRedisPoolSize,
+		Timeout:  cfg.CacheRedisTimeout,
+	})
+	if err = client.Ping(ctx); err != nil {
+		_ = client.Close()
+		return nil, loggerService.LogPropagate(fmt.Errorf("cache: redis '%v' is unavailable: %w", cfg.CacheRedisAddress, err))
+	}
+
+	s := &RedisCacheStorage{
+		ctx:     ctx,
+		logger:  loggerService,
+		metrics: metricsService,
+		client:  client,
+		codec:   NewGobCodec(),
+		local: NewMapCacheStorage(ctx, metricsService, MapCacheOptions{
+			Policy:               cfg.CacheEvictionPolicy,
+			MaxEntries:           cfg.CacheMaxEntries,
+			MaxBytes:             cfg.CacheMaxBytes,
+			Shards:               cfg.CacheShards,
+			StaleWhileRevalidate: cfg.CacheStaleWhileRevalidate,
+			NegativeTTL:          cfg.CacheNegativeTTL,
+		}),
+		prefix:  cfg.CacheRedisKeyPrefix,
+		channel: cfg.CacheRedisInvalidationChannel,
+	}
+
+	go s.subscribe()
+
+	return s, nil
+}
+
+func (s *RedisCacheStorage) Get(
+	key string,
+	fn func(cacher_interface.CacheItem) (data interface{}, err error),
+) (data interface{}, status enum.Status, err error) {
+	return s.local.getOrLoad(key, func() (*Item, enum.Status, error) {
+		if item, found := s.fetch(key); found {
+			s.metrics.IncCacheHits()
+			return item, enum.Hit, nil
+		}
+		s.metrics.IncCacheMisses()
+
+		started, isKnown := s.generation()
+		item, err := s.local.compute(fn)
+		if err != nil {
+			return nil, enum.Miss, err
+		}
+		if isKnown {
+			s.store(key, item, started)
+		}
+
+		return item, enum.Miss, nil
+	})
+}
+
+// Delete - removes the key from Redis and notifies the replicas (and itself) to drop the local copy.
+func (s *RedisCacheStorage) Delete(key string) {
+	s.local.Delete(key)
+
+	if _, err := s.client.Del(context.Background(), s.prefix+key); err != nil {
+		s.logger.Error(fmt.Sprintf("cache: unable to delete key '%v' from redis: %v", key, err))
+	}
+	s.publish(keyMessagePrefix + key)
+}
+
+// Invalidate - removes the keys of the tags from Redis and notifies the replicas (and itself)
+// to drop the local copies which are marked by the tags. The tags are marked by the new generation
+// before the keys are removed, so the values which are computed at now by any replica are not stored.
+func (s *RedisCacheStorage) Invalidate(tags ...string) {
+	s.local.Invalidate(tags...)
+
+	if seq, err := s.client.Incr(context.Background(), s.generationKey()); err != nil {
+		s.logger.Error(fmt.Sprintf("cache: unable to increment generation in redis: %v", err))
+	} else {
+		for _, tag := range tags {
+			err = s.client.Set(context.Background(), s.tagGenerationKey(tag), []byte(strconv.FormatInt(seq, 10)), generationTTL)
+			if err != nil {
+				s.logger.Error(fmt.Sprintf("cache: unable to set generation of tag '%v' in redis: %v", tag, err))
+			}
+		}
+	}
+
+	for _, tag := range tags {
+		keys, err := s.client.SMembers(context.Background(), s.tagKey(tag))
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("cache: unable to get keys of tag '%v' from redis: %v", tag, err))
+			continue
+		}
+
+		redisKeys := []string{s.tagKey(tag)}
+		for _, key := range keys {
+			redisKeys = append(redisKeys, s.prefix+key)
+		}
+		if _, err = s.client.Del(context.Background(), redisKeys...); err != nil {
+			s.logger.Error(fmt.Sprintf("cache: unable to delete keys of tag '%v' from redis: %v", tag, err))
+		}
+
+		s.publish(tagMessagePrefix + tag)
+	}
+}
+
+func (s *RedisCacheStorage) publish(message string) {
+	if err := s.client.Publish(context.Background(), s.channel, message); err != nil {
+		s.logger.Error(fmt.Sprintf("cache: unable to publish invalidation '%v': %v", message, err))
+	}
+}
+
+func (s *RedisCacheStorage) tagKey(tag string) string {
+	return s.prefix + "tag:" + tag
+}
+
+func (s *RedisCacheStorage) generationKey() string {
+	return s.prefix + "generation"
+}
+
+func (s *RedisCacheStorage) tagGenerationKey(tag string) string {
+	return s.prefix + "generation:" + tag
+}
+
+// generation - returns the number of the last invalidation, the value must not be stored if it's unknown.
+func (s *RedisCacheStorage) generation() (seq int64, isKnown bool) {
+	b, err := s.client.Get(context.Background(), s.generationKey())
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, true
+		}
+		if !errors.Is(err, redis.ErrClosed) {
+			s.logger.Warning(fmt.Sprintf("cache: unable to get generation from redis: %v", err))
+		}
+		return 0, false
+	}
+
+	if seq, err = strconv.ParseInt(string(b), 10, 64); err != nil {
+		s.logger.Warning(fmt.Sprintf("cache: unable to parse generation '%s': %v", b, err))
+		return 0, false
+	}
+	return seq, true
+}
+
+// isInvalidated - checks whether any of tags was invalidated after the given generation,
+// the tags are considered as invalidated if the check is failed.
+func (s *RedisCacheStorage) isInvalidated(tags []string, started int64) bool {
+	if len(tags) == 0 {
+		return false
+	}
+
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, s.tagGenerationKey(tag))
+	}
+	values, err := s.client.MGet(context.Background(), keys...)
+	if err != nil {
+		s.logger.Warning(fmt.Sprintf("cache: unable to get generations of tags from redis: %v", err))
+		return true
+	}
+
+	for _, b := range values {
+		if b == nil {
+			continue
+		}
+		if seq, err := strconv.ParseInt(string(b), 10, 64); err != nil || seq > started {
+			return true
+		}
+	}
+	return false
+}
+
+// Displace - removes the expired local copies, the values in Redis are expired by the server.
+func (s *RedisCacheStorage) Displace() {
+	s.local.Displace()
+}
+
+// fetch - reads the value from Redis, any failure is considered as a miss.
+func (s *RedisCacheStorage) fetch(key string) (item *Item, found bool) {
+	b, err := s.client.Get(context.Background(), s.prefix+key)
+	if err != nil {
+		if !errors.Is(err, redis.Nil) && !errors.Is(err, redis.ErrClosed) {
+			s.logger.Warning(fmt.Sprintf("cache: unable to get key '%v' from redis: %v", key, err))
+		}
+		return nil, false
+	}
+
+	item, err = s.codec.Decode(b)
+	if err != nil {
+		s.logger.Debug(fmt.Sprintf("cache: unable to decode value of key '%v': %v", key, err))
+		return nil, false
+	}
+
+	return item, true
+}
+
+// store - writes the value into Redis with the TTL of item, the failure is logged only (the value is kept locally).
+// The cached errors are short-lived and the local items contain credentials, so they are kept locally only.
+// The value which tags were invalidated since the given generation is not stored anywhere: the generations
+// are checked before the key is set and after it (the invalidation which is not seen by the second check
+// will remove the key by the tag index).
+func (s *RedisCacheStorage) store(key string, item *Item, started int64) {
+	if item.err != nil || item.local {
+		return
+	}
+
+	var ttl time.Duration
+	if !item.expiresAt.IsZero() {
+		if ttl = time.Until(item.expiresAt); ttl <= 0 {
+			return
+		}
+	}
+
+	b, err := s.codec.Encode(item)
+	if err != nil {
+		s.logger.Warning(fmt.Sprintf("cache: unable to encode value of key '%v': %v", key, err))
+		return
+	}
+
+	if s.isInvalidated(item.tags, started) {
+		s.local.Invalidate(item.tags...)
+		return
+	}
+
+	// the key is indexed before it's set, so it cannot be missed by the concurrent invalidation
+	for _, tag := range item.tags {
+		if err = s.client.SAdd(context.Background(), s.tagKey(tag), key); err != nil {
+			s.logger.Warning(fmt.Sprintf("cache: unable to tag key '%v' by '%v' in redis: %v", key, tag, err))
+			return
+		}
+		if ttl > 0 {
+			// the tag set lives as long as its latest item, the items of one tag have the same ttl commonly
+			if err = s.client.PExpire(context.Background(), s.tagKey(tag), ttl); err != nil {
+				s.logger.Warning(fmt.Sprintf("cache: unable to set ttl of tag '%v' in redis: %v", tag, err))
+			}
+		}
+	}
+
+	if err = s.client.Set(context.Background(), s.prefix+key, b, ttl); err != nil {
+		s.logger.Warning(fmt.Sprintf("cache: unable to set key '%v' into redis: %v", key, err))
+		return
+	}
+
+	if s.isInvalidated(item.tags, started) {
+		if _, err = s.client.Del(context.Background(), s.prefix+key); err != nil {
+			s.logger.Warning(fmt.Sprintf("cache: unable to delete invalidated key '%v' from redis: %v", key, err))
+		}
+		s.local.Invalidate(item.tags...)
+	}
+}
+
+// subscribe - listens to the invalidation channel until the app context will be canceled.
+// The broken subscription is restored, the whole local cache may be stale at the moment, so it's dropped.
+func (s *RedisCacheStorage) subscribe() {
+	defer func() { _ = s.client.Close() }()
+
+	for {
+		err := s.client.Subscribe(s.ctx, s.channel, func(message string) {
+			switch {
+			case strings.HasPrefix(message, keyMessagePrefix):
+				s.local.Delete(strings.TrimPrefix(message, keyMessagePrefix))
+			case strings.HasPrefix(message, tagMessagePrefix):
+				s.local.Invalidate(strings.TrimPrefix(message, tagMessagePrefix))
+			}
+		})
+		if s.ctx.Err() != nil {
+			return
+		}
+		s.logger.Error(fmt.Sprintf("cache: invalidation subscription is broken: %v", err))
+		s.local.purge()
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(subscriptionRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package cacher
+
+import (
+	"context"
+	"errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	cacher_interface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/redis"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	testRedisPrefix  = "test:"
+	testRedisChannel = "test:invalidation"
+)
+
+func newTestFakeServer(t *testing.T) *redis.FakeServer {
+	server, err := redis.NewFakeServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to start the fake server: %v", err)
+	}
+	t.Cleanup(func() { _ = server.Close() })
+	return server
+}
+
+// newTestRedisStorage - the storage of one replica, it's closed when the test is finished.
+func newTestRedisStorage(t *testing.T, server *redis.FakeServer) *RedisCacheStorage {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s := &RedisCacheStorage{
+		ctx:     ctx,
+		logger:  logger_stub.NewLogger(),
+		metrics: &testMetrics{},
+		client:  redis.NewClient(redis.Options{Address: server.Addr(), Timeout: time.Second}),
+		codec:   NewGobCodec(),
+		local:   newTestMapStorage(MapCacheOptions{}),
+		prefix:  testRedisPrefix,
+		channel: testRedisChannel,
+	}
+	go s.subscribe()
+
+	return s
+}
+
+func TestRedisCacheStorage_GetIsShared(t *testing.T) {
+	server := newTestFakeServer(t)
+	first, second := newTestRedisStorage(t, server), newTestRedisStorage(t, server)
+
+	if _, status, err := first.Get("key", computeOf(testValue{ID: "1"}, time.Hour)); err != nil || status != enum.Miss {
+		t.Fatalf("the first replica must compute the data: %v, %v", status, err)
+	}
+
+	// the type is registered by the encoding on the first replica, it's the same process here
+	data, status, err := second.Get("key", func(item cacher_interface.CacheItem) (interface{}, error) {
+		return nil, errors.New("the data must be fetched from redis")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != enum.Hit || !reflect.DeepEqual(data, testValue{ID: "1"}) {
+		t.Fatalf("expected the hit of the shared data, got: %v, %v", data, status)
+	}
+}
+
+func TestRedisCacheStorage_LocalItemIsNotStored(t *testing.T) {
+	server := newTestFakeServer(t)
+	first, second := newTestRedisStorage(t, server), newTestRedisStorage(t, server)
+
+	local := func(data interface{}) func(cacher_interface.CacheItem) (interface{}, error) {
+		return func(item cacher_interface.CacheItem) (interface{}, error) {
+			item.SetTTL(time.Hour)
+			item.SetLocal()
+			return data, nil
+		}
+	}
+
+	if _, _, err := first.Get("key", local("first")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := first.client.Get(context.Background(), testRedisPrefix+"key"); !errors.Is(err, redis.Nil) {
+		t.Fatalf("the local item must not be written into redis, got: %v", err)
+	}
+
+	// the first replica keeps the item in memory, the second one computes its own
+	if data, status, _ := first.Get("key", local("second")); status != enum.Hit || data != "first" {
+		t.Fatalf("expected the local hit, got: %v, %v", data, status)
+	}
+	if data, status, _ := second.Get("key", local("second")); status != enum.Miss || data != "second" {
+		t.Fatalf("expected the miss on another replica, got: %v, %v", data, status)
+	}
+}
+
+func TestRedisCacheStorage_InvalidateReachesReplicas(t *testing.T) {
+	server := newTestFakeServer(t)
+	first, second := newTestRedisStorage(t, server), newTestRedisStorage(t, server)
+
+	_, _, _ = first.Get("key", computeOf("first", time.Hour, "user:1"))
+	if _, status, _ := second.Get("key", computeOf("second", time.Hour, "user:1")); status != enum.Hit {
+		t.Fatalf("expected the hit on another replica, got: %v", status)
+	}
+
+	first.Invalidate("user:1")
+
+	// the local copy of the second replica is dropped asynchronously by the subscription
+	deadline := time.Now().Add(time.Second)
+	for {
+		data, status, _ := second.Get("key", computeOf("third", time.Hour, "user:1"))
+		if status == enum.Miss && data == "third" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the local copy was not invalidated: %v, %v", data, status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRedisCacheStorage_InvalidateWhileComputing(t *testing.T) {
+	server := newTestFakeServer(t)
+	first, second := newTestRedisStorage(t, server), newTestRedisStorage(t, server)
+
+	computing, release := make(chan struct{}), make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _, _ = first.Get("key", func(item cacher_interface.CacheItem) (interface{}, error) {
+			close(computing)
+			<-release
+			return computeOf("stale", time.Hour, "user:1")(item)
+		})
+	}()
+
+	<-computing
+	second.Invalidate("user:1")
+	close(release)
+	<-done
+
+	if _, err := first.client.Get(context.Background(), testRedisPrefix+"key"); !errors.Is(err, redis.Nil) {
+		t.Fatalf("the value computed before the invalidation must not be written into redis, got: %v", err)
+	}
+	if data, status, _ := first.Get("key", computeOf("fresh", time.Hour, "user:1")); status != enum.Miss || data != "fresh" {
+		t.Fatalf("expected the miss on the computing replica, got: %v, %v", data, status)
+	}
+}
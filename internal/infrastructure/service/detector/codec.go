@@ -5,13 +5,18 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/vansante/go-ffprobe.v2"
 	"os"
 )
 
 type ResourceCodecs struct {
-	ctx    context.Context
 	logger logger_interface.Logger
+	tracer tracer_interface.Tracer
 }
 
 func NewResourceCodecs(serviceContainer di_interface.ContainerManager) (*ResourceCodecs, error) {
@@ -20,34 +25,29 @@ func NewResourceCodecs(serviceContainer di_interface.ContainerManager) (*Resourc
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
+	tracerService, err := serviceContainer.GetTracerService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &ResourceCodecs{
-		ctx:    ctx,
 		logger: loggerService,
+		tracer: tracerService,
 	}, nil
 }
 
 // Detect will determine video and audio stream codecs of target resource
 func (d *ResourceCodecs) Detect(
+	ctx context.Context,
 	resource entity.Resource,
 ) (
 	audioCodec string,
 	videoCodec string,
 	e error,
 ) {
-	file, err := os.Open(resource.GetFilepath())
+	data, err := d.probe(ctx, resource)
 	if err != nil {
-		return "", "", d.logger.LogPropagate(err)
-	}
-	defer func() { _ = file.Close() }()
-
-	data, err := ffprobe.ProbeReader(d.ctx, file)
-	if err != nil {
-		return "", "", d.logger.LogPropagate(err)
+		return "", "", d.logger.WithContext(ctx).LogPropagate(err)
 	}
 
 	audioCodec = ""
@@ -61,3 +61,42 @@ func (d *ResourceCodecs) Detect(
 
 	return audioCodec, videoCodec, nil
 }
+
+// DetectMetadata will determine duration and codec names of target resource streams
+func (d *ResourceCodecs) DetectMetadata(ctx context.Context, resource entity.Resource) (metadata vo.MediaMetadata, e error) {
+	data, err := d.probe(ctx, resource)
+	if err != nil {
+		return vo.MediaMetadata{}, d.logger.WithContext(ctx).LogPropagate(err)
+	}
+
+	if data.Format != nil {
+		metadata.Duration = data.Format.DurationSeconds
+	}
+	if data.FirstAudioStream() != nil {
+		metadata.AudioCodec = data.FirstAudioStream().CodecName
+	}
+	if data.FirstVideoStream() != nil {
+		metadata.VideoCodec = data.FirstVideoStream().CodecName
+	}
+
+	return metadata, nil
+}
+
+// probe will run the ffprobe on target resource file (the run is traced as a separate span).
+func (d *ResourceCodecs) probe(ctx context.Context, resource entity.Resource) (data *ffprobe.ProbeData, err error) {
+	ctx, span := d.tracer.Start(ctx, "ffprobe", trace.WithAttributes(attribute.String("resource.name", resource.Name)))
+	defer func() {
+		if err != nil {
+			tracer.Fail(span, err)
+		}
+		span.End()
+	}()
+
+	file, err := os.Open(resource.GetFilepath())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	return ffprobe.ProbeReader(ctx, file)
+}
@@ -1,7 +1,12 @@
 package detector_interface
 
-import "github.com/Borislavv/video-streaming/internal/domain/entity"
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
 
 type Codecs interface {
-	Detect(resource entity.Resource) (audioCodec string, videoCodec string, err error)
+	Detect(ctx context.Context, resource entity.Resource) (audioCodec string, videoCodec string, err error)
+	DetectMetadata(ctx context.Context, resource entity.Resource) (metadata vo.MediaMetadata, err error)
 }
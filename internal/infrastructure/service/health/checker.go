@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+	// shuttingDownCheckName is a name of the readiness check which fails when the graceful shutdown was started.
+	shuttingDownCheckName = "shutdown"
+)
+
+// report - the response of probe handlers.
+type report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Checker - runs the liveness and readiness checks concurrently and reports the result as JSON
+// with 200 status code if all checks passed and 503 otherwise.
+type Checker struct {
+	logger       logger_interface.Logger
+	liveness     []health_interface.Check
+	readiness    []health_interface.Check
+	timeout      time.Duration
+	shuttingDown *atomic.Bool
+}
+
+func NewHealthChecker(
+	serviceContainer di_interface.ContainerManager,
+	liveness []health_interface.Check,
+	readiness []health_interface.Check,
+) (*Checker, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &Checker{
+		logger:       loggerService,
+		liveness:     liveness,
+		readiness:    readiness,
+		timeout:      cfg.HealthCheckTimeout,
+		shuttingDown: &atomic.Bool{},
+	}, nil
+}
+
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.respond(w, c.run(r.Context(), c.liveness))
+	})
+}
+
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rep := c.run(r.Context(), c.readiness)
+		if c.shuttingDown.Load() {
+			rep.Status = statusFail
+			rep.Checks[shuttingDownCheckName] = "graceful shutdown is in progress"
+		}
+		c.respond(w, rep)
+	})
+}
+
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// run - executes the checks concurrently within the configured timeout.
+func (c *Checker) run(ctx context.Context, checks []health_interface.Check) report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	rep := report{Status: statusOK, Checks: make(map[string]string, len(checks))}
+
+	mu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check health_interface.Check) {
+			defer wg.Done()
+
+			result := statusOK
+			if err := check.Check(ctx); err != nil {
+				result = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if result != statusOK {
+				rep.Status = statusFail
+			}
+			rep.Checks[check.Name()] = result
+		}(check)
+	}
+	wg.Wait()
+
+	return rep
+}
+
+func (c *Checker) respond(w http.ResponseWriter, rep report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if rep.Status != statusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(rep); err != nil {
+		c.logger.Error(err)
+	}
+}
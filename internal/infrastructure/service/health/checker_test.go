@@ -0,0 +1,213 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	health_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/health/interface"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testSaturatedLogger - reports the given saturation of the records buffer.
+type testSaturatedLogger struct {
+	*logger_stub.Logger
+	saturation float64
+}
+
+func (l *testSaturatedLogger) Saturation() float64 { return l.saturation }
+
+// testCheck - fails by the given error or blocks until the context is done.
+type testCheck struct {
+	name    string
+	err     error
+	isStuck bool
+}
+
+func (c *testCheck) Name() string {
+	return c.name
+}
+
+func (c *testCheck) Check(ctx context.Context) error {
+	if c.isStuck {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return c.err
+}
+
+func newTestChecker(liveness []health_interface.Check, readiness []health_interface.Check) *Checker {
+	return &Checker{
+		logger:       logger_stub.NewLogger(),
+		liveness:     liveness,
+		readiness:    readiness,
+		timeout:      50 * time.Millisecond,
+		shuttingDown: &atomic.Bool{},
+	}
+}
+
+func TestChecker_Handlers(t *testing.T) {
+	tests := []struct {
+		name         string
+		checks       []health_interface.Check
+		isReadiness  bool
+		shuttingDown bool
+		status       int
+		report       report
+	}{
+		{
+			name:   "all checks passed",
+			checks: []health_interface.Check{&testCheck{name: "mongo"}, &testCheck{name: "ffprobe"}},
+			status: http.StatusOK,
+			report: report{Status: statusOK, Checks: map[string]string{"mongo": statusOK, "ffprobe": statusOK}},
+		},
+		{
+			name:   "no checks",
+			status: http.StatusOK,
+			report: report{Status: statusOK, Checks: map[string]string{}},
+		},
+		{
+			name:   "one check failed",
+			checks: []health_interface.Check{&testCheck{name: "mongo", err: errors.New("unreachable")}, &testCheck{name: "ffprobe"}},
+			status: http.StatusServiceUnavailable,
+			report: report{Status: statusFail, Checks: map[string]string{"mongo": "unreachable", "ffprobe": statusOK}},
+		},
+		{
+			name:   "stuck check is timed out",
+			checks: []health_interface.Check{&testCheck{name: "mongo", isStuck: true}},
+			status: http.StatusServiceUnavailable,
+			report: report{Status: statusFail, Checks: map[string]string{"mongo": context.DeadlineExceeded.Error()}},
+		},
+		{
+			name:        "ready",
+			checks:      []health_interface.Check{&testCheck{name: "mongo"}},
+			isReadiness: true,
+			status:      http.StatusOK,
+			report:      report{Status: statusOK, Checks: map[string]string{"mongo": statusOK}},
+		},
+		{
+			name:         "not ready on shutdown",
+			checks:       []health_interface.Check{&testCheck{name: "mongo"}},
+			isReadiness:  true,
+			shuttingDown: true,
+			status:       http.StatusServiceUnavailable,
+			report: report{Status: statusFail, Checks: map[string]string{
+				"mongo":               statusOK,
+				shuttingDownCheckName: "graceful shutdown is in progress",
+			}},
+		},
+		{
+			name:         "alive on shutdown",
+			checks:       []health_interface.Check{&testCheck{name: "logger"}},
+			shuttingDown: true,
+			status:       http.StatusOK,
+			report:       report{Status: statusOK, Checks: map[string]string{"logger": statusOK}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c *Checker
+			var handler http.Handler
+			if tt.isReadiness {
+				c = newTestChecker(nil, tt.checks)
+				handler = c.ReadinessHandler()
+			} else {
+				c = newTestChecker(tt.checks, nil)
+				handler = c.LivenessHandler()
+			}
+			if tt.shuttingDown {
+				c.Shutdown()
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("content type = %q, want application/json", ct)
+			}
+
+			var rep report
+			if err := json.NewDecoder(w.Body).Decode(&rep); err != nil {
+				t.Fatalf("unable to decode the report: %v", err)
+			}
+			if !reflect.DeepEqual(rep, tt.report) {
+				t.Errorf("report = %+v, want %+v", rep, tt.report)
+			}
+		})
+	}
+}
+
+func TestChecks(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name    string
+		check   health_interface.Check
+		isValid bool
+	}{
+		{
+			name:    "writable dir",
+			check:   NewWritableDirCheck("resources", func() (string, error) { return dir, nil }),
+			isValid: true,
+		},
+		{
+			name:  "missing dir",
+			check: NewWritableDirCheck("resources", func() (string, error) { return missing, nil }),
+		},
+		{
+			name:  "dir cannot be resolved",
+			check: NewWritableDirCheck("resources", func() (string, error) { return "", errors.New("no wd") }),
+		},
+		{
+			name:    "binary in the path",
+			check:   NewBinaryCheck("sh"),
+			isValid: true,
+		},
+		{
+			name:  "binary is missing",
+			check: NewBinaryCheck("video-streaming-missing-binary"),
+		},
+		{
+			name:    "logger is not saturated",
+			check:   NewLoggerCheck(&testSaturatedLogger{Logger: logger_stub.NewLogger(), saturation: 0.5}, 0.9),
+			isValid: true,
+		},
+		{
+			name:  "logger is saturated",
+			check: NewLoggerCheck(&testSaturatedLogger{Logger: logger_stub.NewLogger(), saturation: 0.9}, 0.9),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check.Check(context.Background())
+			if tt.isValid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+		})
+	}
+
+	// the probe file must not be left into the checked directory
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("the directory is not empty after the check: %v", entries)
+	}
+}
@@ -0,0 +1,99 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"os"
+	"os/exec"
+)
+
+// MongoCheck - checks the mongo database is reachable.
+type MongoCheck struct {
+	database *mongo.Database
+}
+
+func NewMongoCheck(database *mongo.Database) *MongoCheck {
+	return &MongoCheck{database: database}
+}
+
+func (c *MongoCheck) Name() string {
+	return "mongo"
+}
+
+func (c *MongoCheck) Check(ctx context.Context) error {
+	return c.database.Client().Ping(ctx, readpref.Primary())
+}
+
+// WritableDirCheck - checks a file may be created into the directory (for example, the uploaded resources dir).
+type WritableDirCheck struct {
+	name string
+	dir  func() (string, error)
+}
+
+// NewWritableDirCheck - the dir func is called on each check, because the directory path may depend on
+// the working directory of the process.
+func NewWritableDirCheck(name string, dir func() (string, error)) *WritableDirCheck {
+	return &WritableDirCheck{name: name, dir: dir}
+}
+
+func (c *WritableDirCheck) Name() string {
+	return c.name
+}
+
+func (c *WritableDirCheck) Check(_ context.Context) error {
+	dir, err := c.dir()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, ".healthcheck-*")
+	if err != nil {
+		return err
+	}
+	_ = file.Close()
+
+	return os.Remove(file.Name())
+}
+
+// BinaryCheck - checks the executable is available in the PATH (for example, ffprobe).
+type BinaryCheck struct {
+	binary string
+}
+
+func NewBinaryCheck(binary string) *BinaryCheck {
+	return &BinaryCheck{binary: binary}
+}
+
+func (c *BinaryCheck) Name() string {
+	return c.binary
+}
+
+func (c *BinaryCheck) Check(_ context.Context) error {
+	_, err := exec.LookPath(c.binary)
+	return err
+}
+
+// LoggerCheck - checks the logger buffers are not saturated (the logger blocks the callers when the buffer
+// is full, so the saturated logger means that the whole process is stuck on the logging).
+type LoggerCheck struct {
+	logger    logger_interface.Logger
+	threshold float64
+}
+
+func NewLoggerCheck(logger logger_interface.Logger, threshold float64) *LoggerCheck {
+	return &LoggerCheck{logger: logger, threshold: threshold}
+}
+
+func (c *LoggerCheck) Name() string {
+	return "logger"
+}
+
+func (c *LoggerCheck) Check(_ context.Context) error {
+	if saturation := c.logger.Saturation(); saturation >= c.threshold {
+		return fmt.Errorf("logger queue is saturated on %.0f%%", saturation*100)
+	}
+	return nil
+}
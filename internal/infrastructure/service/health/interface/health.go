@@ -0,0 +1,22 @@
+package health_interface
+
+import (
+	"context"
+	"net/http"
+)
+
+type Check interface {
+	// Name will return a name of the checked dependency (used as a key of the report).
+	Name() string
+	// Check will return an error if the dependency is unavailable.
+	Check(ctx context.Context) error
+}
+
+type Checker interface {
+	// LivenessHandler will return the http handler which tells whether the process must be restarted.
+	LivenessHandler() http.Handler
+	// ReadinessHandler will return the http handler which tells whether the process is able to serve the traffic.
+	ReadinessHandler() http.Handler
+	// Shutdown will switch the readiness into failing state, so the new traffic will not be routed to the process.
+	Shutdown()
+}
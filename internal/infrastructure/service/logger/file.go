@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const rotatedFileTimeFormat = "20060102T150405.000000000"
+
+// RotatingFileSink - writes the lines into a file. When the file reaches the max. size, it's renamed
+// with timestamp suffix and a new file is opened. The rotated files are removed by age and number.
+type RotatingFileSink struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func NewRotatingFileSink(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingFileSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("logger: file path of the 'file' sink cannot be empty")
+	}
+
+	s := &RotatingFileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *RotatingFileSink) Write(_ int, line []byte) error {
+	// the line with newline symbol
+	n := int64(len(line) + 1)
+
+	if s.maxSize > 0 && s.size > 0 && s.size+n > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	written, err := s.file.Write(append(line, '\n'))
+	s.size += int64(written)
+
+	return err
+}
+
+func (s *RotatingFileSink) Close() error {
+	return s.file.Close()
+}
+
+func (s *RotatingFileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	s.file = file
+	s.size = stat.Size()
+
+	return nil
+}
+
+func (s *RotatingFileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(s.path, s.path+"."+time.Now().UTC().Format(rotatedFileTimeFormat)); err != nil {
+		// continue writing into the current file
+		if oerr := s.open(); oerr != nil {
+			return oerr
+		}
+		return err
+	}
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	return s.cleanup()
+}
+
+// cleanup - removes the rotated files which are older than max. age or exceed the max. number.
+func (s *RotatingFileSink) cleanup() error {
+	if s.maxAge <= 0 && s.maxBackups <= 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return err
+	}
+
+	backups := make([]string, 0, len(matches))
+	for _, match := range matches {
+		suffix := strings.TrimPrefix(match, s.path+".")
+		if _, perr := time.Parse(rotatedFileTimeFormat, suffix); perr == nil {
+			backups = append(backups, match)
+		}
+	}
+	// the newest files first (the timestamp suffix is sortable as a string)
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	for i, backup := range backups {
+		expired := false
+		if s.maxBackups > 0 && i >= s.maxBackups {
+			expired = true
+		} else if s.maxAge > 0 {
+			createdAt, _ := time.Parse(rotatedFileTimeFormat, strings.TrimPrefix(backup, s.path+"."))
+			expired = time.Since(createdAt) > s.maxAge
+		}
+
+		if expired {
+			if err = os.Remove(backup); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
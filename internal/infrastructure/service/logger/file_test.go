@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileSink_Write(t *testing.T) {
+	line := []byte(strings.Repeat("x", 9)) // 10 bytes with the newline
+
+	tests := []struct {
+		name       string
+		maxSize    int64
+		maxAge     time.Duration
+		maxBackups int
+		lines      int
+		existing   []time.Duration // ages of the backups which were rotated before
+		backups    int
+		size       int64 // size of the current file
+	}{
+		{name: "rotation disabled", lines: 10, size: 100},
+		{name: "fits the max size", maxSize: 100, lines: 10, size: 100},
+		{name: "rotated by size", maxSize: 25, lines: 5, backups: 2, size: 10},
+		{name: "line bigger than max size", maxSize: 5, lines: 3, backups: 2, size: 10},
+		{name: "limited by number", maxSize: 10, maxBackups: 2, lines: 6, backups: 2, size: 10},
+		{
+			name:     "expired backups removed",
+			maxSize:  10,
+			maxAge:   time.Hour,
+			lines:    2,
+			existing: []time.Duration{2 * time.Hour, 30 * time.Minute},
+			backups:  2, // the fresh existing one and the rotated one
+			size:     10,
+		},
+		{
+			name:       "old backups removed by number",
+			maxSize:    10,
+			maxBackups: 1,
+			lines:      2,
+			existing:   []time.Duration{time.Minute, 2 * time.Minute},
+			backups:    1,
+			size:       10,
+		},
+		{
+			name:     "backups kept without limits",
+			maxSize:  10,
+			lines:    2,
+			existing: []time.Duration{24 * time.Hour},
+			backups:  2,
+			size:     10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "logs", "app.log")
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, age := range tt.existing {
+				backup := path + "." + time.Now().Add(-age).UTC().Format(rotatedFileTimeFormat)
+				if err := os.WriteFile(backup, line, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// the foreign file must never be removed by the cleanup
+			foreign := path + ".gz"
+			if err := os.WriteFile(foreign, line, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			sink, err := NewRotatingFileSink(path, tt.maxSize, tt.maxAge, tt.maxBackups)
+			if err != nil {
+				t.Fatalf("unable to open the sink: %v", err)
+			}
+			for i := 0; i < tt.lines; i++ {
+				if err = sink.Write(InfoLevel, line); err != nil {
+					t.Fatalf("unable to write a line: %v", err)
+				}
+			}
+			if err = sink.Close(); err != nil {
+				t.Fatalf("unable to close the sink: %v", err)
+			}
+
+			stat, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("the current file is missing: %v", err)
+			}
+			if stat.Size() != tt.size {
+				t.Errorf("size of the current file = %d, want %d", stat.Size(), tt.size)
+			}
+
+			matches, err := filepath.Glob(path + ".*")
+			if err != nil {
+				t.Fatal(err)
+			}
+			backups := 0
+			for _, match := range matches {
+				if match != foreign {
+					backups++
+				}
+			}
+			if backups != tt.backups {
+				t.Errorf("backups = %d, want %d (%v)", backups, tt.backups, matches)
+			}
+			if _, err = os.Stat(foreign); err != nil {
+				t.Errorf("the foreign file was removed: %v", err)
+			}
+		})
+	}
+}
+
+func TestRotatingFileSink_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	line := []byte("123456789")
+
+	// the size of already existing file is taken into account
+	for i := 0; i < 2; i++ {
+		sink, err := NewRotatingFileSink(path, 15, 0, 0)
+		if err != nil {
+			t.Fatalf("unable to open the sink: %v", err)
+		}
+		if err = sink.Write(InfoLevel, line); err != nil {
+			t.Fatalf("unable to write a line: %v", err)
+		}
+		if err = sink.Close(); err != nil {
+			t.Fatalf("unable to close the sink: %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Errorf("backups = %d, want 1 (%v)", len(matches), matches)
+	}
+}
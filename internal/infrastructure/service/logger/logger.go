@@ -6,54 +6,86 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"io"
-	"log"
+	"math"
 	"runtime"
-	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type abstract struct {
-	mu     *sync.Mutex
-	ctx    context.Context
-	writer io.Writer
-	errCh  chan introspectedError
-	reqCh  chan any
+	ctx   context.Context
+	out   *output
+	errCh chan introspectedError
+	reqCh chan any
 }
 
-func newAbstractLogger(ctx context.Context, w io.Writer, errBuff int, reqBuff int) (logger *abstract, closeFunc func()) {
+func newAbstractLogger(ctx context.Context, opts Options) (logger *abstract, closeFunc func()) {
 	l := &abstract{
-		mu:     new(sync.Mutex),
-		ctx:    ctx,
-		writer: w,
-		errCh:  make(chan introspectedError, errBuff),
-		reqCh:  make(chan any, reqBuff),
+		ctx:   ctx,
+		out:   newOutput(opts),
+		errCh: make(chan introspectedError, opts.ErrorsBuffer),
+		reqCh: make(chan any, opts.RequestsBuffer),
 	}
 	l.handle()
 	return l, l.Close()
 }
 
+// Close - returns a function which stops the logger. The buffered records will be written
+// before the sinks closing, so the function blocks until the buffers are drained.
 func (l *abstract) Close() (closeFunc func()) {
 	return func() {
 		close(l.errCh)
 		close(l.reqCh)
+		l.out.wg.Wait()
+		l.reportDropped()
+		l.out.close()
 	}
 }
 
+// SetOutput - replaces all the sinks of the logger by the given writer.
 func (l *abstract) SetOutput(w io.Writer) {
-	defer l.mu.Unlock()
-	l.mu.Lock()
-	l.writer = w
+	l.out.setSinks(NewWriterSink(w))
 }
 
+// Writer - returns a writer which duplicates the written data into each sink of the logger.
 func (l *abstract) Writer() io.Writer {
-	return l.writer
+	return l.out
 }
 
-func (l *abstract) SetContext(ctx context.Context) {
-	defer l.mu.Unlock()
-	l.mu.Lock()
-	l.ctx = ctx
+// Dropped - returns the number of error and request records which were dropped due to the full buffer
+// (records are dropped only in the non-blocking mode).
+func (l *abstract) Dropped() (errors uint64, requests uint64) {
+	return l.out.dropped()
+}
+
+// Saturation - returns the filling of the most loaded buffer (from 0 to 1), the value close to 1 means
+// that the sinks do not keep up with the records and the callers are blocked (or records are dropped).
+func (l *abstract) Saturation() float64 {
+	saturation := 0.0
+	if c := cap(l.errCh); c > 0 {
+		saturation = float64(len(l.errCh)) / float64(c)
+	}
+	if c := cap(l.reqCh); c > 0 {
+		saturation = math.Max(saturation, float64(len(l.reqCh))/float64(c))
+	}
+	return saturation
+}
+
+// WithContext - will return a logger which shares the output with the current one, but each line written by it
+// will carry the request ID, user ID and route from the given context (the current logger stays untouched).
+func (l *abstract) WithContext(ctx context.Context) logger_interface.Logger {
+	if ctx == nil {
+		return l
+	}
+	return &abstract{
+		ctx:   ctx,
+		out:   l.out,
+		errCh: l.errCh,
+		reqCh: l.reqCh,
+	}
 }
 
 func (l *abstract) Context() context.Context {
@@ -61,7 +93,22 @@ func (l *abstract) Context() context.Context {
 }
 
 func (l *abstract) LogData(data any) {
-	l.reqCh <- data
+	if reqID := l.requestContext().Rq; reqID != "" {
+		if dataObj, ok := data.(RequestIdAware); ok {
+			dataObj.SetRequestID(reqID)
+		}
+	}
+
+	if !l.out.nonBlocking {
+		l.reqCh <- data
+		return
+	}
+
+	select {
+	case l.reqCh <- data:
+	default:
+		atomic.AddUint64(&l.out.droppedRequests, 1)
+	}
 }
 
 func (l *abstract) Log(err error) {
@@ -80,16 +127,17 @@ func (l *abstract) Info(strOrErr any) {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &infoLevelError{
+	l.enqueue(&infoLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: InfoLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             InfoLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 }
 
 func (l *abstract) InfoPropagate(strOrErr any) error {
@@ -97,16 +145,17 @@ func (l *abstract) InfoPropagate(strOrErr any) error {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &infoLevelError{
+	l.enqueue(&infoLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: InfoLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             InfoLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 
 	return err
 }
@@ -116,16 +165,17 @@ func (l *abstract) Debug(strOrErr any) {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &debugLevelError{
+	l.enqueue(&debugLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: DebugLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             DebugLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 }
 
 func (l *abstract) DebugPropagate(strOrErr any) error {
@@ -133,16 +183,17 @@ func (l *abstract) DebugPropagate(strOrErr any) error {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &debugLevelError{
+	l.enqueue(&debugLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: DebugLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             DebugLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 
 	return err
 }
@@ -152,16 +203,17 @@ func (l *abstract) Warning(strOrErr any) {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &warningLevelError{
+	l.enqueue(&warningLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 }
 
 func (l *abstract) WarningPropagate(strOrErr any) error {
@@ -169,16 +221,17 @@ func (l *abstract) WarningPropagate(strOrErr any) error {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &warningLevelError{
+	l.enqueue(&warningLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 
 	return err
 }
@@ -188,16 +241,17 @@ func (l *abstract) Error(strOrErr any) {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &errorLevelError{
+	l.enqueue(&errorLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 }
 
 func (l *abstract) ErrorPropagate(strOrErr any) error {
@@ -205,16 +259,17 @@ func (l *abstract) ErrorPropagate(strOrErr any) error {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &errorLevelError{
+	l.enqueue(&errorLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 
 	return err
 }
@@ -224,16 +279,17 @@ func (l *abstract) Critical(strOrErr any) {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &criticalLevelError{
+	l.enqueue(&criticalLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 }
 
 func (l *abstract) CriticalPropagate(strOrErr any) error {
@@ -241,16 +297,17 @@ func (l *abstract) CriticalPropagate(strOrErr any) error {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &criticalLevelError{
+	l.enqueue(&criticalLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 
 	return err
 }
@@ -260,16 +317,17 @@ func (l *abstract) Emergency(strOrErr any) {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &emergencyLevelError{
+	l.enqueue(&emergencyLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 }
 
 func (l *abstract) EmergencyPropagate(strOrErr any) error {
@@ -277,173 +335,210 @@ func (l *abstract) EmergencyPropagate(strOrErr any) error {
 
 	err := l.error(strOrErr)
 
-	l.errCh <- &emergencyLevelError{
+	l.enqueue(&emergencyLevelError{
 		introspectionError{
-			Dt: time.Now(),
-			Mg: err.Error(),
-			Tp: ErrorLogType,
-			Fl: file,
-			Fn: function,
-			Ln: line,
+			Dt:             time.Now(),
+			requestContext: l.requestContext(),
+			Mg:             err.Error(),
+			Tp:             ErrorLogType,
+			Fl:             file,
+			Fn:             function,
+			Ln:             line,
 		},
-	}
+	})
 
 	return err
 }
 
 func (l *abstract) handle() {
+	l.out.wg.Add(2)
+
 	go func() {
+		defer l.out.wg.Done()
 		for err := range l.errCh {
-			l.mu.Lock()
-			if uniqReqID := l.ctx.Value(enum.UniqueRequestIDKey); uniqReqID != nil {
-				if strUniqReqID, ok := uniqReqID.(string); ok {
-
-					err.SetRequestId(strUniqReqID)
-				}
-			}
-			l.mu.Unlock()
-
-			j, e := json.MarshalIndent(err, "", "  ")
-			if e != nil {
-				_, fmterr := fmt.Fprintln(l.writer, e)
-				if fmterr != nil {
-					log.Println(err)
-					log.Fatalln(fmterr)
-				}
-			} else {
-				_, fmterr := fmt.Fprintln(l.writer, string(j))
-				if fmterr != nil {
-					log.Println(err)
-					log.Fatalln(fmterr)
-				}
-			}
+			l.out.write(err.Level(), l.marshal(err))
+			l.reportDropped()
 		}
 	}()
 
 	go func() {
+		defer l.out.wg.Done()
 		for info := range l.reqCh {
-			l.mu.Lock()
-			if uniqReqID := l.ctx.Value(enum.UniqueRequestIDKey); uniqReqID != nil {
-				if strUniqReqID, ok := uniqReqID.(string); ok {
-					if infoObj, iok := info.(RequestIdAware); iok {
-						infoObj.SetRequestID(strUniqReqID)
-					}
-				}
-			}
-			l.mu.Unlock()
-
-			j, e := json.MarshalIndent(info, "", "  ")
-			if e != nil {
-				_, fmterr := fmt.Fprintln(l.writer, e)
-				if fmterr != nil {
-					log.Println(info)
-					log.Fatalln(fmterr)
-				}
-			} else {
-				_, fmterr := fmt.Fprintln(l.writer, string(j))
-				if fmterr != nil {
-					log.Println(info)
-					log.Fatalln(fmterr)
-				}
-			}
+			l.out.write(InfoLevel, l.marshal(info))
 		}
 	}()
 }
 
-func (l *abstract) log(e error, file string, function string, line int) {
-	err, isLoggableErr := e.(LoggableError)
-	if !isLoggableErr {
-		l.errCh <- &errorLevelError{
+// enqueue - passes the record to the writing goroutine if it's acceptable by the minimum level and the sampler.
+func (l *abstract) enqueue(err introspectedError) {
+	if severity(err.Level()) < l.out.minSeverity {
+		return
+	}
+	if !l.out.sampler.allow(err) {
+		return
+	}
+	err.setLevel(ToReadableLevel(err))
+
+	if !l.out.nonBlocking {
+		l.errCh <- err
+		return
+	}
+
+	select {
+	case l.errCh <- err:
+	default:
+		atomic.AddUint64(&l.out.droppedErrors, 1)
+	}
+}
+
+// reportDropped - writes a warning line when new records were dropped since the last report.
+func (l *abstract) reportDropped() {
+	errs, reqs := l.out.dropped()
+	if total := errs + reqs; total > l.out.reportedDrops {
+		record := &warningLevelError{
 			introspectionError{
 				Dt: time.Now(),
-				Mg: e.Error(),
+				Lv: WarningLevelReadable,
+				Mg: fmt.Sprintf(
+					"logger buffers are full, dropped %d records since the last report (errors: %d, requests: %d in total)",
+					total-l.out.reportedDrops, errs, reqs,
+				),
 				Tp: ErrorLogType,
-				Fl: file,
-				Fn: function,
-				Ln: line,
 			},
 		}
+		l.out.reportedDrops = total
+		l.out.write(record.Level(), l.marshal(record))
+	}
+}
+
+// marshal - encodes the record as a single line JSON.
+func (l *abstract) marshal(record any) []byte {
+	j, err := json.Marshal(record)
+	if err != nil {
+		return []byte(err.Error())
+	}
+	return j
+}
+
+func (l *abstract) log(e error, file string, function string, line int) {
+	err, isLoggableErr := e.(LoggableError)
+	if !isLoggableErr {
+		l.enqueue(&errorLevelError{
+			introspectionError{
+				Dt:             time.Now(),
+				requestContext: l.requestContext(),
+				Mg:             e.Error(),
+				Tp:             ErrorLogType,
+				Fl:             file,
+				Fn:             function,
+				Ln:             line,
+			},
+		})
 		return
 	}
 
 	switch err.Level() {
 	case InfoLevel:
-		l.errCh <- &infoLevelError{
+		l.enqueue(&infoLevelError{
 			introspectionError{
-				Dt: time.Now(),
-				Mg: err.Error(),
-				Tp: InfoLogType,
-				Fl: file,
-				Fn: function,
-				Ln: line,
+				Dt:             time.Now(),
+				requestContext: l.requestContext(),
+				Mg:             err.Error(),
+				Tp:             InfoLogType,
+				Fl:             file,
+				Fn:             function,
+				Ln:             line,
 			},
-		}
+		})
 		return
 	case DebugLevel:
-		l.errCh <- &debugLevelError{
+		l.enqueue(&debugLevelError{
 			introspectionError{
-				Dt: time.Now(),
-				Mg: err.Error(),
-				Tp: DebugLogType,
-				Fl: file,
-				Fn: function,
-				Ln: line,
+				Dt:             time.Now(),
+				requestContext: l.requestContext(),
+				Mg:             err.Error(),
+				Tp:             DebugLogType,
+				Fl:             file,
+				Fn:             function,
+				Ln:             line,
 			},
-		}
+		})
 		return
 	case WarningLevel:
-		l.errCh <- &warningLevelError{
+		l.enqueue(&warningLevelError{
 			introspectionError{
-				Dt: time.Now(),
-				Mg: err.Error(),
-				Tp: ErrorLogType,
-				Fl: file,
-				Fn: function,
-				Ln: line,
+				Dt:             time.Now(),
+				requestContext: l.requestContext(),
+				Mg:             err.Error(),
+				Tp:             ErrorLogType,
+				Fl:             file,
+				Fn:             function,
+				Ln:             line,
 			},
-		}
+		})
 		return
 	case ErrorLevel:
-		l.errCh <- &errorLevelError{
+		l.enqueue(&errorLevelError{
 			introspectionError{
-				Dt: time.Now(),
-				Mg: err.Error(),
-				Tp: ErrorLogType,
-				Fl: file,
-				Fn: function,
-				Ln: line,
+				Dt:             time.Now(),
+				requestContext: l.requestContext(),
+				Mg:             err.Error(),
+				Tp:             ErrorLogType,
+				Fl:             file,
+				Fn:             function,
+				Ln:             line,
 			},
-		}
+		})
 		return
 	case CriticalLevel:
-		l.errCh <- &criticalLevelError{
+		l.enqueue(&criticalLevelError{
 			introspectionError{
-				Dt: time.Now(),
-				Mg: err.Error(),
-				Tp: ErrorLogType,
-				Fl: file,
-				Fn: function,
-				Ln: line,
+				Dt:             time.Now(),
+				requestContext: l.requestContext(),
+				Mg:             err.Error(),
+				Tp:             ErrorLogType,
+				Fl:             file,
+				Fn:             function,
+				Ln:             line,
 			},
-		}
+		})
 		return
 	case EmergencyLevel:
-		l.errCh <- &emergencyLevelError{
+		l.enqueue(&emergencyLevelError{
 			introspectionError{
-				Dt: time.Now(),
-				Mg: err.Error(),
-				Tp: ErrorLogType,
-				Fl: file,
-				Fn: function,
-				Ln: line,
+				Dt:             time.Now(),
+				requestContext: l.requestContext(),
+				Mg:             err.Error(),
+				Tp:             ErrorLogType,
+				Fl:             file,
+				Fn:             function,
+				Ln:             line,
 			},
-		}
+		})
 		return
 	}
 
 	panic("logger.log(): undefined error level received")
 }
 
+// requestContext - extracts the request scoped data from the logger context.
+func (l *abstract) requestContext() requestContext {
+	rc := requestContext{}
+	if l.ctx == nil {
+		return rc
+	}
+	if reqID, ok := l.ctx.Value(enum.UniqueRequestIDKey).(string); ok {
+		rc.Rq = reqID
+	}
+	if userID, ok := l.ctx.Value(enum.UserIDContextKey).(vo.ID); ok && !userID.Value.IsZero() {
+		rc.Us = userID.Value.Hex()
+	}
+	if route, ok := l.ctx.Value(enum.RouteContextKey).(string); ok {
+		rc.Rt = route
+	}
+	return rc
+}
+
 func (l *abstract) trace() (file string, function string, line int) {
 	pc := make([]uintptr, 15)
 	n := runtime.Callers(3, pc)
@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Options - the logger settings.
+type Options struct {
+	// ErrorsBuffer is a capacity of the errors channel.
+	ErrorsBuffer int
+	// RequestsBuffer is a capacity of the requests/responses channel.
+	RequestsBuffer int
+	// MinLevel is a readable level (INFO, DEBUG, etc.), records with lower severity will be skipped.
+	MinLevel string
+	// SamplingInitial is a number of records per call site which will be written within each SamplingTick.
+	// Zero value disables the sampling.
+	SamplingInitial int
+	// SamplingThereafter means that every Nth record per call site will be written after the SamplingInitial
+	// within the SamplingTick (zero value means that all the rest records will be skipped).
+	SamplingThereafter int
+	// SamplingTick is a window within which the records are counted.
+	SamplingTick time.Duration
+	// NonBlocking enables the mode when records are dropped instead of blocking the caller on the full buffer.
+	NonBlocking bool
+	// Sinks is a list of outputs of the logger.
+	Sinks []Sink
+}
+
+// output - the state which is shared between the logger and all its request scoped copies.
+type output struct {
+	mu          *sync.Mutex
+	wg          *sync.WaitGroup
+	sinks       []Sink
+	minSeverity int
+	sampler     *sampler
+	nonBlocking bool
+
+	droppedErrors   uint64
+	droppedRequests uint64
+	reportedDrops   uint64
+}
+
+func newOutput(opts Options) *output {
+	minLevel, err := ParseLevel(opts.MinLevel)
+	if err != nil {
+		minLevel = DebugLevel
+	}
+	return &output{
+		mu:          &sync.Mutex{},
+		wg:          &sync.WaitGroup{},
+		sinks:       opts.Sinks,
+		minSeverity: severity(minLevel),
+		sampler:     newSampler(opts.SamplingInitial, opts.SamplingThereafter, opts.SamplingTick),
+		nonBlocking: opts.NonBlocking,
+	}
+}
+
+func (o *output) setSinks(sinks ...Sink) {
+	defer o.mu.Unlock()
+	o.mu.Lock()
+	o.sinks = sinks
+}
+
+// write - writes the line into each sink. A failed sink does not prevent writing into the rest ones.
+func (o *output) write(level int, line []byte) {
+	defer o.mu.Unlock()
+	o.mu.Lock()
+	for _, sink := range o.sinks {
+		if err := sink.Write(level, line); err != nil {
+			log.Println(fmt.Sprintf("logger: unable to write into sink: %v, line: %s", err, line))
+		}
+	}
+}
+
+// Write - implementation of io.Writer, the data is written into each sink with INFO level.
+func (o *output) Write(p []byte) (n int, err error) {
+	o.write(InfoLevel, p)
+	return len(p), nil
+}
+
+func (o *output) dropped() (errors uint64, requests uint64) {
+	return atomic.LoadUint64(&o.droppedErrors), atomic.LoadUint64(&o.droppedRequests)
+}
+
+func (o *output) close() {
+	defer o.mu.Unlock()
+	o.mu.Lock()
+	for _, sink := range o.sinks {
+		if err := sink.Close(); err != nil {
+			log.Println(fmt.Sprintf("logger: unable to close sink: %v", err))
+		}
+	}
+}
+
+// ParseLevel - converts a readable level into the level constant.
+func ParseLevel(readableLevel string) (int, error) {
+	switch strings.ToUpper(readableLevel) {
+	case InfoLevelReadable:
+		return InfoLevel, nil
+	case DebugLevelReadable:
+		return DebugLevel, nil
+	case WarningLevelReadable:
+		return WarningLevel, nil
+	case ErrorLevelReadable:
+		return ErrorLevel, nil
+	case CriticalLevelReadable:
+		return CriticalLevel, nil
+	case EmergencyLevelReadable:
+		return EmergencyLevel, nil
+	}
+	return 0, fmt.Errorf("logger: undefined level '%v' received", readableLevel)
+}
+
+// severity - returns the ordinal number of level, the greater value means the more important record.
+func severity(level int) int {
+	switch level {
+	case DebugLevel:
+		return 0
+	case InfoLevel:
+		return 1
+	case WarningLevel:
+		return 2
+	case ErrorLevel:
+		return 3
+	case CriticalLevel:
+		return 4
+	case EmergencyLevel:
+		return 5
+	}
+	return 0
+}
+
+// sampler - limits the number of records per call site within a time window.
+// The records of ERROR level and above are never sampled.
+type sampler struct {
+	mu         *sync.Mutex
+	initial    int
+	thereafter int
+	tick       time.Duration
+	counters   map[string]*sampleCounter
+}
+
+type sampleCounter struct {
+	resetAt time.Time
+	count   int
+}
+
+func newSampler(initial int, thereafter int, tick time.Duration) *sampler {
+	if tick <= 0 {
+		tick = time.Second
+	}
+	return &sampler{
+		mu:         &sync.Mutex{},
+		initial:    initial,
+		thereafter: thereafter,
+		tick:       tick,
+		counters:   make(map[string]*sampleCounter),
+	}
+}
+
+// allow - tells whether the record must be written.
+func (s *sampler) allow(err introspectedError) bool {
+	if s.initial <= 0 || severity(err.Level()) >= severity(ErrorLevel) {
+		return true
+	}
+
+	key := fmt.Sprintf("%d:%s:%d", err.Level(), err.File(), err.Line())
+	now := time.Now()
+
+	defer s.mu.Unlock()
+	s.mu.Lock()
+
+	counter, found := s.counters[key]
+	if !found || now.After(counter.resetAt) {
+		counter = &sampleCounter{resetAt: now.Add(s.tick)}
+		s.counters[key] = counter
+	}
+	counter.count++
+
+	if counter.count <= s.initial {
+		return true
+	}
+	if s.thereafter <= 0 {
+		return false
+	}
+	return (counter.count-s.initial)%s.thereafter == 0
+}
@@ -0,0 +1,203 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSink - collects the written lines or fails each write by the given error.
+type testSink struct {
+	err    error
+	lines  []string
+	closed bool
+}
+
+func (s *testSink) Write(_ int, line []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.lines = append(s.lines, string(line))
+	return nil
+}
+
+func (s *testSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		readable string
+		level    int
+		isValid  bool
+	}{
+		{readable: "DEBUG", level: DebugLevel, isValid: true},
+		{readable: "info", level: InfoLevel, isValid: true},
+		{readable: "Warning", level: WarningLevel, isValid: true},
+		{readable: "ERROR", level: ErrorLevel, isValid: true},
+		{readable: "CRITICAL", level: CriticalLevel, isValid: true},
+		{readable: "EMERGENCY", level: EmergencyLevel, isValid: true},
+		{readable: "TRACE"},
+		{readable: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.readable, func(t *testing.T) {
+			level, err := ParseLevel(tt.readable)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("expected an error, got level %d", level)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if level != tt.level {
+				t.Errorf("level = %d, want %d", level, tt.level)
+			}
+		})
+	}
+}
+
+func TestSampler_Allow(t *testing.T) {
+	info := func(line int) introspectedError {
+		return &infoLevelError{introspectionError{Fl: "file.go", Ln: line}}
+	}
+	failure := func(line int) introspectedError {
+		return &errorLevelError{introspectionError{Fl: "file.go", Ln: line}}
+	}
+
+	tests := []struct {
+		name       string
+		initial    int
+		thereafter int
+		records    []introspectedError
+		allowed    int
+	}{
+		{name: "disabled", records: []introspectedError{info(1), info(1), info(1)}, allowed: 3},
+		{name: "initial only", initial: 2, records: []introspectedError{info(1), info(1), info(1), info(1)}, allowed: 2},
+		{
+			name:       "every second thereafter",
+			initial:    1,
+			thereafter: 2,
+			records:    []introspectedError{info(1), info(1), info(1), info(1), info(1)},
+			allowed:    3, // 1st, 3rd and 5th
+		},
+		{name: "per call site", initial: 1, records: []introspectedError{info(1), info(2), info(1), info(2)}, allowed: 2},
+		{name: "errors are never sampled", initial: 1, records: []introspectedError{failure(1), failure(1), failure(1)}, allowed: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSampler(tt.initial, tt.thereafter, time.Hour)
+			allowed := 0
+			for _, record := range tt.records {
+				if s.allow(record) {
+					allowed++
+				}
+			}
+			if allowed != tt.allowed {
+				t.Errorf("allowed = %d, want %d", allowed, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestSampler_AllowAfterTick(t *testing.T) {
+	s := newSampler(1, 0, 10*time.Millisecond)
+	record := &infoLevelError{introspectionError{Fl: "file.go", Ln: 1}}
+
+	if !s.allow(record) {
+		t.Fatalf("the first record must be allowed")
+	}
+	if s.allow(record) {
+		t.Fatalf("the second record within the tick must be skipped")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if !s.allow(record) {
+		t.Fatalf("the first record of the next tick must be allowed")
+	}
+}
+
+func TestOutput_Write(t *testing.T) {
+	failed := &testSink{err: errors.New("sink is broken")}
+	healthy := &testSink{}
+	o := newOutput(Options{Sinks: []Sink{failed, healthy}})
+
+	o.write(InfoLevel, []byte("first"))
+	if _, err := o.Write([]byte("second")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	o.close()
+
+	// the failed sink must not prevent writing into the rest ones
+	if got := strings.Join(healthy.lines, ","); got != "first,second" {
+		t.Errorf("lines = %q, want %q", got, "first,second")
+	}
+	if !failed.closed || !healthy.closed {
+		t.Errorf("all sinks must be closed")
+	}
+}
+
+func TestNewSinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    SinksOptions
+		sinks   int
+		isValid bool
+	}{
+		{name: "stdout and stderr", opts: SinksOptions{Names: []string{"stdout", " STDERR "}}, sinks: 2, isValid: true},
+		{name: "empty names are skipped", opts: SinksOptions{Names: []string{"", "stdout"}}, sinks: 1, isValid: true},
+		{name: "file", opts: SinksOptions{Names: []string{"file"}, FilePath: "app.log"}, sinks: 1, isValid: true},
+		{name: "file without path", opts: SinksOptions{Names: []string{"stdout", "file"}}},
+		{name: "undefined sink", opts: SinksOptions{Names: []string{"stdout", "kafka"}}},
+		{name: "no sinks", opts: SinksOptions{Names: []string{""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opts.FilePath != "" {
+				tt.opts.FilePath = t.TempDir() + "/" + tt.opts.FilePath
+			}
+
+			sinks, err := NewSinks(tt.opts)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("expected an error, got %d sinks", len(sinks))
+				}
+				if sinks != nil {
+					t.Errorf("sinks must not be returned on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(sinks) != tt.sinks {
+				t.Errorf("sinks = %d, want %d", len(sinks), tt.sinks)
+			}
+			for _, sink := range sinks {
+				_ = sink.Close()
+			}
+		})
+	}
+}
+
+func TestWriterSink_Write(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sink := NewWriterSink(buf)
+
+	for _, line := range []string{`{"a":1}`, `{"b":2}`} {
+		if err := sink.Write(InfoLevel, []byte(line)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got, want := buf.String(), "{\"a\":1}\n{\"b\":2}\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	StdOutSinkName = "stdout"
+	StdErrSinkName = "stderr"
+	FileSinkName   = "file"
+	SyslogSinkName = "syslog"
+)
+
+// Sink - an output of the logger. Each call of Write receives exactly one line without the trailing newline.
+type Sink interface {
+	Write(level int, line []byte) error
+	Close() error
+}
+
+// SinksOptions - the settings of sinks which may be built by names.
+type SinksOptions struct {
+	// Names is a list of sinks: stdout, stderr, file, syslog.
+	Names []string
+	// FilePath is a path of the log file (used by 'file' sink).
+	FilePath string
+	// FileMaxSize is a max. size of the log file in bytes before rotation (zero value disables the rotation).
+	FileMaxSize int64
+	// FileMaxAge is a max. age of the rotated files (zero value means that files are kept forever).
+	FileMaxAge time.Duration
+	// FileMaxBackups is a max. number of the rotated files (zero value means an unlimited number).
+	FileMaxBackups int
+	// SyslogNetwork is a network of the syslog socket (used by 'syslog' sink), for example: unixgram.
+	SyslogNetwork string
+	// SyslogAddress is an address of the syslog socket, for example: /dev/log.
+	SyslogAddress string
+	// SyslogTag is a tag (application name) of the syslog messages.
+	SyslogTag string
+}
+
+// NewSinks - builds the sinks by the given names. Already opened sinks will be closed if any of them failed.
+func NewSinks(opts SinksOptions) (sinks []Sink, err error) {
+	defer func() {
+		if err != nil {
+			for _, sink := range sinks {
+				_ = sink.Close()
+			}
+			sinks = nil
+		}
+	}()
+
+	for _, name := range opts.Names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case StdOutSinkName:
+			sinks = append(sinks, NewWriterSink(os.Stdout))
+		case StdErrSinkName:
+			sinks = append(sinks, NewWriterSink(os.Stderr))
+		case FileSinkName:
+			fileSink, ferr := NewRotatingFileSink(opts.FilePath, opts.FileMaxSize, opts.FileMaxAge, opts.FileMaxBackups)
+			if ferr != nil {
+				return sinks, ferr
+			}
+			sinks = append(sinks, fileSink)
+		case SyslogSinkName:
+			syslogSink, serr := NewSyslogSink(opts.SyslogNetwork, opts.SyslogAddress, opts.SyslogTag)
+			if serr != nil {
+				return sinks, serr
+			}
+			sinks = append(sinks, syslogSink)
+		case "":
+			continue
+		default:
+			return sinks, fmt.Errorf("logger: undefined sink '%v' received", name)
+		}
+	}
+
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("logger: at least one sink must be specified")
+	}
+
+	return sinks, nil
+}
+
+// WriterSink - writes the lines into the given io.Writer (the writer is not closed by the sink).
+type WriterSink struct {
+	w io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Write(_ int, line []byte) error {
+	_, err := fmt.Fprintln(s.w, string(line))
+	return err
+}
+
+func (s *WriterSink) Close() error {
+	return nil
+}
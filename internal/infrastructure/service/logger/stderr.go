@@ -10,6 +10,10 @@ type StdOut struct {
 }
 
 func NewStdOut(ctx context.Context, errBuff int, reqBuff int) (logger *StdOut, closeFunc func()) {
-	abstractLogger, closeFunc := newAbstractLogger(ctx, os.Stderr, errBuff, reqBuff)
+	abstractLogger, closeFunc := newAbstractLogger(ctx, Options{
+		ErrorsBuffer:   errBuff,
+		RequestsBuffer: reqBuff,
+		Sinks:          []Sink{NewWriterSink(os.Stderr)},
+	})
 	return &StdOut{abstract: abstractLogger}, closeFunc
 }
@@ -0,0 +1,16 @@
+package logger
+
+import (
+	"context"
+)
+
+// Structured - the logger which writes JSON lines into the configured sinks
+// with respect of the minimum level, sampling and blocking mode.
+type Structured struct {
+	*abstract
+}
+
+func NewStructured(ctx context.Context, opts Options) (logger *Structured, closeFunc func()) {
+	abstractLogger, closeFunc := newAbstractLogger(ctx, opts)
+	return &Structured{abstract: abstractLogger}, closeFunc
+}
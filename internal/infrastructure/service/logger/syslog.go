@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// syslog facility 'user-level messages'
+const syslogFacilityUser = 1
+
+// SyslogSink - writes the lines into the local syslog socket in RFC 3164 format.
+// The connection is re-established once if the write failed (for example, the syslog daemon was restarted).
+type SyslogSink struct {
+	network string
+	address string
+	tag     string
+	conn    net.Conn
+}
+
+func NewSyslogSink(network string, address string, tag string) (*SyslogSink, error) {
+	if address == "" {
+		return nil, fmt.Errorf("logger: socket address of the 'syslog' sink cannot be empty")
+	}
+	if network == "" {
+		network = "unixgram"
+	}
+
+	s := &SyslogSink{
+		network: network,
+		address: address,
+		tag:     tag,
+	}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *SyslogSink) Write(level int, line []byte) error {
+	msg := s.format(level, line)
+
+	if _, err := s.conn.Write(msg); err != nil {
+		if cerr := s.connect(); cerr != nil {
+			return cerr
+		}
+		_, err = s.conn.Write(msg)
+		return err
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) Close() error {
+	return s.conn.Close()
+}
+
+func (s *SyslogSink) connect() error {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+
+	conn, err := net.Dial(s.network, s.address)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+
+	return nil
+}
+
+func (s *SyslogSink) format(level int, line []byte) []byte {
+	priority := syslogFacilityUser*8 + s.severity(level)
+	return []byte(
+		fmt.Sprintf("<%d>%s %s[%d]: %s\n", priority, time.Now().Format(time.Stamp), s.tag, os.Getpid(), line),
+	)
+}
+
+// severity - maps the logger level into the syslog severity.
+func (s *SyslogSink) severity(level int) int {
+	switch level {
+	case EmergencyLevel:
+		return 0
+	case CriticalLevel:
+		return 2
+	case ErrorLevel:
+		return 3
+	case WarningLevel:
+		return 4
+	case DebugLevel:
+		return 7
+	}
+	return 6
+}
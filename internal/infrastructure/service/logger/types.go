@@ -43,16 +43,25 @@ type introspectedError interface {
 	Type() string
 	RequestId() string
 	SetRequestId(id string)
+	setLevel(readable string)
+}
+
+// requestContext - the request scoped data which helps to correlate the log lines with exactly one request.
+type requestContext struct {
+	Rq string `json:"requestID,omitempty"`
+	Us string `json:"userID,omitempty"`
+	Rt string `json:"route,omitempty"`
 }
 
 type introspectionError struct {
 	Dt time.Time `json:"date"`
-	Rq string    `json:"requestID,omitempty"`
-	Tp string    `json:"type"`
-	Mg string    `json:"message"`
-	Fl string    `json:"file"`
-	Fn string    `json:"function"`
-	Ln int       `json:"line"`
+	Lv string    `json:"level"`
+	requestContext
+	Tp string `json:"type"`
+	Mg string `json:"message"`
+	Fl string `json:"file"`
+	Fn string `json:"function"`
+	Ln int    `json:"line"`
 }
 
 func (e *introspectionError) Date() time.Time {
@@ -82,6 +91,9 @@ func (e *introspectionError) RequestId() string {
 func (e *introspectionError) SetRequestId(id string) {
 	e.Rq = id
 }
+func (e *introspectionError) setLevel(readable string) {
+	e.Lv = readable
+}
 
 type infoLevelError struct{ introspectionError }
 
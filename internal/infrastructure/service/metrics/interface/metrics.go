@@ -0,0 +1,45 @@
+package metrics_interface
+
+import (
+	"net/http"
+	"time"
+)
+
+type Metrics interface {
+	// ObserveHTTPRequest will count the request and its latency by route (path template), method and status.
+	ObserveHTTPRequest(route string, method string, status int, duration time.Duration)
+
+	// IncActiveConnections will increase the number of active websocket connections.
+	IncActiveConnections()
+	// DecActiveConnections will decrease the number of active websocket connections.
+	DecActiveConnections()
+	// AddStreamedBytes will count the bytes which were written to websocket by the streaming strategy.
+	AddStreamedBytes(strategy string, bytes int)
+
+	// IncCacheHits will count the cache hit.
+	IncCacheHits()
+	// IncCacheMisses will count the cache miss.
+	IncCacheMisses()
+	// AddCacheEvictions will count the removed cache items.
+	AddCacheEvictions(number int)
+	// SetCacheSize will set the current number of cache items.
+	SetCacheSize(size int)
+	// SetCacheShardStats will set the current number of items and their approx. size in bytes of the cache shard.
+	SetCacheShardStats(shard int, items int, bytes int64)
+
+	// IncChunkCacheHits will count the file chunk which was read from the memory (or was being read by another reader).
+	IncChunkCacheHits()
+	// IncChunkCacheMisses will count the file chunk which was read from the disk.
+	IncChunkCacheMisses()
+	// SetChunkCacheBytes will set the current size of the cached file chunks.
+	SetChunkCacheBytes(bytes int64)
+
+	// ObserveMongoCommand will count the mongo command and its latency by collection (repository) and command name.
+	ObserveMongoCommand(collection string, command string, duration time.Duration, failed bool)
+
+	// ObserveUpload will count the uploaded bytes and the uploading latency by uploader type.
+	ObserveUpload(uploader string, bytes int64, duration time.Duration)
+
+	// Handler will return the http handler which exposes the metrics.
+	Handler() http.Handler
+}
@@ -0,0 +1,284 @@
+package metrics
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const namespace = "streaming"
+
+var (
+	// the collectors are shared by all the applications of the process (resources and streaming apps
+	// are running into the one process), so they must be registered only once
+	sharedCollectors     *collectors
+	sharedCollectorsOnce = &sync.Once{}
+)
+
+type collectors struct {
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+
+	wsActiveConnections prometheus.Gauge
+	wsStreamedBytes     *prometheus.CounterVec
+
+	cacheHits      prometheus.Counter
+	cacheMisses    prometheus.Counter
+	cacheEvictions prometheus.Counter
+	cacheSize      prometheus.Gauge
+	cacheShardSize *prometheus.GaugeVec
+	cacheShardMem  *prometheus.GaugeVec
+
+	chunkCacheHits   prometheus.Counter
+	chunkCacheMisses prometheus.Counter
+	chunkCacheBytes  prometheus.Gauge
+
+	mongoCommandDuration *prometheus.HistogramVec
+	mongoCommandErrors   *prometheus.CounterVec
+
+	uploadedBytes  *prometheus.CounterVec
+	uploadDuration *prometheus.HistogramVec
+}
+
+func newCollectors(registerer prometheus.Registerer) *collectors {
+	c := &collectors{
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by route, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		wsActiveConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "websocket",
+			Name:      "active_connections",
+			Help:      "Number of active websocket connections.",
+		}),
+		wsStreamedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "websocket",
+			Name:      "streamed_bytes_total",
+			Help:      "Number of bytes written to websocket by streaming strategy.",
+		}, []string{"strategy"}),
+		cacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "hits_total",
+			Help:      "Number of cache hits.",
+		}),
+		cacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "misses_total",
+			Help:      "Number of cache misses.",
+		}),
+		cacheEvictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "evictions_total",
+			Help:      "Number of removed cache items.",
+		}),
+		cacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "items",
+			Help:      "Number of cache items.",
+		}),
+		cacheShardSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "shard_items",
+			Help:      "Number of cache items by shard.",
+		}, []string{"shard"}),
+		cacheShardMem: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "shard_bytes",
+			Help:      "Approximate size of cache items by shard.",
+		}, []string{"shard"}),
+		chunkCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "chunk_cache",
+			Name:      "hits_total",
+			Help:      "Number of file chunks which were read from memory.",
+		}),
+		chunkCacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "chunk_cache",
+			Name:      "misses_total",
+			Help:      "Number of file chunks which were read from disk.",
+		}),
+		chunkCacheBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "chunk_cache",
+			Name:      "bytes",
+			Help:      "Size of cached file chunks.",
+		}),
+		mongoCommandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "mongo",
+			Name:      "command_duration_seconds",
+			Help:      "Latency of mongo commands by collection and command.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"collection", "command"}),
+		mongoCommandErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "mongo",
+			Name:      "command_errors_total",
+			Help:      "Number of failed mongo commands by collection and command.",
+		}, []string{"collection", "command"}),
+		uploadedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "uploader",
+			Name:      "uploaded_bytes_total",
+			Help:      "Number of uploaded bytes by uploader type.",
+		}, []string{"uploader"}),
+		uploadDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "uploader",
+			Name:      "upload_duration_seconds",
+			Help:      "Latency of files uploading by uploader type.",
+			Buckets:   []float64{.1, .5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+		}, []string{"uploader"}),
+	}
+
+	registerer.MustRegister(
+		c.httpRequests,
+		c.httpRequestDuration,
+		c.wsActiveConnections,
+		c.wsStreamedBytes,
+		c.cacheHits,
+		c.cacheMisses,
+		c.cacheEvictions,
+		c.cacheSize,
+		c.cacheShardSize,
+		c.cacheShardMem,
+		c.chunkCacheHits,
+		c.chunkCacheMisses,
+		c.chunkCacheBytes,
+		c.mongoCommandDuration,
+		c.mongoCommandErrors,
+		c.uploadedBytes,
+		c.uploadDuration,
+	)
+
+	return c
+}
+
+// PrometheusMetrics - the metrics service which exposes the collected values in Prometheus format.
+type PrometheusMetrics struct {
+	logger     logger_interface.Logger
+	collectors *collectors
+	handler    http.Handler
+}
+
+func NewPrometheusMetrics(serviceContainer di_interface.ContainerManager) (*PrometheusMetrics, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	sharedCollectorsOnce.Do(func() {
+		sharedCollectors = newCollectors(prometheus.DefaultRegisterer)
+	})
+
+	return &PrometheusMetrics{
+		logger:     loggerService,
+		collectors: sharedCollectors,
+		handler: promhttp.InstrumentMetricHandler(
+			prometheus.DefaultRegisterer,
+			promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+				ErrorLog: errorLog{logger: loggerService},
+			}),
+		),
+	}, nil
+}
+
+func (m *PrometheusMetrics) ObserveHTTPRequest(route string, method string, status int, duration time.Duration) {
+	m.collectors.httpRequests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+	m.collectors.httpRequestDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+}
+
+func (m *PrometheusMetrics) IncActiveConnections() {
+	m.collectors.wsActiveConnections.Inc()
+}
+
+func (m *PrometheusMetrics) DecActiveConnections() {
+	m.collectors.wsActiveConnections.Dec()
+}
+
+func (m *PrometheusMetrics) AddStreamedBytes(strategy string, bytes int) {
+	m.collectors.wsStreamedBytes.WithLabelValues(strategy).Add(float64(bytes))
+}
+
+func (m *PrometheusMetrics) IncCacheHits() {
+	m.collectors.cacheHits.Inc()
+}
+
+func (m *PrometheusMetrics) IncCacheMisses() {
+	m.collectors.cacheMisses.Inc()
+}
+
+func (m *PrometheusMetrics) AddCacheEvictions(number int) {
+	m.collectors.cacheEvictions.Add(float64(number))
+}
+
+func (m *PrometheusMetrics) SetCacheSize(size int) {
+	m.collectors.cacheSize.Set(float64(size))
+}
+
+func (m *PrometheusMetrics) SetCacheShardStats(shard int, items int, bytes int64) {
+	label := strconv.Itoa(shard)
+	m.collectors.cacheShardSize.WithLabelValues(label).Set(float64(items))
+	m.collectors.cacheShardMem.WithLabelValues(label).Set(float64(bytes))
+}
+
+func (m *PrometheusMetrics) IncChunkCacheHits() {
+	m.collectors.chunkCacheHits.Inc()
+}
+
+func (m *PrometheusMetrics) IncChunkCacheMisses() {
+	m.collectors.chunkCacheMisses.Inc()
+}
+
+func (m *PrometheusMetrics) SetChunkCacheBytes(bytes int64) {
+	m.collectors.chunkCacheBytes.Set(float64(bytes))
+}
+
+func (m *PrometheusMetrics) ObserveMongoCommand(collection string, command string, duration time.Duration, failed bool) {
+	m.collectors.mongoCommandDuration.WithLabelValues(collection, command).Observe(duration.Seconds())
+	if failed {
+		m.collectors.mongoCommandErrors.WithLabelValues(collection, command).Inc()
+	}
+}
+
+func (m *PrometheusMetrics) ObserveUpload(uploader string, bytes int64, duration time.Duration) {
+	m.collectors.uploadedBytes.WithLabelValues(uploader).Add(float64(bytes))
+	m.collectors.uploadDuration.WithLabelValues(uploader).Observe(duration.Seconds())
+}
+
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return m.handler
+}
+
+// errorLog - passes the metrics exposing errors into the logger.
+type errorLog struct {
+	logger logger_interface.Logger
+}
+
+func (l errorLog) Println(v ...interface{}) {
+	l.logger.Error(fmt.Sprint(v...))
+}
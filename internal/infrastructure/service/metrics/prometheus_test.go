@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestPrometheusMetrics() (*PrometheusMetrics, *prometheus.Registry) {
+	registry := prometheus.NewRegistry()
+	return &PrometheusMetrics{collectors: newCollectors(registry)}, registry
+}
+
+// gather - returns the values of the metric by the sorted label pairs (e.g. "method=GET,route=/api/v1/video/{id},status=200").
+// The counters and gauges are returned as is, the histograms are returned as number of observations.
+func gather(t *testing.T, registry *prometheus.Registry, name string) map[string]float64 {
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unable to gather the metrics: %v", err)
+	}
+
+	values := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make([]string, 0, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, label.GetName()+"="+label.GetValue())
+			}
+			sort.Strings(labels)
+			key := strings.Join(labels, ",")
+			switch {
+			case metric.GetCounter() != nil:
+				values[key] = metric.GetCounter().GetValue()
+			case metric.GetGauge() != nil:
+				values[key] = metric.GetGauge().GetValue()
+			case metric.GetHistogram() != nil:
+				values[key] = float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return values
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		observe func(m *PrometheusMetrics)
+		metric  string
+		want    map[string]float64
+	}{
+		{
+			name: "http requests by route, method and status",
+			observe: func(m *PrometheusMetrics) {
+				m.ObserveHTTPRequest("/api/v1/video/{id}", "GET", 200, time.Millisecond)
+				m.ObserveHTTPRequest("/api/v1/video/{id}", "GET", 200, time.Millisecond)
+				m.ObserveHTTPRequest("/api/v1/video/{id}", "GET", 404, time.Millisecond)
+			},
+			metric: "streaming_http_requests_total",
+			want: map[string]float64{
+				"method=GET,route=/api/v1/video/{id},status=200": 2,
+				"method=GET,route=/api/v1/video/{id},status=404": 1,
+			},
+		},
+		{
+			name: "http request duration by route and method",
+			observe: func(m *PrometheusMetrics) {
+				m.ObserveHTTPRequest("/api/v1/video", "POST", 201, time.Millisecond)
+				m.ObserveHTTPRequest("/api/v1/video", "POST", 400, time.Millisecond)
+			},
+			metric: "streaming_http_request_duration_seconds",
+			want:   map[string]float64{"method=POST,route=/api/v1/video": 2},
+		},
+		{
+			name: "active websocket connections",
+			observe: func(m *PrometheusMetrics) {
+				m.IncActiveConnections()
+				m.IncActiveConnections()
+				m.DecActiveConnections()
+			},
+			metric: "streaming_websocket_active_connections",
+			want:   map[string]float64{"": 1},
+		},
+		{
+			name: "streamed bytes by strategy",
+			observe: func(m *PrometheusMetrics) {
+				m.AddStreamedBytes("byID", 100)
+				m.AddStreamedBytes("byID", 50)
+				m.AddStreamedBytes("byIDWithOffset", 10)
+			},
+			metric: "streaming_websocket_streamed_bytes_total",
+			want:   map[string]float64{"strategy=byID": 150, "strategy=byIDWithOffset": 10},
+		},
+		{
+			name: "cache evictions",
+			observe: func(m *PrometheusMetrics) {
+				m.AddCacheEvictions(3)
+				m.AddCacheEvictions(2)
+			},
+			metric: "streaming_cache_evictions_total",
+			want:   map[string]float64{"": 5},
+		},
+		{
+			name: "cache shard items",
+			observe: func(m *PrometheusMetrics) {
+				m.SetCacheShardStats(0, 10, 1024)
+				m.SetCacheShardStats(1, 5, 512)
+				m.SetCacheShardStats(0, 7, 700)
+			},
+			metric: "streaming_cache_shard_items",
+			want:   map[string]float64{"shard=0": 7, "shard=1": 5},
+		},
+		{
+			name:    "cache shard bytes",
+			observe: func(m *PrometheusMetrics) { m.SetCacheShardStats(2, 1, 2048) },
+			metric:  "streaming_cache_shard_bytes",
+			want:    map[string]float64{"shard=2": 2048},
+		},
+		{
+			name: "mongo command errors",
+			observe: func(m *PrometheusMetrics) {
+				m.ObserveMongoCommand("videos", "find", time.Millisecond, false)
+				m.ObserveMongoCommand("videos", "find", time.Millisecond, true)
+				m.ObserveMongoCommand("users", "update", time.Millisecond, true)
+			},
+			metric: "streaming_mongo_command_errors_total",
+			want:   map[string]float64{"collection=videos,command=find": 1, "collection=users,command=update": 1},
+		},
+		{
+			name: "mongo command duration",
+			observe: func(m *PrometheusMetrics) {
+				m.ObserveMongoCommand("videos", "find", time.Millisecond, false)
+				m.ObserveMongoCommand("videos", "find", time.Millisecond, true)
+			},
+			metric: "streaming_mongo_command_duration_seconds",
+			want:   map[string]float64{"collection=videos,command=find": 2},
+		},
+		{
+			name: "uploaded bytes by uploader",
+			observe: func(m *PrometheusMetrics) {
+				m.ObserveUpload("native", 1000, time.Second)
+				m.ObserveUpload("multipart", 10, time.Second)
+			},
+			metric: "streaming_uploader_uploaded_bytes_total",
+			want:   map[string]float64{"uploader=native": 1000, "uploader=multipart": 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, registry := newTestPrometheusMetrics()
+			tt.observe(m)
+
+			got := gather(t, registry, tt.metric)
+			if len(got) != len(tt.want) {
+				t.Fatalf("%s = %v, want %v", tt.metric, got, tt.want)
+			}
+			for labels, want := range tt.want {
+				if got[labels] != want {
+					t.Errorf("%s{%s} = %v, want %v", tt.metric, labels, got[labels], want)
+				}
+			}
+		})
+	}
+}
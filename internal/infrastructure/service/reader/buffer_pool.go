@@ -0,0 +1,56 @@
+package reader
+
+import "sync"
+
+// bufferPool - the pools of chunk buffers by size classes, the classes are the min. chunk size
+// multiplied by powers of two up to the max. chunk size (the adaptive chunk sizes are the same).
+type bufferPool struct {
+	minSize int64
+	pools   []*sync.Pool
+}
+
+func newBufferPool(minSize int64, maxSize int64) *bufferPool {
+	p := &bufferPool{minSize: minSize}
+	for size := minSize; size <= maxSize; size <<= 1 {
+		classSize := size
+		p.pools = append(p.pools, &sync.Pool{
+			New: func() any {
+				b := make([]byte, classSize)
+				return &b
+			},
+		})
+	}
+	return p
+}
+
+// get - returns the buffer of the given length, the length must not exceed the max. chunk size.
+func (p *bufferPool) get(length int64) *[]byte {
+	class := p.class(length)
+	if class >= len(p.pools) {
+		b := make([]byte, length)
+		return &b
+	}
+
+	b := p.pools[class].Get().(*[]byte)
+	*b = (*b)[:length]
+	return b
+}
+
+// put - returns the buffer into the pool, it must not be used after that.
+func (p *bufferPool) put(b *[]byte) {
+	class := p.class(int64(cap(*b)))
+	if class >= len(p.pools) || p.minSize<<class != int64(cap(*b)) {
+		// the buffer is not of any class
+		return
+	}
+	p.pools[class].Put(b)
+}
+
+// class - returns the index of the smallest class which fits the length.
+func (p *bufferPool) class(length int64) int {
+	class := 0
+	for size := p.minSize; size < length; size <<= 1 {
+		class++
+	}
+	return class
+}
@@ -0,0 +1,213 @@
+package reader
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"os"
+	"sync"
+)
+
+// chunkKey - the identifier of the file chunk (chunks are aligned to the chunk size).
+type chunkKey struct {
+	resource string
+	index    int64
+}
+
+type chunkEntry struct {
+	key     chunkKey
+	buf     *[]byte
+	err     error
+	ready   chan struct{} // it's closed when the loading is finished
+	element *list.Element // nil while the entry is loading
+	refs    int           // the number of readers which hold the buffer
+	evicted bool          // the buffer is returned into the pool when the last reader releases it
+}
+
+// chunkCache - the byte-budgeted LRU cache of file chunks which is shared by all the connections,
+// so the viewers of the same resource share the disk reads. The concurrent loadings of the same chunk
+// are coalesced. The cached data is shared, so it must not be modified by the consumers.
+type chunkCache struct {
+	// ctx bounds the background reads, they are shared, so they must not depend on the context of any reader
+	ctx       context.Context
+	metrics   metrics_interface.Metrics
+	pool      *bufferPool
+	maxBytes  int64
+	prefetchN int64
+	// prefetching is a semaphore which limits the number of background reads
+	prefetching chan struct{}
+
+	mu      sync.Mutex
+	entries map[chunkKey]*chunkEntry
+	order   *list.List // the front is the most recently used chunk
+	bytes   int64
+}
+
+func newChunkCache(
+	ctx context.Context,
+	metrics metrics_interface.Metrics,
+	pool *bufferPool,
+	maxBytes int64,
+	prefetch int,
+	prefetchThreads int,
+) *chunkCache {
+	return &chunkCache{
+		ctx:         ctx,
+		metrics:     metrics,
+		pool:        pool,
+		maxBytes:    maxBytes,
+		prefetchN:   int64(prefetch),
+		prefetching: make(chan struct{}, prefetchThreads),
+		entries:     make(map[chunkKey]*chunkEntry),
+		order:       list.New(),
+	}
+}
+
+// get - returns the cached chunk or loads it by the read func (once for all concurrent callers).
+// The entry is held by the caller until it will be released. The loading which was started by another
+// reader may be abandoned (its file was closed or the cache is stopped), so the caller reads the chunk itself.
+func (c *chunkCache) get(ctx context.Context, key chunkKey, read func() (*[]byte, error)) (*chunkEntry, error) {
+	for {
+		entry, loaded, err := c.acquire(key, read)
+		if err == nil {
+			return entry, nil
+		}
+		if loaded || ctx.Err() != nil || !isAbandoned(err) {
+			return nil, err
+		}
+	}
+}
+
+// acquire - returns the cached (or loaded by another reader) chunk or loads it, the loaded flag is set
+// if the chunk was loaded by the caller.
+func (c *chunkCache) acquire(key chunkKey, read func() (*[]byte, error)) (entry *chunkEntry, loaded bool, err error) {
+	c.mu.Lock()
+	if entry, found := c.entries[key]; found {
+		if entry.element != nil {
+			c.order.MoveToFront(entry.element)
+		}
+		entry.refs++
+		c.mu.Unlock()
+		c.metrics.IncChunkCacheHits()
+
+		<-entry.ready
+		if entry.err != nil {
+			return nil, false, entry.err
+		}
+		return entry, false, nil
+	}
+	entry = c.start(key)
+	entry.refs++
+	c.mu.Unlock()
+	c.metrics.IncChunkCacheMisses()
+
+	c.load(entry, read)
+	if entry.err != nil {
+		return nil, true, entry.err
+	}
+	return entry, true, nil
+}
+
+// release - returns the buffer of entry into the pool if it's evicted and not held by other readers.
+func (c *chunkCache) release(entry *chunkEntry) {
+	defer c.mu.Unlock()
+	c.mu.Lock()
+
+	entry.refs--
+	if entry.refs == 0 && entry.evicted {
+		c.pool.put(entry.buf)
+	}
+}
+
+// prefetch - loads the next chunks in background if they are not cached yet and the reading threads are free.
+func (c *chunkCache) prefetch(key chunkKey, chunks int64, read func(index int64) (*[]byte, error)) {
+	for index := key.index + 1; index <= key.index+c.prefetchN && index < chunks; index++ {
+		next := chunkKey{resource: key.resource, index: index}
+
+		c.mu.Lock()
+		if _, found := c.entries[next]; found {
+			c.mu.Unlock()
+			continue
+		}
+		select {
+		case c.prefetching <- struct{}{}:
+		default:
+			// all threads are busy, the chunk will be read on demand
+			c.mu.Unlock()
+			return
+		}
+		entry := c.start(next)
+		c.mu.Unlock()
+
+		go func(index int64) {
+			defer func() { <-c.prefetching }()
+			c.load(entry, func() (*[]byte, error) {
+				if err := c.ctx.Err(); err != nil {
+					return nil, err
+				}
+				return read(index)
+			})
+		}(index)
+	}
+}
+
+// start - registers the loading entry (the lock must be held by the caller).
+func (c *chunkCache) start(key chunkKey) *chunkEntry {
+	entry := &chunkEntry{key: key, ready: make(chan struct{})}
+	c.entries[key] = entry
+	return entry
+}
+
+// load - reads the chunk and stores it, the failed entry is removed, so the chunk will be read again.
+func (c *chunkCache) load(entry *chunkEntry, read func() (*[]byte, error)) {
+	entry.buf, entry.err = read()
+
+	c.mu.Lock()
+	switch {
+	case entry.err != nil:
+		delete(c.entries, entry.key)
+	case int64(cap(*entry.buf)) > c.maxBytes:
+		// the chunk does not fit the budget, it's used by the current readers only
+		delete(c.entries, entry.key)
+		c.evicted(entry)
+	default:
+		entry.element = c.order.PushFront(entry)
+		c.bytes += int64(cap(*entry.buf))
+		c.evict()
+	}
+	bytes := c.bytes
+	c.mu.Unlock()
+
+	close(entry.ready)
+	c.metrics.SetChunkCacheBytes(bytes)
+}
+
+// evict - removes the least recently used chunks over the budget (the lock must be held by the caller).
+func (c *chunkCache) evict() {
+	for c.bytes > c.maxBytes {
+		element := c.order.Back()
+		if element == nil {
+			return
+		}
+		entry := element.Value.(*chunkEntry)
+		c.order.Remove(element)
+		delete(c.entries, entry.key)
+		c.bytes -= int64(cap(*entry.buf))
+		c.evicted(entry)
+	}
+}
+
+// evicted - marks the entry as evicted, its buffer is returned into the pool immediately if it's not held
+// (otherwise it's done on release), the lock must be held by the caller.
+func (c *chunkCache) evicted(entry *chunkEntry) {
+	entry.evicted = true
+	if entry.refs == 0 {
+		c.pool.put(entry.buf)
+	}
+}
+
+// isAbandoned - checks the loading was failed because of its reader or the cache was stopped, not because of the file.
+func isAbandoned(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrClosed)
+}
@@ -0,0 +1,157 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"os"
+	"testing"
+	"time"
+)
+
+const testChunkSize = 1024
+
+// testMetrics - the metrics which are used by the reader only, the rest methods are not expected to be called.
+type testMetrics struct {
+	metrics_interface.Metrics
+}
+
+func (m *testMetrics) IncChunkCacheHits()             {}
+func (m *testMetrics) IncChunkCacheMisses()           {}
+func (m *testMetrics) SetChunkCacheBytes(bytes int64) {}
+
+func newTestChunkCache(ctx context.Context, maxBytes int64) *chunkCache {
+	return newChunkCache(ctx, &testMetrics{}, newBufferPool(testChunkSize, testChunkSize), maxBytes, 2, readingThreads)
+}
+
+func readOf(pool *bufferPool, fill byte) func() (*[]byte, error) {
+	return func() (*[]byte, error) {
+		buf := pool.get(testChunkSize)
+		for i := range *buf {
+			(*buf)[i] = fill
+		}
+		return buf, nil
+	}
+}
+
+func TestChunkCache_GetIsShared(t *testing.T) {
+	cache := newTestChunkCache(context.Background(), 10*testChunkSize)
+	key := chunkKey{resource: "r", index: 0}
+
+	reads := 0
+	read := func() (*[]byte, error) {
+		reads++
+		return readOf(cache.pool, 1)()
+	}
+
+	for i := 0; i < 3; i++ {
+		entry, err := cache.get(context.Background(), key, read)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cache.release(entry)
+	}
+
+	if reads != 1 {
+		t.Fatalf("the chunk must be read once, read %d times", reads)
+	}
+}
+
+func TestChunkCache_GetRetriesAbandonedLoading(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "the file of the prefetching reader is closed", err: os.ErrClosed},
+		{name: "the prefetching is canceled", err: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newTestChunkCache(context.Background(), 10*testChunkSize)
+			key := chunkKey{resource: "r", index: 1}
+
+			// the prefetching of the next chunk is blocked until the waiting reader comes
+			started := make(chan struct{})
+			unblock := make(chan struct{})
+			cache.prefetch(chunkKey{resource: "r", index: 0}, 2, func(index int64) (*[]byte, error) {
+				close(started)
+				<-unblock
+				return nil, tt.err
+			})
+			<-started
+
+			result := make(chan error, 1)
+			go func() {
+				entry, err := cache.get(context.Background(), key, readOf(cache.pool, 2))
+				if err == nil {
+					if (*entry.buf)[0] != 2 {
+						err = errors.New("the chunk was not read by the waiting reader")
+					}
+					cache.release(entry)
+				}
+				result <- err
+			}()
+
+			// the waiting reader must be blocked on the shared loading before it will be abandoned
+			time.Sleep(10 * time.Millisecond)
+			close(unblock)
+
+			if err := <-result; err != nil {
+				t.Fatalf("the waiting reader must not fail: %v", err)
+			}
+		})
+	}
+}
+
+func TestChunkCache_GetReturnsFileError(t *testing.T) {
+	cache := newTestChunkCache(context.Background(), 10*testChunkSize)
+	fileErr := errors.New("input/output error")
+
+	_, err := cache.get(context.Background(), chunkKey{resource: "r", index: 0}, func() (*[]byte, error) {
+		return nil, fileErr
+	})
+	if !errors.Is(err, fileErr) {
+		t.Fatalf("expected the file error, got: %v", err)
+	}
+}
+
+func TestChunkCache_PrefetchIsNotBoundToReader(t *testing.T) {
+	cache := newTestChunkCache(context.Background(), 10*testChunkSize)
+
+	// the reader context is not passed into the prefetching, so it's loaded even if the reader is gone
+	cache.prefetch(chunkKey{resource: "r", index: 0}, 2, func(index int64) (*[]byte, error) {
+		return readOf(cache.pool, 3)()
+	})
+
+	entry, err := cache.get(context.Background(), chunkKey{resource: "r", index: 1}, func() (*[]byte, error) {
+		return nil, errors.New("the chunk must be prefetched")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cache.release(entry)
+
+	if (*entry.buf)[0] != 3 {
+		t.Fatalf("the prefetched chunk is expected")
+	}
+}
+
+func TestChunkCache_Eviction(t *testing.T) {
+	cache := newTestChunkCache(context.Background(), 2*testChunkSize)
+
+	for index := int64(0); index < 3; index++ {
+		entry, err := cache.get(context.Background(), chunkKey{resource: "r", index: index}, readOf(cache.pool, byte(index)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cache.release(entry)
+	}
+
+	if cache.bytes > cache.maxBytes {
+		t.Fatalf("the cache exceeds the budget: %d > %d", cache.bytes, cache.maxBytes)
+	}
+	if _, found := cache.entries[chunkKey{resource: "r", index: 0}]; found {
+		t.Fatalf("the least recently used chunk must be evicted")
+	}
+}
@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	di_interface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
-	"math"
 	"os"
 	"sync"
 )
@@ -14,12 +15,16 @@ import (
 const (
 	chunksChBuffer = 1
 	readingThreads = 5
+	// targetChunks is a number of chunks from which the chunk size of the file is increased
+	targetChunks = 256
 )
 
 type FileReaderService struct {
-	ctx       context.Context
-	logger    logger_interface.Logger
-	chunkSize int
+	logger       logger_interface.Logger
+	minChunkSize int64
+	maxChunkSize int64
+	pool         *bufferPool
+	cache        *chunkCache // nil if the chunks caching is disabled
 }
 
 func NewFileReaderService(serviceContainer di_interface.ContainerManager) (*FileReaderService, error) {
@@ -28,24 +33,43 @@ func NewFileReaderService(serviceContainer di_interface.ContainerManager) (*File
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
+	cfg, err := serviceContainer.GetConfig()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	cfg, err := serviceContainer.GetConfig()
+	metricsService, err := serviceContainer.GetMetricsService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	minChunkSize := int64(cfg.StreamingChunkSize)
+	maxChunkSize := cfg.FileReaderMaxChunkSize
+	if maxChunkSize < minChunkSize {
+		maxChunkSize = minChunkSize
+	}
+	pool := newBufferPool(minChunkSize, maxChunkSize)
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	var cache *chunkCache
+	if cfg.FileReaderCacheSize > 0 {
+		cache = newChunkCache(ctx, metricsService, pool, cfg.FileReaderCacheSize, cfg.FileReaderPrefetchChunks, readingThreads)
+	}
+
 	return &FileReaderService{
-		ctx:       ctx,
-		logger:    loggerService,
-		chunkSize: cfg.StreamingChunkSize,
+		logger:       loggerService,
+		minChunkSize: minChunkSize,
+		maxChunkSize: maxChunkSize,
+		pool:         pool,
+		cache:        cache,
 	}, nil
 }
 
-// ReadAll - reads a whole file in a single chunk.
+// ReadAll - reads a whole file in a single chunk (the parts are read concurrently into the one buffer).
 func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 	r.logger.Info(fmt.Sprintf("reading all file '%v' started", file.Name()))
 
@@ -55,8 +79,9 @@ func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 		return nil
 	}
 
-	// chunks number
-	chunks := int64(math.Ceil(float64(stat.Size()) / float64(r.chunkSize)))
+	size := stat.Size()
+	chunkSize := r.chunkSizeOf(size)
+	chunks := (size + chunkSize - 1) / chunkSize
 	// reading threads number
 	threads := int64(readingThreads)
 	// check the num of chunks more than threads
@@ -64,134 +89,189 @@ func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 		threads = chunks
 	}
 
+	chunk := model.NewChunk(size, size)
+
 	wg := &sync.WaitGroup{}
+	errOnce := &sync.Once{}
+	indexCh := make(chan int64, threads)
 
-	taskCh := make(chan *struct {
-		num    int64
-		offset int64
-		length int64
-	}, threads)
+	// consumers read their parts into the shared buffer, the parts are not overlapped
+	wg.Add(int(threads))
+	for thrd := int64(0); thrd < threads; thrd++ {
+		go func(thrd int64) {
+			defer wg.Done()
 
-	// provider
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer close(taskCh)
-		// make a task for each chunk and send to consumers
-		for chk := int64(0); chk < chunks; chk++ {
-			offset := chk * int64(r.chunkSize)
-
-			length := int64(r.chunkSize)
-			if length > (stat.Size() - offset) {
-				length = stat.Size() - offset
-			}
+			for index := range indexCh {
+				offset := index * chunkSize
+				length := chunkSize
+				if length > size-offset {
+					length = size - offset
+				}
 
-			taskCh <- &struct {
-				num    int64
-				offset int64
-				length int64
-			}{
-				num:    chk,
-				offset: offset,
-				length: length,
+				if _, err := file.ReadAt(chunk.Data[offset:offset+length], offset); err != nil {
+					r.logger.Critical(
+						fmt.Sprintf("reading all file '%v' error: %v at %d thread", file.Name(), err, thrd),
+					)
+					errOnce.Do(func() { chunk.SetError(err) })
+					return
+				}
 			}
-		}
-	}()
-
-	// map of file chunks by int64 serial number
-	fileMap := make(map[int64][]byte, chunks)
-	// make a mutex for concurrently write into the file map
-	mu := &sync.Mutex{}
+		}(thrd)
+	}
 
-	// consumer
-	wg.Add(int(threads))
-	go func() {
-		for thrd := int64(0); thrd < threads; thrd++ {
-			go func(thrd int64) {
-				defer wg.Done()
-
-				for task := range taskCh {
-					buff := make([]byte, task.length)
-					_, err := file.ReadAt(buff, task.offset)
-					if err != nil {
-						r.logger.Critical(
-							fmt.Sprintf("reading all file '%v' error: %v at %d thread", file.Name(), err, thrd),
-						)
-						return
-					}
-
-					mu.Lock()
-					fileMap[task.num] = buff
-					mu.Unlock()
-				}
-			}(thrd)
-		}
-	}()
+	// provider
+	for index := int64(0); index < chunks; index++ {
+		indexCh <- index
+	}
+	close(indexCh)
 
 	// awaiting while whole file will be read
 	wg.Wait()
 
-	defer r.logger.Info(fmt.Sprintf("reading all file '%v' finished properly", file.Name()))
-
-	// collect the entire file into the one chunk
-	chunk := model.NewChunk(0, stat.Size())
-	for i := int64(0); i < int64(len(fileMap)); i++ {
-		chunk.Data = append(chunk.Data, fileMap[i]...)
+	if chunk.GetError() == nil {
+		r.logger.Info(fmt.Sprintf("reading all file '%v' finished properly", file.Name()))
 	}
 	return chunk
 }
 
 // ReadByChunks - reads a file by separated chunks
 // and passed it into the channel (chunk size is setting up through env. configuration).
-func (r *FileReaderService) ReadByChunks(file *os.File, offset int64) chan *model.Chunk {
+// The reading is interrupted when the given context is done, so the consumer which stopped
+// reading the channel must cancel the context for release the reading goroutine.
+// The chunks are aligned to the chunk size (the first one is cut if the offset is not aligned),
+// so they are cached by the resource identifier and shared with other readers of the resource.
+// The buffers of chunks are pooled, so the consumer should release each chunk after it was sent.
+// The failed reading is passed as the last chunk with the error.
+func (r *FileReaderService) ReadByChunks(ctx context.Context, resourceID vo.ID, file *os.File, offset int64) chan *model.Chunk {
+	ch, err := r.readByChunks(ctx, resourceID, file, offset)
+	if err != nil {
+		return nil
+	}
+	return ch
+}
+
+// NewStream - returns the reader of a file from the offset which is built on top of ReadByChunks,
+// the stream must be closed if it's not read till the end.
+func (r *FileReaderService) NewStream(
+	ctx context.Context,
+	resourceID vo.ID,
+	file *os.File,
+	offset int64,
+) (reader_interface.Stream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	ch, err := r.readByChunks(ctx, resourceID, file, offset)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return newStream(ch, cancel), nil
+}
+
+func (r *FileReaderService) readByChunks(
+	ctx context.Context,
+	resourceID vo.ID,
+	file *os.File,
+	offset int64,
+) (chan *model.Chunk, error) {
 	r.logger.Info(fmt.Sprintf("reading file '%v' by chunks started", file.Name()))
 
 	stat, err := file.Stat()
 	if err != nil {
 		r.logger.Info(fmt.Sprintf("reading file '%v' by chunks file stat with errors: %v", file.Name(), err))
-		return nil
+		return nil, err
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	size := stat.Size()
+	chunkSize := r.chunkSizeOf(size)
+	chunks := (size + chunkSize - 1) / chunkSize
+
+	read := func(index int64) (*[]byte, error) {
+		length := chunkSize
+		if length > size-index*chunkSize {
+			length = size - index*chunkSize
+		}
+		buf := r.pool.get(length)
+		if _, err := file.ReadAt(*buf, index*chunkSize); err != nil {
+			r.pool.put(buf)
+			return nil, err
+		}
+		return buf, nil
 	}
 
 	ch := make(chan *model.Chunk, chunksChBuffer)
 	go func() {
 		defer close(ch)
-		for {
-			select {
-			case <-r.ctx.Done():
+		for offset < size {
+			if ctx.Err() != nil {
 				r.logger.Info(fmt.Sprintf("reading file '%v' by chunks interrupted", file.Name()))
 				return
-			default:
-				currentChunkSize := int64(r.chunkSize)
-				currentLastDataSize := stat.Size() - offset
-				if currentChunkSize > currentLastDataSize {
-					currentChunkSize = currentLastDataSize
-				}
-				if currentChunkSize == 0 {
-					r.logger.Info(fmt.Sprintf("reading file '%v' by chunks finished properly", file.Name()))
-					return
-				}
-
-				// make a new chunk with appropriate buffer
-				chunk := model.NewChunk(currentChunkSize, currentChunkSize)
-
-				// read the current batch of bites
-				length, err := file.ReadAt(chunk.Data, offset)
-				if err != nil {
-					r.logger.Error(err)
-					r.logger.Info(fmt.Sprintf("reading file '%v' by chunks finished with errors", file.Name()))
-					return
-				}
-				offset += int64(length)
+			}
 
-				// cut the last chunk to its real length
-				if chunk.GetLen() < r.chunkSize {
-					chunk.SetData(chunk.GetData()[:chunk.GetLen()])
-				}
+			index := offset / chunkSize
+			chunk, err := r.readChunk(ctx, chunkKey{resource: resourceID.Value.Hex(), index: index}, chunks, read)
+			if err != nil {
+				r.logger.Error(err)
+				r.logger.Info(fmt.Sprintf("reading file '%v' by chunks finished with errors", file.Name()))
+				chunk = &model.Chunk{Err: err}
+			} else {
+				// the chunk is cut from the beginning if the offset is not aligned
+				chunk.SetData(chunk.GetData()[offset-index*chunkSize:])
+				offset += int64(chunk.GetLen())
+			}
 
-				// sent the chunk to consumer
-				ch <- chunk
+			// sent the chunk to consumer
+			select {
+			case ch <- chunk:
+			case <-ctx.Done():
+				chunk.Release()
+				r.logger.Info(fmt.Sprintf("reading file '%v' by chunks interrupted", file.Name()))
+				return
+			}
+			if err != nil {
+				return
 			}
 		}
+		r.logger.Info(fmt.Sprintf("reading file '%v' by chunks finished properly", file.Name()))
 	}()
-	return ch
+	return ch, nil
+}
+
+// readChunk - reads the chunk through the cache (if it's enabled) and prefetches the next ones,
+// so they are read while the current one is being sent.
+func (r *FileReaderService) readChunk(
+	ctx context.Context,
+	key chunkKey,
+	chunks int64,
+	read func(index int64) (*[]byte, error),
+) (*model.Chunk, error) {
+	if r.cache == nil {
+		buf, err := read(key.index)
+		if err != nil {
+			return nil, err
+		}
+		return model.NewPooledChunk(*buf, func() { r.pool.put(buf) }), nil
+	}
+
+	entry, err := r.cache.get(ctx, key, func() (*[]byte, error) { return read(key.index) })
+	if err != nil {
+		return nil, err
+	}
+	r.cache.prefetch(key, chunks, read)
+
+	return model.NewPooledChunk(*entry.buf, func() { r.cache.release(entry) }), nil
+}
+
+// chunkSizeOf - returns the chunk size of the file: the min. size is doubled until the file is split into
+// no more than targetChunks chunks or the max. size is reached, so the big files are sent by fewer messages.
+// The size depends on the file size only, so all readers of the resource have the same aligned chunks.
+func (r *FileReaderService) chunkSizeOf(size int64) int64 {
+	chunkSize := r.minChunkSize
+	for chunkSize<<1 <= r.maxChunkSize && size > chunkSize*targetChunks {
+		chunkSize <<= 1
+	}
+	return chunkSize
 }
@@ -0,0 +1,86 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+const (
+	benchmarkStreams   = 100
+	benchmarkFileSize  = 4 << 20
+	benchmarkChunkSize = 64 << 10
+)
+
+// newBenchmarkFile - creates the file which is read by all the streams of benchmark.
+func newBenchmarkFile(b *testing.B) *os.File {
+	path := filepath.Join(b.TempDir(), "video.mp4")
+	if err := os.WriteFile(path, make([]byte, benchmarkFileSize), 0644); err != nil {
+		b.Fatalf("unable to create the file: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		b.Fatalf("unable to open the file: %v", err)
+	}
+	b.Cleanup(func() { _ = file.Close() })
+
+	return file
+}
+
+// BenchmarkFileReaderService_NewStream - reads the file by the concurrent streams with the pooled buffers
+// and with the allocated ones (the pool without size classes allocates each buffer), the chunks caching
+// is disabled, so each stream reads the file itself.
+func BenchmarkFileReaderService_NewStream(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		pool *bufferPool
+	}{
+		{name: "pooled", pool: newBufferPool(benchmarkChunkSize, benchmarkChunkSize)},
+		{name: "unpooled", pool: newBufferPool(benchmarkChunkSize, 0)},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(fmt.Sprintf("%s/%d streams", bm.name, benchmarkStreams), func(b *testing.B) {
+			file := newBenchmarkFile(b)
+			r := &FileReaderService{
+				logger:       logger_stub.NewLogger(),
+				minChunkSize: benchmarkChunkSize,
+				maxChunkSize: benchmarkChunkSize,
+				pool:         bm.pool,
+			}
+
+			b.ReportAllocs()
+			b.SetBytes(benchmarkFileSize * benchmarkStreams)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				wg := sync.WaitGroup{}
+				for s := 0; s < benchmarkStreams; s++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+
+						stream, err := r.NewStream(context.Background(), vo.ID{}, file, 0)
+						if err != nil {
+							b.Errorf("unable to open the stream: %v", err)
+							return
+						}
+						defer func() { _ = stream.Close() }()
+
+						if _, err = io.Copy(io.Discard, stream); err != nil {
+							b.Errorf("unable to read the stream: %v", err)
+						}
+					}()
+				}
+				wg.Wait()
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package reader_interface
 
 import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	"io"
 	"os"
 )
 
@@ -9,5 +12,17 @@ type FileReader interface {
 	// ReadAll - reads a whole file in a single chunk.
 	ReadAll(file *os.File) *model.Chunk
 	// ReadByChunks - reads a file by separated chunks and passed it into the channel.
-	ReadByChunks(file *os.File, offset int64) chan *model.Chunk
+	// The reading is interrupted (and the channel is closed) when the given context is done.
+	// The chunks may be shared with other readers of the resource, so their data must not be modified.
+	// Each chunk should be released after it was sent, so its buffer will be reused.
+	ReadByChunks(ctx context.Context, resourceID vo.ID, file *os.File, offset int64) chan *model.Chunk
+	// NewStream - returns the reader of a file from the offset, it must be closed if it's not read till the end.
+	NewStream(ctx context.Context, resourceID vo.ID, file *os.File, offset int64) (Stream, error)
+}
+
+// Stream - the reader of a file which releases the buffers of chunks as soon as they are consumed.
+type Stream interface {
+	io.Reader
+	io.WriterTo
+	io.Closer
 }
@@ -5,12 +5,28 @@ import "io"
 type Chunk struct {
 	Data []byte
 	Err  error
+	// release returns the buffer of data to the reader, it's set if the buffer is pooled
+	release func()
 }
 
 func NewChunk(length int64, capacity int64) *Chunk {
 	return &Chunk{Data: make([]byte, length, capacity)}
 }
 
+// NewPooledChunk - makes a chunk of the buffer which will be reused after the chunk is released.
+func NewPooledChunk(data []byte, release func()) *Chunk {
+	return &Chunk{Data: data, release: release}
+}
+
+// Release - returns the buffer of chunk to the reader, so it can be reused. The data must not be used after that.
+func (c *Chunk) Release() {
+	if c.release != nil {
+		c.release()
+		c.release = nil
+	}
+	c.Data = nil
+}
+
 func (c *Chunk) Read(p []byte) (n int, err error) {
 	if c.Data == nil || len(c.Data) == 0 {
 		return 0, io.EOF
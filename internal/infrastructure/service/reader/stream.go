@@ -0,0 +1,98 @@
+package reader
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	"io"
+)
+
+// Stream - the io.Reader and io.WriterTo over the chunks of a file. The buffers of chunks are released
+// as soon as they are consumed, so the WriteTo (used by io.Copy) does not copy the data into the extra buffer.
+type Stream struct {
+	chunks  chan *model.Chunk
+	cancel  context.CancelFunc
+	current *model.Chunk
+}
+
+func newStream(chunks chan *model.Chunk, cancel context.CancelFunc) *Stream {
+	return &Stream{chunks: chunks, cancel: cancel}
+}
+
+func (s *Stream) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if s.current == nil {
+			if s.current, err = s.next(); err != nil {
+				if n > 0 && err == io.EOF {
+					return n, nil
+				}
+				return n, err
+			}
+		}
+
+		copied := copy(p[n:], s.current.GetData())
+		s.current.SetData(s.current.GetData()[copied:])
+		n += copied
+
+		if s.current.GetLen() == 0 {
+			s.current.Release()
+			s.current = nil
+		}
+	}
+	return n, nil
+}
+
+func (s *Stream) WriteTo(w io.Writer) (written int64, err error) {
+	// the rest of partially read chunk is written first
+	if s.current != nil {
+		n, werr := w.Write(s.current.GetData())
+		written += int64(n)
+		s.current.Release()
+		s.current = nil
+		if werr != nil {
+			return written, werr
+		}
+	}
+
+	for {
+		chunk, nerr := s.next()
+		if nerr == io.EOF {
+			return written, nil
+		} else if nerr != nil {
+			return written, nerr
+		}
+
+		n, werr := w.Write(chunk.GetData())
+		written += int64(n)
+		chunk.Release()
+		if werr != nil {
+			return written, werr
+		}
+	}
+}
+
+// Close - interrupts the reading, the stream cannot be used after that.
+func (s *Stream) Close() error {
+	s.cancel()
+	if s.current != nil {
+		s.current.Release()
+		s.current = nil
+	}
+	// the reading goroutine may be blocked on sending, so the channel is drained
+	for chunk := range s.chunks {
+		chunk.Release()
+	}
+	return nil
+}
+
+func (s *Stream) next() (*model.Chunk, error) {
+	chunk, ok := <-s.chunks
+	if !ok {
+		s.cancel()
+		return nil, io.EOF
+	}
+	if chunk.GetError() != nil {
+		s.cancel()
+		return nil, chunk.GetError()
+	}
+	return chunk, nil
+}
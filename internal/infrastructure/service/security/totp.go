@@ -0,0 +1,133 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// totpSecretLength is a length of the shared secret in bytes (160 bits, recommended by RFC 4226).
+	totpSecretLength = 20
+	// totpPeriod is a time step in seconds.
+	totpPeriod = 30
+	// totpDigits is a number of digits of the one-time code.
+	totpDigits = 6
+	// totpAlgorithm is an HMAC algorithm which will be used (supported by all commonly used authenticator apps).
+	totpAlgorithm = "SHA1"
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPService is an implementation of the time-based one-time passwords (RFC 6238).
+type TOTPService struct {
+	logger logger_interface.Logger
+	issuer string
+	skew   int
+}
+
+func NewTOTPService(serviceContainer di_interface.ContainerManager) (*TOTPService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TOTPService{
+		logger: loggerService,
+		issuer: cfg.TOTPIssuer,
+		skew:   cfg.TOTPSkew,
+	}, nil
+}
+
+// GenerateSecret will generate a new random base32 encoded shared secret.
+func (s *TOTPService) GenerateSecret() (secret string, err error) {
+	b := make([]byte, totpSecretLength)
+	if _, err = rand.Read(b); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// URI will build an otpauth:// URI which may be scanned by authenticator apps (as a QR code).
+func (s *TOTPService) URI(secret string, account string) (uri string) {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", s.issuer)
+	q.Set("algorithm", totpAlgorithm)
+	q.Set("digits", strconv.Itoa(totpDigits))
+	q.Set("period", strconv.Itoa(totpPeriod))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + s.issuer + ":" + account,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+// Verify will check that the code is valid for given secret at the current moment and was not accepted yet
+// (the time step of code must be later than the lastCounter, so the intercepted code cannot be replayed).
+func (s *TOTPService) Verify(secret string, code string, lastCounter int64) (counter int64, isValid bool) {
+	return s.verify(secret, code, lastCounter, time.Now())
+}
+
+func (s *TOTPService) verify(secret string, code string, lastCounter int64, now time.Time) (counter int64, isValid bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		s.logger.Error(err)
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := -s.skew; step <= s.skew; step++ {
+		counter = current + int64(step)
+		if counter <= lastCounter {
+			continue
+		}
+		if hmac.Equal([]byte(s.code(key, counter)), []byte(code)) {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+// code will compute the one-time code for given counter (HOTP, RFC 4226).
+func (s *TOTPService) code(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, bin%mod)
+}
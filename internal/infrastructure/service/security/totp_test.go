@@ -0,0 +1,72 @@
+package security
+
+import (
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	"testing"
+	"time"
+)
+
+func TestTOTPService_Verify(t *testing.T) {
+	// the secret and the code at 59 seconds are from RFC 6238 test vectors (the last 6 digits of SHA1 code)
+	const (
+		secret  = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+		code    = "287082"
+		counter = 1
+	)
+
+	tests := []struct {
+		name        string
+		skew        int
+		now         time.Time
+		code        string
+		lastCounter int64
+		isValid     bool
+	}{
+		{name: "the code of the current step", now: time.Unix(59, 0), code: code, isValid: true},
+		{name: "the code of the previous step within the skew", skew: 1, now: time.Unix(60, 0), code: code, isValid: true},
+		{name: "the code of the previous step out of the skew", now: time.Unix(60, 0), code: code},
+		{name: "the replayed code", now: time.Unix(59, 0), code: code, lastCounter: counter},
+		{name: "the code which is earlier than accepted one", skew: 1, now: time.Unix(60, 0), code: code, lastCounter: counter + 1},
+		{name: "the code with spaces", now: time.Unix(59, 0), code: " " + code + " ", isValid: true},
+		{name: "the code of wrong length", now: time.Unix(59, 0), code: code[1:]},
+		{name: "the wrong code", now: time.Unix(59, 0), code: "000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TOTPService{logger: logger_stub.NewLogger(), skew: tt.skew}
+
+			c, isValid := s.verify(secret, tt.code, tt.lastCounter, tt.now)
+			if isValid != tt.isValid {
+				t.Fatalf("expected valid=%v, got %v", tt.isValid, isValid)
+			}
+			if isValid && c != counter {
+				t.Fatalf("expected counter %d, got %d", counter, c)
+			}
+		})
+	}
+}
+
+func TestTOTPService_VerifyGenerated(t *testing.T) {
+	s := &TOTPService{logger: logger_stub.NewLogger(), skew: 1}
+
+	secret, err := s.GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now()
+	code := s.code(key, now.Unix()/totpPeriod)
+
+	counter, isValid := s.verify(secret, code, 0, now)
+	if !isValid {
+		t.Fatalf("the generated code must be valid")
+	}
+	if _, isValid = s.verify(secret, code, counter, now); isValid {
+		t.Fatalf("the accepted code must not be valid again")
+	}
+}
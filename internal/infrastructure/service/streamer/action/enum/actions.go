@@ -3,6 +3,17 @@ package enum
 const (
 	StreamByID           Actions = "ID"
 	StreamByIDWithOffset Actions = "ID_WITH_OFFSET"
+	StreamPlaylist       Actions = "PLAYLIST"
+	StreamLive           Actions = "LIVE"
+	RoomCreate           Actions = "ROOM_CREATE"
+	RoomJoin             Actions = "ROOM_JOIN"
+	RoomLeave            Actions = "ROOM_LEAVE"
+	RoomControl          Actions = "ROOM_CONTROL"
+	RoomTransfer         Actions = "ROOM_TRANSFER"
+	RoomSync             Actions = "ROOM_SYNC"
+	Progress             Actions = "PROGRESS"
+	// Publish - is received on the live publishing path only.
+	Publish Actions = "PUBLISH"
 )
 
 type Actions string
@@ -10,3 +21,13 @@ type Actions string
 func (a Actions) String() string {
 	return string(a)
 }
+
+// IsStream - tells whether the action streams into the connection until its end (one stream at once is sent).
+func (a Actions) IsStream() bool {
+	switch a {
+	case StreamByID, StreamByIDWithOffset, StreamPlaylist, StreamLive:
+		return true
+	default:
+		return false
+	}
+}
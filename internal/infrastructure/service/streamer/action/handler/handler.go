@@ -6,12 +6,16 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	strategy_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"sync"
 )
 
 type WebSocketActionsHandler struct {
-	ctx              context.Context
 	logger           logger_interface.Logger
+	tracer           tracer_interface.Tracer
 	actionStrategies []strategy_interface.ActionStrategy
 }
 
@@ -21,7 +25,7 @@ func NewWebSocketActionsHandler(serviceContainer di_interface.ContainerManager)
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
+	tracerService, err := serviceContainer.GetTracerService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
@@ -32,26 +36,95 @@ func NewWebSocketActionsHandler(serviceContainer di_interface.ContainerManager)
 	}
 
 	return &WebSocketActionsHandler{
-		ctx:              ctx,
 		logger:           loggerService,
+		tracer:           tracerService,
 		actionStrategies: strategies,
 	}, nil
 }
 
-func (h *WebSocketActionsHandler) Handle(wg *sync.WaitGroup, actionsCh <-chan model.Action) {
+// Handle - handles the actions of connection until the listener will be stopped. The streams are sent in the background,
+// so the rest of actions are handled meanwhile, and the next stream replaces the in-flight one. The in-flight stream
+// is canceled when the listener exits (the connection is closed), so it does not wait for an idle live broadcast.
+func (h *WebSocketActionsHandler) Handle(ctx context.Context, wg *sync.WaitGroup, actionsCh <-chan model.Action) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 
-		for action := range actionsCh {
-			for _, actionStrategy := range h.actionStrategies {
-				if actionStrategy.IsAppropriate(action) {
-					if err := actionStrategy.Do(action); err != nil {
-						h.logger.Error(err)
-						break
-					}
+		connCtx, closeConn := context.WithCancelCause(ctx)
+		var current *stream
+		defer func() {
+			closeConn(model.ConnectionClosedError)
+			current.wait()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				// the connection is draining, the rest of actions will not be handled
+				return
+			case action, ok := <-actionsCh:
+				if !ok {
+					return
+				}
+				if !action.Do.IsStream() {
+					h.handle(connCtx, action)
+					continue
 				}
+				// the previous stream is finished before the next one is started, so their messages are not mixed up
+				current.stop(model.StreamReplacedError)
+				current = h.stream(connCtx, action)
 			}
 		}
 	}()
 }
+
+// stream - the stream action which is handled in the background.
+type stream struct {
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+func (h *WebSocketActionsHandler) stream(ctx context.Context, action model.Action) *stream {
+	ctx, cancel := context.WithCancelCause(ctx)
+	s := &stream{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		defer cancel(nil)
+		h.handle(ctx, action)
+	}()
+	return s
+}
+
+// wait - waits for the end of stream (if any).
+func (s *stream) wait() {
+	if s != nil {
+		<-s.done
+	}
+}
+
+// stop - cancels the stream (if any) by the given cause and waits for its end.
+func (s *stream) stop(cause error) {
+	if s != nil {
+		s.cancel(cause)
+		<-s.done
+	}
+}
+
+// handle will pass the action to the appropriate strategies, each action is traced as a separate span.
+func (h *WebSocketActionsHandler) handle(ctx context.Context, action model.Action) {
+	ctx, span := h.tracer.Start(ctx, "WS "+action.Do.String(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("ws.action", action.Do.String())),
+	)
+	defer span.End()
+
+	for _, actionStrategy := range h.actionStrategies {
+		if actionStrategy.IsAppropriate(action) {
+			if err := actionStrategy.Do(ctx, action); err != nil {
+				tracer.Fail(span, err)
+				h.logger.WithContext(ctx).Error(err)
+				break
+			}
+		}
+	}
+}
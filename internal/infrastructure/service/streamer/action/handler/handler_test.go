@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	strategy_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testTracer struct {
+	tracer trace.Tracer
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, name, opts...)
+}
+func (t *testTracer) Extract(ctx context.Context, header http.Header) context.Context { return ctx }
+func (t *testTracer) Shutdown(ctx context.Context) error                              { return nil }
+
+// testStrategy - reports the data of each handled action, the streams are lasted until their cancellation
+// (like the stream of an idle live broadcast) and their causes are recorded.
+type testStrategy struct {
+	handled chan string
+	mu      sync.Mutex
+	causes  []error
+}
+
+func (s *testStrategy) IsAppropriate(action model.Action) bool { return true }
+
+func (s *testStrategy) Do(ctx context.Context, action model.Action) error {
+	s.handled <- action.Data.(string)
+	if action.Do.IsStream() {
+		<-ctx.Done()
+		s.mu.Lock()
+		s.causes = append(s.causes, context.Cause(ctx))
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func TestWebSocketActionsHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []enum.Actions
+		causes  []error // of the canceled streams in order
+	}{
+		{
+			name:    "closed connection cancels the in-flight stream",
+			actions: []enum.Actions{enum.StreamLive},
+			causes:  []error{model.ConnectionClosedError},
+		},
+		{
+			name:    "next stream replaces the in-flight one",
+			actions: []enum.Actions{enum.StreamLive, enum.StreamByID},
+			causes:  []error{model.StreamReplacedError, model.ConnectionClosedError},
+		},
+		{
+			name:    "actions are handled while streaming",
+			actions: []enum.Actions{enum.StreamLive, enum.Progress, enum.RoomSync},
+			causes:  []error{model.ConnectionClosedError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := &testStrategy{handled: make(chan string)}
+			h := &WebSocketActionsHandler{
+				logger:           logger_stub.NewLogger(),
+				tracer:           &testTracer{tracer: noop.NewTracerProvider().Tracer("test")},
+				actionStrategies: []strategy_interface.ActionStrategy{strategy},
+			}
+
+			wg := &sync.WaitGroup{}
+			actionsCh := make(chan model.Action)
+			h.Handle(context.Background(), wg, actionsCh)
+
+			for i, do := range tt.actions {
+				data := string(do)
+				actionsCh <- model.Action{Do: do, Data: data}
+				select {
+				case handled := <-strategy.handled:
+					if handled != data {
+						t.Fatalf("handled action %d = %v, want %v", i, handled, data)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("action %v is not handled, it's queued behind the stream", data)
+				}
+			}
+			// the listener exits
+			close(actionsCh)
+			wg.Wait()
+
+			if !reflect.DeepEqual(strategy.causes, tt.causes) {
+				t.Errorf("causes of the canceled streams = %v, want %v", strategy.causes, tt.causes)
+			}
+		})
+	}
+}
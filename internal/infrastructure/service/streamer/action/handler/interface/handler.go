@@ -1,10 +1,11 @@
 package handler_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	"sync"
 )
 
 type ActionsHandler interface {
-	Handle(wg *sync.WaitGroup, actionsCh <-chan model.Action)
+	Handle(ctx context.Context, wg *sync.WaitGroup, actionsCh <-chan model.Action)
 }
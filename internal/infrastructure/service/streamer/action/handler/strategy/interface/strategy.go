@@ -1,6 +1,7 @@
 package strategy_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 )
 
@@ -8,5 +9,5 @@ type ActionStrategy interface {
 	// IsAppropriate - method will tell the service architect that the strategy is acceptable.
 	IsAppropriate(action model.Action) bool
 	// Do is a method which contains the useful work of target strategy.
-	Do(action model.Action) error
+	Do(ctx context.Context, action model.Action) error
 }
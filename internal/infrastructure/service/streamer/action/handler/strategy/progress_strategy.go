@@ -0,0 +1,112 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	domain_enum "github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	analytics_interface "github.com/Borislavv/video-streaming/internal/domain/service/analytics/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
+	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProgressActionStrategy struct {
+	logger       logger_interface.Logger
+	history      history_interface.WatchHistory
+	analytics    analytics_interface.Analytics
+	communicator proto_interface.Communicator
+	tokenizer    tokenizer_interface.Tokenizer
+}
+
+func NewProgressActionStrategy(serviceContainer di_interface.ContainerManager) (*ProgressActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	watchHistoryService, err := serviceContainer.GetWatchHistoryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	analyticsService, err := serviceContainer.GetAnalyticsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ProgressActionStrategy{
+		logger:       loggerService,
+		history:      watchHistoryService,
+		analytics:    analyticsService,
+		communicator: webSocketCommunicator,
+		tokenizer:    tokenizerService,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *ProgressActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.Progress
+}
+
+// Do - will save the playback position reported by client side, so the next stream of the video can be resumed,
+// and the completion of the play session of the video is computed from it.
+// The errors are sent to client side, but the connection is kept because they do not affect the streaming.
+func (s *ProgressActionStrategy) Do(ctx context.Context, action model.Action) error {
+	logger := s.logger.WithContext(ctx)
+
+	// check the data is eligible
+	data, ok := action.Data.(*model.ProgressData)
+	if !ok {
+		return logger.CriticalPropagate(
+			fmt.Errorf("'progress' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	// user authentication
+	userID, err := s.tokenizer.Verify(data.Token)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+	// the further records will be bound to the authed user
+	ctx = context.WithValue(ctx, domain_enum.UserIDContextKey, userID)
+	logger = s.logger.WithContext(ctx)
+
+	// parse the given video identifier
+	oid, err := primitive.ObjectIDFromHex(data.ID)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	q := dto.NewWatchProgressRequestDTO(userID, vo.NewID(oid), data.Position, data.Duration)
+	progress, err := s.history.Progress(ctx, q)
+	if err != nil {
+		if e := s.communicator.Error(err, action.Conn); e != nil {
+			return logger.LogPropagate(e)
+		}
+		return logger.LogPropagate(err)
+	}
+
+	// the position is saved already, so the failed analytics is not reported to client side
+	if err = s.analytics.Watched(ctx, progress); err != nil {
+		logger.Error(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type RoomControlActionStrategy struct {
+	*roomActionStrategy
+}
+
+func NewRoomControlActionStrategy(serviceContainer di_interface.ContainerManager) (*RoomControlActionStrategy, error) {
+	s, err := newRoomActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomControlActionStrategy{roomActionStrategy: s}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *RoomControlActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.RoomControl
+}
+
+// Do - will broadcast the playback command (play, pause or seek) of the room owner to all participants.
+func (s *RoomControlActionStrategy) Do(ctx context.Context, action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.RoomControlData)
+	if !ok {
+		return s.logger.WithContext(ctx).CriticalPropagate(
+			fmt.Errorf("'room control' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	ctx, _, err := s.authenticate(ctx, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err = s.rooms.Control(ctx, data.ID, data.Command, data.Position, action.Conn); err != nil {
+		return s.fail(ctx, err, action.Conn)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type RoomCreateActionStrategy struct {
+	*roomActionStrategy
+}
+
+func NewRoomCreateActionStrategy(serviceContainer di_interface.ContainerManager) (*RoomCreateActionStrategy, error) {
+	s, err := newRoomActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomCreateActionStrategy{roomActionStrategy: s}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *RoomCreateActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.RoomCreate
+}
+
+// Do - will open a new watch-party room of the video, the user becomes its owner.
+func (s *RoomCreateActionStrategy) Do(ctx context.Context, action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.RoomCreateData)
+	if !ok {
+		return s.logger.WithContext(ctx).CriticalPropagate(
+			fmt.Errorf("'room create' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	ctx, userID, err := s.authenticate(ctx, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err = s.rooms.Create(ctx, userID, data.VideoID, action.Conn); err != nil {
+		return s.fail(ctx, err, action.Conn)
+	}
+	return nil
+}
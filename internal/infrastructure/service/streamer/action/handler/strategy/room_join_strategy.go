@@ -0,0 +1,47 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type RoomJoinActionStrategy struct {
+	*roomActionStrategy
+}
+
+func NewRoomJoinActionStrategy(serviceContainer di_interface.ContainerManager) (*RoomJoinActionStrategy, error) {
+	s, err := newRoomActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomJoinActionStrategy{roomActionStrategy: s}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *RoomJoinActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.RoomJoin
+}
+
+// Do - will add the connection to the watch-party room by the invite token.
+func (s *RoomJoinActionStrategy) Do(ctx context.Context, action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.RoomJoinData)
+	if !ok {
+		return s.logger.WithContext(ctx).CriticalPropagate(
+			fmt.Errorf("'room join' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	ctx, userID, err := s.authenticate(ctx, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err = s.rooms.Join(ctx, data.ID, data.Invite, userID, action.Conn); err != nil {
+		return s.fail(ctx, err, action.Conn)
+	}
+	return nil
+}
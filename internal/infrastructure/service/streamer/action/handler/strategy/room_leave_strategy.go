@@ -0,0 +1,47 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type RoomLeaveActionStrategy struct {
+	*roomActionStrategy
+}
+
+func NewRoomLeaveActionStrategy(serviceContainer di_interface.ContainerManager) (*RoomLeaveActionStrategy, error) {
+	s, err := newRoomActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomLeaveActionStrategy{roomActionStrategy: s}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *RoomLeaveActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.RoomLeave
+}
+
+// Do - will remove the connection from the watch-party room.
+func (s *RoomLeaveActionStrategy) Do(ctx context.Context, action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.RoomLeaveData)
+	if !ok {
+		return s.logger.WithContext(ctx).CriticalPropagate(
+			fmt.Errorf("'room leave' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	ctx, _, err := s.authenticate(ctx, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err = s.rooms.Leave(ctx, data.ID, action.Conn); err != nil {
+		return s.fail(ctx, err, action.Conn)
+	}
+	return nil
+}
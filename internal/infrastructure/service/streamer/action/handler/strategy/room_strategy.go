@@ -0,0 +1,71 @@
+package strategy
+
+import (
+	"context"
+	domain_enum "github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	room_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room/interface"
+	"github.com/gorilla/websocket"
+)
+
+// roomActionStrategy - the common part of the watch-party room strategies. The room actions are handled
+// immediately (they do not block the connection like streaming), the events are sent by the rooms service.
+type roomActionStrategy struct {
+	logger       logger_interface.Logger
+	rooms        room_interface.Rooms
+	communicator proto_interface.Communicator
+	tokenizer    tokenizer_interface.Tokenizer
+}
+
+func newRoomActionStrategy(serviceContainer di_interface.ContainerManager) (*roomActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	roomsService, err := serviceContainer.GetRoomsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &roomActionStrategy{
+		logger:       loggerService,
+		rooms:        roomsService,
+		communicator: webSocketCommunicator,
+		tokenizer:    tokenizerService,
+	}, nil
+}
+
+// authenticate - verifies the token, the returned context is bound to the authed user.
+func (s *roomActionStrategy) authenticate(ctx context.Context, token string) (context.Context, vo.ID, error) {
+	userID, err := s.tokenizer.Verify(token)
+	if err != nil {
+		return ctx, vo.ID{}, s.logger.WithContext(ctx).LogPropagate(err)
+	}
+	// the further records will be bound to the authed user
+	return context.WithValue(ctx, domain_enum.UserIDContextKey, userID), userID, nil
+}
+
+// fail - sends the error to client side, the connection is kept because the room errors are not fatal.
+func (s *roomActionStrategy) fail(ctx context.Context, err error, conn *websocket.Conn) error {
+	logger := s.logger.WithContext(ctx)
+
+	if e := s.communicator.Error(err, conn); e != nil {
+		return logger.LogPropagate(e)
+	}
+	return logger.LogPropagate(err)
+}
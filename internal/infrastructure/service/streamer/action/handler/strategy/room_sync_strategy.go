@@ -0,0 +1,47 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type RoomSyncActionStrategy struct {
+	*roomActionStrategy
+}
+
+func NewRoomSyncActionStrategy(serviceContainer di_interface.ContainerManager) (*RoomSyncActionStrategy, error) {
+	s, err := newRoomActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomSyncActionStrategy{roomActionStrategy: s}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *RoomSyncActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.RoomSync
+}
+
+// Do - will answer the clock sync request, so the participant computes its offset from the server time.
+func (s *RoomSyncActionStrategy) Do(ctx context.Context, action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.RoomSyncData)
+	if !ok {
+		return s.logger.WithContext(ctx).CriticalPropagate(
+			fmt.Errorf("'room sync' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	ctx, _, err := s.authenticate(ctx, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err = s.rooms.Sync(ctx, data.ID, data.ClientTime, action.Conn); err != nil {
+		return s.fail(ctx, err, action.Conn)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type RoomTransferActionStrategy struct {
+	*roomActionStrategy
+}
+
+func NewRoomTransferActionStrategy(serviceContainer di_interface.ContainerManager) (*RoomTransferActionStrategy, error) {
+	s, err := newRoomActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, err
+	}
+	return &RoomTransferActionStrategy{roomActionStrategy: s}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *RoomTransferActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.RoomTransfer
+}
+
+// Do - will pass the ownership of the watch-party room to another participant.
+func (s *RoomTransferActionStrategy) Do(ctx context.Context, action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.RoomTransferData)
+	if !ok {
+		return s.logger.WithContext(ctx).CriticalPropagate(
+			fmt.Errorf("'room transfer' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	ctx, _, err := s.authenticate(ctx, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err = s.rooms.Transfer(ctx, data.ID, data.UserID, action.Conn); err != nil {
+		return s.fail(ctx, err, action.Conn)
+	}
+	return nil
+}
@@ -3,34 +3,49 @@ package strategy
 import (
 	"context"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	domain_enum "github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	analytics_interface "github.com/Borislavv/video-streaming/internal/domain/service/analytics/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
 	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
 	reader_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	room_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
 	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"os"
+	"time"
 )
 
 const zeroOffset = 0
 
 type StreamByIDActionStrategy struct {
-	ctx             context.Context
 	logger          logger_interface.Logger
 	videoRepository repository_interface.Video
+	history         history_interface.WatchHistory
+	analytics       analytics_interface.Analytics
 	reader          reader_interface.FileReader
 	codecInfo       detector_interface.Codecs
 	communicator    proto_interface.Communicator
 	tokenizer       tokenizer_interface.Tokenizer
+	rooms           room_interface.Rooms
+	metrics         metrics_interface.Metrics
+	tracer          tracer_interface.Tracer
 }
 
 func NewStreamByIDActionStrategy(serviceContainer di_interface.ContainerManager) (*StreamByIDActionStrategy, error) {
@@ -39,12 +54,17 @@ func NewStreamByIDActionStrategy(serviceContainer di_interface.ContainerManager)
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
+	videoRepository, err := serviceContainer.GetVideoRepository()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	videoRepository, err := serviceContainer.GetVideoRepository()
+	watchHistoryService, err := serviceContainer.GetWatchHistoryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	analyticsService, err := serviceContainer.GetAnalyticsService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
@@ -69,14 +89,33 @@ func NewStreamByIDActionStrategy(serviceContainer di_interface.ContainerManager)
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	roomsService, err := serviceContainer.GetRoomsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	metricsService, err := serviceContainer.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tracerService, err := serviceContainer.GetTracerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &StreamByIDActionStrategy{
-		ctx:             ctx,
 		logger:          loggerService,
 		videoRepository: videoRepository,
+		history:         watchHistoryService,
+		analytics:       analyticsService,
 		reader:          fileReader,
 		codecInfo:       codecsDetector,
 		communicator:    webSocketCommunicator,
 		tokenizer:       tokenizerService,
+		rooms:           roomsService,
+		metrics:         metricsService,
+		tracer:          tracerService,
 	}, nil
 }
 
@@ -86,11 +125,13 @@ func (s *StreamByIDActionStrategy) IsAppropriate(action model.Action) bool {
 }
 
 // Do - will be streaming a target resource by ID.
-func (s *StreamByIDActionStrategy) Do(action model.Action) error {
+func (s *StreamByIDActionStrategy) Do(ctx context.Context, action model.Action) error {
+	logger := s.logger.WithContext(ctx)
+
 	// check the data is eligible
 	data, ok := action.Data.(*model.StreamByIdData)
 	if !ok {
-		return s.logger.CriticalPropagate(
+		return logger.CriticalPropagate(
 			fmt.Errorf("'by id' strategy cannot handle the given data '%+v'", data),
 		)
 	}
@@ -98,87 +139,247 @@ func (s *StreamByIDActionStrategy) Do(action model.Action) error {
 	// user authentication
 	userID, err := s.tokenizer.Verify(data.Token)
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
+	// the further records will be bound to the authed user
+	ctx = context.WithValue(ctx, domain_enum.UserIDContextKey, userID)
+	logger = s.logger.WithContext(ctx)
 
 	// parse the given video resource identifier
 	oid, err := primitive.ObjectIDFromHex(data.ID)
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
 	// find the target resource
-	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID)
-	v, err := s.videoRepository.FindOneByID(s.ctx, q)
+	v, err := s.find(ctx, vo.NewID(oid), userID, action.Conn)
 	if err != nil {
 		if errors.IsEntityNotFoundError(err) {
 			if err = s.communicator.Error(err, action.Conn); err != nil {
-				return s.logger.LogPropagate(err)
+				return logger.LogPropagate(err)
 			}
 		}
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
-	s.logger.Info(fmt.Sprintf("[%v]: streaming 'resource':'%v'", action.Conn.RemoteAddr(), v.Resource.Name))
+	logger.Info(fmt.Sprintf("[%v]: streaming 'resource':'%v'", action.Conn.RemoteAddr(), v.Resource.Name))
 
 	// video resource streaming
-	s.stream(v.Resource, action.Conn)
+	s.stream(ctx, model.GoingAway{Action: enum.StreamByID, ID: data.ID}, v, zeroOffset, action.Conn)
 
 	return nil
 }
 
-// stream - the method which composed all useful work of really streaming.
-func (s *StreamByIDActionStrategy) stream(resource entity.Resource, conn *websocket.Conn) {
+// find - returns the video of the user or the video of other user which is watched in the room
+// joined by the connection (the participant streams it on behalf of its owner).
+func (s *StreamByIDActionStrategy) find(ctx context.Context, id vo.ID, userID vo.ID, conn *websocket.Conn) (*agg.Video, error) {
+	v, err := s.videoRepository.FindOneByID(ctx, dto.NewVideoGetRequestDTO(id, "", vo.ID{}, userID))
+	if err == nil || !errors.IsEntityNotFoundError(err) {
+		return v, err
+	}
+
+	ownerID, isGranted := s.rooms.Access(id.Value.Hex(), conn)
+	if !isGranted || ownerID == userID {
+		return nil, err
+	}
+	return s.videoRepository.FindOneByID(ctx, dto.NewVideoGetRequestDTO(id, "", vo.ID{}, ownerID))
+}
+
+// stream - the method which composed all useful work of really streaming the video file from the offset.
+// The given going away message will be completed by the offset and sent to client side if the stream
+// is interrupted by the server shutdown (its action is also used as the strategy label of the streamed bytes metric).
+// The reached offset is saved into the watch history, so the next stream of the video can be resumed,
+// and the play session is recorded into the analytics of the video.
+func (s *StreamByIDActionStrategy) stream(
+	ctx context.Context,
+	goingAway model.GoingAway,
+	video *agg.Video,
+	offset int64,
+	conn *websocket.Conn,
+) {
+	logger := s.logger.WithContext(ctx)
+	resource := video.Resource
+
 	// detect the audio and video codecs
-	audioCodec, videoCodec, err := s.codecInfo.Detect(resource)
+	audioCodec, videoCodec, err := s.codecInfo.Detect(ctx, resource)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 
 	// send the initializing message to client side
 	if err = s.communicator.Start(audioCodec, videoCodec, conn); err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 
 	// open the target resource file
 	file, err := os.Open(resource.GetFilepath())
 	if err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
+		logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 	defer func() { _ = file.Close() }()
 
+	stat, err := file.Stat()
+	if err != nil {
+		logger.Critical(fmt.Sprintf("[%v]: error receiving resource stat: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	session := s.started(ctx, &agg.PlaySession{
+		PlaySession: entity.PlaySession{
+			UserID:    video.UserID,
+			VideoID:   video.ID,
+			Action:    goingAway.Action.String(),
+			Offset:    offset,
+			Size:      stat.Size(),
+			StartedAt: time.Now(),
+		},
+	})
+
+	// the reading must be interrupted if the sending failed
+	readCtx, cancelReading := context.WithCancel(ctx)
+	defer cancelReading()
+
 	// read the whole target file
 	//chunk := s.reader.ReadAll(file)
 	//// send the received chunk which is contains whole file
 	//if err = s.communicator.Send(chunk, conn); err != nil {
-	//	s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err))
+	//	logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err))
 	//	return
 	//}
-	//s.logger.Info(
+	//logger.Info(
 	//	fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
 	//		conn.RemoteAddr(), chunk.GetLen(), resource.Name,
 	//	),
 	//)
 
-	// read the target file by chunks from zero offset
-	for chunk := range s.reader.ReadByChunks(file, zeroOffset) {
-		if err = s.communicator.Send(chunk, conn); err != nil {
-			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err))
+	// the whole sending is traced as one span, the time of writes into websocket is summed up separately
+	// from the reading of chunks for find out whether the client connection is a bottleneck
+	_, span := s.tracer.Start(ctx, "websocket.stream", trace.WithAttributes(attribute.String("resource.name", resource.Name)))
+	var (
+		chunks  int
+		bytes   int
+		writing time.Duration
+	)
+
+	// read the target file by chunks from the offset
+	for chunk := range s.reader.ReadByChunks(readCtx, resource.ID, file, offset) {
+		from := time.Now()
+		err = s.communicator.Send(chunk, conn)
+		writing += time.Since(from)
+		if err != nil {
+			chunk.Release()
+			tracer.Fail(span, err)
+			logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err))
 			break
 		}
+		s.metrics.AddStreamedBytes(goingAway.Action.String(), chunk.GetLen())
+		chunks++
+		bytes += chunk.GetLen()
 
-		s.logger.Info(
+		logger.Debug(
 			fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
 				conn.RemoteAddr(), chunk.GetLen(), resource.Name,
 			),
 		)
+		// the buffer is reused by the reader
+		chunk.Release()
+	}
+
+	span.SetAttributes(
+		attribute.Int("stream.chunks", chunks),
+		attribute.Int("stream.bytes", bytes),
+		attribute.Int64("stream.write_ms", writing.Milliseconds()),
+	)
+	span.End()
+
+	if bytes > 0 {
+		s.sent(ctx, video, offset+int64(bytes), stat.Size())
+	}
+
+	stopReason := domain_enum.PlayStopCompleted
+	switch {
+	case err != nil:
+		stopReason = domain_enum.PlayStopFailed
+	case isDrained(ctx):
+		stopReason = domain_enum.PlayStopShutdown
+	case context.Cause(ctx) == model.StreamReplacedError:
+		stopReason = domain_enum.PlayStopReplaced
+	case offset+int64(bytes) < stat.Size():
+		// the reading was failed
+		stopReason = domain_enum.PlayStopFailed
+	}
+	s.played(ctx, session, int64(bytes), stopReason)
+
+	// the connection is drained by the server shutdown, so the client side must resume the stream elsewhere
+	if isDrained(ctx) && err == nil {
+		goingAway.Offset = offset + int64(bytes)
+		goingAway.Size = stat.Size()
+		if err = s.communicator.GoingAway(goingAway, conn); err != nil {
+			logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		return
+	}
+
+	// nobody receives the stop message on the closed connection
+	if context.Cause(ctx) == model.ConnectionClosedError {
+		return
 	}
 
 	// stop the streaming by sending appropriate message to client side
 	if err = s.communicator.Stop(conn); err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 }
+
+// isDrained - tells whether the stream is interrupted by the server shutdown, not by the connection itself
+// (the connection is closed or the next stream is requested).
+func isDrained(ctx context.Context) bool {
+	cause := context.Cause(ctx)
+	return ctx.Err() != nil && cause != model.ConnectionClosedError && cause != model.StreamReplacedError
+}
+
+// sent - saves the reached offset into the watch history, the connection may be already drained here,
+// so the saving is not bound to its cancellation. The failed saving does not affect the streaming.
+func (s *StreamByIDActionStrategy) sent(ctx context.Context, video *agg.Video, offset int64, size int64) {
+	ctx = context.WithoutCancel(ctx)
+
+	userID, _ := ctx.Value(domain_enum.UserIDContextKey).(vo.ID)
+	if err := s.history.Sent(ctx, userID, video.ID, offset, size); err != nil {
+		s.logger.WithContext(ctx).Error(err)
+	}
+}
+
+// started - records the play session of the video by the authed user from the client of the connection,
+// nil is returned if the recording failed (it does not affect the streaming).
+func (s *StreamByIDActionStrategy) started(ctx context.Context, session *agg.PlaySession) *agg.PlaySession {
+	session.ViewerID, _ = ctx.Value(domain_enum.UserIDContextKey).(vo.ID)
+	session.ClientIP, _ = ctx.Value(domain_enum.ClientIPContextKey).(string)
+	session.UserAgent, _ = ctx.Value(domain_enum.UserAgentContextKey).(string)
+
+	session, err := s.analytics.Start(ctx, session)
+	if err != nil {
+		s.logger.WithContext(ctx).Error(err)
+		return nil
+	}
+	return session
+}
+
+// played - records the stop of the play session, the connection may be already drained here,
+// so the recording is not bound to its cancellation. The failed recording does not affect the streaming.
+func (s *StreamByIDActionStrategy) played(ctx context.Context, session *agg.PlaySession, bytes int64, stopReason string) {
+	// the session was not started
+	if session == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	session.Bytes = bytes
+	session.StopReason = stopReason
+	session.StoppedAt = time.Now()
+	if err := s.analytics.Stop(ctx, session); err != nil {
+		s.logger.WithContext(ctx).Error(err)
+	}
+}
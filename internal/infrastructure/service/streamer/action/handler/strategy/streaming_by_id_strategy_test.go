@@ -0,0 +1,86 @@
+package strategy
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	query_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	room_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+// testVideoRepository - a video is found only for its owner.
+type testVideoRepository struct {
+	repository_interface.Video
+	videos map[vo.ID]vo.ID // video -> owner
+}
+
+func (r *testVideoRepository) FindOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	if userID, ok := r.videos[q.GetID()]; ok && userID == q.GetUserID() {
+		return &agg.Video{Video: entity.Video{ID: q.GetID(), UserID: userID}}, nil
+	}
+	return nil, errors.NewEntityNotFoundError("video", "id")
+}
+
+// testRooms - the connection has joined the room of the video of owner.
+type testRooms struct {
+	room_interface.Rooms
+	conn    *websocket.Conn
+	videoID vo.ID
+	ownerID vo.ID
+}
+
+func (r *testRooms) Access(videoID string, conn *websocket.Conn) (ownerID vo.ID, isGranted bool) {
+	if conn == r.conn && videoID == r.videoID.Value.Hex() {
+		return r.ownerID, true
+	}
+	return vo.ID{}, false
+}
+
+func TestStreamByIDActionStrategy_Find(t *testing.T) {
+	owner, participant := vo.NewID(primitive.NewObjectID()), vo.NewID(primitive.NewObjectID())
+	roomVideo, otherVideo := vo.NewID(primitive.NewObjectID()), vo.NewID(primitive.NewObjectID())
+	joined, notJoined := &websocket.Conn{}, &websocket.Conn{}
+
+	tests := []struct {
+		name    string
+		userID  vo.ID
+		videoID vo.ID
+		conn    *websocket.Conn
+		isFound bool
+	}{
+		{name: "own video", userID: owner, videoID: otherVideo, conn: notJoined, isFound: true},
+		{name: "participant streams the video of room", userID: participant, videoID: roomVideo, conn: joined, isFound: true},
+		{name: "video of other user without room", userID: participant, videoID: roomVideo, conn: notJoined},
+		{name: "other video of the owner of room", userID: participant, videoID: otherVideo, conn: joined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &StreamByIDActionStrategy{
+				videoRepository: &testVideoRepository{videos: map[vo.ID]vo.ID{roomVideo: owner, otherVideo: owner}},
+				rooms:           &testRooms{conn: joined, videoID: roomVideo, ownerID: owner},
+			}
+
+			v, err := s.find(context.Background(), tt.videoID, tt.userID, tt.conn)
+			if !tt.isFound {
+				if !errors.IsEntityNotFoundError(err) {
+					t.Fatalf("error = %v, want 'not found'", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// the video is always found on behalf of its owner
+			if v.ID != tt.videoID || v.UserID != owner {
+				t.Errorf("found video %v of %v, want %v of %v", v.ID.Value.Hex(), v.UserID.Value.Hex(), tt.videoID.Value.Hex(), owner.Value.Hex())
+			}
+		})
+	}
+}
@@ -3,55 +3,70 @@ package strategy
 import (
 	"context"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
-	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	domain_enum "github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
-	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
 	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
-	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
-	reader_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
-	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"math"
-	"os"
 )
 
 type StreamByIDWithOffsetActionStrategy struct {
-	ctx             context.Context
-	logger          logger_interface.Logger
-	videoRepository repository_interface.Video
-	reader          reader_interface.FileReader
-	codecInfo       detector_interface.Codecs
-	communicator    proto_interface.Communicator
-	tokenizer       tokenizer_interface.Tokenizer
-	chunkSize       int
+	logger       logger_interface.Logger
+	history      history_interface.WatchHistory
+	communicator proto_interface.Communicator
+	tokenizer    tokenizer_interface.Tokenizer
+	streamByID   *StreamByIDActionStrategy
+	chunkSize    int64
 }
 
-func NewStreamByIDWithOffsetActionStrategy(
-	ctx context.Context,
-	logger logger_interface.Logger,
-	videoRepository repository_interface.Video,
-	reader reader_interface.FileReader,
-	codecInfo detector_interface.Codecs,
-	communicator proto_interface.Communicator,
-	tokenizer tokenizer_interface.Tokenizer,
-	chunkSize int,
-) *StreamByIDWithOffsetActionStrategy {
-	return &StreamByIDWithOffsetActionStrategy{
-		ctx:             ctx,
-		logger:          logger,
-		videoRepository: videoRepository,
-		reader:          reader,
-		codecInfo:       codecInfo,
-		communicator:    communicator,
-		tokenizer:       tokenizer,
-		chunkSize:       chunkSize,
+func NewStreamByIDWithOffsetActionStrategy(serviceContainer di_interface.ContainerManager) (*StreamByIDWithOffsetActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	watchHistoryService, err := serviceContainer.GetWatchHistoryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// the video is streamed the same way as by ID, but from the offset
+	streamByIDStrategy, err := NewStreamByIDActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
 	}
+
+	return &StreamByIDWithOffsetActionStrategy{
+		logger:       loggerService,
+		history:      watchHistoryService,
+		communicator: webSocketCommunicator,
+		tokenizer:    tokenizerService,
+		streamByID:   streamByIDStrategy,
+		chunkSize:    int64(cfg.StreamingChunkSize),
+	}, nil
 }
 
 // IsAppropriate - method will tell the service architect that the strategy is acceptable.
@@ -59,12 +74,15 @@ func (s *StreamByIDWithOffsetActionStrategy) IsAppropriate(action model.Action)
 	return action.Do == enum.StreamByIDWithOffset
 }
 
-// Do - will be streaming a target resource by ID from given offset.
-func (s *StreamByIDWithOffsetActionStrategy) Do(action model.Action) error {
+// Do - will be streaming a target resource by ID from given position,
+// or from the saved position of the watch history if it's not given.
+func (s *StreamByIDWithOffsetActionStrategy) Do(ctx context.Context, action model.Action) error {
+	logger := s.logger.WithContext(ctx)
+
 	// check the data is eligible
 	data, ok := action.Data.(*model.StreamByIdWithOffsetData)
 	if !ok {
-		return s.logger.CriticalPropagate(
+		return logger.CriticalPropagate(
 			fmt.Errorf("'by id with offset' strategy cannot handle the given data '%+v'", data),
 		)
 	}
@@ -72,86 +90,96 @@ func (s *StreamByIDWithOffsetActionStrategy) Do(action model.Action) error {
 	// user authentication
 	userID, err := s.tokenizer.Verify(data.Token)
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
+	// the further records will be bound to the authed user
+	ctx = context.WithValue(ctx, domain_enum.UserIDContextKey, userID)
+	logger = s.logger.WithContext(ctx)
 
 	// parse the given video resource identifier
 	oid, err := primitive.ObjectIDFromHex(data.ID)
 	if err != nil {
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
 
-	// searching the requested video resource
-	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID)
-	v, err := s.videoRepository.FindOneByID(s.ctx, q)
+	// searching the requested video resource (of the user or of the room joined by the connection)
+	v, err := s.streamByID.find(ctx, vo.NewID(oid), userID, action.Conn)
 	if err != nil {
 		if errors.IsEntityNotFoundError(err) {
 			if err = s.communicator.Error(err, action.Conn); err != nil {
-				return s.logger.LogPropagate(err)
+				return logger.LogPropagate(err)
 			}
 		}
-		return s.logger.LogPropagate(err)
+		return logger.LogPropagate(err)
 	}
-	s.logger.Info(fmt.Sprintf("[%v]: streaming 'resource':'%v'", action.Conn.RemoteAddr(), v.Resource.Name))
+
+	offset := s.offset(ctx, userID, v, data)
+	logger.Info(
+		fmt.Sprintf("[%v]: streaming 'resource':'%v' from offset %d",
+			action.Conn.RemoteAddr(), v.Resource.Name, offset,
+		),
+	)
 
 	// video resource streaming
-	s.stream(v.Resource, data, action.Conn)
+	s.streamByID.stream(ctx, model.GoingAway{Action: enum.StreamByIDWithOffset, ID: data.ID}, v, offset, action.Conn)
 
 	return nil
 }
 
-func (s *StreamByIDWithOffsetActionStrategy) stream(
-	resource entity.Resource,
+// offset - computes the offset of the video file from the requested position. If the position is not given,
+// the saved progress is used: the position reported by client side is preferred to the offset sent by the server
+// (the client has buffered more than it has played), the finished videos are streamed from the beginning.
+func (s *StreamByIDWithOffsetActionStrategy) offset(
+	ctx context.Context,
+	userID vo.ID,
+	video *agg.Video,
 	data *model.StreamByIdWithOffsetData,
-	conn *websocket.Conn,
-) {
-	audioCodec, videoCodec, err := s.codecInfo.Detect(resource)
-	if err != nil {
-		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
-		return
-	}
-
-	if err = s.communicator.Start(audioCodec, videoCodec, conn); err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
-		return
+) int64 {
+	size := video.Resource.Filesize
+	duration := data.Duration
+	if duration <= 0 {
+		duration = video.Resource.Metadata.Duration
 	}
 
-	file, err := os.Open(resource.GetFilepath())
-	if err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
-		return
+	if data.From != nil {
+		return s.offsetOf(*data.From, duration, size)
 	}
-	defer func() { _ = file.Close() }()
 
-	stat, err := file.Stat()
+	progress, err := s.history.Get(ctx, dto.NewWatchProgressGetRequestDTO(userID, video.ID))
 	if err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: error receiving resource stat: %v", conn.RemoteAddr(), err.Error()))
-		return
+		if !errors.IsEntityNotFoundError(err) {
+			s.logger.WithContext(ctx).Error(err)
+		}
+		return zeroOffset
 	}
 
-	totalChunks := stat.Size() / int64(s.chunkSize)
-
-	chunkDuration := data.Duration / float64(totalChunks)
-
-	targetChunk := math.Ceil(data.From / chunkDuration)
-
-	offset := int64((s.chunkSize * int(targetChunk)) - s.chunkSize)
-
-	for chunk := range s.reader.ReadByChunks(file, offset) {
-		if err = s.communicator.Send(chunk, conn); err != nil {
-			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
-			break
+	switch {
+	case progress.Finished:
+		return zeroOffset
+	case progress.Position > 0:
+		if data.Duration <= 0 && progress.Duration > 0 {
+			duration = progress.Duration
 		}
+		return s.offsetOf(progress.Position, duration, size)
+	case progress.Size == size && progress.Offset < size:
+		// the file was not sent completely and it was not changed since then
+		return progress.Offset
+	default:
+		return zeroOffset
+	}
+}
 
-		s.logger.Info(
-			fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
-				conn.RemoteAddr(), chunk.GetLen(), resource.Name,
-			),
-		)
+// offsetOf - converts the position of the video (in seconds) to the offset of its file (the bitrate is considered
+// constant), the offset is aligned to the start of the chunk which contains the position.
+func (s *StreamByIDWithOffsetActionStrategy) offsetOf(position float64, duration float64, size int64) int64 {
+	if position <= 0 || duration <= 0 || size <= 0 || s.chunkSize <= 0 {
+		return zeroOffset
 	}
 
-	if err = s.communicator.Stop(conn); err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
-		return
+	target := int64(float64(size) * (position / duration))
+	if target >= size {
+		target = size - 1
 	}
+
+	return target / s.chunkSize * s.chunkSize
 }
@@ -0,0 +1,71 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	history_interface "github.com/Borislavv/video-streaming/internal/domain/service/history/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	"testing"
+)
+
+// testHistory - returns the given progress of any video (or 'not found' if it's nil).
+type testHistory struct {
+	history_interface.WatchHistory
+	progress *agg.WatchProgress
+}
+
+func (h *testHistory) Get(ctx context.Context, req dto_interface.GetWatchProgressRequest) (*agg.WatchProgress, error) {
+	if h.progress == nil {
+		return nil, errors.NewEntityNotFoundError("watch progress", "videoID")
+	}
+	return h.progress, nil
+}
+
+func TestStreamByIDWithOffsetActionStrategy_Offset(t *testing.T) {
+	const (
+		chunkSize = 100
+		size      = 10000
+		duration  = 100
+	)
+	video := &agg.Video{Resource: entity.Resource{Filesize: size, Metadata: vo.MediaMetadata{Duration: duration}}}
+
+	tests := []struct {
+		name     string
+		data     string
+		progress *entity.WatchProgress
+		offset   int64
+	}{
+		{name: "from the given position", data: `{"from":50}`, offset: 5000},
+		{name: "the given position is aligned to the chunk", data: `{"from":50.55}`, offset: 5000},
+		{name: "from the beginning", data: `{"from":0}`, progress: &entity.WatchProgress{Position: 50}, offset: 0},
+		{name: "from the saved position", data: `{}`, progress: &entity.WatchProgress{Position: 25}, offset: 2500},
+		{name: "from the saved offset", data: `{}`, progress: &entity.WatchProgress{Offset: 700, Size: size}, offset: 700},
+		{name: "the saved offset of the changed file", data: `{}`, progress: &entity.WatchProgress{Offset: 700, Size: 1}, offset: 0},
+		{name: "the finished video", data: `{}`, progress: &entity.WatchProgress{Position: 99, Finished: true}, offset: 0},
+		{name: "nothing is saved", data: `{}`, offset: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &testHistory{}
+			if tt.progress != nil {
+				history.progress = &agg.WatchProgress{WatchProgress: *tt.progress}
+			}
+			s := &StreamByIDWithOffsetActionStrategy{history: history, chunkSize: chunkSize}
+
+			data := &model.StreamByIdWithOffsetData{}
+			if err := json.Unmarshal([]byte(tt.data), data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if offset := s.offset(context.Background(), vo.ID{}, video, data); offset != tt.offset {
+				t.Fatalf("expected offset %d, got %d", tt.offset, offset)
+			}
+		})
+	}
+}
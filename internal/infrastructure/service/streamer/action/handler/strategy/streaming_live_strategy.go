@@ -0,0 +1,125 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	domain_enum "github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+)
+
+type StreamLiveActionStrategy struct {
+	logger       logger_interface.Logger
+	hub          live_interface.Hub
+	communicator proto_interface.Communicator
+	tokenizer    tokenizer_interface.Tokenizer
+	metrics      metrics_interface.Metrics
+}
+
+func NewStreamLiveActionStrategy(serviceContainer di_interface.ContainerManager) (*StreamLiveActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	liveHub, err := serviceContainer.GetLiveHub()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	metricsService, err := serviceContainer.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &StreamLiveActionStrategy{
+		logger:       loggerService,
+		hub:          liveHub,
+		communicator: webSocketCommunicator,
+		tokenizer:    tokenizerService,
+		metrics:      metricsService,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *StreamLiveActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.StreamLive
+}
+
+// Do - will be streaming a live broadcast by ID until it will be ended.
+func (s *StreamLiveActionStrategy) Do(ctx context.Context, action model.Action) error {
+	logger := s.logger.WithContext(ctx)
+
+	// check the data is eligible
+	data, ok := action.Data.(*model.StreamLiveData)
+	if !ok {
+		return logger.CriticalPropagate(
+			fmt.Errorf("'live' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	// user authentication
+	userID, err := s.tokenizer.Verify(data.Token)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+	// the further records will be bound to the authed user
+	ctx = context.WithValue(ctx, domain_enum.UserIDContextKey, userID)
+	logger = s.logger.WithContext(ctx)
+
+	viewer, err := s.hub.Subscribe(data.ID)
+	if err != nil {
+		if errors.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(err, action.Conn); err != nil {
+				return logger.LogPropagate(err)
+			}
+		}
+		return logger.LogPropagate(err)
+	}
+	defer viewer.Close()
+	logger.Info(fmt.Sprintf("[%v]: streaming 'broadcast':'%v'", action.Conn.RemoteAddr(), data.ID))
+
+	// send the initializing message to client side
+	if err = s.communicator.Start(viewer.GetAudioCodec(), viewer.GetVideoCodec(), action.Conn); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// the connection is drained or closed, or the next stream is requested (the live broadcast
+			// cannot be resumed from the offset anyway)
+			return nil
+		case segment, ok := <-viewer.Segments():
+			if !ok {
+				// the broadcast is ended (or the viewer was dropped)
+				if err = s.communicator.Stop(action.Conn); err != nil {
+					return logger.LogPropagate(err)
+				}
+				return nil
+			}
+			if err = s.communicator.Send(&dto.ChunkDTO{Data: segment}, action.Conn); err != nil {
+				return logger.LogPropagate(err)
+			}
+			s.metrics.AddStreamedBytes(enum.StreamLive.String(), len(segment))
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	domain_enum "github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StreamPlaylistActionStrategy - streams a single video of the playlist by position. The client side
+// receives the position message before the stream and requests the adjacent positions by itself,
+// so the playback goes sequentially in the order which was defined on the server side.
+type StreamPlaylistActionStrategy struct {
+	logger             logger_interface.Logger
+	videoRepository    repository_interface.Video
+	playlistRepository repository_interface.Playlist
+	communicator       proto_interface.Communicator
+	tokenizer          tokenizer_interface.Tokenizer
+	streamByID         *StreamByIDActionStrategy
+}
+
+func NewStreamPlaylistActionStrategy(serviceContainer di_interface.ContainerManager) (*StreamPlaylistActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// the video streaming itself is delegated to the 'by id' strategy
+	streamByIDStrategy, err := NewStreamByIDActionStrategy(serviceContainer)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &StreamPlaylistActionStrategy{
+		logger:             loggerService,
+		videoRepository:    videoRepository,
+		playlistRepository: playlistRepository,
+		communicator:       webSocketCommunicator,
+		tokenizer:          tokenizerService,
+		streamByID:         streamByIDStrategy,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *StreamPlaylistActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.StreamPlaylist
+}
+
+// Do - will be streaming a video of the target playlist by position.
+func (s *StreamPlaylistActionStrategy) Do(ctx context.Context, action model.Action) error {
+	logger := s.logger.WithContext(ctx)
+
+	// check the data is eligible
+	data, ok := action.Data.(*model.StreamPlaylistData)
+	if !ok {
+		return logger.CriticalPropagate(
+			fmt.Errorf("'playlist' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	// user authentication
+	userID, err := s.tokenizer.Verify(data.Token)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+	// the further records will be bound to the authed user
+	ctx = context.WithValue(ctx, domain_enum.UserIDContextKey, userID)
+	logger = s.logger.WithContext(ctx)
+
+	// parse the given playlist identifier
+	oid, err := primitive.ObjectIDFromHex(data.ID)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// find the target playlist
+	playlist, err := s.playlistRepository.FindOneByID(ctx, dto.NewPlaylistGetRequestDTO(vo.NewID(oid), userID))
+	if err != nil {
+		if errors.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(err, action.Conn); err != nil {
+				return logger.LogPropagate(err)
+			}
+		}
+		return logger.LogPropagate(err)
+	}
+
+	// check the requested position
+	total := len(playlist.VideoIDs)
+	if data.Position < 0 || data.Position >= total {
+		err = errors.NewPositionIsOutOfRangeError(data.Position, total)
+		if e := s.communicator.Error(err, action.Conn); e != nil {
+			return logger.LogPropagate(e)
+		}
+		return logger.LogPropagate(err)
+	}
+
+	// find the target video
+	videoID := playlist.VideoIDs[data.Position]
+	v, err := s.videoRepository.FindOneByID(ctx, dto.NewVideoGetRequestDTO(videoID, "", vo.ID{}, userID))
+	if err != nil {
+		if errors.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(err, action.Conn); err != nil {
+				return logger.LogPropagate(err)
+			}
+		}
+		return logger.LogPropagate(err)
+	}
+
+	// send the position into the playlist to client side
+	position := model.PlaylistPosition{
+		ID:       playlist.ID.Value.Hex(),
+		VideoID:  videoID.Value.Hex(),
+		Position: data.Position,
+		Total:    total,
+	}
+	if err = s.communicator.Playlist(position, action.Conn); err != nil {
+		return logger.LogPropagate(err)
+	}
+	logger.Info(
+		fmt.Sprintf("[%v]: streaming 'playlist':'%v' at position %d of %d 'resource':'%v'",
+			action.Conn.RemoteAddr(), playlist.Name, data.Position, total, v.Resource.Name,
+		),
+	)
+
+	// video resource streaming
+	s.streamByID.stream(
+		ctx,
+		model.GoingAway{Action: enum.StreamPlaylist, ID: data.ID, Position: data.Position},
+		v,
+		zeroOffset,
+		action.Conn,
+	)
+
+	return nil
+}
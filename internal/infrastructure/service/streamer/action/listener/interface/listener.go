@@ -1,11 +1,12 @@
 package listener_interface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	"github.com/gorilla/websocket"
 	"sync"
 )
 
 type ActionsListener interface {
-	Listen(wg *sync.WaitGroup, conn *websocket.Conn) <-chan model.Action
+	Listen(ctx context.Context, wg *sync.WaitGroup, conn *websocket.Conn) <-chan model.Action
 }
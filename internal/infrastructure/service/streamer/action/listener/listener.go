@@ -1,6 +1,7 @@
 package listener
 
 import (
+	"context"
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
@@ -13,7 +14,17 @@ import (
 
 var (
 	supportedActionsMap = map[enum.Actions]struct{}{
-		enum.StreamByID: {},
+		enum.StreamByID:           {},
+		enum.StreamByIDWithOffset: {},
+		enum.StreamPlaylist:       {},
+		enum.StreamLive:           {},
+		enum.RoomCreate:           {},
+		enum.RoomJoin:             {},
+		enum.RoomLeave:            {},
+		enum.RoomControl:          {},
+		enum.RoomTransfer:         {},
+		enum.RoomSync:             {},
+		enum.Progress:             {},
 	}
 )
 
@@ -39,7 +50,9 @@ func NewWebSocketActionsListener(serviceContainer di_interface.ContainerManager)
 	}, nil
 }
 
-func (l *WebSocketActionsListener) Listen(wg *sync.WaitGroup, conn *websocket.Conn) <-chan model.Action {
+func (l *WebSocketActionsListener) Listen(ctx context.Context, wg *sync.WaitGroup, conn *websocket.Conn) <-chan model.Action {
+	logger := l.logger.WithContext(ctx)
+
 	actionsCh := make(chan model.Action, 1)
 
 	wg.Add(1)
@@ -53,23 +66,27 @@ func (l *WebSocketActionsListener) Listen(wg *sync.WaitGroup, conn *websocket.Co
 			t, b, err := conn.ReadMessage()
 			if err != nil {
 				if websocket.IsCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
-					l.logger.Info(fmt.Sprintf("[%v]: websocket connection has been closed", conn.RemoteAddr()))
+					logger.Info(fmt.Sprintf("[%v]: websocket connection has been closed", conn.RemoteAddr()))
 					return
 				}
-				l.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+				logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 				return
 			}
 			if t == websocket.TextMessage {
 				do, data, perr := l.communicator.Parse(b)
 				if perr != nil {
-					l.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), perr.Error()))
+					logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), perr.Error()))
 					return
 				}
 				if _, isSupported := supportedActionsMap[do]; isSupported {
-					actionsCh <- model.Action{Do: do, Data: data, Conn: conn}
-					l.logger.Info(fmt.Sprintf("action '%v' with data '%v' received", do, data))
+					select {
+					case <-ctx.Done():
+						return
+					case actionsCh <- model.Action{Do: do, Data: data, Conn: conn}:
+					}
+					logger.Info(fmt.Sprintf("action '%v' with data '%v' received", do, data))
 				} else {
-					l.logger.Critical(fmt.Sprintf("do: %+v, data: %+v received unsupport action", do, data))
+					logger.Critical(fmt.Sprintf("do: %+v, data: %+v received unsupport action", do, data))
 				}
 			}
 		}
@@ -1,12 +1,29 @@
 package model
 
 import (
+	"errors"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/gorilla/websocket"
 )
 
+// The causes of cancellation of the action context, the rest of causes mean that the connection is drained.
+var (
+	ConnectionClosedError = errors.New("the connection is closed")
+	StreamReplacedError   = errors.New("the stream is replaced by the next one")
+)
+
 type Action struct {
 	Do   enum.Actions
 	Data interface{}
 	Conn *websocket.Conn
 }
+
+// GoingAway - is sent to client side when the stream was interrupted by the server shutdown,
+// so the client can resume the stream on another instance from the given offset.
+type GoingAway struct {
+	Action   enum.Actions `json:"action"`
+	ID       string       `json:"id"`       // the requested video or playlist identifier
+	Position int          `json:"position"` // zero based index of the video into the playlist (playlist action only)
+	Offset   int64        `json:"offset"`   // number of bytes of the resource which were already sent
+	Size     int64        `json:"size"`     // total number of bytes of the resource
+}
@@ -6,8 +6,102 @@ type StreamByIdData struct {
 }
 
 type StreamByIdWithOffsetData struct {
+	ID       string   `json:"id"`
+	Token    string   `json:"token"`
+	From     *float64 `json:"from"` // nil if it's not given (the saved position is used), zero means the beginning
+	Duration float64  `json:"duration"`
+}
+
+type StreamPlaylistData struct {
+	ID       string `json:"id"`
+	Token    string `json:"token"`
+	Position int    `json:"position"` // zero based index of the video into the playlist
+}
+
+type StreamLiveData struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+type RoomCreateData struct {
+	Token   string `json:"token"`
+	VideoID string `json:"videoID"`
+}
+
+type RoomJoinData struct {
+	ID     string `json:"id"`
+	Token  string `json:"token"`
+	Invite string `json:"invite"`
+}
+
+type RoomLeaveData struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+type RoomControlData struct {
 	ID       string  `json:"id"`
 	Token    string  `json:"token"`
-	From     float64 `json:"from"`
-	Duration float64 `json:"duration"`
+	Command  string  `json:"command"`  // play, pause or seek
+	Position float64 `json:"position"` // position of the video in seconds
+}
+
+type RoomTransferData struct {
+	ID     string `json:"id"`
+	Token  string `json:"token"`
+	UserID string `json:"userID"` // the participant which will be the owner
+}
+
+type RoomSyncData struct {
+	ID         string `json:"id"`
+	Token      string `json:"token"`
+	ClientTime int64  `json:"clientTime"` // unix time of the client side in milliseconds
+}
+
+type ProgressData struct {
+	ID       string  `json:"id"`
+	Token    string  `json:"token"`
+	Position float64 `json:"position"` // position of the video in seconds
+	Duration float64 `json:"duration"` // duration of the video in seconds (optional)
+}
+
+type PublishData struct {
+	Token      string `json:"token"`
+	AudioCodec string `json:"audioCodec"`
+	VideoCodec string `json:"videoCodec"`
+	Container  string `json:"container"` // webm or mp4 (the extension of recorded file)
+	Record     bool   `json:"record"`
+}
+
+// PlaylistPosition - is sent to client side before the video streaming,
+// so the client knows which videos are previous and next into the playlist.
+type PlaylistPosition struct {
+	ID       string `json:"id"`
+	VideoID  string `json:"videoID"`
+	Position int    `json:"position"`
+	Total    int    `json:"total"`
+}
+
+// LiveBroadcast - is sent to the publisher when the broadcast is started, so it can share the identifier with viewers,
+// and when the recording of the broadcast is stopped before its end (the broadcast itself is continued).
+type LiveBroadcast struct {
+	ID        string `json:"id"`
+	Recording bool   `json:"recording"`
+	Reason    string `json:"reason,omitempty"` // why the recording is stopped
+}
+
+// RoomEvent - is sent to the participants of watch-party room. The position is actual at the server time,
+// so the client side computes the current one by the clock offset which is measured by the sync messages.
+type RoomEvent struct {
+	Event        string  `json:"event"` // created, joined, left, play, pause, seek, owner, sync or closed
+	RoomID       string  `json:"roomID"`
+	UserID       string  `json:"userID,omitempty"` // the participant which caused the event
+	OwnerID      string  `json:"ownerID"`
+	VideoID      string  `json:"videoID"`
+	Invite       string  `json:"invite,omitempty"` // sent to the owner only
+	Playing      bool    `json:"playing"`
+	Position     float64 `json:"position"`
+	Participants int     `json:"participants"`
+	ServerTime   int64   `json:"serverTime"`           // unix time in milliseconds
+	ClientTime   int64   `json:"clientTime,omitempty"` // the time of the sync request (sync response only)
 }
@@ -1,7 +1,10 @@
 package streamer_interface
 
-import "github.com/gorilla/websocket"
+import (
+	"context"
+	"github.com/gorilla/websocket"
+)
 
 type Streamer interface {
-	HandleConn(conn *websocket.Conn)
+	HandleConn(ctx context.Context, conn *websocket.Conn)
 }
@@ -0,0 +1,170 @@
+package live
+
+import (
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	"sync"
+)
+
+// Broadcast - fans out the published segments to the viewers. The segments from the last keyframe (GOP)
+// are kept while they fit the budget, so the late joiners start on a keyframe instead of waiting for the next one.
+type Broadcast struct {
+	hub        *Hub
+	id         string
+	audioCodec string
+	videoCodec string
+
+	mu       sync.Mutex
+	init     []byte
+	gop      [][]byte
+	gopBytes int64
+	viewers  map[*Viewer]struct{}
+	closed   bool
+}
+
+func (b *Broadcast) GetID() string {
+	return b.id
+}
+
+// Init - sets the initialization segment of the container. It's expected before the media segments,
+// if it's changed while broadcasting, the viewers which cannot receive it immediately are dropped.
+func (b *Broadcast) Init(segment []byte) {
+	defer b.mu.Unlock()
+	b.mu.Lock()
+
+	if b.closed {
+		return
+	}
+
+	b.init = segment
+	// the segments of previous initialization cannot be decoded after the new one
+	b.gop = nil
+	b.gopBytes = 0
+
+	for v := range b.viewers {
+		select {
+		case v.segments <- segment:
+			v.skipping = true
+		default:
+			b.unsubscribe(v)
+		}
+	}
+}
+
+// Write - fans out the media segment to the viewers. The viewer which queue is full skips the segments
+// until the next keyframe, so the slow connection does not block the publisher and other viewers.
+func (b *Broadcast) Write(segment []byte, keyframe bool) {
+	defer b.mu.Unlock()
+	b.mu.Lock()
+
+	if b.closed {
+		return
+	}
+
+	switch {
+	case keyframe:
+		b.gop = append(b.gop[:0], segment)
+		b.gopBytes = int64(len(segment))
+	case len(b.gop) > 0 && b.gopBytes+int64(len(segment)) <= b.hub.gopMaxBytes:
+		b.gop = append(b.gop, segment)
+		b.gopBytes += int64(len(segment))
+	default:
+		// the group of pictures is too big, the late joiners will wait for the next keyframe
+		b.gop = b.gop[:0]
+		b.gopBytes = 0
+	}
+
+	for v := range b.viewers {
+		if v.skipping && !keyframe {
+			continue
+		}
+		select {
+		case v.segments <- segment:
+			v.skipping = false
+		default:
+			v.skipping = true
+		}
+	}
+}
+
+// Close - ends the broadcast, the segments channels of viewers are closed.
+func (b *Broadcast) Close() {
+	b.hub.remove(b.id)
+
+	defer b.mu.Unlock()
+	b.mu.Lock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+
+	for v := range b.viewers {
+		b.unsubscribe(v)
+	}
+	b.init = nil
+	b.gop = nil
+}
+
+func (b *Broadcast) subscribe() (live_interface.Viewer, error) {
+	defer b.mu.Unlock()
+	b.mu.Lock()
+
+	if b.closed {
+		return nil, BroadcastNotFoundByIdError
+	}
+
+	// the queue fits the buffered segments, so they are sent without blocking
+	v := &Viewer{
+		broadcast: b,
+		segments:  make(chan []byte, b.hub.viewerBuffer+len(b.gop)+1),
+		skipping:  len(b.gop) == 0,
+	}
+	if b.init != nil {
+		v.segments <- b.init
+	}
+	for _, segment := range b.gop {
+		v.segments <- segment
+	}
+	b.viewers[v] = struct{}{}
+
+	return v, nil
+}
+
+// unsubscribe - removes the viewer and closes its channel (the lock must be held by the caller).
+func (b *Broadcast) unsubscribe(v *Viewer) {
+	if _, found := b.viewers[v]; !found {
+		return
+	}
+	delete(b.viewers, v)
+	close(v.segments)
+}
+
+// Viewer - the subscription of the connection to the broadcast.
+type Viewer struct {
+	broadcast *Broadcast
+	segments  chan []byte
+	// skipping means that the viewer has fallen behind (or joined without buffered GOP)
+	// and waits for the next keyframe (it's guarded by the lock of broadcast)
+	skipping bool
+}
+
+func (v *Viewer) GetAudioCodec() string {
+	return v.broadcast.audioCodec
+}
+
+func (v *Viewer) GetVideoCodec() string {
+	return v.broadcast.videoCodec
+}
+
+// Segments - returns the channel of segments which is closed when the broadcast is ended
+// or the viewer was dropped.
+func (v *Viewer) Segments() <-chan []byte {
+	return v.segments
+}
+
+// Close - unsubscribes the viewer.
+func (v *Viewer) Close() {
+	defer v.broadcast.mu.Unlock()
+	v.broadcast.mu.Lock()
+	v.broadcast.unsubscribe(v)
+}
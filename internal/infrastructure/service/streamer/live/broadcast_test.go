@@ -0,0 +1,203 @@
+package live
+
+import (
+	"errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"testing"
+)
+
+func newTestHub(gopMaxBytes int64, viewerBuffer int) *Hub {
+	return &Hub{
+		logger:       logger_stub.NewLogger(),
+		gopMaxBytes:  gopMaxBytes,
+		viewerBuffer: viewerBuffer,
+		broadcasts:   make(map[string]*Broadcast),
+	}
+}
+
+// testSegment - the segment of broadcast: "init" is the initialization segment, the keyframes start with "k".
+type testSegment string
+
+func (s testSegment) publish(b live_interface.Broadcast) {
+	switch {
+	case s == "init":
+		b.Init([]byte(s))
+	default:
+		b.Write([]byte(s), s[0] == 'k')
+	}
+}
+
+// received - returns the queued segments of viewer without blocking.
+func received(v live_interface.Viewer) (segments []string, isClosed bool) {
+	for {
+		select {
+		case segment, ok := <-v.Segments():
+			if !ok {
+				return segments, true
+			}
+			segments = append(segments, string(segment))
+		default:
+			return segments, false
+		}
+	}
+}
+
+func TestBroadcast_LateJoiner(t *testing.T) {
+	tests := []struct {
+		name        string
+		gopMaxBytes int64
+		before      []testSegment // published before the viewer joined
+		after       []testSegment // published after the viewer joined
+		want        []string
+	}{
+		{
+			name:        "starts on the last keyframe",
+			gopMaxBytes: 100,
+			before:      []testSegment{"init", "k1", "d1", "k2", "d2"},
+			after:       []testSegment{"d3"},
+			want:        []string{"init", "k2", "d2", "d3"},
+		},
+		{
+			name:        "waits for the first keyframe",
+			gopMaxBytes: 100,
+			before:      []testSegment{"init", "d0"},
+			after:       []testSegment{"d1", "k1", "d2"},
+			want:        []string{"init", "k1", "d2"},
+		},
+		{
+			name:        "waits for the next keyframe when gop is too big",
+			gopMaxBytes: 4,
+			before:      []testSegment{"init", "k1", "d1", "d2"},
+			after:       []testSegment{"d3", "k2"},
+			want:        []string{"init", "k2"},
+		},
+		{
+			name:        "new init drops the buffered gop",
+			gopMaxBytes: 100,
+			before:      []testSegment{"init", "k1", "d1", "init"},
+			after:       []testSegment{"d2", "k2"},
+			want:        []string{"init", "k2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := newTestHub(tt.gopMaxBytes, 10)
+			broadcast := hub.Open(vo.NewID(primitive.NewObjectID()), "opus", "vp9")
+			for _, segment := range tt.before {
+				segment.publish(broadcast)
+			}
+
+			viewer, err := hub.Subscribe(broadcast.GetID())
+			if err != nil {
+				t.Fatalf("unable to subscribe: %v", err)
+			}
+			for _, segment := range tt.after {
+				segment.publish(broadcast)
+			}
+
+			if segments, _ := received(viewer); !reflect.DeepEqual(segments, tt.want) {
+				t.Errorf("segments = %v, want %v", segments, tt.want)
+			}
+		})
+	}
+}
+
+func TestBroadcast_SlowViewer(t *testing.T) {
+	hub := newTestHub(100, 2)
+	broadcast := hub.Open(vo.NewID(primitive.NewObjectID()), "opus", "vp9")
+	testSegment("init").publish(broadcast)
+	testSegment("k1").publish(broadcast)
+
+	slow, err := hub.Subscribe(broadcast.GetID())
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	fast, err := hub.Subscribe(broadcast.GetID())
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+
+	// the queue of the slow viewer fits init, k1 and two more segments
+	var fastSegments []string
+	for _, segment := range []testSegment{"d1", "d2", "d3", "d4", "k2", "d5"} {
+		segment.publish(broadcast)
+		// the fast viewer reads each segment immediately
+		got, _ := received(fast)
+		fastSegments = append(fastSegments, got...)
+		if segment == "d4" {
+			// the slow viewer reads the queue when it's already full
+			got, _ = received(slow)
+			if want := []string{"init", "k1", "d1", "d2"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("slow viewer segments = %v, want %v", got, want)
+			}
+		}
+	}
+
+	// the slow viewer skips the rest of group and continues from the next keyframe
+	if got, _ := received(slow); !reflect.DeepEqual(got, []string{"k2", "d5"}) {
+		t.Errorf("slow viewer segments after skipping = %v, want [k2 d5]", got)
+	}
+	// the slow viewer does not block the fast one
+	if want := []string{"init", "k1", "d1", "d2", "d3", "d4", "k2", "d5"}; !reflect.DeepEqual(fastSegments, want) {
+		t.Errorf("fast viewer segments = %v, want %v", fastSegments, want)
+	}
+}
+
+func TestBroadcast_Close(t *testing.T) {
+	tests := []struct {
+		name    string
+		close   func(broadcast live_interface.Broadcast, viewer live_interface.Viewer)
+		isEnded bool // the broadcast is not available anymore
+	}{
+		{
+			name:    "broadcast is ended",
+			close:   func(broadcast live_interface.Broadcast, viewer live_interface.Viewer) { broadcast.Close() },
+			isEnded: true,
+		},
+		{
+			name:  "viewer is unsubscribed",
+			close: func(broadcast live_interface.Broadcast, viewer live_interface.Viewer) { viewer.Close() },
+		},
+		{
+			name: "closed twice",
+			close: func(broadcast live_interface.Broadcast, viewer live_interface.Viewer) {
+				viewer.Close()
+				broadcast.Close()
+				broadcast.Close()
+			},
+			isEnded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := newTestHub(100, 10)
+			broadcast := hub.Open(vo.NewID(primitive.NewObjectID()), "opus", "vp9")
+			viewer, err := hub.Subscribe(broadcast.GetID())
+			if err != nil {
+				t.Fatalf("unable to subscribe: %v", err)
+			}
+
+			tt.close(broadcast, viewer)
+			// the segments after closing are not delivered
+			testSegment("k1").publish(broadcast)
+
+			if segments, isClosed := received(viewer); !isClosed || len(segments) != 0 {
+				t.Errorf("viewer segments = %v, is closed = %v, want no segments and closed channel", segments, isClosed)
+			}
+
+			_, err = hub.Subscribe(broadcast.GetID())
+			if tt.isEnded && !errors.Is(err, BroadcastNotFoundByIdError) {
+				t.Errorf("expected BroadcastNotFoundByIdError, got %v", err)
+			}
+			if !tt.isEnded && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
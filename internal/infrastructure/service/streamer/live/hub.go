@@ -0,0 +1,82 @@
+package live
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+)
+
+var BroadcastNotFoundByIdError = errors.NewEntityNotFoundError("broadcast", "id")
+
+// Hub - the registry of the live broadcasts of the instance, the viewers must be connected to the same instance
+// on which the broadcast is published.
+type Hub struct {
+	logger       logger_interface.Logger
+	gopMaxBytes  int64
+	viewerBuffer int
+
+	mu         sync.RWMutex
+	broadcasts map[string]*Broadcast
+}
+
+func NewHub(serviceContainer di_interface.ContainerManager) (*Hub, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &Hub{
+		logger:       loggerService,
+		gopMaxBytes:  cfg.LiveGOPMaxBytes,
+		viewerBuffer: cfg.LiveViewerBuffer,
+		broadcasts:   make(map[string]*Broadcast),
+	}, nil
+}
+
+// Open - registers a new broadcast of the user, it's available for viewers until it will be closed.
+func (h *Hub) Open(userID vo.ID, audioCodec string, videoCodec string) live_interface.Broadcast {
+	b := &Broadcast{
+		hub:        h,
+		id:         primitive.NewObjectID().Hex(),
+		audioCodec: audioCodec,
+		videoCodec: videoCodec,
+		viewers:    make(map[*Viewer]struct{}),
+	}
+
+	h.mu.Lock()
+	h.broadcasts[b.id] = b
+	h.mu.Unlock()
+
+	h.logger.Info(fmt.Sprintf("live broadcast '%v' of user '%v' is opened", b.id, userID.Value.Hex()))
+
+	return b
+}
+
+// Subscribe - returns a new viewer of the broadcast, the viewer receives the initialization segment
+// and the segments from the last keyframe first, so it starts on a keyframe.
+func (h *Hub) Subscribe(id string) (live_interface.Viewer, error) {
+	h.mu.RLock()
+	b, found := h.broadcasts[id]
+	h.mu.RUnlock()
+	if !found {
+		return nil, BroadcastNotFoundByIdError
+	}
+
+	return b.subscribe()
+}
+
+func (h *Hub) remove(id string) {
+	defer h.mu.Unlock()
+	h.mu.Lock()
+	delete(h.broadcasts, id)
+}
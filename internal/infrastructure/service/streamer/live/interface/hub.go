@@ -0,0 +1,29 @@
+package live_interface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type Hub interface {
+	// Open - registers a new broadcast of the user, it's available for viewers until it will be closed.
+	Open(userID vo.ID, audioCodec string, videoCodec string) Broadcast
+	// Subscribe - returns a new viewer of the broadcast (the entity not found error is returned if it's not live).
+	Subscribe(id string) (Viewer, error)
+}
+
+type Broadcast interface {
+	GetID() string
+	// Init - sets the initialization segment of the container which is sent to each viewer first.
+	Init(segment []byte)
+	// Write - fans out the media segment to the viewers, the segment must not be modified after that.
+	Write(segment []byte, keyframe bool)
+	// Close - ends the broadcast, the segments channels of viewers are closed.
+	Close()
+}
+
+type Viewer interface {
+	GetAudioCodec() string
+	GetVideoCodec() string
+	// Segments - returns the channel of segments which is closed when the broadcast is ended.
+	Segments() <-chan []byte
+	// Close - unsubscribes the viewer.
+	Close()
+}
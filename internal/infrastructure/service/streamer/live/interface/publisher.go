@@ -0,0 +1,11 @@
+package live_interface
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+)
+
+type Publisher interface {
+	// HandleConn - receives the broadcast from the connection until it will be closed.
+	HandleConn(ctx context.Context, conn *websocket.Conn)
+}
@@ -0,0 +1,325 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	domain_enum "github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	tokenizer_interface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	live_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer"
+	tracer_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/tracer/interface"
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+const (
+	// the flags of the first byte of each binary message
+	keyframeFlag    byte = 1 << 0
+	initSegmentFlag byte = 1 << 1
+	// the text message which ends the broadcast
+	stopMsg = "stop"
+)
+
+var UnexpectedPublishingMessageError = errors.New("the first message of the publisher must be the 'PUBLISH' action")
+
+// Publisher - receives the broadcast from the publisher connection: the first text message is the publishing
+// action (authentication and codecs), the binary messages are the media segments prefixed by the flags byte.
+type Publisher struct {
+	logger             logger_interface.Logger
+	tracer             tracer_interface.Tracer
+	communicator       proto_interface.Communicator
+	tokenizer          tokenizer_interface.Tokenizer
+	hub                live_interface.Hub
+	resourceRepository repository_interface.Resource
+	quota              quota_interface.Quota
+	detector           detector_interface.Codecs
+	maxSegmentSize     int64
+	recording          bool
+}
+
+func NewPublisher(serviceContainer di_interface.ContainerManager) (*Publisher, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	tracerService, err := serviceContainer.GetTracerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	liveHub, err := serviceContainer.GetLiveHub()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceRepository, err := serviceContainer.GetResourceRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	quotaService, err := serviceContainer.GetQuotaService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &Publisher{
+		logger:             loggerService,
+		tracer:             tracerService,
+		communicator:       webSocketCommunicator,
+		tokenizer:          tokenizerService,
+		hub:                liveHub,
+		resourceRepository: resourceRepository,
+		quota:              quotaService,
+		detector:           codecsDetector,
+		maxSegmentSize:     cfg.LiveMaxSegmentSize,
+		recording:          cfg.LiveRecordingEnabled,
+	}, nil
+}
+
+// HandleConn - receives the broadcast until the publisher stops it or the connection will be closed.
+// Cancellation of the context means that the connection is drained, so the broadcast is ended.
+func (p *Publisher) HandleConn(ctx context.Context, conn *websocket.Conn) {
+	logger := p.logger.WithContext(ctx)
+
+	// the segment is prefixed by the flags byte
+	conn.SetReadLimit(p.maxSegmentSize + 1)
+
+	// the reading of the next segment is blocked, so it's interrupted by closing the connection
+	stop := context.AfterFunc(ctx, func() {
+		if err := p.communicator.Close(conn); err != nil {
+			logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		_ = conn.Close()
+	})
+	defer stop()
+	defer p.communicator.Release(conn)
+
+	if err := p.publish(ctx, conn); err != nil {
+		logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		if ctx.Err() == nil {
+			_ = p.communicator.Error(err, conn)
+		}
+	}
+}
+
+func (p *Publisher) publish(ctx context.Context, conn *websocket.Conn) error {
+	logger := p.logger.WithContext(ctx)
+
+	data, err := p.handshake(conn)
+	if err != nil {
+		return err
+	}
+
+	// user authentication
+	userID, err := p.tokenizer.Verify(data.Token)
+	if err != nil {
+		return logger.LogPropagate(err)
+	}
+	// the further records will be bound to the authed user
+	ctx = context.WithValue(ctx, domain_enum.UserIDContextKey, userID)
+	logger = p.logger.WithContext(ctx)
+
+	var rec *recorder
+	broadcast := p.hub.Open(userID, data.AudioCodec, data.VideoCodec)
+	defer func() {
+		broadcast.Close()
+		logger.Info(fmt.Sprintf("[%v]: live broadcast '%v' is closed", conn.RemoteAddr(), broadcast.GetID()))
+		if rec != nil {
+			p.save(ctx, userID, rec)
+		}
+	}()
+
+	if data.Record {
+		if !p.recording {
+			return logger.LogPropagate(errors.New("recording of the live broadcasts is disabled"))
+		}
+		// the recording is stopped as soon as the file crosses the remaining quota, like the uploading is aborted
+		var remaining int64
+		if remaining, err = p.quota.Remaining(ctx, userID); err != nil {
+			return logger.LogPropagate(err)
+		}
+		if rec, err = newRecorder(broadcast.GetID(), data.Container, remaining); err != nil {
+			return logger.LogPropagate(err)
+		}
+	}
+
+	recording := rec != nil
+	if err = p.communicator.Live(model.LiveBroadcast{ID: broadcast.GetID(), Recording: recording}, conn); err != nil {
+		return logger.LogPropagate(err)
+	}
+
+	// the whole broadcast is traced as one span
+	_, span := p.tracer.Start(ctx, "websocket.publish", trace.WithAttributes(attribute.String("broadcast.id", broadcast.GetID())))
+	defer span.End()
+
+	var (
+		segments int
+		bytes    int
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("broadcast.segments", segments), attribute.Int("broadcast.bytes", bytes))
+	}()
+
+	for {
+		t, b, rerr := conn.ReadMessage()
+		if rerr != nil {
+			if ctx.Err() != nil || websocket.IsCloseError(rerr, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				return nil
+			}
+			tracer.Fail(span, rerr)
+			return logger.LogPropagate(rerr)
+		}
+
+		switch t {
+		case websocket.TextMessage:
+			if string(b) == stopMsg {
+				return nil
+			}
+		case websocket.BinaryMessage:
+			if len(b) < 2 {
+				continue
+			}
+			flags, segment := b[0], b[1:]
+			if flags&initSegmentFlag != 0 {
+				broadcast.Init(segment)
+			} else {
+				broadcast.Write(segment, flags&keyframeFlag != 0)
+			}
+			if recording {
+				if recording = rec.write(segment); !recording {
+					p.stopRecording(ctx, conn, broadcast.GetID(), rec)
+				}
+			}
+			segments++
+			bytes += len(segment)
+		}
+	}
+}
+
+// stopRecording - notifies the publisher that the broadcast is not recorded anymore, the recorded part is saved
+// when the broadcast is ended.
+func (p *Publisher) stopRecording(ctx context.Context, conn *websocket.Conn, broadcastID string, rec *recorder) {
+	logger := p.logger.WithContext(ctx)
+
+	reason := rec.stopReason()
+	logger.Warning(fmt.Sprintf("[%v]: recording of the live broadcast '%v' is stopped: %v", conn.RemoteAddr(), broadcastID, reason))
+
+	if err := p.communicator.Live(model.LiveBroadcast{ID: broadcastID, Reason: reason}, conn); err != nil {
+		logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+}
+
+// handshake - reads the publishing action which must be the first message of the connection.
+func (p *Publisher) handshake(conn *websocket.Conn) (*model.PublishData, error) {
+	t, b, err := conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	if t != websocket.TextMessage {
+		return nil, UnexpectedPublishingMessageError
+	}
+
+	do, data, err := p.communicator.Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	publishData, ok := data.(*model.PublishData)
+	if do != enum.Publish || !ok {
+		return nil, UnexpectedPublishingMessageError
+	}
+
+	return publishData, nil
+}
+
+// save - stores the recorded broadcast as a new resource of the publisher, the connection may be already
+// drained here, so the saving is not bound to its cancellation.
+func (p *Publisher) save(ctx context.Context, userID vo.ID, rec *recorder) {
+	ctx = context.WithoutCancel(ctx)
+	logger := p.logger.WithContext(ctx)
+
+	recorded, err := rec.close()
+	if err != nil {
+		logger.Error(fmt.Sprintf("recording of the live broadcast '%v' failed: %v", rec.filename, err))
+		return
+	}
+	if !recorded {
+		return
+	}
+
+	resource := &agg.Resource{
+		Resource: entity.Resource{
+			UserID:   userID,
+			Name:     rec.filename,
+			Filename: rec.filename,
+			Filepath: rec.filepath,
+			Filesize: rec.size,
+			Filetype: rec.filetype,
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}
+
+	// detecting media metadata, the resource is still usable without it, so only log the error
+	if resource.Metadata, err = p.detector.DetectMetadata(ctx, resource.Resource); err != nil {
+		logger.Warning(fmt.Sprintf("unable to detect metadata of resource '%v': %v", resource.Filename, err))
+	}
+
+	// the recording is counted into the used storage of the publisher, it's discarded if the quota is exceeded
+	if err = p.quota.Consume(ctx, resource); err != nil {
+		logger.Warning(fmt.Sprintf("live broadcast recording '%v' is discarded: %v", rec.filename, err))
+		if err = rec.remove(); err != nil {
+			logger.Error(err)
+		}
+		return
+	}
+
+	inserted, err := p.resourceRepository.Insert(ctx, resource)
+	if err != nil {
+		logger.Error(err)
+		if e := p.quota.Release(ctx, resource); e != nil {
+			logger.Error(e)
+		}
+		if err = rec.remove(); err != nil {
+			logger.Error(err)
+		}
+		return
+	}
+
+	logger.Info(fmt.Sprintf("live broadcast is recorded into the resource '%v'", inserted.ID.Value.Hex()))
+}
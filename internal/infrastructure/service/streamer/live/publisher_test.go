@@ -0,0 +1,197 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	domain_errors "github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	quota_interface "github.com/Borislavv/video-streaming/internal/domain/service/quota/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detector_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testQuota - fails the consuming by the given error (or by the canceled context) and records
+// the consumed and released bytes.
+type testQuota struct {
+	quota_interface.Quota
+	err      error
+	consumed int64
+	released int64
+}
+
+func (q *testQuota) Consume(ctx context.Context, resource *agg.Resource) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if q.err != nil {
+		return q.err
+	}
+	q.consumed += resource.Filesize
+	return nil
+}
+
+func (q *testQuota) Release(ctx context.Context, resource *agg.Resource) error {
+	q.released += resource.Filesize
+	return nil
+}
+
+// testResourceRepository - fails the inserting by the given error (or by the canceled context)
+// and records the inserted resources.
+type testResourceRepository struct {
+	repository_interface.Resource
+	err      error
+	inserted []*agg.Resource
+}
+
+func (r *testResourceRepository) Insert(ctx context.Context, resource *agg.Resource) (*agg.Resource, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	resource.ID = vo.NewID(primitive.NewObjectID())
+	r.inserted = append(r.inserted, resource)
+	return resource, nil
+}
+
+// testDetector - the metadata cannot be detected for the recorded files.
+type testDetector struct {
+	detector_interface.Codecs
+}
+
+func (d *testDetector) DetectMetadata(ctx context.Context, resource entity.Resource) (vo.MediaMetadata, error) {
+	return vo.MediaMetadata{}, errors.New("ffprobe is not available")
+}
+
+func newTestRecorder(t *testing.T, limit int64) *recorder {
+	filename := "live-" + primitive.NewObjectID().Hex() + ".webm"
+	path := filepath.Join(t.TempDir(), filename)
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &recorder{file: file, filename: filename, filepath: path, filetype: "video/webm", limit: limit}
+}
+
+func TestRecorder_Write(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int64
+		segments  []string
+		recording []bool // the result of writing of each segment
+		size      int64
+	}{
+		{name: "unlimited", segments: []string{"init", "k1", "d1"}, recording: []bool{true, true, true}, size: 8},
+		{name: "segment which fits exactly", limit: 8, segments: []string{"init", "k1", "d1"}, recording: []bool{true, true, true}, size: 8},
+		{
+			name:      "segment over the limit stops the recording",
+			limit:     7,
+			segments:  []string{"init", "k1", "d1", "d"},
+			recording: []bool{true, true, false, false},
+			size:      6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := newTestRecorder(t, tt.limit)
+			defer func() { _ = rec.file.Close() }()
+
+			for i, segment := range tt.segments {
+				if isRecording := rec.write([]byte(segment)); isRecording != tt.recording[i] {
+					t.Errorf("recording after segment '%v' = %v, want %v", segment, isRecording, tt.recording[i])
+				}
+			}
+			if rec.size != tt.size {
+				t.Errorf("recorded size = %d, want %d", rec.size, tt.size)
+			}
+			if stat, err := rec.file.Stat(); err != nil || stat.Size() != tt.size {
+				t.Errorf("size of the file = %v (%v), want %d", stat.Size(), err, tt.size)
+			}
+		})
+	}
+}
+
+func TestPublisher_Save(t *testing.T) {
+	tests := []struct {
+		name      string
+		segments  []string
+		quotaErr  error
+		insertErr error
+		isSaved   bool
+		consumed  int64
+		released  int64
+	}{
+		{name: "recorded broadcast is saved", segments: []string{"init", "k1", "d1"}, isSaved: true, consumed: 8},
+		{name: "empty recording is discarded", segments: nil},
+		{
+			name:     "recording over the quota is discarded",
+			segments: []string{"init", "k1"},
+			quotaErr: domain_errors.NewQuotaExceededError("the storage limit is reached"),
+		},
+		{
+			name:      "consumed quota is released if the resource was not saved",
+			segments:  []string{"init", "k1"},
+			insertErr: errors.New("insert failed"),
+			consumed:  6,
+			released:  6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := &testQuota{err: tt.quotaErr}
+			repository := &testResourceRepository{err: tt.insertErr}
+			p := &Publisher{
+				logger:             logger_stub.NewLogger(),
+				resourceRepository: repository,
+				quota:              quota,
+				detector:           &testDetector{},
+			}
+
+			rec := newTestRecorder(t, 0)
+			for _, segment := range tt.segments {
+				rec.write([]byte(segment))
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			// the connection is already drained when the recording is saved
+			cancel()
+			userID := vo.NewID(primitive.NewObjectID())
+			p.save(ctx, userID, rec)
+
+			_, statErr := os.Stat(rec.filepath)
+			if tt.isSaved {
+				if len(repository.inserted) != 1 {
+					t.Fatalf("inserted resources = %d, want 1", len(repository.inserted))
+				}
+				resource := repository.inserted[0]
+				if resource.UserID != userID || resource.Filesize != rec.size || resource.Filepath != rec.filepath {
+					t.Errorf("inserted resource = %+v, want of user %v with the recorded file", resource, userID)
+				}
+				if statErr != nil {
+					t.Errorf("the recorded file must be kept: %v", statErr)
+				}
+			} else {
+				if len(repository.inserted) != 0 {
+					t.Errorf("inserted resources = %d, want 0", len(repository.inserted))
+				}
+				if !os.IsNotExist(statErr) {
+					t.Errorf("the recorded file must be removed, stat error: %v", statErr)
+				}
+			}
+
+			if quota.consumed != tt.consumed || quota.released != tt.released {
+				t.Errorf("consumed = %d, released = %d, want %d and %d", quota.consumed, quota.released, tt.consumed, tt.released)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package live
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"os"
+)
+
+const defaultContainer = "webm"
+
+// containers - the supported containers of recorded broadcasts by name (filetype and extension).
+var containers = map[string]struct {
+	filetype  string
+	extension string
+}{
+	"webm": {filetype: "video/webm", extension: ".webm"},
+	"mp4":  {filetype: "video/mp4", extension: ".mp4"},
+}
+
+// recorder - writes the segments of broadcast into a file of the resources directory. The initialization
+// segment and the media ones make up the valid file of the container (the segments are written as is).
+type recorder struct {
+	file     *os.File
+	filename string
+	filepath string
+	filetype string
+	size     int64
+	// limit is a max. size of the file (zero means unlimited), the segment which crosses it stops the recording
+	limit     int64
+	isLimited bool
+	// err is the first writing error, the further segments are not written after that
+	err error
+}
+
+func newRecorder(broadcastID string, container string, limit int64) (*recorder, error) {
+	if container == "" {
+		container = defaultContainer
+	}
+	c, supported := containers[container]
+	if !supported {
+		return nil, fmt.Errorf("unable to record the broadcast into unsupported container '%v'", container)
+	}
+
+	dir, err := helper.ResourcesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	filename := "live-" + broadcastID + c.extension
+	filepath := dir + filename
+
+	file, err := os.Create(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recorder{
+		file:     file,
+		filename: filename,
+		filepath: filepath,
+		filetype: c.filetype,
+		limit:    limit,
+	}, nil
+}
+
+// write - appends the segment to the file, returns false if the recording is stopped (the segment is not written).
+// The file is kept within the limit, so the recorded part still may be saved.
+func (r *recorder) write(segment []byte) (isRecording bool) {
+	if r.err != nil || r.isLimited {
+		return false
+	}
+	if r.limit > 0 && r.size+int64(len(segment)) > r.limit {
+		r.isLimited = true
+		return false
+	}
+	n, err := r.file.Write(segment)
+	r.size += int64(n)
+	r.err = err
+	return r.err == nil
+}
+
+// stopReason - describes why the recording is stopped before the end of broadcast.
+func (r *recorder) stopReason() string {
+	if r.err != nil {
+		return fmt.Sprintf("recording failed: %v", r.err)
+	}
+	return "the recording exceeds the storage quota"
+}
+
+// close - closes the file, it's removed if nothing was recorded or the recording failed.
+func (r *recorder) close() (recorded bool, err error) {
+	if err = r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if r.err == nil && r.size > 0 {
+		return true, nil
+	}
+	if err = os.Remove(r.filepath); err != nil {
+		return false, err
+	}
+	return false, r.err
+}
+
+// remove - removes the recorded file (it's used when the resource could not be saved).
+func (r *recorder) remove() error {
+	return os.Remove(r.filepath)
+}
@@ -3,6 +3,7 @@ package proto_interface
 import (
 	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	"github.com/gorilla/websocket"
 )
 
@@ -10,6 +11,13 @@ type Communicator interface {
 	Start(audioCodec string, videoCodec string, conn *websocket.Conn) error
 	Send(chunk dto_interface.Chunk, conn *websocket.Conn) error
 	Parse(bytes []byte) (action enum.Actions, data interface{}, err error)
+	Playlist(position model.PlaylistPosition, conn *websocket.Conn) error
+	Room(event model.RoomEvent, conn *websocket.Conn) error
+	Live(broadcast model.LiveBroadcast, conn *websocket.Conn) error
+	GoingAway(goingAway model.GoingAway, conn *websocket.Conn) error
 	Error(err error, conn *websocket.Conn) error
 	Stop(conn *websocket.Conn) error
+	Close(conn *websocket.Conn) error
+	// Release - forgets the state of the connection, it must be called when the connection is closed.
+	Release(conn *websocket.Conn)
 }
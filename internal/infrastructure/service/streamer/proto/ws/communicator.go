@@ -11,19 +11,31 @@ import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	"github.com/gorilla/websocket"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	// message parts separator
 	protoSeparator string = "::"
 	// message prefixes
-	startMsgPref string = "start"
-	errMsgPref   string = "error"
-	stopMsgPref  string = "stop"
+	startMsgPref    string = "start"
+	errMsgPref      string = "error"
+	stopMsgPref     string = "stop"
+	playlistMsgPref string = "playlist"
+	goAwayMsgPref   string = "goaway"
+	liveMsgPref     string = "live"
+	roomMsgPref     string = "room"
 )
 
+// closeFrameWriteTimeout is a deadline of writing the close frame (the client may not read the connection anymore).
+const closeFrameWriteTimeout = time.Second
+
 type Communicator struct {
 	logger logger_interface.Logger
+	// writers are the write locks of connections: the connection supports one concurrent writer only,
+	// but the room events are written into it from the connections of other participants
+	writers *sync.Map
 }
 
 func NewWebSocketCommunicator(serviceContainer di_interface.ContainerManager) (*Communicator, error) {
@@ -33,7 +45,8 @@ func NewWebSocketCommunicator(serviceContainer di_interface.ContainerManager) (*
 	}
 
 	return &Communicator{
-		logger: loggerService,
+		logger:  loggerService,
+		writers: &sync.Map{},
 	}, nil
 }
 
@@ -47,7 +60,7 @@ func (w *Communicator) Start(audioCodec string, videoCodec string, conn *websock
 	initMessage := b.String()
 
 	// writing the stream initialization message in a websocket connection
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(initMessage)); err != nil {
+	if err := w.write(conn, websocket.TextMessage, []byte(initMessage)); err != nil {
 		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
@@ -59,7 +72,7 @@ func (w *Communicator) Send(chunk dto_interface.Chunk, conn *websocket.Conn) err
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), chunk.GetError().Error()))
 	}
 
-	if err := conn.WriteMessage(websocket.BinaryMessage, chunk.GetData()); err != nil {
+	if err := w.write(conn, websocket.BinaryMessage, chunk.GetData()); err != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
@@ -88,6 +101,66 @@ func (w *Communicator) Parse(bytes []byte) (action enum.Actions, data interface{
 			return "", nil, w.logger.LogPropagate(err)
 		}
 		return enum.StreamByIDWithOffset, data, nil
+	case enum.StreamPlaylist:
+		data = &model.StreamPlaylistData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.StreamPlaylist, data, nil
+	case enum.StreamLive:
+		data = &model.StreamLiveData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.StreamLive, data, nil
+	case enum.RoomCreate:
+		data = &model.RoomCreateData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.RoomCreate, data, nil
+	case enum.RoomJoin:
+		data = &model.RoomJoinData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.RoomJoin, data, nil
+	case enum.RoomLeave:
+		data = &model.RoomLeaveData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.RoomLeave, data, nil
+	case enum.RoomControl:
+		data = &model.RoomControlData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.RoomControl, data, nil
+	case enum.RoomTransfer:
+		data = &model.RoomTransferData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.RoomTransfer, data, nil
+	case enum.RoomSync:
+		data = &model.RoomSyncData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.RoomSync, data, nil
+	case enum.Progress:
+		data = &model.ProgressData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.Progress, data, nil
+	case enum.Publish:
+		data = &model.PublishData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.Publish, data, nil
 	default:
 		return "", nil, fmt.Errorf(
 			"unable to parse message because received unknown strategy '%v'", strategy,
@@ -95,10 +168,79 @@ func (w *Communicator) Parse(bytes []byte) (action enum.Actions, data interface{
 	}
 }
 
+// Playlist - will send the current playlist position to client side (the message looks like "playlist::{json}").
+func (w *Communicator) Playlist(position model.PlaylistPosition, conn *websocket.Conn) error {
+	positionBytes, err := json.Marshal(position)
+	if err != nil {
+		return w.logger.LogPropagate(err)
+	}
+
+	msg := []byte(playlistMsgPref + protoSeparator + string(positionBytes))
+	if err = w.write(conn, websocket.TextMessage, msg); err != nil {
+		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	return nil
+}
+
+// Live - will send the identifier of the started broadcast to the publisher (the message looks like "live::{json}").
+func (w *Communicator) Live(broadcast model.LiveBroadcast, conn *websocket.Conn) error {
+	broadcastBytes, err := json.Marshal(broadcast)
+	if err != nil {
+		return w.logger.LogPropagate(err)
+	}
+
+	msg := []byte(liveMsgPref + protoSeparator + string(broadcastBytes))
+	if err = w.write(conn, websocket.TextMessage, msg); err != nil {
+		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	return nil
+}
+
+// Room - will send the event of watch-party room to the participant (the message looks like "room::{json}").
+func (w *Communicator) Room(event model.RoomEvent, conn *websocket.Conn) error {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return w.logger.LogPropagate(err)
+	}
+
+	msg := []byte(roomMsgPref + protoSeparator + string(eventBytes))
+	if err = w.write(conn, websocket.TextMessage, msg); err != nil {
+		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	return nil
+}
+
+// GoingAway - will send the point from which the interrupted stream may be resumed (the message looks like "goaway::{json}").
+func (w *Communicator) GoingAway(goingAway model.GoingAway, conn *websocket.Conn) error {
+	goingAwayBytes, err := json.Marshal(goingAway)
+	if err != nil {
+		return w.logger.LogPropagate(err)
+	}
+
+	msg := []byte(goAwayMsgPref + protoSeparator + string(goingAwayBytes))
+	if err = w.write(conn, websocket.TextMessage, msg); err != nil {
+		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	return nil
+}
+
+// Close - will send the close frame with 'going away' code, so the client side knows that the server is shutting down.
+func (w *Communicator) Close(conn *websocket.Conn) error {
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeFrameWriteTimeout)); err != nil {
+		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+	return nil
+}
+
 func (w *Communicator) Error(err error, conn *websocket.Conn) error {
 	msg := []byte(fmt.Sprintf("%v:%v", errMsgPref, err.Error()))
 
-	if e := conn.WriteMessage(websocket.TextMessage, msg); e != nil {
+	if e := w.write(conn, websocket.TextMessage, msg); e != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), e.Error()))
 	}
 
@@ -106,8 +248,25 @@ func (w *Communicator) Error(err error, conn *websocket.Conn) error {
 }
 
 func (w *Communicator) Stop(conn *websocket.Conn) error {
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(stopMsgPref)); err != nil {
+	if err := w.write(conn, websocket.TextMessage, []byte(stopMsgPref)); err != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 	return nil
 }
+
+// Release - will forget the write lock of the connection, it must be called when the connection is closed.
+func (w *Communicator) Release(conn *websocket.Conn) {
+	w.writers.Delete(conn)
+}
+
+// write - writes the message into the connection under its write lock.
+func (w *Communicator) write(conn *websocket.Conn, messageType int, data []byte) error {
+	mu, found := w.writers.Load(conn)
+	if !found {
+		mu, _ = w.writers.LoadOrStore(conn, &sync.Mutex{})
+	}
+	defer mu.(*sync.Mutex).Unlock()
+	mu.(*sync.Mutex).Lock()
+
+	return conn.WriteMessage(messageType, data)
+}
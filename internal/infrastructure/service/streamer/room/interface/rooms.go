@@ -0,0 +1,26 @@
+package room_interface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/gorilla/websocket"
+)
+
+type Rooms interface {
+	// Create - opens a new room of the video, the user is the owner of the room which drives the playback.
+	Create(ctx context.Context, userID vo.ID, videoID string, conn *websocket.Conn) error
+	// Join - adds the connection to the room by the invite token.
+	Join(ctx context.Context, id string, invite string, userID vo.ID, conn *websocket.Conn) error
+	// Leave - removes the connection from the room, the ownership is passed to the next participant.
+	Leave(ctx context.Context, id string, conn *websocket.Conn) error
+	// Control - changes the playback state of the room (owner only): play, pause or seek.
+	Control(ctx context.Context, id string, command string, position float64, conn *websocket.Conn) error
+	// Transfer - passes the ownership of the room to the participant by the user identifier (owner only).
+	Transfer(ctx context.Context, id string, userID string, conn *websocket.Conn) error
+	// Sync - answers the clock sync request of the participant.
+	Sync(ctx context.Context, id string, clientTime int64, conn *websocket.Conn) error
+	// Disconnect - removes the closed connection from all the rooms.
+	Disconnect(ctx context.Context, conn *websocket.Conn)
+	// Access - returns the owner of the video if it's watched in any room joined by the connection.
+	Access(videoID string, conn *websocket.Conn) (ownerID vo.ID, isGranted bool)
+}
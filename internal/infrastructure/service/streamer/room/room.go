@@ -0,0 +1,83 @@
+package room
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	"github.com/gorilla/websocket"
+	"sync"
+	"time"
+)
+
+type participant struct {
+	userID vo.ID
+	conn   *websocket.Conn
+}
+
+type room struct {
+	id      string
+	invite  string
+	videoID string
+	// videoOwnerID is the owner of the video, the participants stream it on behalf of the owner
+	videoOwnerID vo.ID
+
+	mu sync.Mutex
+	// participants are ordered by joining, the first one is the owner
+	participants []*participant
+	playing      bool
+	position     float64   // position of the video in seconds at the moment 'at'
+	at           time.Time // the moment of the last playback command
+	closed       bool
+	stop         chan struct{} // it's closed with the room, so the sync messages are stopped
+}
+
+// notification - the event and its recipients, the invite token is sent to the owner only.
+type notification struct {
+	event  model.RoomEvent
+	conns  []*websocket.Conn
+	owner  *websocket.Conn
+	invite string
+}
+
+// notification - builds the event of the current state for all participants (the lock must be held by the caller).
+func (rm *room) notification(event string, userID vo.ID) notification {
+	now := time.Now()
+
+	position := rm.position
+	if rm.playing {
+		position += now.Sub(rm.at).Seconds()
+	}
+
+	n := notification{
+		event: model.RoomEvent{
+			Event:        event,
+			RoomID:       rm.id,
+			OwnerID:      rm.participants[0].userID.Value.Hex(),
+			VideoID:      rm.videoID,
+			Playing:      rm.playing,
+			Position:     position,
+			Participants: len(rm.participants),
+			ServerTime:   now.UnixMilli(),
+		},
+		conns:  make([]*websocket.Conn, 0, len(rm.participants)),
+		owner:  rm.participants[0].conn,
+		invite: rm.invite,
+	}
+	if !userID.Value.IsZero() {
+		n.event.UserID = userID.Value.Hex()
+	}
+	for _, p := range rm.participants {
+		n.conns = append(n.conns, p.conn)
+	}
+
+	return n
+}
+
+// indexOf - returns the index of participant by the connection or -1 (the lock must be held by the caller).
+func (rm *room) indexOf(conn *websocket.Conn) int {
+	for i, p := range rm.participants {
+		if p.conn == conn {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,415 @@
+package room
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+	"time"
+)
+
+const (
+	// playback commands of the owner
+	PlayCommand  = "play"
+	PauseCommand = "pause"
+	SeekCommand  = "seek"
+	// events of the room
+	createdEvent = "created"
+	joinedEvent  = "joined"
+	leftEvent    = "left"
+	ownerEvent   = "owner"
+	syncEvent    = "sync"
+	// inviteTokenLength is a number of random bytes of the invite token
+	inviteTokenLength = 16
+)
+
+var (
+	RoomNotFoundByIdError                = errors.NewEntityNotFoundError("room", "id")
+	ParticipantNotFoundByUserIdError     = errors.NewEntityNotFoundError("participant", "userID")
+	ParticipantNotFoundByConnectionError = errors.NewEntityNotFoundError("participant", "connection")
+	InviteTokenIsInvalidError            = errors.NewAccessDeniedError("invite token of the room is invalid")
+	RoomIsFullError                      = errors.NewAccessDeniedError("room has reached the max. number of participants")
+	OwnerOnlyError                       = errors.NewAccessDeniedError("only the owner of the room can do it")
+)
+
+// Rooms - the watch-party rooms of the instance: the owner connection drives the playback and its commands
+// are broadcast to all participants (the participants must be connected to the same instance). The video
+// of the room is streamed to the participants on behalf of the user who has created the room.
+type Rooms struct {
+	ctx             context.Context
+	logger          logger_interface.Logger
+	communicator    proto_interface.Communicator
+	videoRepository repository_interface.Video
+	syncInterval    time.Duration
+	maxParticipants int
+
+	mu    sync.RWMutex
+	rooms map[string]*room
+}
+
+func NewRooms(serviceContainer di_interface.ContainerManager) (*Rooms, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &Rooms{
+		ctx:             ctx,
+		logger:          loggerService,
+		communicator:    webSocketCommunicator,
+		videoRepository: videoRepository,
+		syncInterval:    cfg.RoomSyncInterval,
+		maxParticipants: cfg.RoomMaxParticipants,
+		rooms:           make(map[string]*room),
+	}, nil
+}
+
+// Create - opens a new room of the video, the user is the owner of the room which drives the playback.
+// The owner receives the invite token which must be passed to the other participants for join the room.
+// The video must belong to the user, the participants are granted to stream it while they are in the room.
+func (r *Rooms) Create(ctx context.Context, userID vo.ID, videoID string, conn *websocket.Conn) error {
+	oid, err := primitive.ObjectIDFromHex(videoID)
+	if err != nil {
+		return err
+	}
+
+	video, err := r.videoRepository.FindOneByID(ctx, dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID))
+	if err != nil {
+		return err
+	}
+
+	invite, err := inviteToken()
+	if err != nil {
+		return err
+	}
+
+	rm := &room{
+		id:           primitive.NewObjectID().Hex(),
+		invite:       invite,
+		videoID:      videoID,
+		videoOwnerID: video.UserID,
+		participants: []*participant{{userID: userID, conn: conn}},
+		at:           time.Now(),
+		stop:         make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	r.rooms[rm.id] = rm
+	r.mu.Unlock()
+
+	if r.syncInterval > 0 {
+		go r.sync(rm)
+	}
+
+	rm.mu.Lock()
+	n := rm.notification(createdEvent, userID)
+	rm.mu.Unlock()
+	r.notify(ctx, n)
+
+	return nil
+}
+
+// Join - adds the connection to the room by the invite token, all the participants are notified.
+func (r *Rooms) Join(ctx context.Context, id string, invite string, userID vo.ID, conn *websocket.Conn) error {
+	rm, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	switch {
+	case rm.closed:
+		rm.mu.Unlock()
+		return RoomNotFoundByIdError
+	case subtle.ConstantTimeCompare([]byte(rm.invite), []byte(invite)) != 1:
+		rm.mu.Unlock()
+		return InviteTokenIsInvalidError
+	case rm.indexOf(conn) >= 0:
+		// the connection is already joined, the current state is sent to it only
+		n := rm.notification(joinedEvent, userID)
+		n.conns = []*websocket.Conn{conn}
+		rm.mu.Unlock()
+		r.notify(ctx, n)
+		return nil
+	case len(rm.participants) >= r.maxParticipants:
+		rm.mu.Unlock()
+		return RoomIsFullError
+	}
+	rm.participants = append(rm.participants, &participant{userID: userID, conn: conn})
+	n := rm.notification(joinedEvent, userID)
+	rm.mu.Unlock()
+
+	r.notify(ctx, n)
+
+	return nil
+}
+
+// Leave - removes the connection from the room, the ownership is passed to the next participant
+// if the owner has left. The room is closed when the last participant has left.
+func (r *Rooms) Leave(ctx context.Context, id string, conn *websocket.Conn) error {
+	rm, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	index := rm.indexOf(conn)
+	if index < 0 {
+		rm.mu.Unlock()
+		return ParticipantNotFoundByConnectionError
+	}
+	left := rm.participants[index]
+	rm.participants = append(rm.participants[:index], rm.participants[index+1:]...)
+
+	if len(rm.participants) == 0 {
+		rm.closed = true
+		close(rm.stop)
+		rm.mu.Unlock()
+
+		r.remove(id)
+		r.logger.WithContext(ctx).Info(fmt.Sprintf("room '%v' is closed", id))
+		return nil
+	}
+
+	// the next participant becomes the owner if the owner has left (the event contains the new one)
+	n := rm.notification(leftEvent, left.userID)
+	rm.mu.Unlock()
+
+	// the left connection is notified too, so it knows that the leaving is done
+	n.conns = append(n.conns, conn)
+	r.notify(ctx, n)
+
+	return nil
+}
+
+// Control - changes the playback state of the room (owner only). The position is the position of video
+// at the moment of command on the owner side (in seconds), it's extrapolated by the server time while playing.
+func (r *Rooms) Control(ctx context.Context, id string, command string, position float64, conn *websocket.Conn) error {
+	if position < 0 {
+		return errors.NewValueMustBeNonNegativeError("position")
+	}
+
+	rm, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	if rm.indexOf(conn) != 0 {
+		rm.mu.Unlock()
+		return OwnerOnlyError
+	}
+	switch command {
+	case PlayCommand:
+		rm.playing = true
+	case PauseCommand:
+		rm.playing = false
+	case SeekCommand:
+	default:
+		rm.mu.Unlock()
+		return fmt.Errorf("room command '%v' is not supported, available commands: [%v, %v, %v]",
+			command, PlayCommand, PauseCommand, SeekCommand,
+		)
+	}
+	rm.position = position
+	rm.at = time.Now()
+	n := rm.notification(command, rm.participants[0].userID)
+	rm.mu.Unlock()
+
+	r.notify(ctx, n)
+
+	return nil
+}
+
+// Transfer - passes the ownership of the room to the participant by the user identifier (owner only).
+func (r *Rooms) Transfer(ctx context.Context, id string, userID string, conn *websocket.Conn) error {
+	rm, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	if rm.indexOf(conn) != 0 {
+		rm.mu.Unlock()
+		return OwnerOnlyError
+	}
+	index := -1
+	for i, p := range rm.participants {
+		if p.userID.Value.Hex() == userID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		rm.mu.Unlock()
+		return ParticipantNotFoundByUserIdError
+	}
+	// the owner is the first participant, the rest order is kept for passing the ownership on leaving
+	owner := rm.participants[index]
+	copy(rm.participants[1:index+1], rm.participants[:index])
+	rm.participants[0] = owner
+	n := rm.notification(ownerEvent, owner.userID)
+	rm.mu.Unlock()
+
+	r.notify(ctx, n)
+
+	return nil
+}
+
+// Sync - answers the clock sync request of the participant: the response contains the time of request
+// and the server time, so the client side computes the round trip and the clock offset.
+func (r *Rooms) Sync(ctx context.Context, id string, clientTime int64, conn *websocket.Conn) error {
+	rm, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	index := rm.indexOf(conn)
+	if index < 0 {
+		rm.mu.Unlock()
+		return ParticipantNotFoundByConnectionError
+	}
+	n := rm.notification(syncEvent, rm.participants[index].userID)
+	rm.mu.Unlock()
+
+	n.event.ClientTime = clientTime
+	n.conns = []*websocket.Conn{conn}
+	r.notify(ctx, n)
+
+	return nil
+}
+
+// Disconnect - removes the closed connection from all the rooms.
+func (r *Rooms) Disconnect(ctx context.Context, conn *websocket.Conn) {
+	r.mu.RLock()
+	joined := make([]string, 0)
+	for id, rm := range r.rooms {
+		rm.mu.Lock()
+		if rm.indexOf(conn) >= 0 {
+			joined = append(joined, id)
+		}
+		rm.mu.Unlock()
+	}
+	r.mu.RUnlock()
+
+	for _, id := range joined {
+		if err := r.Leave(ctx, id, conn); err != nil && !errors.IsEntityNotFoundError(err) {
+			r.logger.WithContext(ctx).Error(err)
+		}
+	}
+}
+
+// Access - returns the owner of the video if it's watched in any room joined by the connection,
+// so the participant can stream the video of other user on behalf of its owner.
+func (r *Rooms) Access(videoID string, conn *websocket.Conn) (ownerID vo.ID, isGranted bool) {
+	defer r.mu.RUnlock()
+	r.mu.RLock()
+
+	for _, rm := range r.rooms {
+		if rm.videoID != videoID {
+			continue
+		}
+		rm.mu.Lock()
+		isGranted = !rm.closed && rm.indexOf(conn) >= 0
+		rm.mu.Unlock()
+		if isGranted {
+			return rm.videoOwnerID, true
+		}
+	}
+	return vo.ID{}, false
+}
+
+// sync - sends the playback state to all the participants periodically, so they correct the drift.
+func (r *Rooms) sync(rm *room) {
+	ticker := time.NewTicker(r.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-rm.stop:
+			return
+		case <-ticker.C:
+			rm.mu.Lock()
+			if rm.closed {
+				rm.mu.Unlock()
+				return
+			}
+			n := rm.notification(syncEvent, vo.ID{})
+			rm.mu.Unlock()
+			r.notify(r.ctx, n)
+		}
+	}
+}
+
+// notify - sends the event to the connections, the failed sending is logged only because
+// it's caused by the connection of other participant (which will be disconnected).
+func (r *Rooms) notify(ctx context.Context, n notification) {
+	for _, conn := range n.conns {
+		event := n.event
+		if conn == n.owner {
+			event.Invite = n.invite
+		}
+		if err := r.communicator.Room(event, conn); err != nil {
+			r.logger.WithContext(ctx).Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+	}
+}
+
+func (r *Rooms) get(id string) (*room, error) {
+	defer r.mu.RUnlock()
+	r.mu.RLock()
+
+	rm, found := r.rooms[id]
+	if !found {
+		return nil, RoomNotFoundByIdError
+	}
+	return rm, nil
+}
+
+func (r *Rooms) remove(id string) {
+	defer r.mu.Unlock()
+	r.mu.Lock()
+	delete(r.rooms, id)
+}
+
+func inviteToken() (string, error) {
+	b := make([]byte, inviteTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
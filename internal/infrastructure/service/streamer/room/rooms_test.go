@@ -0,0 +1,510 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	domain_errors "github.com/Borislavv/video-streaming/internal/domain/errors"
+	logger_stub "github.com/Borislavv/video-streaming/internal/domain/logger/stub"
+	repository_interface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	query_interface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testVideoRepository - a video is found only for its owner.
+type testVideoRepository struct {
+	repository_interface.Video
+	videos map[vo.ID]vo.ID // video -> owner
+}
+
+func (r *testVideoRepository) FindOneByID(ctx context.Context, q query_interface.FindOneVideoByID) (*agg.Video, error) {
+	if userID, ok := r.videos[q.GetID()]; ok && userID == q.GetUserID() {
+		return &agg.Video{Video: entity.Video{ID: q.GetID(), UserID: userID}}, nil
+	}
+	return nil, domain_errors.NewEntityNotFoundError("video", "id")
+}
+
+// testCommunicator - records the sent room events by the connection.
+type testCommunicator struct {
+	proto_interface.Communicator
+	mu     sync.Mutex
+	events map[*websocket.Conn][]model.RoomEvent
+}
+
+func (c *testCommunicator) Room(event model.RoomEvent, conn *websocket.Conn) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events[conn] = append(c.events[conn], event)
+	return nil
+}
+
+// last - returns the last event received by the connection and forgets the received ones.
+func (c *testCommunicator) last(conn *websocket.Conn) (model.RoomEvent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	events := c.events[conn]
+	delete(c.events, conn)
+	if len(events) == 0 {
+		return model.RoomEvent{}, false
+	}
+	return events[len(events)-1], true
+}
+
+// testParticipant - the user and its connection (the connection is used as identity only).
+type testParticipant struct {
+	userID vo.ID
+	conn   *websocket.Conn
+}
+
+func newTestParticipant() testParticipant {
+	return testParticipant{userID: vo.NewID(primitive.NewObjectID()), conn: &websocket.Conn{}}
+}
+
+// testRoom - the room of the video of owner, the guest (other user) has joined it.
+type testRoom struct {
+	rooms        *Rooms
+	communicator *testCommunicator
+	id           string
+	invite       string
+	videoID      vo.ID
+	owner        testParticipant
+	guest        testParticipant
+	stranger     testParticipant // is not joined
+	strangeVideo vo.ID           // the video of stranger
+}
+
+func newTestRoom(t *testing.T, maxParticipants int) *testRoom {
+	communicator := &testCommunicator{events: make(map[*websocket.Conn][]model.RoomEvent)}
+	f := &testRoom{
+		communicator: communicator,
+		videoID:      vo.NewID(primitive.NewObjectID()),
+		owner:        newTestParticipant(),
+		guest:        newTestParticipant(),
+		stranger:     newTestParticipant(),
+		strangeVideo: vo.NewID(primitive.NewObjectID()),
+	}
+	f.rooms = &Rooms{
+		ctx:          context.Background(),
+		logger:       logger_stub.NewLogger(),
+		communicator: communicator,
+		videoRepository: &testVideoRepository{
+			videos: map[vo.ID]vo.ID{f.videoID: f.owner.userID, f.strangeVideo: f.stranger.userID},
+		},
+		maxParticipants: maxParticipants,
+		rooms:           make(map[string]*room),
+	}
+
+	ctx := context.Background()
+	if err := f.rooms.Create(ctx, f.owner.userID, f.videoID.Value.Hex(), f.owner.conn); err != nil {
+		t.Fatalf("unable to create room: %v", err)
+	}
+	created, ok := communicator.last(f.owner.conn)
+	if !ok || created.Event != createdEvent || created.Invite == "" {
+		t.Fatalf("created event = %+v, want the event with invite", created)
+	}
+	f.id, f.invite = created.RoomID, created.Invite
+
+	if err := f.rooms.Join(ctx, f.id, f.invite, f.guest.userID, f.guest.conn); err != nil {
+		t.Fatalf("unable to join room: %v", err)
+	}
+	communicator.last(f.owner.conn)
+	communicator.last(f.guest.conn)
+
+	return f
+}
+
+func TestRooms_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		videoID func(f *testRoom) string
+		err     func(err error) bool
+	}{
+		{name: "own video", videoID: func(f *testRoom) string { return f.videoID.Value.Hex() }},
+		{
+			name:    "video of other user",
+			videoID: func(f *testRoom) string { return f.strangeVideo.Value.Hex() },
+			err:     domain_errors.IsEntityNotFoundError,
+		},
+		{
+			name:    "invalid video identifier",
+			videoID: func(f *testRoom) string { return "invalid" },
+			err:     func(err error) bool { return err != nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestRoom(t, 3)
+
+			conn := &websocket.Conn{}
+			err := f.rooms.Create(context.Background(), f.owner.userID, tt.videoID(f), conn)
+			event, received := f.communicator.last(conn)
+			if tt.err != nil {
+				if !tt.err(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if received {
+					t.Errorf("rejected connection received %+v", event)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.Event != createdEvent || event.VideoID != tt.videoID(f) {
+				t.Errorf("created event = %+v, want of video %v", event, tt.videoID(f))
+			}
+		})
+	}
+}
+
+func TestRooms_Access(t *testing.T) {
+	tests := []struct {
+		name      string
+		before    func(f *testRoom) // changes the room before the check
+		videoID   func(f *testRoom) vo.ID
+		conn      func(f *testRoom) *websocket.Conn
+		isGranted bool
+	}{
+		{
+			name:      "guest of other user is granted to the video of room",
+			videoID:   func(f *testRoom) vo.ID { return f.videoID },
+			conn:      func(f *testRoom) *websocket.Conn { return f.guest.conn },
+			isGranted: true,
+		},
+		{
+			name:      "owner is granted",
+			videoID:   func(f *testRoom) vo.ID { return f.videoID },
+			conn:      func(f *testRoom) *websocket.Conn { return f.owner.conn },
+			isGranted: true,
+		},
+		{
+			name: "guest is granted after the ownership is passed to it",
+			before: func(f *testRoom) {
+				_ = f.rooms.Transfer(context.Background(), f.id, f.guest.userID.Value.Hex(), f.owner.conn)
+			},
+			videoID:   func(f *testRoom) vo.ID { return f.videoID },
+			conn:      func(f *testRoom) *websocket.Conn { return f.guest.conn },
+			isGranted: true,
+		},
+		{
+			name:    "not joined connection",
+			videoID: func(f *testRoom) vo.ID { return f.videoID },
+			conn:    func(f *testRoom) *websocket.Conn { return f.stranger.conn },
+		},
+		{
+			name:    "other video",
+			videoID: func(f *testRoom) vo.ID { return f.strangeVideo },
+			conn:    func(f *testRoom) *websocket.Conn { return f.guest.conn },
+		},
+		{
+			name:    "left guest",
+			before:  func(f *testRoom) { _ = f.rooms.Leave(context.Background(), f.id, f.guest.conn) },
+			videoID: func(f *testRoom) vo.ID { return f.videoID },
+			conn:    func(f *testRoom) *websocket.Conn { return f.guest.conn },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestRoom(t, 3)
+			if f.owner.userID == f.guest.userID {
+				t.Fatalf("the owner and the guest must be different users")
+			}
+			if tt.before != nil {
+				tt.before(f)
+			}
+
+			ownerID, isGranted := f.rooms.Access(tt.videoID(f).Value.Hex(), tt.conn(f))
+			if isGranted != tt.isGranted {
+				t.Fatalf("access is granted = %v, want %v", isGranted, tt.isGranted)
+			}
+			// the video is streamed on behalf of its owner, even if the room is driven by the guest
+			if isGranted && ownerID != f.owner.userID {
+				t.Errorf("owner of the video = %v, want %v", ownerID.Value.Hex(), f.owner.userID.Value.Hex())
+			}
+		})
+	}
+}
+
+func TestRooms_Join(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxParticipants int
+		join            func(f *testRoom) (testParticipant, error)
+		err             error
+		participants    int
+	}{
+		{
+			name:            "joined by invite",
+			maxParticipants: 3,
+			join: func(f *testRoom) (testParticipant, error) {
+				return f.stranger, f.rooms.Join(context.Background(), f.id, f.invite, f.stranger.userID, f.stranger.conn)
+			},
+			participants: 3,
+		},
+		{
+			name:            "invalid invite",
+			maxParticipants: 3,
+			join: func(f *testRoom) (testParticipant, error) {
+				return f.stranger, f.rooms.Join(context.Background(), f.id, "invalid", f.stranger.userID, f.stranger.conn)
+			},
+			err: InviteTokenIsInvalidError,
+		},
+		{
+			name:            "room is full",
+			maxParticipants: 2,
+			join: func(f *testRoom) (testParticipant, error) {
+				return f.stranger, f.rooms.Join(context.Background(), f.id, f.invite, f.stranger.userID, f.stranger.conn)
+			},
+			err: RoomIsFullError,
+		},
+		{
+			name:            "already joined connection of full room receives the state",
+			maxParticipants: 2,
+			join: func(f *testRoom) (testParticipant, error) {
+				return f.guest, f.rooms.Join(context.Background(), f.id, f.invite, f.guest.userID, f.guest.conn)
+			},
+			participants: 2,
+		},
+		{
+			name:            "unknown room",
+			maxParticipants: 3,
+			join: func(f *testRoom) (testParticipant, error) {
+				return f.stranger, f.rooms.Join(context.Background(), "unknown", f.invite, f.stranger.userID, f.stranger.conn)
+			},
+			err: RoomNotFoundByIdError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestRoom(t, tt.maxParticipants)
+
+			joined, err := tt.join(f)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			event, received := f.communicator.last(joined.conn)
+			if tt.err != nil {
+				if received {
+					t.Errorf("rejected connection received %+v", event)
+				}
+				return
+			}
+			if event.Event != joinedEvent || event.Participants != tt.participants || event.UserID != joined.userID.Value.Hex() {
+				t.Errorf("joined event = %+v, want %v participants", event, tt.participants)
+			}
+			// the invite token is sent to the owner only
+			if event.Invite != "" {
+				t.Errorf("invite token is sent to the participant")
+			}
+		})
+	}
+}
+
+func TestRooms_Control(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		position float64
+		isGuest  bool
+		err      string // substring of the expected error, empty means no error
+		playing  bool
+	}{
+		{name: "play", command: PlayCommand, position: 10, playing: true},
+		{name: "pause", command: PauseCommand, position: 10},
+		{name: "seek", command: SeekCommand, position: 42},
+		{name: "guest is not allowed", command: PlayCommand, isGuest: true, err: OwnerOnlyError.Error()},
+		{name: "unknown command", command: "rewind", err: "room command 'rewind' is not supported"},
+		{name: "negative position", command: SeekCommand, position: -1, err: "position"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestRoom(t, 3)
+
+			conn := f.owner.conn
+			if tt.isGuest {
+				conn = f.guest.conn
+			}
+			err := f.rooms.Control(context.Background(), f.id, tt.command, tt.position, conn)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+				}
+				if _, received := f.communicator.last(f.guest.conn); received {
+					t.Errorf("rejected command is broadcast")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, p := range []testParticipant{f.owner, f.guest} {
+				event, _ := f.communicator.last(p.conn)
+				if event.Event != tt.command || event.Playing != tt.playing || event.Position < tt.position {
+					t.Errorf("event = %+v, want %v at %v, playing %v", event, tt.command, tt.position, tt.playing)
+				}
+			}
+		})
+	}
+}
+
+func TestRooms_Leave(t *testing.T) {
+	tests := []struct {
+		name     string
+		leave    func(f *testRoom) error
+		err      error
+		owner    func(f *testRoom) testParticipant // the owner after leaving, nil if the room is closed
+		isClosed bool
+	}{
+		{
+			name:  "guest leaves",
+			leave: func(f *testRoom) error { return f.rooms.Leave(context.Background(), f.id, f.guest.conn) },
+			owner: func(f *testRoom) testParticipant { return f.owner },
+		},
+		{
+			name:  "owner leaves, the ownership is passed to the guest",
+			leave: func(f *testRoom) error { return f.rooms.Leave(context.Background(), f.id, f.owner.conn) },
+			owner: func(f *testRoom) testParticipant { return f.guest },
+		},
+		{
+			name: "last participant closes the room",
+			leave: func(f *testRoom) error {
+				if err := f.rooms.Leave(context.Background(), f.id, f.owner.conn); err != nil {
+					return err
+				}
+				return f.rooms.Leave(context.Background(), f.id, f.guest.conn)
+			},
+			isClosed: true,
+		},
+		{
+			name:  "not joined connection",
+			leave: func(f *testRoom) error { return f.rooms.Leave(context.Background(), f.id, f.stranger.conn) },
+			err:   ParticipantNotFoundByConnectionError,
+			owner: func(f *testRoom) testParticipant { return f.owner },
+		},
+		{
+			name: "disconnected connection leaves all rooms",
+			leave: func(f *testRoom) error {
+				f.rooms.Disconnect(context.Background(), f.owner.conn)
+				return nil
+			},
+			owner: func(f *testRoom) testParticipant { return f.guest },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestRoom(t, 3)
+
+			if err := tt.leave(f); !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			_, err := f.rooms.get(f.id)
+			if tt.isClosed {
+				if !errors.Is(err, RoomNotFoundByIdError) {
+					t.Errorf("closed room is still available: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("room is not available: %v", err)
+			}
+
+			// the owner drives the playback, so the command of the owner is accepted only
+			owner := tt.owner(f)
+			if err = f.rooms.Control(context.Background(), f.id, PlayCommand, 0, owner.conn); err != nil {
+				t.Errorf("owner %v is not able to control the room: %v", owner.userID.Value.Hex(), err)
+			}
+			event, _ := f.communicator.last(owner.conn)
+			if event.OwnerID != owner.userID.Value.Hex() || event.Invite != f.invite {
+				t.Errorf("event of the owner = %+v, want owner %v with invite", event, owner.userID.Value.Hex())
+			}
+		})
+	}
+}
+
+func TestRooms_Transfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		isGuest bool // the request is sent by the guest
+		userID  func(f *testRoom) string
+		err     error
+	}{
+		{name: "passed to the guest", userID: func(f *testRoom) string { return f.guest.userID.Value.Hex() }},
+		{
+			name:    "guest is not allowed",
+			isGuest: true,
+			userID:  func(f *testRoom) string { return f.guest.userID.Value.Hex() },
+			err:     OwnerOnlyError,
+		},
+		{
+			name:   "user is not a participant",
+			userID: func(f *testRoom) string { return f.stranger.userID.Value.Hex() },
+			err:    ParticipantNotFoundByUserIdError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestRoom(t, 3)
+
+			conn := f.owner.conn
+			if tt.isGuest {
+				conn = f.guest.conn
+			}
+			if err := f.rooms.Transfer(context.Background(), f.id, tt.userID(f), conn); !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			owner := f.owner
+			if tt.err == nil {
+				owner = f.guest
+				event, _ := f.communicator.last(f.guest.conn)
+				if event.Event != ownerEvent || event.OwnerID != f.guest.userID.Value.Hex() || event.Invite != f.invite {
+					t.Errorf("event of the new owner = %+v, want owner event with invite", event)
+				}
+				if event, _ = f.communicator.last(f.owner.conn); event.Invite != "" {
+					t.Errorf("invite token is sent to the previous owner")
+				}
+			}
+
+			if err := f.rooms.Control(context.Background(), f.id, PauseCommand, 0, owner.conn); err != nil {
+				t.Errorf("owner is not able to control the room: %v", err)
+			}
+		})
+	}
+}
+
+func TestRooms_Sync(t *testing.T) {
+	f := newTestRoom(t, 3)
+
+	if err := f.rooms.Sync(context.Background(), f.id, 12345, f.guest.conn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event, _ := f.communicator.last(f.guest.conn)
+	if event.Event != syncEvent || event.ClientTime != 12345 || event.ServerTime == 0 {
+		t.Errorf("sync event = %+v, want the client and server time", event)
+	}
+	// the response is sent to the requester only
+	if event, received := f.communicator.last(f.owner.conn); received {
+		t.Errorf("owner received %+v", event)
+	}
+
+	if err := f.rooms.Sync(context.Background(), f.id, 12345, f.stranger.conn); !errors.Is(err, ParticipantNotFoundByConnectionError) {
+		t.Errorf("error = %v, want %v", err, ParticipantNotFoundByConnectionError)
+	}
+}
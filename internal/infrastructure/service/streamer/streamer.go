@@ -1,19 +1,24 @@
 package streamer
 
 import (
+	"context"
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	handler_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	listener_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
+	proto_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	room_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/room/interface"
 	"github.com/gorilla/websocket"
 	"sync"
 )
 
 type ResourceStreamer struct {
-	logger   logger_interface.Logger
-	listener listener_interface.ActionsListener
-	handler  handler_interface.ActionsHandler
+	logger       logger_interface.Logger
+	listener     listener_interface.ActionsListener
+	handler      handler_interface.ActionsHandler
+	communicator proto_interface.Communicator
+	rooms        room_interface.Rooms
 }
 
 func NewStreamingService(serviceContainer di_interface.ContainerManager) (*ResourceStreamer, error) {
@@ -32,19 +37,51 @@ func NewStreamingService(serviceContainer di_interface.ContainerManager) (*Resou
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	roomsService, err := serviceContainer.GetRoomsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &ResourceStreamer{
-		logger:   loggerService,
-		listener: webSocketListener,
-		handler:  webSocketHandler,
+		logger:       loggerService,
+		listener:     webSocketListener,
+		handler:      webSocketHandler,
+		communicator: webSocketCommunicator,
+		rooms:        roomsService,
 	}, nil
 }
 
-func (s *ResourceStreamer) HandleConn(conn *websocket.Conn) {
-	s.logger.Info(fmt.Sprintf("[%v]: start streaming", conn.RemoteAddr()))
+// HandleConn - serves the websocket connection until it will be closed. The given context
+// must be bound to the connection, so all the records of its lifecycle can be correlated.
+// Cancellation of the context means that the connection is drained: the current stream is
+// interrupted and the connection is closed with 'going away' status.
+func (s *ResourceStreamer) HandleConn(ctx context.Context, conn *websocket.Conn) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info(fmt.Sprintf("[%v]: start streaming", conn.RemoteAddr()))
+
+	listenerWg := &sync.WaitGroup{}
+	handlerWg := &sync.WaitGroup{}
+	s.handler.Handle(ctx, handlerWg, s.listener.Listen(ctx, listenerWg, conn))
+	handlerWg.Wait()
+
+	if ctx.Err() != nil {
+		if err := s.communicator.Close(conn); err != nil {
+			logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		// unblocks the listener which is waiting for the next message
+		_ = conn.Close()
+	}
+	listenerWg.Wait()
 
-	wg := &sync.WaitGroup{}
-	s.handler.Handle(wg, s.listener.Listen(wg, conn))
-	wg.Wait()
+	// the closed connection must not receive the events of rooms anymore
+	s.rooms.Disconnect(ctx, conn)
+	s.communicator.Release(conn)
 
-	s.logger.Info(fmt.Sprintf("[%v]: streaming is stopped", conn.RemoteAddr()))
+	logger.Info(fmt.Sprintf("[%v]: streaming is stopped", conn.RemoteAddr()))
 }
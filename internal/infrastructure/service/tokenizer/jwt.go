@@ -14,6 +14,14 @@ import (
 	"time"
 )
 
+const (
+	// tokenTypeClaim is a private claim which distinguishes the MFA challenge tokens from the access tokens.
+	tokenTypeClaim = "typ"
+	// accessTokenType is an empty because the access tokens were issued without the type claim.
+	accessTokenType       = ""
+	mfaChallengeTokenType = "mfa_challenge"
+)
+
 type JwtService struct {
 	ctx                     context.Context
 	logger                  logger_interface.Logger
@@ -23,6 +31,7 @@ type JwtService struct {
 	jwtTokenIssuer          string
 	jwtTokenEncryptAlgo     string
 	jwtTokenExpiresAfter    int64
+	jwtMFAChallengeExpires  int64
 }
 
 func NewJwtService(serviceContainer di_interface.ContainerManager) (*JwtService, error) {
@@ -55,6 +64,7 @@ func NewJwtService(serviceContainer di_interface.ContainerManager) (*JwtService,
 		jwtTokenIssuer:          cfg.JwtTokenIssuer,
 		jwtTokenEncryptAlgo:     cfg.JwtTokenEncryptAlgo,
 		jwtTokenExpiresAfter:    cfg.JwtTokenExpiresAfter,
+		jwtMFAChallengeExpires:  cfg.JwtMFAChallengeExpiresAfter,
 	}, nil
 }
 
@@ -73,8 +83,33 @@ func (s *JwtService) New(user *agg.User) (token string, err error) {
 	}
 }
 
+// NewMFAChallenge will generate a short-lived token which can be exchanged only on the access token.
+func (s *JwtService) NewMFAChallenge(user *agg.User) (token string, err error) {
+	tkn := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":          user.ID.Value.Hex(),
+		"iss":          s.jwtTokenIssuer,
+		"exp":          &jwt.NumericDate{Time: time.Now().Add(time.Second * time.Duration(s.jwtMFAChallengeExpires))},
+		tokenTypeClaim: mfaChallengeTokenType,
+	})
+
+	if token, err = tkn.SignedString(s.jwtSecretSalt); err != nil {
+		return "", s.logger.LogPropagate(err)
+	} else {
+		return token, nil
+	}
+}
+
 // Verify will decode the token and return a user ID or error, if it was occurred.
 func (s *JwtService) Verify(token string) (userID vo.ID, err error) {
+	return s.verify(token, accessTokenType)
+}
+
+// VerifyMFAChallenge will decode the challenge token and return a user ID or error, if it was occurred.
+func (s *JwtService) VerifyMFAChallenge(token string) (userID vo.ID, err error) {
+	return s.verify(token, mfaChallengeTokenType)
+}
+
+func (s *JwtService) verify(token string, tokenType string) (userID vo.ID, err error) {
 	// checking that token is not blocked
 	found, err := s.blockedTokenRepository.Has(s.ctx, token)
 	if err != nil {
@@ -112,6 +147,13 @@ func (s *JwtService) Verify(token string) (userID vo.ID, err error) {
 			return vo.ID{}, s.logger.LogPropagate(errors.NewAccessTokenIsInvalidError())
 		}
 
+		if err = s.isValidType(token, claims, tokenType); err != nil {
+			// the challenge token cannot be used as access token and vice versa
+			s.logger.Log(err)
+			// return a token invalid error
+			return vo.ID{}, s.logger.LogPropagate(errors.NewAccessTokenIsInvalidError())
+		}
+
 		userID, err = s.getUserID(claims)
 		if err != nil {
 			return vo.ID{}, s.logger.LogPropagate(err)
@@ -194,6 +236,14 @@ func (s *JwtService) isValidIssuer(token string, claims jwt.Claims) error {
 	return nil
 }
 
+func (s *JwtService) isValidType(token string, claims jwt.MapClaims, tokenType string) error {
+	// the access tokens have no type claim, so the absence of claim is an empty type
+	if typ, _ := claims[tokenTypeClaim].(string); typ != tokenType {
+		return errors.NewTokenTypeWasNotMatchedInternalError(token)
+	}
+	return nil
+}
+
 func (s *JwtService) getUserID(claims jwt.Claims) (userID vo.ID, err error) {
 	// extracting subject (hexID of user) from the claims
 	hexID, err := claims.GetSubject()
@@ -0,0 +1,16 @@
+package tracer_interface
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+type Tracer interface {
+	// Start will start a new span as a child of the span which is stored in the given ctx (if any).
+	Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span)
+	// Extract will extract the W3C trace context from the incoming request headers into the given ctx.
+	Extract(ctx context.Context, header http.Header) context.Context
+	// Shutdown will export the buffered spans and stop the exporter.
+	Shutdown(ctx context.Context) error
+}
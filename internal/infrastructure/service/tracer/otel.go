@@ -0,0 +1,118 @@
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	NoneExporterName   = "none"
+	OTLPExporterName   = "otlp"
+	StdOutExporterName = "stdout"
+)
+
+// instrumentationName is a name of the tracer (instrumentation scope) of the spans.
+const instrumentationName = "github.com/Borislavv/video-streaming"
+
+// OTelTracer - the tracer service which exports the spans by OpenTelemetry SDK.
+// When the exporter is 'none', the spans are not recorded but the incoming trace context is still propagated.
+type OTelTracer struct {
+	logger     logger_interface.Logger
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	shutdown   func(ctx context.Context) error
+}
+
+func NewOTelTracer(serviceContainer di_interface.ContainerManager) (*OTelTracer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	t := &OTelTracer{
+		logger:     loggerService,
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+
+	var exporter sdktrace.SpanExporter
+	switch strings.ToLower(cfg.TracingExporter) {
+	case NoneExporterName, "":
+		t.tracer = noop.NewTracerProvider().Tracer(instrumentationName)
+		t.shutdown = func(context.Context) error { return nil }
+		return t, nil
+	case OTLPExporterName:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.TracingOTLPEndpoint)}
+		if cfg.TracingOTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if exporter, err = otlptracehttp.New(ctx, opts...); err != nil {
+			return nil, loggerService.LogPropagate(err)
+		}
+	case StdOutExporterName:
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout)); err != nil {
+			return nil, loggerService.LogPropagate(err)
+		}
+	default:
+		return nil, loggerService.LogPropagate(
+			fmt.Errorf("tracer: undefined exporter '%v' received", cfg.TracingExporter),
+		)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSamplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.TracingServiceName))),
+	)
+	// the exporter errors are passed into the logger instead of the stderr
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		loggerService.Error(fmt.Sprintf("tracer: %v", err))
+	}))
+
+	t.tracer = provider.Tracer(instrumentationName)
+	t.shutdown = provider.Shutdown
+
+	return t, nil
+}
+
+func (t *OTelTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, name, opts...)
+}
+
+func (t *OTelTracer) Extract(ctx context.Context, header http.Header) context.Context {
+	return t.propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+func (t *OTelTracer) Shutdown(ctx context.Context) error {
+	return t.shutdown(ctx)
+}
+
+// Fail - marks the span as failed by the given error.
+func Fail(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
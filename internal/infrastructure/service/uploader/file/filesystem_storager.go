@@ -12,11 +12,22 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 )
 
+// ignoredFilenames are the files of the resources directory which are kept by the repository itself.
+var ignoredFilenames = map[string]struct{}{
+	"readme.md": {},
+}
+
 type FilesystemStorageService struct {
 	ctx    context.Context
 	logger logger_interface.Logger
+
+	// partials is a set of paths of the files which are being written at now
+	partialsMu *sync.Mutex
+	partials   map[string]struct{}
 }
 
 func NewFilesystemStorageService(serviceContainer di_interface.ContainerManager) (*FilesystemStorageService, error) {
@@ -31,8 +42,10 @@ func NewFilesystemStorageService(serviceContainer di_interface.ContainerManager)
 	}
 
 	return &FilesystemStorageService{
-		ctx:    ctx,
-		logger: loggerService,
+		ctx:        ctx,
+		logger:     loggerService,
+		partialsMu: &sync.Mutex{},
+		partials:   make(map[string]struct{}),
 	}, nil
 }
 
@@ -95,9 +108,17 @@ func (s *FilesystemStorageService) Store(
 	}
 	defer func() { _ = createdFile.Close() }()
 
+	// the file will be removed by RemovePartials if the process is stopped while writing
+	s.addPartial(filepath)
+	defer s.removePartial(filepath)
+
 	// moving the data in to the created file from tmp
 	length, err = io.Copy(createdFile, reader)
 	if err != nil {
+		// the half-written file cannot be streamed, so it must not be left on disk
+		if rmErr := os.Remove(filepath); rmErr != nil {
+			s.logger.Error(rmErr)
+		}
 		return 0, "", "", s.logger.LogPropagate(err)
 	}
 
@@ -123,6 +144,104 @@ func (s *FilesystemStorageService) Remove(name string) error {
 	return nil
 }
 
+// RemovePartials is delete the files which are still being written (used on shutdown after the uploads were drained).
+func (s *FilesystemStorageService) RemovePartials() error {
+	defer s.partialsMu.Unlock()
+	s.partialsMu.Lock()
+
+	for path := range s.partials {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return s.logger.LogPropagate(err)
+		}
+		s.logger.Info(fmt.Sprintf("partially uploaded file '%v' was removed", path))
+		delete(s.partials, path)
+	}
+
+	return nil
+}
+
+// List is returning the stored files (the files which are being written at now are skipped).
+func (s *FilesystemStorageService) List() (files []os.FileInfo, err error) {
+	// resources files directory
+	dir, err := helper.ResourcesDir()
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	files = make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || s.isPartial(dir+entry.Name()) {
+			continue
+		}
+		if _, ignored := ignoredFilenames[entry.Name()]; ignored {
+			continue
+		}
+
+		info, ierr := entry.Info()
+		if ierr != nil {
+			// the file was removed after reading the directory
+			if os.IsNotExist(ierr) {
+				continue
+			}
+			return nil, s.logger.LogPropagate(ierr)
+		}
+		files = append(files, info)
+	}
+
+	return files, nil
+}
+
+// Quarantine is moving the file by name from resources directory into the quarantine one,
+// so it is not served anymore, but still may be restored manually.
+func (s *FilesystemStorageService) Quarantine(name string) error {
+	// resources files directory
+	dir, err := helper.ResourcesDir()
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// quarantined files directory
+	quarantineDir, err := helper.QuarantineDir()
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = os.MkdirAll(quarantineDir, 0755); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// moving the target file
+	if err = os.Rename(fmt.Sprintf("%v%v", dir, name), fmt.Sprintf("%v%v", quarantineDir, name)); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+func (s *FilesystemStorageService) isPartial(path string) bool {
+	defer s.partialsMu.Unlock()
+	s.partialsMu.Lock()
+	_, found := s.partials[path]
+	return found
+}
+
+func (s *FilesystemStorageService) addPartial(path string) {
+	defer s.partialsMu.Unlock()
+	s.partialsMu.Lock()
+	s.partials[path] = struct{}{}
+}
+
+func (s *FilesystemStorageService) removePartial(path string) {
+	defer s.partialsMu.Unlock()
+	s.partialsMu.Lock()
+	delete(s.partials, path)
+}
+
 // getFilename - will return calculated filename with extension
 func (s *FilesystemStorageService) getFilename(header *multipart.FileHeader) (filename string, err error) {
 	hash := sha256.New()
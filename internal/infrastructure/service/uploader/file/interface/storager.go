@@ -1,6 +1,9 @@
 package file_interface
 
-import "io"
+import (
+	"io"
+	"os"
+)
 
 type Storage interface {
 	// Has is checking whether the file already exists.
@@ -9,4 +12,10 @@ type Storage interface {
 	Store(name string, reader io.Reader) (length int64, filename string, filepath string, err error)
 	// Remove is delete the file by name from resources directory.
 	Remove(name string) (err error)
+	// RemovePartials is delete the files which are still being written (used on shutdown).
+	RemovePartials() (err error)
+	// List is returning the stored files (the files which are being written at now are skipped).
+	List() (files []os.FileInfo, err error)
+	// Quarantine is moving the file by name from resources directory into the quarantine one.
+	Quarantine(name string) (err error)
 }
@@ -0,0 +1,45 @@
+package uploader
+
+import (
+	"fmt"
+	dto_interface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errors"
+	"io"
+)
+
+// limitedReader - counts the read bytes and fails as soon as the limit is crossed,
+// so the uploading is aborted without reading the rest of the file.
+type limitedReader struct {
+	reader io.Reader
+	read   int64
+	limit  int64
+	err    error
+}
+
+// newLimitedReader - the limit is the smallest one of the max. filesize and the remaining quota of the user
+// (zero means unlimited), the error tells the client which of them was crossed.
+func newLimitedReader(reader io.Reader, maxFilesize int64, reqDTO dto_interface.UploadResourceRequest) *limitedReader {
+	l := &limitedReader{reader: reader, limit: maxFilesize, err: errors.NewFileIsTooLargeError(maxFilesize)}
+	if quota := reqDTO.GetQuota(); quota > 0 && (l.limit <= 0 || quota < l.limit) {
+		l.limit = quota
+		l.err = errors.NewQuotaExceededError(fmt.Sprintf("only %d bytes may be uploaded yet", quota))
+	}
+	return l
+}
+
+func (l *limitedReader) Read(p []byte) (n int, err error) {
+	n, err = l.reader.Read(p)
+	l.read += int64(n)
+	if l.exceeded() != nil {
+		return n, l.err
+	}
+	return n, err
+}
+
+// exceeded - returns the error if the limit was crossed (the readers of the body may wrap the read errors).
+func (l *limitedReader) exceeded() error {
+	if l.limit > 0 && l.read > l.limit {
+		return l.err
+	}
+	return nil
+}
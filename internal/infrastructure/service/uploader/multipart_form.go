@@ -5,7 +5,10 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/errors"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
 	file_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"io"
+	"time"
 )
 
 const MultipartFormUploadingType = "multipart_form"
@@ -19,6 +22,7 @@ type MultipartFormUploader struct {
 	logger                    logger_interface.Logger
 	fileStorage               file_interface.Storage
 	fileNameComputer          file_interface.NameComputer
+	metrics                   metrics_interface.Metrics
 	formFilename              string
 	maxFilesize               int64
 	inMemoryFileSizeThreshold int64
@@ -40,6 +44,11 @@ func NewNativeUploader(serviceContainer di_interface.ContainerManager) (*Multipa
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	metricsService, err := serviceContainer.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	config, err := serviceContainer.GetConfig()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -49,16 +58,28 @@ func NewNativeUploader(serviceContainer di_interface.ContainerManager) (*Multipa
 		logger:                    loggerService,
 		fileStorage:               storageService,
 		fileNameComputer:          filenameService,
+		metrics:                   metricsService,
 		formFilename:              config.ResourceFormFilename,
+		maxFilesize:               config.ResourceMaxFilesizeThreshold,
 		inMemoryFileSizeThreshold: config.ResourceInMemoryFileSizeThreshold,
 	}, nil
 }
 
 // Upload method will be store a file on a disk and calculate a new hashed name. Request DTO mutation!
 func (u *MultipartFormUploader) Upload(reqDTO dto_interface.UploadResourceRequest) (err error) {
+	from := time.Now()
+
+	// the parsing is aborted as soon as the request crosses the max. filesize or the quota of the user
+	// (the whole form is limited here, not only the file)
+	body := newLimitedReader(reqDTO.GetRequest().Body, u.maxFilesize, reqDTO)
+	reqDTO.GetRequest().Body = io.NopCloser(body)
+
 	// request will be parsed and stored in the memory if it is under the RAM threshold,
 	// otherwise last parts of parsed file will be stored in the tmp files on the disk space
 	if err = reqDTO.GetRequest().ParseMultipartForm(u.inMemoryFileSizeThreshold); err != nil {
+		if e := body.exceeded(); e != nil {
+			return u.logger.LogPropagate(e)
+		}
 		return u.logger.LogPropagate(err)
 	}
 
@@ -91,6 +112,7 @@ func (u *MultipartFormUploader) Upload(reqDTO dto_interface.UploadResourceReques
 	if err != nil {
 		return u.logger.LogPropagate(err)
 	}
+	u.metrics.ObserveUpload(MultipartFormUploadingType, length, time.Since(from))
 
 	// mutate request reqDTO
 	reqDTO.SetOriginFilename(header.Filename)
@@ -6,9 +6,11 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	storager_interface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	metrics_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/metrics/interface"
 	file_interface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"io"
 	"mime/multipart"
+	"time"
 )
 
 const MultipartPartUploadingType = "multipart_part"
@@ -20,6 +22,7 @@ type MultipartPartUploader struct {
 	logger      logger_interface.Logger
 	storage     storager_interface.Storage
 	filename    file_interface.NameComputer
+	metrics     metrics_interface.Metrics
 	maxFilesize int64
 }
 
@@ -39,14 +42,28 @@ func NewPartsUploader(serviceContainer di_interface.ContainerManager) (*Multipar
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	metricsService, err := serviceContainer.GetMetricsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &MultipartPartUploader{
-		logger:   loggerService,
-		storage:  storageService,
-		filename: filenameService,
+		logger:      loggerService,
+		storage:     storageService,
+		filename:    filenameService,
+		metrics:     metricsService,
+		maxFilesize: cfg.ResourceMaxFilesizeThreshold,
 	}, nil
 }
 
 func (u *MultipartPartUploader) Upload(reqDTO dto_interface.UploadResourceRequest) (err error) {
+	from := time.Now()
+
 	part, err := u.getFilePart(reqDTO)
 	if err != nil {
 		return u.logger.LogPropagate(err)
@@ -68,11 +85,17 @@ func (u *MultipartPartUploader) Upload(reqDTO dto_interface.UploadResourceReques
 		return u.logger.LogPropagate(errors.NewResourceAlreadyExistsError(part.FileName()))
 	}
 
-	// saving a file on disk and calculating new hashed name with full qualified path
-	length, filename, filepath, err := u.storage.Store(computedFilename, part)
+	// saving a file on disk and calculating new hashed name with full qualified path,
+	// the saving is aborted as soon as the file crosses the max. filesize or the quota of the user
+	file := newLimitedReader(part, u.maxFilesize, reqDTO)
+	length, filename, filepath, err := u.storage.Store(computedFilename, file)
 	if err != nil {
+		if e := file.exceeded(); e != nil {
+			return u.logger.LogPropagate(e)
+		}
 		return u.logger.LogPropagate(err)
 	}
+	u.metrics.ObserveUpload(MultipartPartUploadingType, length, time.Since(from))
 
 	// mutate request reqDTO
 	reqDTO.SetOriginFilename(part.FileName())
@@ -17,9 +17,32 @@ document.getElementById('loginForm').addEventListener('submit', function (event)
         body: JSON.stringify(requestBody)
     })
         .then(response => response.json())
+        .then(data => {
+            if (!data.data.mfaRequired) {
+                return data;
+            }
+
+            // Two-factor authentication is enabled, exchange the challenge token on the access token.
+            const code = window.prompt('Enter the code from your authenticator app (or a recovery code):');
+
+            const mfaRequestBody = {challengeToken: data.data.token};
+            if (/^\d{6}$/.test(code.trim())) {
+                mfaRequestBody.code = code.trim();
+            } else {
+                mfaRequestBody.recoveryCode = code.trim();
+            }
+
+            return fetch('http://0.0.0.0:8000/api/v1/authorization/mfa', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json'
+                },
+                body: JSON.stringify(mfaRequestBody)
+            }).then(response => response.json());
+        })
         .then(data => {
             // Set up the access token into the cookies by x-access-token key.
-            document.cookie = `x-access-token=${data.data}`;
+            document.cookie = `x-access-token=${data.data.token}`;
             // Redirect to homepage.
             window.location.replace("/");
         })
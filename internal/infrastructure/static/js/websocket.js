@@ -11,6 +11,10 @@ let mediaSource;
 let chunks;
 let mediaSourceReady;
 let token;
+// the playlist which is playing now: { id, videoID, position, total }, the order is defined by server
+let playlist = null;
+// the playlist can be requested by the page query, for example: "/?playlist=<id>"
+const requestedPlaylistID = new URLSearchParams(window.location.search).get('playlist');
 
 // ws event: open
 websocket.onopen = (event) => {
@@ -38,6 +42,7 @@ websocket.onmessage = (event) => {
 
     if (typeof data === 'string' && (
         data.startsWith('start') ||
+        data.startsWith('playlist') ||
         data.startsWith('error') ||
         data.startsWith('stop')
     )) {
@@ -48,6 +53,10 @@ websocket.onmessage = (event) => {
             let dataParts = data.split('::')
             console.log(dataParts)
             makeMediaResource(dataParts[1], dataParts[2])
+        } else if (data.startsWith('playlist')) {
+            playlist = JSON.parse(data.substring(data.indexOf('::') + 2))
+            currentVideoID = playlist.videoID
+            console.log("Playlist position received: " + (playlist.position + 1) + " of " + playlist.total)
         } else if (data.startsWith('error')) {
             let dataParts = data.split('::')
             console.log("Server error occurred: " + dataParts[1])
@@ -67,6 +76,26 @@ websocket.onmessage = (event) => {
     }
 };
 
+// the playback position is reported periodically while playing and on pause/end,
+// so the video can be resumed later by ID_WITH_OFFSET action without "from"
+const progressReportInterval = 10000;
+let lastReportedPosition = -1;
+setInterval(function () {
+    if (!videoPlayer.paused) {
+        reportProgress()
+    }
+}, progressReportInterval);
+videoPlayer.addEventListener('pause', reportProgress);
+
+// the playlist is playing sequentially, so the next video will be requested when the current one is ended
+videoPlayer.addEventListener('ended', function () {
+    reportProgress()
+
+    if (playlist !== null && playlist.position + 1 < playlist.total) {
+        requestPlaylist(playlist.id, playlist.position + 1)
+    }
+});
+
 videoPlayer.addEventListener('seeking', function (event) {
     console.log("---> REQUEST FROM: ", event.currentTarget.currentTime, event.currentTarget.duration)
 
@@ -103,6 +132,12 @@ function waitForVideoListWillBeRendered(selector, callback) {
 
 // called init. function
 waitForVideoListWillBeRendered('.video-list', function () {
+    if (requestedPlaylistID !== null && requestedPlaylistID !== '') {
+        console.log('Requesting the first video of playlist ' + "ID:" + requestedPlaylistID)
+        requestPlaylist(requestedPlaylistID, 0)
+        return;
+    }
+
     let UL = document.querySelector('.video-list');
     if (UL !== null) {
         let LIs = UL.getElementsByTagName('li');
@@ -120,6 +155,13 @@ waitForVideoListWillBeRendered('.video-list', function () {
 
 // next video handler
 nextBtn.addEventListener('click', function() {
+    if (playlist !== null) { // walking the playlist in the server-defined order
+        if (playlist.position + 1 < playlist.total) {
+            requestPlaylist(playlist.id, playlist.position + 1)
+        }
+        return;
+    }
+
     let UL = document.querySelector('.video-list');
     let found = false
     if (UL !== null) {
@@ -144,6 +186,13 @@ nextBtn.addEventListener('click', function() {
 
 // previous video handler
 prevBtn.addEventListener('click', function(event) {
+    if (playlist !== null) { // walking the playlist in the server-defined order
+        if (playlist.position > 0) {
+            requestPlaylist(playlist.id, playlist.position - 1)
+        }
+        return;
+    }
+
     let UL = document.querySelector('.video-list');
     if (UL !== null) {
         let LIs             = UL.getElementsByTagName('li');
@@ -182,6 +231,7 @@ document.addEventListener('click', function (event) {
                 currentVideoID = li.id
             }
             if (event.target === li && currentVideoID !== li.id) { // check the target video ID is not equals with the current
+                playlist = null; // the video was chosen manually, so leaving the playlist
                 // requesting the prev video/audio from server
                 requestByID('ID', li.id)
                 currentVideoID = li.id; // updating the current video ID
@@ -196,6 +246,29 @@ function requestByID(strategy, id) {
     websocket.send(data)
 }
 
+function reportProgress() {
+    if (currentVideoID === '' || !token || websocket.readyState !== WebSocket.OPEN) {
+        return;
+    }
+    const position = videoPlayer.currentTime;
+    if (position === lastReportedPosition) {
+        return;
+    }
+    lastReportedPosition = position;
+
+    // the duration of the media source may be unknown, the detected one is used by server then
+    const duration = isFinite(videoPlayer.duration) ? videoPlayer.duration : 0;
+    let data = `PROGRESS::{ "id": "${currentVideoID}", "token": "${token}", "position": ${position}, "duration": ${duration} }`
+    console.log("websocket request: " + data);
+    websocket.send(data)
+}
+
+function requestPlaylist(id, position) {
+    let data = `PLAYLIST::{ "id": "${id}", "token": "${token}", "position": ${position} }`
+    console.log("websocket request: " + data);
+    websocket.send(data)
+}
+
 function addNextChunk() {
     awaiting()
         .then(